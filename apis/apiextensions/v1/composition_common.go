@@ -18,6 +18,7 @@ package v1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -112,6 +113,15 @@ type ComposedTemplate struct {
 	// +optional
 	// +kubebuilder:default={{type:"MatchCondition",matchCondition:{type:"Ready",status:"True"}}}
 	ReadinessChecks []ReadinessCheck `json:"readinessChecks,omitempty"`
+
+	// ReadinessGracePeriod is how long a composed resource that was ready may
+	// fail its readiness checks before it's considered not ready. This stops a
+	// composed resource that briefly flaps between ready and not ready from
+	// changing the composite resource's Ready condition. By default a composed
+	// resource is considered not ready as soon as it fails its readiness
+	// checks.
+	// +optional
+	ReadinessGracePeriod *metav1.Duration `json:"readinessGracePeriod,omitempty"`
 }
 
 // GetName returns the name of the composed template or an empty string if it is nil.
@@ -134,12 +144,13 @@ const (
 	ReadinessCheckTypeMatchFalse     ReadinessCheckType = "MatchFalse"
 	ReadinessCheckTypeMatchCondition ReadinessCheckType = "MatchCondition"
 	ReadinessCheckTypeNone           ReadinessCheckType = "None"
+	ReadinessCheckTypeCEL            ReadinessCheckType = "CEL"
 )
 
 // IsValid returns nil if the readiness check type is valid, or an error otherwise.
 func (t *ReadinessCheckType) IsValid() bool {
 	switch *t {
-	case ReadinessCheckTypeNonEmpty, ReadinessCheckTypeMatchString, ReadinessCheckTypeMatchInteger, ReadinessCheckTypeMatchTrue, ReadinessCheckTypeMatchFalse, ReadinessCheckTypeMatchCondition, ReadinessCheckTypeNone:
+	case ReadinessCheckTypeNonEmpty, ReadinessCheckTypeMatchString, ReadinessCheckTypeMatchInteger, ReadinessCheckTypeMatchTrue, ReadinessCheckTypeMatchFalse, ReadinessCheckTypeMatchCondition, ReadinessCheckTypeNone, ReadinessCheckTypeCEL:
 		return true
 	}
 	return false
@@ -153,7 +164,7 @@ type ReadinessCheck struct {
 	// or 0?
 
 	// Type indicates the type of probe you'd like to use.
-	// +kubebuilder:validation:Enum="MatchString";"MatchInteger";"NonEmpty";"MatchCondition";"MatchTrue";"MatchFalse";"None"
+	// +kubebuilder:validation:Enum="MatchString";"MatchInteger";"NonEmpty";"MatchCondition";"MatchTrue";"MatchFalse";"None";"CEL"
 	Type ReadinessCheckType `json:"type"`
 
 	// FieldPath shows the path of the field whose value will be used.
@@ -171,6 +182,13 @@ type ReadinessCheck struct {
 	// MatchCondition specifies the condition you'd like to match if you're using "MatchCondition" type.
 	// +optional
 	MatchCondition *MatchConditionReadinessCheck `json:"matchCondition,omitempty"`
+
+	// Expression is the CEL expression you'd like to evaluate if you're using
+	// "CEL" type. The composed resource is available as the 'object' variable,
+	// e.g. "object.status.readyReplicas == object.spec.replicas". The
+	// expression must evaluate to a boolean.
+	// +optional
+	Expression string `json:"expression,omitempty"`
 }
 
 // MatchConditionReadinessCheck is used to indicate how to tell whether a resource is ready
@@ -222,6 +240,11 @@ func (r *ReadinessCheck) Validate() *field.Error { //nolint:gocyclo // This func
 			return errors.WrapFieldError(err, field.NewPath("matchCondition"))
 		}
 		return nil
+	case ReadinessCheckTypeCEL:
+		if r.Expression == "" {
+			return field.Required(field.NewPath("expression"), "cannot be empty for type CEL")
+		}
+		return nil
 	case ReadinessCheckTypeNonEmpty, ReadinessCheckTypeMatchFalse, ReadinessCheckTypeMatchTrue:
 		// No specific validation required.
 	}
@@ -86,6 +86,29 @@ func TestReadinessCheckValidate(t *testing.T) {
 				},
 			},
 		},
+		"ValidTypeCEL": {
+			reason: "Type CEL should be valid",
+			args: args{
+				r: &ReadinessCheck{
+					Type:       ReadinessCheckTypeCEL,
+					Expression: "object.status.readyReplicas == object.spec.replicas",
+				},
+			},
+		},
+		"InvalidTypeCELMissingExpression": {
+			reason: "Type CEL should require an expression",
+			args: args{
+				r: &ReadinessCheck{
+					Type: ReadinessCheckTypeCEL,
+				},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "expression",
+				},
+			},
+		},
 		"InvalidType": {
 			reason: "Invalid type",
 			args: args{
@@ -210,3 +210,225 @@ func TestEnvironmentSourceSelectorValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvironmentSourceValidate(t *testing.T) {
+	type args struct {
+		src *EnvironmentSource
+	}
+	type want struct {
+		output *field.Error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"ValidEnvironmentConfigReference": {
+			reason: "Should accept a reference to an EnvironmentConfig without a kind",
+			args: args{
+				src: &EnvironmentSource{
+					Type: EnvironmentSourceTypeReference,
+					Ref:  &EnvironmentSourceReference{Name: "cool"},
+				},
+			},
+		},
+		"InvalidNamespacedEnvironmentConfig": {
+			reason: "Should reject a namespaced reference to an EnvironmentConfig",
+			args: args{
+				src: &EnvironmentSource{
+					Type: EnvironmentSourceTypeReference,
+					Kind: EnvironmentSourceKindEnvironmentConfig,
+					Ref:  &EnvironmentSourceReference{Name: "cool", Namespace: "default"},
+				},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeForbidden,
+					Field: "ref.namespace",
+				},
+			},
+		},
+		"ValidConfigMapSelector": {
+			reason: "Should accept a selector of ConfigMaps in a namespace",
+			args: args{
+				src: &EnvironmentSource{
+					Type: EnvironmentSourceTypeSelector,
+					Kind: EnvironmentSourceKindConfigMap,
+					Selector: &EnvironmentSourceSelector{
+						Mode:      EnvironmentSourceSelectorMultiMode,
+						Namespace: "default",
+						MatchLabels: []EnvironmentSourceSelectorLabelMatcher{{
+							Type:  EnvironmentSourceSelectorLabelMatcherTypeValue,
+							Key:   "cool",
+							Value: ptr.To("true"),
+						}},
+					},
+				},
+			},
+		},
+		"InvalidSecretWithoutNamespace": {
+			reason: "Should reject a reference to a Secret without a namespace",
+			args: args{
+				src: &EnvironmentSource{
+					Type: EnvironmentSourceTypeReference,
+					Kind: EnvironmentSourceKindSecret,
+					Ref:  &EnvironmentSourceReference{Name: "cool"},
+				},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "ref.namespace",
+				},
+			},
+		},
+		"ValidResource": {
+			reason: "Should accept a reference to a cluster scoped resource with a field path",
+			args: args{
+				src: &EnvironmentSource{
+					Type: EnvironmentSourceTypeReference,
+					Kind: EnvironmentSourceKindResource,
+					Ref:  &EnvironmentSourceReference{Name: "cool"},
+					Resource: &EnvironmentSourceResource{
+						APIVersion: "example.org/v1",
+						Kind:       "Cluster",
+						FieldPath:  "status.atProvider",
+					},
+				},
+			},
+		},
+		"InvalidResourceWithoutFieldPath": {
+			reason: "Should reject a resource without a field path",
+			args: args{
+				src: &EnvironmentSource{
+					Type: EnvironmentSourceTypeReference,
+					Kind: EnvironmentSourceKindResource,
+					Ref:  &EnvironmentSourceReference{Name: "cool"},
+					Resource: &EnvironmentSourceResource{
+						APIVersion: "example.org/v1",
+						Kind:       "Cluster",
+					},
+				},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "resource.fieldPath",
+				},
+			},
+		},
+		"InvalidResourceForConfigMap": {
+			reason: "Should reject a resource for a kind other than Resource",
+			args: args{
+				src: &EnvironmentSource{
+					Type: EnvironmentSourceTypeReference,
+					Kind: EnvironmentSourceKindConfigMap,
+					Ref:  &EnvironmentSourceReference{Name: "cool", Namespace: "default"},
+					Resource: &EnvironmentSourceResource{
+						APIVersion: "example.org/v1",
+						Kind:       "Cluster",
+						FieldPath:  "status.atProvider",
+					},
+				},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeForbidden,
+					Field: "resource",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.args.src.Validate()
+			if diff := cmp.Diff(tc.want.output, got, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
+				t.Errorf("%s\nValidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestEnvironmentMergeStrategyValidate(t *testing.T) {
+	type args struct {
+		s *EnvironmentMergeStrategy
+	}
+	type want struct {
+		output *field.Error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Valid": {
+			reason: "Should accept a valid merge strategy",
+			args: args{
+				s: &EnvironmentMergeStrategy{
+					Type: EnvironmentMergeStrategyKeepFirst,
+					Paths: []EnvironmentPathMergeStrategy{
+						{FieldPath: "network.allowedCIDRs", Type: EnvironmentMergeStrategyUnion},
+						{FieldPath: "tags", Type: EnvironmentMergeStrategyAppend},
+					},
+				},
+			},
+		},
+		"InvalidType": {
+			reason: "Should reject an unknown merge strategy",
+			args: args{
+				s: &EnvironmentMergeStrategy{Type: "Cool"},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "type",
+				},
+			},
+		},
+		"InvalidArrayIndex": {
+			reason: "Should reject a field path with an array index",
+			args: args{
+				s: &EnvironmentMergeStrategy{
+					Paths: []EnvironmentPathMergeStrategy{
+						{FieldPath: "tags[0]", Type: EnvironmentMergeStrategyFail},
+					},
+				},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "paths[0].fieldPath",
+				},
+			},
+		},
+		"DuplicatePath": {
+			reason: "Should reject a field path that is configured twice",
+			args: args{
+				s: &EnvironmentMergeStrategy{
+					Paths: []EnvironmentPathMergeStrategy{
+						{FieldPath: "tags", Type: EnvironmentMergeStrategyAppend},
+						{FieldPath: "tags", Type: EnvironmentMergeStrategyUnion},
+					},
+				},
+			},
+			want: want{
+				output: &field.Error{
+					Type:  field.ErrorTypeDuplicate,
+					Field: "paths[1].fieldPath",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := tc.args.s.Validate()
+			if diff := cmp.Diff(tc.want.output, got, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
+				t.Errorf("%s\nValidate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -40,7 +40,12 @@ func (c Composition) Hash() string {
 		return "unknown"
 	}
 
-	s, err := yaml.Marshal(c.Spec)
+	// The rollout strategy doesn't affect how composite resources are
+	// composed, so changing it shouldn't produce a new revision.
+	spec := c.Spec
+	spec.Rollout = nil
+
+	s, err := yaml.Marshal(spec)
 	if err != nil {
 		return "unknown"
 	}
@@ -19,6 +19,7 @@ package v1
 import (
 	"encoding/json"
 	"regexp"
+	"text/template"
 
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -40,6 +41,8 @@ const (
 	TransformTypeMath    TransformType = "math"
 	TransformTypeString  TransformType = "string"
 	TransformTypeConvert TransformType = "convert"
+
+	TransformTypeExpression TransformType = "expression"
 )
 
 // Transform is a unit of process whose input is transformed into an output with
@@ -47,7 +50,7 @@ const (
 type Transform struct {
 
 	// Type of the transform to be run.
-	// +kubebuilder:validation:Enum=map;match;math;string;convert
+	// +kubebuilder:validation:Enum=map;match;math;string;convert;expression
 	Type TransformType `json:"type"`
 
 	// Math is used to transform the input via mathematical operations such as
@@ -71,6 +74,11 @@ type Transform struct {
 	// Convert is used to cast the input into the given output type.
 	// +optional
 	Convert *ConvertTransform `json:"convert,omitempty"`
+
+	// Expression is used to transform the input by evaluating a CEL
+	// expression or a Go template.
+	// +optional
+	Expression *ExpressionTransform `json:"expression,omitempty"`
 }
 
 // Validate this Transform is valid.
@@ -105,6 +113,11 @@ func (t *Transform) Validate() *field.Error {
 		if err := t.Convert.Validate(); err != nil {
 			return verrors.WrapFieldError(err, field.NewPath("convert"))
 		}
+	case TransformTypeExpression:
+		if t.Expression == nil {
+			return field.Required(field.NewPath("expression"), "given transform type expression requires configuration")
+		}
+		return verrors.WrapFieldError(t.Expression.Validate(), field.NewPath("expression"))
 	default:
 		// Should never happen
 		return field.Invalid(field.NewPath("type"), t.Type, "unknown transform type")
@@ -135,6 +148,8 @@ func (t *Transform) GetOutputType() (*TransformIOType, error) {
 		out = TransformIOTypeString
 	case TransformTypeConvert:
 		out = t.Convert.ToType
+	case TransformTypeExpression:
+		out = t.Expression.OutputType
 	default:
 		return nil, errors.Errorf("unable to get output type, unknown transform type: %s", t.Type)
 	}
@@ -510,3 +525,67 @@ func (t ConvertTransform) Validate() *field.Error {
 	}
 	return nil
 }
+
+// ExpressionLanguage is the language of an ExpressionTransform.
+type ExpressionLanguage string
+
+// Accepted ExpressionLanguages.
+const (
+	ExpressionLanguageCEL        ExpressionLanguage = "CEL"
+	ExpressionLanguageGoTemplate ExpressionLanguage = "GoTemplate"
+)
+
+// An ExpressionTransform evaluates an expression against the input.
+type ExpressionTransform struct {
+	// Language of the expression.
+	//
+	// * `CEL` - a Common Expression Language expression. The input is
+	// available as the 'input' variable, e.g. `input.split(',')`.
+	// * `GoTemplate` - a Go template. The input is available as '.', e.g.
+	// `{{ . }}.example.org`.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=CEL;GoTemplate
+	// +kubebuilder:default=CEL
+	Language ExpressionLanguage `json:"language,omitempty"`
+
+	// Expression to evaluate.
+	Expression string `json:"expression"`
+
+	// OutputType is the type of the output of this transform. A Go template
+	// always renders a string. If the output type isn't string the rendered
+	// string is parsed as JSON.
+	// +kubebuilder:validation:Enum=string;int;int64;bool;float64;object;array
+	OutputType TransformIOType `json:"outputType"`
+}
+
+// GetLanguage returns the language of the expression, returning the default
+// if not specified.
+func (t *ExpressionTransform) GetLanguage() ExpressionLanguage {
+	if t.Language == "" {
+		return ExpressionLanguageCEL
+	}
+	return t.Language
+}
+
+// Validate returns an error if the ExpressionTransform is invalid.
+func (t *ExpressionTransform) Validate() *field.Error {
+	if t.Expression == "" {
+		return field.Required(field.NewPath("expression"), "expression transform requires an expression")
+	}
+	if !t.OutputType.IsValid() {
+		return field.Invalid(field.NewPath("outputType"), t.OutputType, "invalid type")
+	}
+	switch t.GetLanguage() {
+	case ExpressionLanguageCEL:
+		// CEL expressions are compiled when the Composition is validated, and
+		// when the transform is resolved.
+	case ExpressionLanguageGoTemplate:
+		if _, err := template.New("").Parse(t.Expression); err != nil {
+			return field.Invalid(field.NewPath("expression"), t.Expression, err.Error())
+		}
+	default:
+		return field.Invalid(field.NewPath("language"), t.Language, "unknown expression language")
+	}
+	return nil
+}
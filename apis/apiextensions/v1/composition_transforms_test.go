@@ -327,6 +327,69 @@ func TestTransformValidate(t *testing.T) {
 				},
 			},
 		},
+		"InvalidExpressionMissingConfig": {
+			reason: "Expression transform without configuration should be invalid",
+			args: args{
+				transform: &Transform{
+					Type: TransformTypeExpression,
+				},
+			},
+			want: want{
+				err: &field.Error{
+					Type:  field.ErrorTypeRequired,
+					Field: "expression",
+				},
+			},
+		},
+		"InvalidExpressionGoTemplate": {
+			reason: "Expression transform with a Go template that doesn't parse should be invalid",
+			args: args{
+				transform: &Transform{
+					Type: TransformTypeExpression,
+					Expression: &ExpressionTransform{
+						Language:   ExpressionLanguageGoTemplate,
+						Expression: "{{ . ",
+						OutputType: TransformIOTypeString,
+					},
+				},
+			},
+			want: want{
+				err: &field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "expression.expression",
+				},
+			},
+		},
+		"InvalidExpressionOutputType": {
+			reason: "Expression transform with an unknown output type should be invalid",
+			args: args{
+				transform: &Transform{
+					Type: TransformTypeExpression,
+					Expression: &ExpressionTransform{
+						Expression: "input",
+						OutputType: TransformIOType("foo"),
+					},
+				},
+			},
+			want: want{
+				err: &field.Error{
+					Type:  field.ErrorTypeInvalid,
+					Field: "expression.outputType",
+				},
+			},
+		},
+		"ValidExpression": {
+			reason: "Expression transform with a valid expression and output type should be valid",
+			args: args{
+				transform: &Transform{
+					Type: TransformTypeExpression,
+					Expression: &ExpressionTransform{
+						Expression: "input.join(',')",
+						OutputType: TransformIOTypeString,
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -374,6 +437,18 @@ func TestTransformGetOutputType(t *testing.T) {
 				output: &[]TransformIOType{"fakeType"}[0],
 			},
 		},
+		"ExpressionTransform": {
+			reason: "Output of Expression transform should be the declared output type",
+			args: args{
+				transform: &Transform{
+					Type:       TransformTypeExpression,
+					Expression: &ExpressionTransform{OutputType: TransformIOTypeArray},
+				},
+			},
+			want: want{
+				output: &[]TransformIOType{TransformIOTypeArray}[0],
+			},
+		},
 		"ErrorUnknownType": {
 			reason: "Output of Unknown transform type returns an error",
 			args: args{
@@ -18,6 +18,7 @@ package v1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // CompositionSpec specifies desired state of a composition.
@@ -102,6 +103,155 @@ type CompositionSpec struct {
 	// +optional
 	// +kubebuilder:default={"name": "default"}
 	PublishConnectionDetailsWithStoreConfigRef *StoreConfigReference `json:"publishConnectionDetailsWithStoreConfigRef,omitempty"`
+
+	// Rollout configures how composite resources with an Automatic
+	// composition update policy are moved to new revisions of this
+	// Composition. By default they're all moved to a new revision as soon as
+	// it's created. Changing the rollout strategy doesn't create a new
+	// revision.
+	//
+	// THIS IS AN ALPHA FIELD. Do not use it in production. It is not honored
+	// unless the relevant Crossplane feature flag is enabled, and may be
+	// changed or removed without notice.
+	// +optional
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+}
+
+// A RolloutFailurePolicy determines what happens when too many of the
+// composite resources moved to a new revision fail.
+type RolloutFailurePolicy string
+
+// Rollout failure policies.
+const (
+	// RolloutFailurePolicyPause stops moving composite resources to the new
+	// revision. Composite resources that were already moved stay on it.
+	RolloutFailurePolicyPause RolloutFailurePolicy = "Pause"
+
+	// RolloutFailurePolicyRollback stops moving composite resources to the
+	// new revision, and moves the composite resources that were already
+	// moved back to the previous revision.
+	RolloutFailurePolicyRollback RolloutFailurePolicy = "Rollback"
+)
+
+// A RolloutStrategy configures how composite resources are progressively
+// moved to a new revision of a Composition.
+//
+// Only composite resources with an Automatic composition update policy and
+// no composition revision selector are rolled out progressively.
+type RolloutStrategy struct {
+	// BatchSize is the number of composite resources to move to a new
+	// revision at once. It may be an absolute number (e.g. 5) or a percentage
+	// of the composite resources that use this Composition (e.g. 10%). The
+	// next batch is moved only once every composite resource that was already
+	// moved is Ready.
+	// +kubebuilder:validation:XIntOrString
+	BatchSize intstr.IntOrString `json:"batchSize"`
+
+	// FailureThreshold is the number of composite resources moved to a new
+	// revision that may fail before the rollout fails. It may be an absolute
+	// number (e.g. 2) or a percentage of the composite resources that were
+	// moved (e.g. 10%). A composite resource fails when its Synced condition
+	// is False, or when it isn't Ready within the progress deadline. Any
+	// failure fails the rollout by default. Failures are only counted while
+	// the rollout is progressing, not once it's complete.
+	// +optional
+	// +kubebuilder:validation:XIntOrString
+	FailureThreshold *intstr.IntOrString `json:"failureThreshold,omitempty"`
+
+	// FailurePolicy determines what happens when a rollout fails. Pause stops
+	// moving composite resources to the new revision. Rollback also moves
+	// composite resources that were already moved back to the previous
+	// revision. A failed rollout stays failed until a new revision is
+	// created, or it's retried.
+	// +optional
+	// +kubebuilder:validation:Enum=Pause;Rollback
+	// +kubebuilder:default=Pause
+	FailurePolicy *RolloutFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// ProgressDeadline is how long composite resources moved to a new
+	// revision may take to become Ready. A composite resource that isn't
+	// Ready within the deadline fails.
+	// +optional
+	// +kubebuilder:default="10m"
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+
+	// Retry a failed rollout by changing this to any new value, for example
+	// the current time. Composite resources that were already moved to the
+	// new revision get another progress deadline. Composite resources that
+	// were moved back to the previous revision are rolled out again.
+	// +optional
+	Retry string `json:"retry,omitempty"`
+}
+
+// A RolloutPhase is the phase of a rollout.
+type RolloutPhase string
+
+// Rollout phases.
+const (
+	// RolloutPhaseProgressing indicates composite resources are being moved
+	// to the new revision.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+
+	// RolloutPhaseComplete indicates every composite resource was moved to
+	// the new revision, and became Ready.
+	RolloutPhaseComplete RolloutPhase = "Complete"
+
+	// RolloutPhasePaused indicates the rollout failed, and no more composite
+	// resources will be moved to the new revision unless it's retried.
+	RolloutPhasePaused RolloutPhase = "Paused"
+
+	// RolloutPhaseRolledBack indicates the rollout failed, and composite
+	// resources are being moved back to the previous revision.
+	RolloutPhaseRolledBack RolloutPhase = "RolledBack"
+)
+
+// CompositionStatus shows the observed state of the composition.
+type CompositionStatus struct {
+	// Rollout reports the progress of rolling out the latest revision of the
+	// Composition.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+}
+
+// RolloutStatus reports the progress of a rollout.
+type RolloutStatus struct {
+	// Phase of the rollout.
+	Phase RolloutPhase `json:"phase"`
+
+	// Revision is the name of the CompositionRevision being rolled out.
+	Revision string `json:"revision"`
+
+	// PreviousRevision is the name of the CompositionRevision composite
+	// resources are rolled back to if the rollout fails.
+	// +optional
+	PreviousRevision string `json:"previousRevision,omitempty"`
+
+	// Total number of composite resources being rolled out.
+	Total int64 `json:"total"`
+
+	// Updated is the number of composite resources that were moved to the
+	// revision being rolled out.
+	Updated int64 `json:"updated"`
+
+	// Ready is the number of updated composite resources that are Ready.
+	Ready int64 `json:"ready"`
+
+	// Failed is the number of updated composite resources that failed.
+	Failed int64 `json:"failed"`
+
+	// LastUpdateTime is the last time composite resources were moved to the
+	// revision being rolled out, or the rollout was retried.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// LastRetry is the value of the rollout strategy's retry field when the
+	// rollout was last retried.
+	// +optional
+	LastRetry string `json:"lastRetry,omitempty"`
+
+	// Message is a human-readable description of the rollout's progress.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -114,11 +264,13 @@ type CompositionSpec struct {
 // +kubebuilder:printcolumn:name="XR-APIVERSION",type="string",JSONPath=".spec.compositeTypeRef.apiVersion"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:scope=Cluster,categories=crossplane,shortName=comp
+// +kubebuilder:subresource:status
 type Composition struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec CompositionSpec `json:"spec,omitempty"`
+	Spec   CompositionSpec   `json:"spec,omitempty"`
+	Status CompositionStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
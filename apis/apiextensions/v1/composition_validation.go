@@ -124,6 +124,9 @@ func (c *Composition) validateResources() (errs field.ErrorList) {
 				errs = append(errs, verrors.WrapFieldError(err, field.NewPath("spec", "resources").Index(i).Child("readinessChecks").Index(j)))
 			}
 		}
+		if gp := res.ReadinessGracePeriod; gp != nil && gp.Duration < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("spec", "resources").Index(i).Child("readinessGracePeriod"), gp.Duration.String(), "cannot be negative"))
+		}
 		// TODO(phisco): we should validate also ConnectionDetails, but would need a major refactoring
 	}
 	return errs
@@ -19,9 +19,11 @@ package v1
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
 )
@@ -683,6 +685,29 @@ func TestCompositionValidateResources(t *testing.T) {
 				},
 			},
 		},
+		"InvalidNegativeReadinessGracePeriod": {
+			reason: "resource with a negative readiness grace period should be invalid",
+			args: args{
+				comp: &Composition{
+					Spec: CompositionSpec{
+						Resources: []ComposedTemplate{
+							{
+								Name:                 ptr.To("foo"),
+								ReadinessGracePeriod: &metav1.Duration{Duration: -time.Minute},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				output: field.ErrorList{
+					{
+						Type:  field.ErrorTypeInvalid,
+						Field: "spec.resources[0].readinessGracePeriod",
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -753,6 +778,27 @@ func TestCompositionValidateEnvironment(t *testing.T) {
 												ValueFromFieldPath: ptr.To("spec.foo"),
 											}}}}}}}}},
 		},
+		"InvalidMergeStrategyEnvironment": {
+			reason: "Should reject an environment declaring an invalid merge strategy",
+			want: want{
+				output: field.ErrorList{
+					{
+						Type:  field.ErrorTypeRequired,
+						Field: "spec.environment.mergeStrategy.paths[0].fieldPath",
+					},
+				},
+			},
+			args: args{
+				comp: &Composition{
+					Spec: CompositionSpec{
+						Environment: &EnvironmentConfiguration{
+							MergeStrategy: &EnvironmentMergeStrategy{
+								Paths: []EnvironmentPathMergeStrategy{
+									{Type: EnvironmentMergeStrategyUnion},
+								},
+							},
+						}}}},
+		},
 		"InvalidPatchEnvironment": {
 			reason: "Should reject an environment declaring an invalid patch",
 			want: want{
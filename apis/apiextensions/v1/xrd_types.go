@@ -96,11 +96,79 @@ type CompositeResourceDefinitionSpec struct {
 	// +optional
 	Conversion *extv1.CustomResourceConversion `json:"conversion,omitempty"`
 
+	// Conversions configures Crossplane to convert the defined composite
+	// resource and claim between versions. Crossplane serves conversion using
+	// its own webhook, so you don't need to run a conversion webhook. Each
+	// conversion applies to a pair of versions. A conversion is used in both
+	// directions unless the reverse conversion is also specified. Versions
+	// with no conversion between them are converted via the referenceable
+	// version. Converting fails if there's no such path. Mutually exclusive
+	// with conversion.
+	// +optional
+	// +listType=atomic
+	Conversions []CompositeResourceConversion `json:"conversions,omitempty"`
+
 	// Metadata specifies the desired metadata for the defined composite resource and claim CRD's.
 	// +optional
 	Metadata *CompositeResourceDefinitionSpecMetadata `json:"metadata,omitempty"`
 }
 
+// A CompositeResourceConversion configures how Crossplane converts a composite
+// resource or claim from one version to another.
+type CompositeResourceConversion struct {
+	// FromVersion is the version to convert from.
+	FromVersion string `json:"fromVersion"`
+
+	// ToVersion is the version to convert to.
+	ToVersion string `json:"toVersion"`
+
+	// Mappings move fields from one path to another when converting. Fields
+	// that aren't mapped are copied as-is. When the conversion is used to
+	// convert from ToVersion to FromVersion the mappings are reversed.
+	// Mutually exclusive with functionRef.
+	// +optional
+	Mappings []ConversionMapping `json:"mappings,omitempty"`
+
+	// FunctionRef references a Composition Function that converts the
+	// composite resource or claim. The Function is called with the resource
+	// to convert as the observed composite resource, and the version to
+	// convert to in the context key apiextensions.crossplane.io/desired-version.
+	// It must return the converted resource as the desired composite
+	// resource. Mutually exclusive with mappings.
+	// +optional
+	FunctionRef *FunctionReference `json:"functionRef,omitempty"`
+}
+
+// A ConversionMapping moves a field from one path to another.
+type ConversionMapping struct {
+	// FromFieldPath is the path of the field in the version converted from.
+	FromFieldPath string `json:"fromFieldPath"`
+
+	// ToFieldPath is the path of the field in the version converted to.
+	ToFieldPath string `json:"toFieldPath"`
+}
+
+// GetConversion returns the conversion from the supplied version to the
+// supplied version, and whether the conversion must be applied in reverse. It
+// returns nil if there is no conversion between the versions. A conversion
+// declared explicitly in the requested direction takes precedence over the
+// reverse of a conversion declared in the opposite direction.
+func (s CompositeResourceDefinitionSpec) GetConversion(from, to string) (*CompositeResourceConversion, bool) {
+	for i := range s.Conversions {
+		c := &s.Conversions[i]
+		if c.FromVersion == from && c.ToVersion == to {
+			return c, false
+		}
+	}
+	for i := range s.Conversions {
+		c := &s.Conversions[i]
+		if c.FromVersion == to && c.ToVersion == from {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
 // A CompositionReference references a Composition.
 type CompositionReference struct {
 	// Name of the Composition.
@@ -169,6 +237,35 @@ type CompositeResourceDefinitionVersion struct {
 	// https://kubernetes.io/docs/reference/using-api/api-concepts/#receiving-resources-as-tables
 	// +optional
 	AdditionalPrinterColumns []extv1.CustomResourceColumnDefinition `json:"additionalPrinterColumns,omitempty"`
+
+	// Scale configures the scale subresource of the composite resource and
+	// claim CRDs for this version. Enabling the scale subresource allows
+	// composite resources and claims to be scaled using kubectl scale or a
+	// HorizontalPodAutoscaler.
+	// +optional
+	Scale *CompositeResourceScale `json:"scale,omitempty"`
+}
+
+// CompositeResourceScale configures the scale subresource of a composite
+// resource and its claim.
+type CompositeResourceScale struct {
+	// SpecReplicasPath is the JSON path of the field within the composite
+	// resource that corresponds to Scale spec.replicas. Only JSON paths without
+	// array notation are allowed. It must be a path under .spec.
+	SpecReplicasPath string `json:"specReplicasPath"`
+
+	// StatusReplicasPath is the JSON path of the field within the composite
+	// resource that corresponds to Scale status.replicas. Only JSON paths
+	// without array notation are allowed. It must be a path under .status.
+	StatusReplicasPath string `json:"statusReplicasPath"`
+
+	// LabelSelectorPath is the JSON path of the field within the composite
+	// resource that corresponds to Scale status.selector. Only JSON paths
+	// without array notation are allowed. It must be a path under .status or
+	// .spec. The field must contain a serialized label selector in string
+	// form.
+	// +optional
+	LabelSelectorPath *string `json:"labelSelectorPath,omitempty"`
 }
 
 // CompositeResourceValidation is a list of validation methods for a composite
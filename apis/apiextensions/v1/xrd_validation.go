@@ -2,6 +2,7 @@ package v1
 
 import (
 	"fmt"
+	"strings"
 
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -12,6 +13,8 @@ func (c *CompositeResourceDefinition) Validate() (warns []string, errs field.Err
 	type validationFunc func() field.ErrorList
 	validations := []validationFunc{
 		c.validateConversion,
+		c.validateConversions,
+		c.validateScale,
 	}
 	for _, f := range validations {
 		errs = append(errs, f()...)
@@ -29,6 +32,91 @@ func (c *CompositeResourceDefinition) validateConversion() (errs field.ErrorList
 	return errs
 }
 
+// validateConversions checks that the supplied CompositeResourceDefinition's
+// declarative conversions are valid.
+func (c *CompositeResourceDefinition) validateConversions() (errs field.ErrorList) {
+	if len(c.Spec.Conversions) == 0 {
+		return nil
+	}
+	p := field.NewPath("spec", "conversions")
+	if c.Spec.Conversion != nil {
+		errs = append(errs, field.Forbidden(p, "conversions and conversion are mutually exclusive"))
+	}
+
+	versions := make(map[string]bool, len(c.Spec.Versions))
+	for _, v := range c.Spec.Versions {
+		versions[v.Name] = true
+	}
+
+	type pair struct{ from, to string }
+	seen := make(map[pair]bool, len(c.Spec.Conversions))
+	for i, conv := range c.Spec.Conversions {
+		if !versions[conv.FromVersion] {
+			errs = append(errs, field.NotFound(p.Index(i).Child("fromVersion"), conv.FromVersion))
+		}
+		if !versions[conv.ToVersion] {
+			errs = append(errs, field.NotFound(p.Index(i).Child("toVersion"), conv.ToVersion))
+		}
+		if conv.FromVersion == conv.ToVersion {
+			errs = append(errs, field.Invalid(p.Index(i).Child("toVersion"), conv.ToVersion, "must differ from fromVersion"))
+		}
+		if seen[pair{conv.FromVersion, conv.ToVersion}] {
+			errs = append(errs, field.Duplicate(p.Index(i), fmt.Sprintf("%s to %s", conv.FromVersion, conv.ToVersion)))
+		}
+		seen[pair{conv.FromVersion, conv.ToVersion}] = true
+
+		if len(conv.Mappings) > 0 && conv.FunctionRef != nil {
+			errs = append(errs, field.Forbidden(p.Index(i).Child("functionRef"), "functionRef and mappings are mutually exclusive"))
+		}
+		if conv.FunctionRef != nil && conv.FunctionRef.Name == "" {
+			errs = append(errs, field.Required(p.Index(i).Child("functionRef", "name"), "a Function name is required"))
+		}
+		for j, m := range conv.Mappings {
+			if m.FromFieldPath == "" {
+				errs = append(errs, field.Required(p.Index(i).Child("mappings").Index(j).Child("fromFieldPath"), "a field path is required"))
+			}
+			if m.ToFieldPath == "" {
+				errs = append(errs, field.Required(p.Index(i).Child("mappings").Index(j).Child("toFieldPath"), "a field path is required"))
+			}
+		}
+	}
+	return errs
+}
+
+// validateScale checks that the scale subresource of each of the supplied
+// CompositeResourceDefinition's versions refers to valid fields.
+func (c *CompositeResourceDefinition) validateScale() (errs field.ErrorList) {
+	for i, v := range c.Spec.Versions {
+		if v.Scale == nil {
+			continue
+		}
+		p := field.NewPath("spec", "versions").Index(i).Child("scale")
+		errs = append(errs, validateScalePath(p.Child("specReplicasPath"), v.Scale.SpecReplicasPath, ".spec.")...)
+		errs = append(errs, validateScalePath(p.Child("statusReplicasPath"), v.Scale.StatusReplicasPath, ".status.")...)
+		if v.Scale.LabelSelectorPath != nil {
+			errs = append(errs, validateScalePath(p.Child("labelSelectorPath"), *v.Scale.LabelSelectorPath, ".spec.", ".status.")...)
+		}
+	}
+	return errs
+}
+
+// validateScalePath checks that the supplied scale subresource path has one of
+// the supplied prefixes, and doesn't use array notation.
+func validateScalePath(p *field.Path, path string, prefixes ...string) field.ErrorList {
+	if path == "" {
+		return field.ErrorList{field.Required(p, "a field path is required")}
+	}
+	if strings.Contains(path, "[") {
+		return field.ErrorList{field.Invalid(p, path, "array notation is not allowed")}
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return nil
+		}
+	}
+	return field.ErrorList{field.Invalid(p, path, fmt.Sprintf("must be a path under %s", strings.Join(prefixes, " or ")))}
+}
+
 // ValidateUpdate checks that the supplied CompositeResourceDefinition update is valid w.r.t. the old one.
 func (c *CompositeResourceDefinition) ValidateUpdate(old *CompositeResourceDefinition) (warns []string, errs field.ErrorList) {
 	// Validate the update
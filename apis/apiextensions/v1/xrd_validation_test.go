@@ -20,6 +20,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
@@ -188,3 +189,172 @@ func TestValidateUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConversions(t *testing.T) {
+	versions := []CompositeResourceDefinitionVersion{{Name: "v1alpha1"}, {Name: "v1beta1"}}
+
+	cases := map[string]struct {
+		reason string
+		c      *CompositeResourceDefinition
+		want   field.ErrorList
+	}{
+		"Valid": {
+			reason: "A CompositeResourceDefinition with valid conversions should be accepted",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions: versions,
+					Conversions: []CompositeResourceConversion{
+						{
+							FromVersion: "v1alpha1",
+							ToVersion:   "v1beta1",
+							Mappings:    []ConversionMapping{{FromFieldPath: "spec.size", ToFieldPath: "spec.parameters.size"}},
+						},
+						{
+							FromVersion: "v1beta1",
+							ToVersion:   "v1alpha1",
+							FunctionRef: &FunctionReference{Name: "function-convert"},
+						},
+					},
+				},
+			},
+		},
+		"ConversionAndConversions": {
+			reason: "A CompositeResourceDefinition may not specify both conversion and conversions",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions:    versions,
+					Conversion:  &extv1.CustomResourceConversion{Strategy: extv1.NoneConverter},
+					Conversions: []CompositeResourceConversion{{FromVersion: "v1alpha1", ToVersion: "v1beta1"}},
+				},
+			},
+			want: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "conversions"), ""),
+			},
+		},
+		"UnknownVersions": {
+			reason: "Conversions must be between versions of the CompositeResourceDefinition",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions:    versions,
+					Conversions: []CompositeResourceConversion{{FromVersion: "v1", ToVersion: "v2"}},
+				},
+			},
+			want: field.ErrorList{
+				field.NotFound(field.NewPath("spec", "conversions").Index(0).Child("fromVersion"), "v1"),
+				field.NotFound(field.NewPath("spec", "conversions").Index(0).Child("toVersion"), "v2"),
+			},
+		},
+		"SameVersion": {
+			reason: "A conversion must be between two different versions",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions:    versions,
+					Conversions: []CompositeResourceConversion{{FromVersion: "v1alpha1", ToVersion: "v1alpha1"}},
+				},
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "conversions").Index(0).Child("toVersion"), "v1alpha1", ""),
+			},
+		},
+		"DuplicateConversion": {
+			reason: "A conversion between two versions may only be specified once",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions: versions,
+					Conversions: []CompositeResourceConversion{
+						{FromVersion: "v1alpha1", ToVersion: "v1beta1"},
+						{FromVersion: "v1alpha1", ToVersion: "v1beta1"},
+					},
+				},
+			},
+			want: field.ErrorList{
+				field.Duplicate(field.NewPath("spec", "conversions").Index(1), "v1alpha1 to v1beta1"),
+			},
+		},
+		"MappingsAndFunction": {
+			reason: "A conversion may not specify both mappings and a Function",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions: versions,
+					Conversions: []CompositeResourceConversion{{
+						FromVersion: "v1alpha1",
+						ToVersion:   "v1beta1",
+						Mappings:    []ConversionMapping{{FromFieldPath: "spec.size"}},
+						FunctionRef: &FunctionReference{Name: "function-convert"},
+					}},
+				},
+			},
+			want: field.ErrorList{
+				field.Forbidden(field.NewPath("spec", "conversions").Index(0).Child("functionRef"), ""),
+				field.Required(field.NewPath("spec", "conversions").Index(0).Child("mappings").Index(0).Child("toFieldPath"), ""),
+			},
+		},
+	}
+	for tcName, tc := range cases {
+		t.Run(tcName, func(t *testing.T) {
+			got := tc.c.validateConversions()
+			if diff := cmp.Diff(tc.want, got, sortFieldErrors(), cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("\n%s\nValidateConversions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestValidateScale(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		c      *CompositeResourceDefinition
+		want   field.ErrorList
+	}{
+		"NoScale": {
+			reason: "A CompositeResourceDefinition without a scale subresource should be accepted",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions: []CompositeResourceDefinitionVersion{{Name: "v1"}},
+				},
+			},
+		},
+		"Valid": {
+			reason: "A CompositeResourceDefinition with a valid scale subresource should be accepted",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions: []CompositeResourceDefinitionVersion{{
+						Name: "v1",
+						Scale: &CompositeResourceScale{
+							SpecReplicasPath:   ".spec.replicas",
+							StatusReplicasPath: ".status.replicas",
+							LabelSelectorPath:  ptr.To(".status.selector"),
+						},
+					}},
+				},
+			},
+		},
+		"InvalidPaths": {
+			reason: "Scale subresource paths must be under the right field, and must not use array notation",
+			c: &CompositeResourceDefinition{
+				Spec: CompositeResourceDefinitionSpec{
+					Versions: []CompositeResourceDefinitionVersion{{
+						Name: "v1",
+						Scale: &CompositeResourceScale{
+							SpecReplicasPath:  ".status.replicas",
+							LabelSelectorPath: ptr.To(".spec.selectors[0]"),
+						},
+					}},
+				},
+			},
+			want: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "versions").Index(0).Child("scale", "specReplicasPath"), ".status.replicas", ""),
+				field.Required(field.NewPath("spec", "versions").Index(0).Child("scale", "statusReplicasPath"), ""),
+				field.Invalid(field.NewPath("spec", "versions").Index(0).Child("scale", "labelSelectorPath"), ".spec.selectors[0]", ""),
+			},
+		},
+	}
+	for tcName, tc := range cases {
+		t.Run(tcName, func(t *testing.T) {
+			got := tc.c.validateScale()
+			if diff := cmp.Diff(tc.want, got, sortFieldErrors(), cmpopts.IgnoreFields(field.Error{}, "Detail")); diff != "" {
+				t.Errorf("\n%s\nValidateScale(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
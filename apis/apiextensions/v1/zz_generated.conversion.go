@@ -7,7 +7,9 @@ import (
 	v11 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	v12 "k8s.io/api/core/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	v13 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"time"
 )
 
 type GeneratedRevisionSpecConverter struct{}
@@ -139,6 +141,15 @@ func (c *GeneratedRevisionSpecConverter) pV1ConvertTransformToPV1ConvertTransfor
 	}
 	return pV1ConvertTransform
 }
+func (c *GeneratedRevisionSpecConverter) pV1DurationToPV1Duration(source *v13.Duration) *v13.Duration {
+	var pV1Duration *v13.Duration
+	if source != nil {
+		var v1Duration v13.Duration
+		v1Duration.Duration = time.Duration((*source).Duration)
+		pV1Duration = &v1Duration
+	}
+	return pV1Duration
+}
 func (c *GeneratedRevisionSpecConverter) pV1EnvironmentConfigurationToPV1EnvironmentConfiguration(source *EnvironmentConfiguration) *EnvironmentConfiguration {
 	var pV1EnvironmentConfiguration *EnvironmentConfiguration
 	if source != nil {
@@ -165,19 +176,49 @@ func (c *GeneratedRevisionSpecConverter) pV1EnvironmentConfigurationToPV1Environ
 		}
 		v1EnvironmentConfiguration.Patches = v1EnvironmentPatchList
 		v1EnvironmentConfiguration.Policy = c.pV1PolicyToPV1Policy((*source).Policy)
+		v1EnvironmentConfiguration.MergeStrategy = c.pV1EnvironmentMergeStrategyToPV1EnvironmentMergeStrategy((*source).MergeStrategy)
 		pV1EnvironmentConfiguration = &v1EnvironmentConfiguration
 	}
 	return pV1EnvironmentConfiguration
 }
+func (c *GeneratedRevisionSpecConverter) pV1EnvironmentMergeStrategyToPV1EnvironmentMergeStrategy(source *EnvironmentMergeStrategy) *EnvironmentMergeStrategy {
+	var pV1EnvironmentMergeStrategy *EnvironmentMergeStrategy
+	if source != nil {
+		var v1EnvironmentMergeStrategy EnvironmentMergeStrategy
+		v1EnvironmentMergeStrategy.Type = EnvironmentMergeStrategyType((*source).Type)
+		var v1EnvironmentPathMergeStrategyList []EnvironmentPathMergeStrategy
+		if (*source).Paths != nil {
+			v1EnvironmentPathMergeStrategyList = make([]EnvironmentPathMergeStrategy, len((*source).Paths))
+			for i := 0; i < len((*source).Paths); i++ {
+				v1EnvironmentPathMergeStrategyList[i] = c.v1EnvironmentPathMergeStrategyToV1EnvironmentPathMergeStrategy((*source).Paths[i])
+			}
+		}
+		v1EnvironmentMergeStrategy.Paths = v1EnvironmentPathMergeStrategyList
+		pV1EnvironmentMergeStrategy = &v1EnvironmentMergeStrategy
+	}
+	return pV1EnvironmentMergeStrategy
+}
 func (c *GeneratedRevisionSpecConverter) pV1EnvironmentSourceReferenceToPV1EnvironmentSourceReference(source *EnvironmentSourceReference) *EnvironmentSourceReference {
 	var pV1EnvironmentSourceReference *EnvironmentSourceReference
 	if source != nil {
 		var v1EnvironmentSourceReference EnvironmentSourceReference
 		v1EnvironmentSourceReference.Name = (*source).Name
+		v1EnvironmentSourceReference.Namespace = (*source).Namespace
 		pV1EnvironmentSourceReference = &v1EnvironmentSourceReference
 	}
 	return pV1EnvironmentSourceReference
 }
+func (c *GeneratedRevisionSpecConverter) pV1EnvironmentSourceResourceToPV1EnvironmentSourceResource(source *EnvironmentSourceResource) *EnvironmentSourceResource {
+	var pV1EnvironmentSourceResource *EnvironmentSourceResource
+	if source != nil {
+		var v1EnvironmentSourceResource EnvironmentSourceResource
+		v1EnvironmentSourceResource.APIVersion = (*source).APIVersion
+		v1EnvironmentSourceResource.Kind = (*source).Kind
+		v1EnvironmentSourceResource.FieldPath = (*source).FieldPath
+		pV1EnvironmentSourceResource = &v1EnvironmentSourceResource
+	}
+	return pV1EnvironmentSourceResource
+}
 func (c *GeneratedRevisionSpecConverter) pV1EnvironmentSourceSelectorToPV1EnvironmentSourceSelector(source *EnvironmentSourceSelector) *EnvironmentSourceSelector {
 	var pV1EnvironmentSourceSelector *EnvironmentSourceSelector
 	if source != nil {
@@ -204,10 +245,22 @@ func (c *GeneratedRevisionSpecConverter) pV1EnvironmentSourceSelectorToPV1Enviro
 			}
 		}
 		v1EnvironmentSourceSelector.MatchLabels = v1EnvironmentSourceSelectorLabelMatcherList
+		v1EnvironmentSourceSelector.Namespace = (*source).Namespace
 		pV1EnvironmentSourceSelector = &v1EnvironmentSourceSelector
 	}
 	return pV1EnvironmentSourceSelector
 }
+func (c *GeneratedRevisionSpecConverter) pV1ExpressionTransformToPV1ExpressionTransform(source *ExpressionTransform) *ExpressionTransform {
+	var pV1ExpressionTransform *ExpressionTransform
+	if source != nil {
+		var v1ExpressionTransform ExpressionTransform
+		v1ExpressionTransform.Language = ExpressionLanguage((*source).Language)
+		v1ExpressionTransform.Expression = (*source).Expression
+		v1ExpressionTransform.OutputType = TransformIOType((*source).OutputType)
+		pV1ExpressionTransform = &v1ExpressionTransform
+	}
+	return pV1ExpressionTransform
+}
 func (c *GeneratedRevisionSpecConverter) pV1MapTransformToPV1MapTransform(source *MapTransform) *MapTransform {
 	var pV1MapTransform *MapTransform
 	if source != nil {
@@ -430,6 +483,7 @@ func (c *GeneratedRevisionSpecConverter) v1ComposedTemplateToV1ComposedTemplate(
 		}
 	}
 	v1ComposedTemplate.ReadinessChecks = v1ReadinessCheckList
+	v1ComposedTemplate.ReadinessGracePeriod = c.pV1DurationToPV1Duration(source.ReadinessGracePeriod)
 	return v1ComposedTemplate
 }
 func (c *GeneratedRevisionSpecConverter) v1ConnectionDetailToV1ConnectionDetail(source ConnectionDetail) ConnectionDetail {
@@ -517,9 +571,17 @@ func (c *GeneratedRevisionSpecConverter) v1EnvironmentSourceSelectorLabelMatcher
 	v1EnvironmentSourceSelectorLabelMatcher.Value = pString2
 	return v1EnvironmentSourceSelectorLabelMatcher
 }
+func (c *GeneratedRevisionSpecConverter) v1EnvironmentPathMergeStrategyToV1EnvironmentPathMergeStrategy(source EnvironmentPathMergeStrategy) EnvironmentPathMergeStrategy {
+	var v1EnvironmentPathMergeStrategy EnvironmentPathMergeStrategy
+	v1EnvironmentPathMergeStrategy.FieldPath = source.FieldPath
+	v1EnvironmentPathMergeStrategy.Type = EnvironmentMergeStrategyType(source.Type)
+	return v1EnvironmentPathMergeStrategy
+}
 func (c *GeneratedRevisionSpecConverter) v1EnvironmentSourceToV1EnvironmentSource(source EnvironmentSource) EnvironmentSource {
 	var v1EnvironmentSource EnvironmentSource
 	v1EnvironmentSource.Type = EnvironmentSourceType(source.Type)
+	v1EnvironmentSource.Kind = EnvironmentSourceKind(source.Kind)
+	v1EnvironmentSource.Resource = c.pV1EnvironmentSourceResourceToPV1EnvironmentSourceResource(source.Resource)
 	v1EnvironmentSource.Ref = c.pV1EnvironmentSourceReferenceToPV1EnvironmentSourceReference(source.Ref)
 	v1EnvironmentSource.Selector = c.pV1EnvironmentSourceSelectorToPV1EnvironmentSourceSelector(source.Selector)
 	return v1EnvironmentSource
@@ -619,6 +681,7 @@ func (c *GeneratedRevisionSpecConverter) v1ReadinessCheckToV1ReadinessCheck(sour
 	v1ReadinessCheck.MatchString = source.MatchString
 	v1ReadinessCheck.MatchInteger = source.MatchInteger
 	v1ReadinessCheck.MatchCondition = c.pV1MatchConditionReadinessCheckToPV1MatchConditionReadinessCheck(source.MatchCondition)
+	v1ReadinessCheck.Expression = source.Expression
 	return v1ReadinessCheck
 }
 func (c *GeneratedRevisionSpecConverter) v1TransformToV1Transform(source Transform) Transform {
@@ -629,6 +692,7 @@ func (c *GeneratedRevisionSpecConverter) v1TransformToV1Transform(source Transfo
 	v1Transform.Match = c.pV1MatchTransformToPV1MatchTransform(source.Match)
 	v1Transform.String = c.pV1StringTransformToPV1StringTransform(source.String)
 	v1Transform.Convert = c.pV1ConvertTransformToPV1ConvertTransform(source.Convert)
+	v1Transform.Expression = c.pV1ExpressionTransformToPV1ExpressionTransform(source.Expression)
 	return v1Transform
 }
 func (c *GeneratedRevisionSpecConverter) v1TypeReferenceToV1TypeReference(source TypeReference) TypeReference {
@@ -23,7 +23,9 @@ package v1
 import (
 	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -96,6 +98,11 @@ func (in *ComposedTemplate) DeepCopyInto(out *ComposedTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReadinessGracePeriod != nil {
+		in, out := &in.ReadinessGracePeriod, &out.ReadinessGracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTemplate.
@@ -108,6 +115,31 @@ func (in *ComposedTemplate) DeepCopy() *ComposedTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeResourceConversion) DeepCopyInto(out *CompositeResourceConversion) {
+	*out = *in
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]ConversionMapping, len(*in))
+		copy(*out, *in)
+	}
+	if in.FunctionRef != nil {
+		in, out := &in.FunctionRef, &out.FunctionRef
+		*out = new(FunctionReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeResourceConversion.
+func (in *CompositeResourceConversion) DeepCopy() *CompositeResourceConversion {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeResourceConversion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompositeResourceDefinition) DeepCopyInto(out *CompositeResourceDefinition) {
 	*out = *in
@@ -230,6 +262,13 @@ func (in *CompositeResourceDefinitionSpec) DeepCopyInto(out *CompositeResourceDe
 		*out = new(apiextensionsv1.CustomResourceConversion)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conversions != nil {
+		in, out := &in.Conversions, &out.Conversions
+		*out = make([]CompositeResourceConversion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Metadata != nil {
 		in, out := &in.Metadata, &out.Metadata
 		*out = new(CompositeResourceDefinitionSpecMetadata)
@@ -316,6 +355,11 @@ func (in *CompositeResourceDefinitionVersion) DeepCopyInto(out *CompositeResourc
 		*out = make([]apiextensionsv1.CustomResourceColumnDefinition, len(*in))
 		copy(*out, *in)
 	}
+	if in.Scale != nil {
+		in, out := &in.Scale, &out.Scale
+		*out = new(CompositeResourceScale)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeResourceDefinitionVersion.
@@ -328,6 +372,26 @@ func (in *CompositeResourceDefinitionVersion) DeepCopy() *CompositeResourceDefin
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositeResourceScale) DeepCopyInto(out *CompositeResourceScale) {
+	*out = *in
+	if in.LabelSelectorPath != nil {
+		in, out := &in.LabelSelectorPath, &out.LabelSelectorPath
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositeResourceScale.
+func (in *CompositeResourceScale) DeepCopy() *CompositeResourceScale {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositeResourceScale)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompositeResourceValidation) DeepCopyInto(out *CompositeResourceValidation) {
 	*out = *in
@@ -350,6 +414,7 @@ func (in *Composition) DeepCopyInto(out *Composition) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Composition.
@@ -594,6 +659,11 @@ func (in *CompositionSpec) DeepCopyInto(out *CompositionSpec) {
 		*out = new(StoreConfigReference)
 		**out = **in
 	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionSpec.
@@ -606,6 +676,26 @@ func (in *CompositionSpec) DeepCopy() *CompositionSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CompositionStatus) DeepCopyInto(out *CompositionStatus) {
+	*out = *in
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompositionStatus.
+func (in *CompositionStatus) DeepCopy() *CompositionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CompositionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionDetail) DeepCopyInto(out *ConnectionDetail) {
 	*out = *in
@@ -646,6 +736,21 @@ func (in *ConnectionDetail) DeepCopy() *ConnectionDetail {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConversionMapping) DeepCopyInto(out *ConversionMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConversionMapping.
+func (in *ConversionMapping) DeepCopy() *ConversionMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ConversionMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConvertTransform) DeepCopyInto(out *ConvertTransform) {
 	*out = *in
@@ -695,6 +800,11 @@ func (in *EnvironmentConfiguration) DeepCopyInto(out *EnvironmentConfiguration)
 		*out = new(commonv1.Policy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MergeStrategy != nil {
+		in, out := &in.MergeStrategy, &out.MergeStrategy
+		*out = new(EnvironmentMergeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfiguration.
@@ -707,6 +817,26 @@ func (in *EnvironmentConfiguration) DeepCopy() *EnvironmentConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentMergeStrategy) DeepCopyInto(out *EnvironmentMergeStrategy) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]EnvironmentPathMergeStrategy, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentMergeStrategy.
+func (in *EnvironmentMergeStrategy) DeepCopy() *EnvironmentMergeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentMergeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentPatch) DeepCopyInto(out *EnvironmentPatch) {
 	*out = *in
@@ -749,9 +879,29 @@ func (in *EnvironmentPatch) DeepCopy() *EnvironmentPatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentPathMergeStrategy) DeepCopyInto(out *EnvironmentPathMergeStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentPathMergeStrategy.
+func (in *EnvironmentPathMergeStrategy) DeepCopy() *EnvironmentPathMergeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentPathMergeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentSource) DeepCopyInto(out *EnvironmentSource) {
 	*out = *in
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(EnvironmentSourceResource)
+		**out = **in
+	}
 	if in.Ref != nil {
 		in, out := &in.Ref, &out.Ref
 		*out = new(EnvironmentSourceReference)
@@ -789,6 +939,21 @@ func (in *EnvironmentSourceReference) DeepCopy() *EnvironmentSourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSourceResource) DeepCopyInto(out *EnvironmentSourceResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSourceResource.
+func (in *EnvironmentSourceResource) DeepCopy() *EnvironmentSourceResource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSourceResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentSourceSelector) DeepCopyInto(out *EnvironmentSourceSelector) {
 	*out = *in
@@ -851,6 +1016,21 @@ func (in *EnvironmentSourceSelectorLabelMatcher) DeepCopy() *EnvironmentSourceSe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressionTransform) DeepCopyInto(out *ExpressionTransform) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionTransform.
+func (in *ExpressionTransform) DeepCopy() *ExpressionTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionReference) DeepCopyInto(out *FunctionReference) {
 	*out = *in
@@ -1132,6 +1312,56 @@ func (in *ReadinessCheck) DeepCopy() *ReadinessCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStatus) DeepCopyInto(out *RolloutStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStatus.
+func (in *RolloutStatus) DeepCopy() *RolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	out.BatchSize = in.BatchSize
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(RolloutFailurePolicy)
+		**out = **in
+	}
+	if in.ProgressDeadline != nil {
+		in, out := &in.ProgressDeadline, &out.ProgressDeadline
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StoreConfigReference) DeepCopyInto(out *StoreConfigReference) {
 	*out = *in
@@ -1245,6 +1475,11 @@ func (in *Transform) DeepCopyInto(out *Transform) {
 		*out = new(ConvertTransform)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(ExpressionTransform)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transform.
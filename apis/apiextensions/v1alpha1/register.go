@@ -56,7 +56,16 @@ var (
 	UsageGroupVersionKind = SchemeGroupVersion.WithKind(UsageKind)
 )
 
+// NamespacedUsage type metadata.
+var (
+	NamespacedUsageKind             = reflect.TypeOf(NamespacedUsage{}).Name()
+	NamespacedUsageGroupKind        = schema.GroupKind{Group: Group, Kind: NamespacedUsageKind}.String()
+	NamespacedUsageKindAPIVersion   = NamespacedUsageKind + "." + SchemeGroupVersion.String()
+	NamespacedUsageGroupVersionKind = SchemeGroupVersion.WithKind(NamespacedUsageKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&EnvironmentConfig{}, &EnvironmentConfigList{})
 	SchemeBuilder.Register(&Usage{}, &UsageList{})
+	SchemeBuilder.Register(&NamespacedUsage{}, &NamespacedUsageList{})
 }
@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// A UsageResource is either a cluster scoped Usage or a NamespacedUsage.
+// +k8s:deepcopy-gen=false
+type UsageResource interface {
+	resource.Object
+	resource.Conditioned
+
+	GetOf() Resource
+	SetOf(r Resource)
+
+	GetBy() *Resource
+	SetBy(r *Resource)
+
+	GetReason() *string
+
+	GetReplayDeletion() *bool
+}
+
+// GetCondition of this Usage.
+func (u *Usage) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return u.Status.GetCondition(ct)
+}
+
+// SetConditions of this Usage.
+func (u *Usage) SetConditions(c ...xpv1.Condition) {
+	u.Status.SetConditions(c...)
+}
+
+// GetOf returns the resource this Usage is of.
+func (u *Usage) GetOf() Resource {
+	return u.Spec.Of
+}
+
+// SetOf sets the resource this Usage is of.
+func (u *Usage) SetOf(r Resource) {
+	u.Spec.Of = r
+}
+
+// GetBy returns the resource this Usage is by.
+func (u *Usage) GetBy() *Resource {
+	return u.Spec.By
+}
+
+// SetBy sets the resource this Usage is by.
+func (u *Usage) SetBy(r *Resource) {
+	u.Spec.By = r
+}
+
+// GetReason returns the reason for this Usage.
+func (u *Usage) GetReason() *string {
+	return u.Spec.Reason
+}
+
+// GetReplayDeletion returns whether this Usage replays blocked deletions.
+func (u *Usage) GetReplayDeletion() *bool {
+	return u.Spec.ReplayDeletion
+}
+
+// GetCondition of this NamespacedUsage.
+func (u *NamespacedUsage) GetCondition(ct xpv1.ConditionType) xpv1.Condition {
+	return u.Status.GetCondition(ct)
+}
+
+// SetConditions of this NamespacedUsage.
+func (u *NamespacedUsage) SetConditions(c ...xpv1.Condition) {
+	u.Status.SetConditions(c...)
+}
+
+// GetOf returns the resource this NamespacedUsage is of.
+func (u *NamespacedUsage) GetOf() Resource {
+	return u.Spec.Of
+}
+
+// SetOf sets the resource this NamespacedUsage is of.
+func (u *NamespacedUsage) SetOf(r Resource) {
+	u.Spec.Of = r
+}
+
+// GetBy returns the resource this NamespacedUsage is by.
+func (u *NamespacedUsage) GetBy() *Resource {
+	return u.Spec.By
+}
+
+// SetBy sets the resource this NamespacedUsage is by.
+func (u *NamespacedUsage) SetBy(r *Resource) {
+	u.Spec.By = r
+}
+
+// GetReason returns the reason for this NamespacedUsage.
+func (u *NamespacedUsage) GetReason() *string {
+	return u.Spec.Reason
+}
+
+// GetReplayDeletion returns whether this NamespacedUsage replays blocked deletions.
+func (u *NamespacedUsage) GetReplayDeletion() *bool {
+	return u.Spec.ReplayDeletion
+}
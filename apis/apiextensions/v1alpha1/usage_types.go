@@ -38,7 +38,8 @@ type ResourceSelector struct {
 	MatchControllerRef *bool `json:"matchControllerRef,omitempty"`
 }
 
-// Resource defines a cluster-scoped resource.
+// Resource defines a resource. A Usage may only refer to cluster scoped
+// resources. A NamespacedUsage may only refer to resources in its namespace.
 type Resource struct {
 	// API version of the referent.
 	// +optional
@@ -68,6 +69,12 @@ type UsageSpec struct {
 	// Reason is the reason for blocking deletion of the resource.
 	// +optional
 	Reason *string `json:"reason,omitempty"`
+	// ReplayDeletion will trigger a deletion on the used resource during the
+	// deletion of the usage itself, if it was attempted to be deleted at least
+	// once. The deletion is replayed with the propagation policy of the
+	// blocked deletion attempt.
+	// +optional
+	ReplayDeletion *bool `json:"replayDeletion,omitempty"`
 }
 
 // UsageStatus defines the observed state of Usage.
@@ -99,3 +106,29 @@ type UsageList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []Usage `json:"items"`
 }
+
+// A NamespacedUsage defines a deletion blocking relationship between two
+// resources in the same namespace, for example two claims.
+// +kubebuilder:object:root=true
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="DETAILS",type="string",JSONPath=".metadata.annotations.crossplane\\.io/usage-details"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Namespaced,categories=crossplane
+// +kubebuilder:subresource:status
+type NamespacedUsage struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// +kubebuilder:validation:XValidation:rule="has(self.by) || has(self.reason)",message="either \"spec.by\" or \"spec.reason\" must be specified."
+	Spec   UsageSpec   `json:"spec"`
+	Status UsageStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NamespacedUsageList contains a list of NamespacedUsage.
+type NamespacedUsageList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NamespacedUsage `json:"items"`
+}
@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 
 	"github.com/crossplane/crossplane/internal/validation/errors"
 )
@@ -37,14 +38,16 @@ type EnvironmentConfiguration struct {
 	// It is overwritten by the selected environment configs.
 	DefaultData map[string]extv1.JSON `json:"defaultData,omitempty"`
 
-	// EnvironmentConfigs selects a list of `EnvironmentConfig`s. The resolved
-	// resources are stored in the composite resource at
-	// `spec.environmentConfigRefs` and is only updated if it is null.
+	// EnvironmentConfigs selects a list of `EnvironmentConfig`s, ConfigMaps,
+	// Secrets, or other resources. The resolved `EnvironmentConfig`s are
+	// stored in the composite resource at `spec.environmentConfigRefs` and is
+	// only updated if it is null. Other kinds of resources are resolved every
+	// time the composite resource is reconciled, and are never stored.
 	//
 	// The list of references is used to compute an in-memory environment at
 	// compose time. The data of all object is merged in the order they are
-	// listed, meaning the values of EnvironmentConfigs with a larger index take
-	// priority over ones with smaller indices.
+	// listed, meaning the values of sources with a larger index take priority
+	// over ones with smaller indices, regardless of their kind.
 	//
 	// The computed environment can be accessed in a composition using
 	// `FromEnvironmentFieldPath` and `CombineFromEnvironment` patches.
@@ -59,6 +62,14 @@ type EnvironmentConfiguration struct {
 	// all EnvironmentSourceReferences in EnvironmentConfigs list.
 	// +optional
 	Policy *xpv1.Policy `json:"policy,omitempty"`
+
+	// MergeStrategy configures how the data of the selected environment
+	// sources is merged. By default objects are merged deeply, and otherwise
+	// the values of sources with a larger index replace the values of sources
+	// with smaller indices. The default data is always replaced by the data
+	// of the selected sources.
+	// +optional
+	MergeStrategy *EnvironmentMergeStrategy `json:"mergeStrategy,omitempty"`
 }
 
 // Validate the EnvironmentConfiguration.
@@ -77,9 +88,113 @@ func (e *EnvironmentConfiguration) Validate() field.ErrorList {
 		}
 	}
 
+	if e.MergeStrategy != nil {
+		if err := errors.WrapFieldError(e.MergeStrategy.Validate(), field.NewPath("mergeStrategy")); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return errs
 }
 
+// EnvironmentMergeStrategyType specifies how a value of an environment source
+// is merged with the value of a previous source at the same path.
+type EnvironmentMergeStrategyType string
+
+const (
+	// EnvironmentMergeStrategyReplace replaces the previous value.
+	EnvironmentMergeStrategyReplace EnvironmentMergeStrategyType = "Replace"
+	// EnvironmentMergeStrategyAppend appends a list to the previous list.
+	// Other values are replaced.
+	EnvironmentMergeStrategyAppend EnvironmentMergeStrategyType = "Append"
+	// EnvironmentMergeStrategyUnion appends the items of a list that aren't
+	// already in the previous list. Other values are replaced.
+	EnvironmentMergeStrategyUnion EnvironmentMergeStrategyType = "Union"
+	// EnvironmentMergeStrategyKeepFirst keeps the previous value.
+	EnvironmentMergeStrategyKeepFirst EnvironmentMergeStrategyType = "KeepFirst"
+	// EnvironmentMergeStrategyFail fails if the previous value is different.
+	EnvironmentMergeStrategyFail EnvironmentMergeStrategyType = "Fail"
+)
+
+// An EnvironmentMergeStrategy configures how the data of environment sources
+// is merged. Objects are always merged deeply, so strategies apply to the
+// values of their fields.
+type EnvironmentMergeStrategy struct {
+	// Type is the strategy used for all fields, unless overridden for a
+	// field path.
+	// +optional
+	// +kubebuilder:validation:Enum=Replace;Append;Union;KeepFirst;Fail
+	// +kubebuilder:default=Replace
+	Type EnvironmentMergeStrategyType `json:"type,omitempty"`
+
+	// Paths overrides the strategy for the fields at specific paths of the
+	// environment, and the fields nested under them. The most specific path
+	// takes precedence.
+	// +optional
+	Paths []EnvironmentPathMergeStrategy `json:"paths,omitempty"`
+}
+
+// An EnvironmentPathMergeStrategy configures how the field at a path of the
+// environment is merged.
+type EnvironmentPathMergeStrategy struct {
+	// FieldPath of the field, for example `network.allowedCIDRs`. Array
+	// indices aren't supported.
+	FieldPath string `json:"fieldPath"`
+
+	// Type is the strategy used for the field.
+	// +kubebuilder:validation:Enum=Replace;Append;Union;KeepFirst;Fail
+	Type EnvironmentMergeStrategyType `json:"type"`
+}
+
+// GetType returns the merge strategy used for all fields, returning the default
+// if not set.
+func (e *EnvironmentMergeStrategy) GetType() EnvironmentMergeStrategyType {
+	if e == nil || e.Type == "" {
+		return EnvironmentMergeStrategyReplace
+	}
+	return e.Type
+}
+
+// Validate the EnvironmentMergeStrategy.
+func (e *EnvironmentMergeStrategy) Validate() *field.Error {
+	if !validEnvironmentMergeStrategyType(e.GetType()) {
+		return field.Invalid(field.NewPath("type"), e.Type, "invalid merge strategy")
+	}
+
+	seen := make(map[string]bool, len(e.Paths))
+	for i, p := range e.Paths {
+		path := field.NewPath("paths").Index(i)
+		if p.FieldPath == "" {
+			return field.Required(path.Child("fieldPath"), "fieldPath is required")
+		}
+		segments, err := fieldpath.Parse(p.FieldPath)
+		if err != nil {
+			return field.Invalid(path.Child("fieldPath"), p.FieldPath, err.Error())
+		}
+		for _, s := range segments {
+			if s.Type != fieldpath.SegmentField {
+				return field.Invalid(path.Child("fieldPath"), p.FieldPath, "array indices are not supported")
+			}
+		}
+		if seen[segments.String()] {
+			return field.Duplicate(path.Child("fieldPath"), p.FieldPath)
+		}
+		seen[segments.String()] = true
+		if !validEnvironmentMergeStrategyType(p.Type) {
+			return field.Invalid(path.Child("type"), p.Type, "invalid merge strategy")
+		}
+	}
+	return nil
+}
+
+func validEnvironmentMergeStrategyType(t EnvironmentMergeStrategyType) bool {
+	switch t {
+	case EnvironmentMergeStrategyReplace, EnvironmentMergeStrategyAppend, EnvironmentMergeStrategyUnion, EnvironmentMergeStrategyKeepFirst, EnvironmentMergeStrategyFail:
+		return true
+	}
+	return false
+}
+
 // ShouldResolve specifies whether EnvironmentConfiguration should be resolved or not.
 func (e *EnvironmentConfiguration) ShouldResolve(currentRefs []corev1.ObjectReference) bool {
 
@@ -112,6 +227,30 @@ const (
 	EnvironmentSourceTypeSelector EnvironmentSourceType = "Selector"
 )
 
+// EnvironmentSourceKind specifies the kind of resource the environment is read
+// from.
+type EnvironmentSourceKind string
+
+// LabelEnvironmentSource must be set to "true" on a Secret for Crossplane to
+// read it into the environment of a composite resource.
+const LabelEnvironmentSource = "apiextensions.crossplane.io/environment-source"
+
+const (
+	// EnvironmentSourceKindEnvironmentConfig reads the data of an
+	// EnvironmentConfig.
+	EnvironmentSourceKindEnvironmentConfig EnvironmentSourceKind = "EnvironmentConfig"
+	// EnvironmentSourceKindConfigMap reads the data of a ConfigMap.
+	EnvironmentSourceKindConfigMap EnvironmentSourceKind = "ConfigMap"
+	// EnvironmentSourceKindSecret reads the decoded data of a Secret. Anyone
+	// who can edit a Composition could otherwise read any Secret Crossplane
+	// can read into the environment of its composite resources, so only
+	// Secrets labeled LabelEnvironmentSource may be read.
+	EnvironmentSourceKindSecret EnvironmentSourceKind = "Secret"
+	// EnvironmentSourceKindResource reads an object at a field path of any
+	// kind of resource.
+	EnvironmentSourceKindResource EnvironmentSourceKind = "Resource"
+)
+
 // EnvironmentSource selects a EnvironmentConfig resource.
 type EnvironmentSource struct {
 	// Type specifies the way the EnvironmentConfig is selected.
@@ -121,6 +260,27 @@ type EnvironmentSource struct {
 	// +kubebuilder:default=Reference
 	Type EnvironmentSourceType `json:"type,omitempty"`
 
+	// Kind specifies the kind of resource the environment is read from. The
+	// data of EnvironmentConfigs and ConfigMaps is merged into the
+	// environment, as is the decoded data of Secrets. Secrets are only read
+	// if they're labeled `apiextensions.crossplane.io/environment-source:
+	// "true"`, whatever kind of source reads them. For any other kind of
+	// Resource the object at the configured field path is merged into the
+	// environment. Crossplane must be allowed to get and list the kind of
+	// Resource, for example by a ClusterRole with the label
+	// `rbac.crossplane.io/aggregate-to-crossplane: "true"`. Otherwise reading
+	// it fails as forbidden.
+	// Default is `EnvironmentConfig`
+	// +optional
+	// +kubebuilder:validation:Enum=EnvironmentConfig;ConfigMap;Secret;Resource
+	// +kubebuilder:default=EnvironmentConfig
+	Kind EnvironmentSourceKind `json:"kind,omitempty"`
+
+	// Resource specifies the kind of resource to read the environment from,
+	// and the field path to read. Required when kind is `Resource`.
+	// +optional
+	Resource *EnvironmentSourceResource `json:"resource,omitempty"`
+
 	// Ref is a named reference to a single EnvironmentConfig.
 	// Either Ref or Selector is required.
 	// +optional
@@ -131,8 +291,67 @@ type EnvironmentSource struct {
 	Selector *EnvironmentSourceSelector `json:"selector,omitempty"`
 }
 
+// GetKind returns the kind of resource the environment is read from, returning
+// the default if not set.
+func (e *EnvironmentSource) GetKind() EnvironmentSourceKind {
+	if e == nil || e.Kind == "" {
+		return EnvironmentSourceKindEnvironmentConfig
+	}
+	return e.Kind
+}
+
+// GetNamespace returns the namespace the environment is read from.
+func (e *EnvironmentSource) GetNamespace() string {
+	switch {
+	case e.Type == EnvironmentSourceTypeReference && e.Ref != nil:
+		return e.Ref.Namespace
+	case e.Type == EnvironmentSourceTypeSelector && e.Selector != nil:
+		return e.Selector.Namespace
+	}
+	return ""
+}
+
 // Validate the EnvironmentSource.
 func (e *EnvironmentSource) Validate() *field.Error {
+	if err := e.validateType(); err != nil {
+		return err
+	}
+
+	switch e.GetKind() {
+	case EnvironmentSourceKindEnvironmentConfig:
+		if e.GetNamespace() != "" {
+			return field.Forbidden(e.namespacePath(), "EnvironmentConfigs are cluster scoped")
+		}
+	case EnvironmentSourceKindConfigMap, EnvironmentSourceKindSecret:
+		if e.GetNamespace() == "" {
+			return field.Required(e.namespacePath(), "namespace is required for ConfigMaps and Secrets")
+		}
+	case EnvironmentSourceKindResource:
+		if e.Resource == nil {
+			return field.Required(field.NewPath("resource"), "resource is required")
+		}
+		if err := e.Resource.Validate(); err != nil {
+			return errors.WrapFieldError(err, field.NewPath("resource"))
+		}
+		return nil
+	default:
+		return field.Invalid(field.NewPath("kind"), e.Kind, "invalid kind")
+	}
+
+	if e.Resource != nil {
+		return field.Forbidden(field.NewPath("resource"), "resource is only supported for kind Resource")
+	}
+	return nil
+}
+
+func (e *EnvironmentSource) namespacePath() *field.Path {
+	if e.Type == EnvironmentSourceTypeSelector {
+		return field.NewPath("selector", "namespace")
+	}
+	return field.NewPath("ref", "namespace")
+}
+
+func (e *EnvironmentSource) validateType() *field.Error {
 	switch e.Type {
 	case EnvironmentSourceTypeReference:
 		if e.Ref == nil {
@@ -163,6 +382,11 @@ func (e *EnvironmentSource) Validate() *field.Error {
 type EnvironmentSourceReference struct {
 	// The name of the object.
 	Name string `json:"name"`
+
+	// The namespace of the object. Required for ConfigMaps, Secrets, and
+	// namespaced Resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // Validate the EnvironmentSourceReference.
@@ -173,6 +397,34 @@ func (e *EnvironmentSourceReference) Validate() *field.Error {
 	return nil
 }
 
+// An EnvironmentSourceResource specifies the kind of resource to read an
+// environment from, and the field path to read.
+type EnvironmentSourceResource struct {
+	// APIVersion of the resource.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the resource.
+	Kind string `json:"kind"`
+
+	// FieldPath of the object to merge into the environment, for example
+	// `status.atProvider`.
+	FieldPath string `json:"fieldPath"`
+}
+
+// Validate the EnvironmentSourceResource.
+func (e *EnvironmentSourceResource) Validate() *field.Error {
+	if e.APIVersion == "" {
+		return field.Required(field.NewPath("apiVersion"), "apiVersion is required")
+	}
+	if e.Kind == "" {
+		return field.Required(field.NewPath("kind"), "kind is required")
+	}
+	if e.FieldPath == "" {
+		return field.Required(field.NewPath("fieldPath"), "fieldPath is required")
+	}
+	return nil
+}
+
 // EnvironmentSourceSelectorModeType specifies amount of retrieved EnvironmentConfigs
 // with matching label.
 type EnvironmentSourceSelectorModeType string
@@ -205,6 +457,11 @@ type EnvironmentSourceSelector struct {
 
 	// MatchLabels ensures an object with matching labels is selected.
 	MatchLabels []EnvironmentSourceSelectorLabelMatcher `json:"matchLabels,omitempty"`
+
+	// Namespace to select objects in. Required for ConfigMaps, Secrets, and
+	// namespaced Resources.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // Validate logically validates the EnvironmentSourceSelector.
@@ -22,7 +22,8 @@ package v1beta1
 
 import (
 	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -96,6 +97,11 @@ func (in *ComposedTemplate) DeepCopyInto(out *ComposedTemplate) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReadinessGracePeriod != nil {
+		in, out := &in.ReadinessGracePeriod, &out.ReadinessGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComposedTemplate.
@@ -305,7 +311,7 @@ func (in *EnvironmentConfiguration) DeepCopyInto(out *EnvironmentConfiguration)
 	*out = *in
 	if in.DefaultData != nil {
 		in, out := &in.DefaultData, &out.DefaultData
-		*out = make(map[string]v1.JSON, len(*in))
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
@@ -329,6 +335,11 @@ func (in *EnvironmentConfiguration) DeepCopyInto(out *EnvironmentConfiguration)
 		*out = new(commonv1.Policy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MergeStrategy != nil {
+		in, out := &in.MergeStrategy, &out.MergeStrategy
+		*out = new(EnvironmentMergeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentConfiguration.
@@ -341,6 +352,26 @@ func (in *EnvironmentConfiguration) DeepCopy() *EnvironmentConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentMergeStrategy) DeepCopyInto(out *EnvironmentMergeStrategy) {
+	*out = *in
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]EnvironmentPathMergeStrategy, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentMergeStrategy.
+func (in *EnvironmentMergeStrategy) DeepCopy() *EnvironmentMergeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentMergeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentPatch) DeepCopyInto(out *EnvironmentPatch) {
 	*out = *in
@@ -383,9 +414,29 @@ func (in *EnvironmentPatch) DeepCopy() *EnvironmentPatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentPathMergeStrategy) DeepCopyInto(out *EnvironmentPathMergeStrategy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentPathMergeStrategy.
+func (in *EnvironmentPathMergeStrategy) DeepCopy() *EnvironmentPathMergeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentPathMergeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentSource) DeepCopyInto(out *EnvironmentSource) {
 	*out = *in
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(EnvironmentSourceResource)
+		**out = **in
+	}
 	if in.Ref != nil {
 		in, out := &in.Ref, &out.Ref
 		*out = new(EnvironmentSourceReference)
@@ -423,6 +474,21 @@ func (in *EnvironmentSourceReference) DeepCopy() *EnvironmentSourceReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvironmentSourceResource) DeepCopyInto(out *EnvironmentSourceResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvironmentSourceResource.
+func (in *EnvironmentSourceResource) DeepCopy() *EnvironmentSourceResource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvironmentSourceResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvironmentSourceSelector) DeepCopyInto(out *EnvironmentSourceSelector) {
 	*out = *in
@@ -485,6 +551,21 @@ func (in *EnvironmentSourceSelectorLabelMatcher) DeepCopy() *EnvironmentSourceSe
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExpressionTransform) DeepCopyInto(out *ExpressionTransform) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExpressionTransform.
+func (in *ExpressionTransform) DeepCopy() *ExpressionTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(ExpressionTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FunctionReference) DeepCopyInto(out *FunctionReference) {
 	*out = *in
@@ -505,7 +586,7 @@ func (in *MapTransform) DeepCopyInto(out *MapTransform) {
 	*out = *in
 	if in.Pairs != nil {
 		in, out := &in.Pairs, &out.Pairs
-		*out = make(map[string]v1.JSON, len(*in))
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
@@ -864,6 +945,11 @@ func (in *Transform) DeepCopyInto(out *Transform) {
 		*out = new(ConvertTransform)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Expression != nil {
+		in, out := &in.Expression, &out.Expression
+		*out = new(ExpressionTransform)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Transform.
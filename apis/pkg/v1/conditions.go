@@ -30,6 +30,10 @@ const (
 
 	// A TypeHealthy indicates whether a package is healthy.
 	TypeHealthy xpv1.ConditionType = "Healthy"
+
+	// A TypeVerified indicates whether a package revision's image satisfies
+	// its verification policy.
+	TypeVerified xpv1.ConditionType = "Verified"
 )
 
 // Reasons a package is or is not installed.
@@ -42,6 +46,13 @@ const (
 	ReasonUnknownHealth xpv1.ConditionReason = "UnknownPackageRevisionHealth"
 )
 
+// Reasons a package is or is not verified.
+const (
+	ReasonVerified             xpv1.ConditionReason = "VerificationSucceeded"
+	ReasonVerificationFailed   xpv1.ConditionReason = "VerificationFailed"
+	ReasonVerificationDisabled xpv1.ConditionReason = "VerificationDisabled"
+)
+
 // Unpacking indicates that the package manager is waiting for a package
 // revision to be unpacked.
 func Unpacking() xpv1.Condition {
@@ -104,3 +115,38 @@ func UnknownHealth() xpv1.Condition {
 		Reason:             ReasonUnknownHealth,
 	}
 }
+
+// Verified indicates that a package revision's image satisfies its
+// verification policy.
+func Verified() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeVerified,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVerified,
+	}
+}
+
+// VerificationFailed indicates that a package revision's image does not
+// satisfy its verification policy.
+func VerificationFailed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeVerified,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVerificationFailed,
+	}
+}
+
+// VerificationDisabled indicates that a package revision's image was not
+// verified against its verification policy, because package verification is
+// disabled.
+func VerificationDisabled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeVerified,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonVerificationDisabled,
+		Message:            "Package verification is disabled, so the package image was not verified against its verification policy",
+	}
+}
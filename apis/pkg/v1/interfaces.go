@@ -112,6 +112,12 @@ type Package interface {
 	GetSkipDependencyResolution() *bool
 	SetSkipDependencyResolution(*bool)
 
+	GetSkipDependencyUpgrade() *bool
+	SetSkipDependencyUpgrade(*bool)
+
+	GetVerificationPolicyRef() *VerificationPolicyReference
+	SetVerificationPolicyRef(r *VerificationPolicyReference)
+
 	GetCommonLabels() map[string]string
 	SetCommonLabels(l map[string]string)
 }
@@ -231,6 +237,26 @@ func (p *Provider) SetSkipDependencyResolution(b *bool) {
 	p.Spec.SkipDependencyResolution = b
 }
 
+// GetVerificationPolicyRef of this Provider.
+func (p *Provider) GetVerificationPolicyRef() *VerificationPolicyReference {
+	return p.Spec.VerificationPolicyRef
+}
+
+// SetVerificationPolicyRef of this Provider.
+func (p *Provider) SetVerificationPolicyRef(r *VerificationPolicyReference) {
+	p.Spec.VerificationPolicyRef = r
+}
+
+// GetSkipDependencyUpgrade of this Provider.
+func (p *Provider) GetSkipDependencyUpgrade() *bool {
+	return p.Spec.SkipDependencyUpgrade
+}
+
+// SetSkipDependencyUpgrade of this Provider.
+func (p *Provider) SetSkipDependencyUpgrade(b *bool) {
+	p.Spec.SkipDependencyUpgrade = b
+}
+
 // GetCurrentIdentifier of this Provider.
 func (p *Provider) GetCurrentIdentifier() string {
 	return p.Status.CurrentIdentifier
@@ -356,6 +382,26 @@ func (p *Configuration) SetSkipDependencyResolution(b *bool) {
 	p.Spec.SkipDependencyResolution = b
 }
 
+// GetVerificationPolicyRef of this Configuration.
+func (p *Configuration) GetVerificationPolicyRef() *VerificationPolicyReference {
+	return p.Spec.VerificationPolicyRef
+}
+
+// SetVerificationPolicyRef of this Configuration.
+func (p *Configuration) SetVerificationPolicyRef(r *VerificationPolicyReference) {
+	p.Spec.VerificationPolicyRef = r
+}
+
+// GetSkipDependencyUpgrade of this Configuration.
+func (p *Configuration) GetSkipDependencyUpgrade() *bool {
+	return p.Spec.SkipDependencyUpgrade
+}
+
+// SetSkipDependencyUpgrade of this Configuration.
+func (p *Configuration) SetSkipDependencyUpgrade(b *bool) {
+	p.Spec.SkipDependencyUpgrade = b
+}
+
 // GetCurrentIdentifier of this Configuration.
 func (p *Configuration) GetCurrentIdentifier() string {
 	return p.Status.CurrentIdentifier
@@ -427,9 +473,15 @@ type PackageRevision interface {
 	GetSkipDependencyResolution() *bool
 	SetSkipDependencyResolution(*bool)
 
+	GetVerificationPolicyRef() *VerificationPolicyReference
+	SetVerificationPolicyRef(r *VerificationPolicyReference)
+
 	GetDependencyStatus() (found, installed, invalid int64)
 	SetDependencyStatus(found, installed, invalid int64)
 
+	GetVerificationStatus() *VerificationStatus
+	SetVerificationStatus(v *VerificationStatus)
+
 	GetCommonLabels() map[string]string
 	SetCommonLabels(l map[string]string)
 }
@@ -521,6 +573,16 @@ func (p *ProviderRevision) SetDependencyStatus(found, installed, invalid int64)
 	p.Status.InvalidDependencies = invalid
 }
 
+// GetVerificationStatus of this ProviderRevision.
+func (p *ProviderRevision) GetVerificationStatus() *VerificationStatus {
+	return p.Status.Verification
+}
+
+// SetVerificationStatus of this ProviderRevision.
+func (p *ProviderRevision) SetVerificationStatus(v *VerificationStatus) {
+	p.Status.Verification = v
+}
+
 // GetIgnoreCrossplaneConstraints of this ProviderRevision.
 func (p *ProviderRevision) GetIgnoreCrossplaneConstraints() *bool {
 	return p.Spec.IgnoreCrossplaneConstraints
@@ -561,6 +623,16 @@ func (p *ProviderRevision) SetSkipDependencyResolution(b *bool) {
 	p.Spec.SkipDependencyResolution = b
 }
 
+// GetVerificationPolicyRef of this ProviderRevision.
+func (p *ProviderRevision) GetVerificationPolicyRef() *VerificationPolicyReference {
+	return p.Spec.VerificationPolicyRef
+}
+
+// SetVerificationPolicyRef of this ProviderRevision.
+func (p *ProviderRevision) SetVerificationPolicyRef(r *VerificationPolicyReference) {
+	p.Spec.VerificationPolicyRef = r
+}
+
 // GetTLSServerSecretName of this ProviderRevision.
 func (p *ProviderRevision) GetTLSServerSecretName() *string {
 	return p.Spec.TLSServerSecretName
@@ -678,6 +750,16 @@ func (p *ConfigurationRevision) SetDependencyStatus(found, installed, invalid in
 	p.Status.InvalidDependencies = invalid
 }
 
+// GetVerificationStatus of this ConfigurationRevision.
+func (p *ConfigurationRevision) GetVerificationStatus() *VerificationStatus {
+	return p.Status.Verification
+}
+
+// SetVerificationStatus of this ConfigurationRevision.
+func (p *ConfigurationRevision) SetVerificationStatus(v *VerificationStatus) {
+	p.Status.Verification = v
+}
+
 // GetIgnoreCrossplaneConstraints of this ConfigurationRevision.
 func (p *ConfigurationRevision) GetIgnoreCrossplaneConstraints() *bool {
 	return p.Spec.IgnoreCrossplaneConstraints
@@ -698,6 +780,16 @@ func (p *ConfigurationRevision) SetSkipDependencyResolution(b *bool) {
 	p.Spec.SkipDependencyResolution = b
 }
 
+// GetVerificationPolicyRef of this ConfigurationRevision.
+func (p *ConfigurationRevision) GetVerificationPolicyRef() *VerificationPolicyReference {
+	return p.Spec.VerificationPolicyRef
+}
+
+// SetVerificationPolicyRef of this ConfigurationRevision.
+func (p *ConfigurationRevision) SetVerificationPolicyRef(r *VerificationPolicyReference) {
+	p.Spec.VerificationPolicyRef = r
+}
+
 // GetCommonLabels of this ConfigurationRevision.
 func (p *ConfigurationRevision) GetCommonLabels() map[string]string {
 	return p.Spec.CommonLabels
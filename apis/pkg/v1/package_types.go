@@ -67,6 +67,20 @@ type PackageSpec struct {
 	// +kubebuilder:default=false
 	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
 
+	// SkipDependencyUpgrade indicates to the package manager whether to skip
+	// upgrading this package when it is a dependency whose version no longer
+	// satisfies the constraints of the packages that depend on it.
+	// Default is false.
+	// +optional
+	// +kubebuilder:default=false
+	SkipDependencyUpgrade *bool `json:"skipDependencyUpgrade,omitempty"`
+
+	// VerificationPolicyRef references a VerificationPolicy that the package
+	// image's signatures and attestations must satisfy before it is
+	// installed.
+	// +optional
+	VerificationPolicyRef *VerificationPolicyReference `json:"verificationPolicyRef,omitempty"`
+
 	// Map of string keys and values that can be used to organize and categorize
 	// (scope and select) objects. May match selectors of replication controllers
 	// and services.
@@ -75,6 +75,12 @@ type PackageRevisionSpec struct {
 	// +kubebuilder:default=false
 	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
 
+	// VerificationPolicyRef references a VerificationPolicy that the package
+	// image's signatures and attestations must satisfy before it is
+	// installed.
+	// +optional
+	VerificationPolicyRef *VerificationPolicyReference `json:"verificationPolicyRef,omitempty"`
+
 	// Map of string keys and values that can be used to organize and categorize
 	// (scope and select) objects. May match selectors of replication controllers
 	// and services.
@@ -99,6 +105,28 @@ type PackageRevisionStatus struct {
 	// controller needs these permissions to run. The RBAC manager is
 	// responsible for granting them.
 	PermissionRequests []rbacv1.PolicyRule `json:"permissionRequests,omitempty"`
+
+	// Verification records the package image that was last verified against
+	// the revision's VerificationPolicy.
+	// +optional
+	Verification *VerificationStatus `json:"verification,omitempty"`
+}
+
+// VerificationStatus records the package image that was last verified against
+// a VerificationPolicy.
+type VerificationStatus struct {
+	// Digest of the verified package image.
+	Digest string `json:"digest"`
+
+	// PolicyHash is a hash of the spec of the VerificationPolicy the package
+	// image was verified against.
+	PolicyHash string `json:"policyHash"`
+}
+
+// A VerificationPolicyReference references a VerificationPolicy.
+type VerificationPolicyReference struct {
+	// Name of the VerificationPolicy.
+	Name string `json:"name"`
 }
 
 // A ControllerReference references the controller (e.g. Deployment), if any,
@@ -257,6 +257,11 @@ func (in *PackageRevisionSpec) DeepCopyInto(out *PackageRevisionSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.VerificationPolicyRef != nil {
+		in, out := &in.VerificationPolicyRef, &out.VerificationPolicyRef
+		*out = new(VerificationPolicyReference)
+		**out = **in
+	}
 	if in.CommonLabels != nil {
 		in, out := &in.CommonLabels, &out.CommonLabels
 		*out = make(map[string]string, len(*in))
@@ -292,6 +297,11 @@ func (in *PackageRevisionStatus) DeepCopyInto(out *PackageRevisionStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(VerificationStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRevisionStatus.
@@ -362,6 +372,16 @@ func (in *PackageSpec) DeepCopyInto(out *PackageSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.SkipDependencyUpgrade != nil {
+		in, out := &in.SkipDependencyUpgrade, &out.SkipDependencyUpgrade
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VerificationPolicyRef != nil {
+		in, out := &in.VerificationPolicyRef, &out.VerificationPolicyRef
+		*out = new(VerificationPolicyReference)
+		**out = **in
+	}
 	if in.CommonLabels != nil {
 		in, out := &in.CommonLabels, &out.CommonLabels
 		*out = make(map[string]string, len(*in))
@@ -589,3 +609,33 @@ func (in *RuntimeConfigReference) DeepCopy() *RuntimeConfigReference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationPolicyReference) DeepCopyInto(out *VerificationPolicyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationPolicyReference.
+func (in *VerificationPolicyReference) DeepCopy() *VerificationPolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationPolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationStatus) DeepCopyInto(out *VerificationStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationStatus.
+func (in *VerificationStatus) DeepCopy() *VerificationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
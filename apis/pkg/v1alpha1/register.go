@@ -48,6 +48,15 @@ var (
 	ControllerConfigGroupVersionKind = SchemeGroupVersion.WithKind(ControllerConfigKind)
 )
 
+// VerificationPolicy type metadata.
+var (
+	VerificationPolicyKind             = reflect.TypeOf(VerificationPolicy{}).Name()
+	VerificationPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: VerificationPolicyKind}.String()
+	VerificationPolicyKindAPIVersion   = VerificationPolicyKind + "." + SchemeGroupVersion.String()
+	VerificationPolicyGroupVersionKind = SchemeGroupVersion.WithKind(VerificationPolicyKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ControllerConfig{}, &ControllerConfigList{})
+	SchemeBuilder.Register(&VerificationPolicy{}, &VerificationPolicyList{})
 }
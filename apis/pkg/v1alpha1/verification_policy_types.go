@@ -0,0 +1,135 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// VerificationPolicySpec specifies how the package manager verifies the cosign
+// signatures and attestations of a package image before installing it.
+type VerificationPolicySpec struct {
+	// Authorities that are trusted to sign package images. A package image
+	// must be signed by at least one of them.
+	// +kubebuilder:validation:MinItems=1
+	Authorities []VerificationAuthority `json:"authorities"`
+
+	// Attestations that a package image must have. Each attestation must be
+	// signed by at least one of the trusted authorities.
+	// +optional
+	Attestations []RequiredAttestation `json:"attestations,omitempty"`
+}
+
+// A VerificationAuthority is trusted to sign package images, either using a
+// key or using a keyless identity. Exactly one of key or keyless must be set.
+type VerificationAuthority struct {
+	// Name of the authority.
+	Name string `json:"name"`
+
+	// Key verifies signatures made using a key pair.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.ignoreTlog || has(self.rekorPublicKey)",message="rekorPublicKey is required unless ignoreTlog is true"
+	Key *KeyAuthority `json:"key,omitempty"`
+
+	// Keyless verifies signatures made using a short-lived certificate issued
+	// to an OIDC identity by a Fulcio certificate authority, and recorded in a
+	// Rekor transparency log.
+	// +optional
+	Keyless *KeylessAuthority `json:"keyless,omitempty"`
+}
+
+// A KeyAuthority verifies signatures made using a key pair.
+type KeyAuthority struct {
+	// PublicKey is the PEM encoded public key used to verify signatures.
+	PublicKey string `json:"publicKey"`
+
+	// RekorPublicKey is the PEM encoded public key of the Rekor transparency
+	// log that signatures are recorded in. It's required unless IgnoreTlog is
+	// true.
+	// +optional
+	RekorPublicKey string `json:"rekorPublicKey,omitempty"`
+
+	// IgnoreTlog skips verifying that signatures are recorded in a Rekor
+	// transparency log. Without the log there is no trusted record of when a
+	// signature was made, so a signature made using a leaked key can't be
+	// told apart from a legitimate one.
+	// +optional
+	// +kubebuilder:default=false
+	IgnoreTlog bool `json:"ignoreTlog"`
+}
+
+// A KeylessAuthority verifies signatures made using short-lived certificates.
+type KeylessAuthority struct {
+	// Identities that are trusted to sign package images. The signing
+	// certificate must match at least one of them.
+	// +kubebuilder:validation:MinItems=1
+	Identities []KeylessIdentity `json:"identities"`
+
+	// FulcioRoots are the PEM encoded root and intermediate certificates of
+	// the Fulcio certificate authority that issues signing certificates.
+	FulcioRoots string `json:"fulcioRoots"`
+
+	// RekorPublicKey is the PEM encoded public key of the Rekor transparency
+	// log that signatures are recorded in.
+	RekorPublicKey string `json:"rekorPublicKey"`
+}
+
+// A KeylessIdentity is an OIDC identity that is trusted to sign package images.
+type KeylessIdentity struct {
+	// Issuer is the OIDC issuer that authenticated the identity, for example
+	// https://token.actions.githubusercontent.com.
+	Issuer string `json:"issuer"`
+
+	// Subject is the identity, for example an email address or the URI of a CI
+	// workflow.
+	Subject string `json:"subject"`
+}
+
+// A RequiredAttestation is an in-toto attestation a package image must have.
+type RequiredAttestation struct {
+	// Name of the attestation.
+	Name string `json:"name"`
+
+	// PredicateType of the attestation, for example
+	// https://slsa.dev/provenance/v0.2.
+	PredicateType string `json:"predicateType"`
+}
+
+// +kubebuilder:object:root=true
+// +genclient
+// +genclient:nonNamespaced
+
+// A VerificationPolicy specifies how the package manager verifies the cosign
+// signatures and attestations of a package image before installing it.
+// Providers, Configurations, and Functions reference a VerificationPolicy.
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories=crossplane
+type VerificationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VerificationPolicySpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// VerificationPolicyList contains a list of VerificationPolicy.
+type VerificationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VerificationPolicy `json:"items"`
+}
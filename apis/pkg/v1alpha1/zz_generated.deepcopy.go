@@ -215,6 +215,56 @@ func (in *ControllerConfigSpec) DeepCopy() *ControllerConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyAuthority) DeepCopyInto(out *KeyAuthority) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyAuthority.
+func (in *KeyAuthority) DeepCopy() *KeyAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeylessAuthority) DeepCopyInto(out *KeylessAuthority) {
+	*out = *in
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]KeylessIdentity, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeylessAuthority.
+func (in *KeylessAuthority) DeepCopy() *KeylessAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(KeylessAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeylessIdentity) DeepCopyInto(out *KeylessIdentity) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeylessIdentity.
+func (in *KeylessIdentity) DeepCopy() *KeylessIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(KeylessIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodObjectMeta) DeepCopyInto(out *PodObjectMeta) {
 	*out = *in
@@ -243,3 +293,128 @@ func (in *PodObjectMeta) DeepCopy() *PodObjectMeta {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequiredAttestation) DeepCopyInto(out *RequiredAttestation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequiredAttestation.
+func (in *RequiredAttestation) DeepCopy() *RequiredAttestation {
+	if in == nil {
+		return nil
+	}
+	out := new(RequiredAttestation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationAuthority) DeepCopyInto(out *VerificationAuthority) {
+	*out = *in
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
+		*out = new(KeyAuthority)
+		**out = **in
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = new(KeylessAuthority)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationAuthority.
+func (in *VerificationAuthority) DeepCopy() *VerificationAuthority {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationAuthority)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationPolicy) DeepCopyInto(out *VerificationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationPolicy.
+func (in *VerificationPolicy) DeepCopy() *VerificationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerificationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationPolicyList) DeepCopyInto(out *VerificationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VerificationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationPolicyList.
+func (in *VerificationPolicyList) DeepCopy() *VerificationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerificationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationPolicySpec) DeepCopyInto(out *VerificationPolicySpec) {
+	*out = *in
+	if in.Authorities != nil {
+		in, out := &in.Authorities, &out.Authorities
+		*out = make([]VerificationAuthority, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Attestations != nil {
+		in, out := &in.Attestations, &out.Attestations
+		*out = make([]RequiredAttestation, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationPolicySpec.
+func (in *VerificationPolicySpec) DeepCopy() *VerificationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
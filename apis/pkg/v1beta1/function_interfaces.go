@@ -137,6 +137,26 @@ func (f *Function) SetSkipDependencyResolution(b *bool) {
 	f.Spec.SkipDependencyResolution = b
 }
 
+// GetVerificationPolicyRef of this Function.
+func (f *Function) GetVerificationPolicyRef() *v1.VerificationPolicyReference {
+	return f.Spec.VerificationPolicyRef
+}
+
+// SetVerificationPolicyRef of this Function.
+func (f *Function) SetVerificationPolicyRef(ref *v1.VerificationPolicyReference) {
+	f.Spec.VerificationPolicyRef = ref
+}
+
+// GetSkipDependencyUpgrade of this Function.
+func (f *Function) GetSkipDependencyUpgrade() *bool {
+	return f.Spec.SkipDependencyUpgrade
+}
+
+// SetSkipDependencyUpgrade of this Function.
+func (f *Function) SetSkipDependencyUpgrade(b *bool) {
+	f.Spec.SkipDependencyUpgrade = b
+}
+
 // GetCurrentIdentifier of this Function.
 func (f *Function) GetCurrentIdentifier() string {
 	return f.Status.CurrentIdentifier
@@ -162,9 +182,10 @@ func (f *Function) GetTLSServerSecretName() *string {
 	return v1.GetSecretNameWithSuffix(f.GetName(), v1.TLSServerSecretNameSuffix)
 }
 
-// GetTLSClientSecretName of this Function.
+// GetTLSClientSecretName of this Function. Crossplane uses the client
+// certificate stored in this Secret to call the Function.
 func (f *Function) GetTLSClientSecretName() *string {
-	return nil
+	return v1.GetSecretNameWithSuffix(f.GetName(), v1.TLSClientSecretNameSuffix)
 }
 
 // GetCondition of this FunctionRevision.
@@ -254,6 +275,16 @@ func (r *FunctionRevision) SetDependencyStatus(found, installed, invalid int64)
 	r.Status.InvalidDependencies = invalid
 }
 
+// GetVerificationStatus of this FunctionRevision.
+func (r *FunctionRevision) GetVerificationStatus() *v1.VerificationStatus {
+	return r.Status.Verification
+}
+
+// SetVerificationStatus of this FunctionRevision.
+func (r *FunctionRevision) SetVerificationStatus(v *v1.VerificationStatus) {
+	r.Status.Verification = v
+}
+
 // GetIgnoreCrossplaneConstraints of this FunctionRevision.
 func (r *FunctionRevision) GetIgnoreCrossplaneConstraints() *bool {
 	return r.Spec.IgnoreCrossplaneConstraints
@@ -294,6 +325,16 @@ func (r *FunctionRevision) SetSkipDependencyResolution(b *bool) {
 	r.Spec.SkipDependencyResolution = b
 }
 
+// GetVerificationPolicyRef of this FunctionRevision.
+func (r *FunctionRevision) GetVerificationPolicyRef() *v1.VerificationPolicyReference {
+	return r.Spec.VerificationPolicyRef
+}
+
+// SetVerificationPolicyRef of this FunctionRevision.
+func (r *FunctionRevision) SetVerificationPolicyRef(ref *v1.VerificationPolicyReference) {
+	r.Spec.VerificationPolicyRef = ref
+}
+
 // GetTLSServerSecretName of this FunctionRevision.
 func (r *FunctionRevision) GetTLSServerSecretName() *string {
 	return r.Spec.TLSServerSecretName
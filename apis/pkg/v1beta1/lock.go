@@ -130,6 +130,69 @@ type Lock struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
 	Packages []LockPackage `json:"packages,omitempty"`
+
+	Status LockStatus `json:"status,omitempty"`
+}
+
+// A DependencyUpgradeState is the state of a planned dependency upgrade.
+type DependencyUpgradeState string
+
+// Dependency upgrade states.
+const (
+	// DependencyUpgradeApplied indicates the package resolver updated the
+	// dependency's package source to the desired version.
+	DependencyUpgradeApplied DependencyUpgradeState = "Applied"
+
+	// DependencyUpgradeSkipped indicates the dependency needs to be upgraded
+	// but opted out of automatic upgrades. No other dependency is upgraded
+	// until it is.
+	DependencyUpgradeSkipped DependencyUpgradeState = "Skipped"
+
+	// DependencyUpgradeUnsatisfiable indicates the package resolver could not
+	// find a version of every package in the lock that satisfies the
+	// constraints of the packages that depend on it, given the dependencies
+	// of each version.
+	DependencyUpgradeUnsatisfiable DependencyUpgradeState = "Unsatisfiable"
+)
+
+// A DependencyUpgrade is a planned change to the version of a package in the
+// lock, computed in order to satisfy the constraints of the packages that
+// depend on it.
+type DependencyUpgrade struct {
+	// Package is the OCI image name without a tag or digest.
+	Package string `json:"package"`
+
+	// Type is the type of package. Can be Configuration, Provider, or
+	// Function.
+	Type PackageType `json:"type"`
+
+	// Constraints are the version constraints placed upon the package by the
+	// packages that depend on it.
+	Constraints []string `json:"constraints,omitempty"`
+
+	// CurrentVersion is the installed tag of the OCI image.
+	CurrentVersion string `json:"currentVersion"`
+
+	// DesiredVersion is the tag of the OCI image the package resolver chose
+	// in order to satisfy the constraints of every package in the lock. For
+	// skipped upgrades it is the highest tag that satisfies the constraints
+	// placed upon the package. It is empty if no such tag exists.
+	// +optional
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+
+	// State of the planned upgrade.
+	State DependencyUpgradeState `json:"state"`
+}
+
+// LockStatus represents the observed state of a Lock.
+type LockStatus struct {
+	// Upgrades is the plan of dependency upgrades computed by the package
+	// resolver. It lists the packages whose version the resolver changed in
+	// order to satisfy the constraints of every package in the lock, or, if
+	// it found no such set of versions, the packages whose installed version
+	// does not satisfy the constraints of the packages that depend on them.
+	// +optional
+	Upgrades []DependencyUpgrade `json:"upgrades,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -40,6 +40,26 @@ func (in *Dependency) DeepCopy() *Dependency {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyUpgrade) DeepCopyInto(out *DependencyUpgrade) {
+	*out = *in
+	if in.Constraints != nil {
+		in, out := &in.Constraints, &out.Constraints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyUpgrade.
+func (in *DependencyUpgrade) DeepCopy() *DependencyUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DeploymentRuntimeConfig) DeepCopyInto(out *DeploymentRuntimeConfig) {
 	*out = *in
@@ -350,6 +370,7 @@ func (in *Lock) DeepCopyInto(out *Lock) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Lock.
@@ -422,6 +443,28 @@ func (in *LockPackage) DeepCopy() *LockPackage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LockStatus) DeepCopyInto(out *LockStatus) {
+	*out = *in
+	if in.Upgrades != nil {
+		in, out := &in.Upgrades, &out.Upgrades
+		*out = make([]DependencyUpgrade, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LockStatus.
+func (in *LockStatus) DeepCopy() *LockStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LockStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ObjectMeta) DeepCopyInto(out *ObjectMeta) {
 	*out = *in
@@ -26,7 +26,10 @@ import (
 	"github.com/alecthomas/kong"
 	"github.com/spf13/afero"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
@@ -34,18 +37,22 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
 
 	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	apiextensionsv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 )
 
 // Cmd arguments and flags for render subcommand.
 type Cmd struct {
 	// Arguments.
 	CompositeResource string `arg:"" type:"existingfile" help:"A YAML file specifying the composite resource (XR) to render."`
-	Composition       string `arg:"" type:"existingfile" help:"A YAML file specifying the Composition to use to render the XR. Must be mode: Pipeline."`
-	Functions         string `arg:"" type:"path" help:"A YAML file or directory of YAML files specifying the Composition Functions to use to render the XR."`
+	Composition       string `arg:"" type:"existingfile" help:"A YAML file specifying the Composition to use to render the XR."`
+	Functions         string `arg:"" optional:"" type:"path" help:"A YAML file or directory of YAML files specifying the Composition Functions to use to render the XR. Required if the Composition uses mode: Pipeline."`
 
 	// Flags. Keep them in alphabetical order.
 	ContextFiles           map[string]string `mapsep:"," help:"Comma-separated context key-value pairs to pass to the Function pipeline. Values must be files containing JSON."`
 	ContextValues          map[string]string `mapsep:"," help:"Comma-separated context key-value pairs to pass to the Function pipeline. Values must be JSON. Keys take precedence over --context-files."`
+	Diff                   bool              `help:"Read the XR, its composed resources, and extra resources from the cluster, then print what rendering would change instead of the rendered resources."`
+	EnvironmentConfigs     string            `placeholder:"PATH" type:"path" help:"A YAML file or directory of YAML files specifying EnvironmentConfigs to use to render the XR. Only used if the Composition uses mode: Resources."`
 	IncludeFunctionResults bool              `short:"r" help:"Include informational and warning messages from Functions in the rendered output as resources of kind: Result."`
 	IncludeFullXR          bool              `short:"x" help:"Include a direct copy of the input XR's spec and metadata fields in the rendered output."`
 	ObservedResources      string            `short:"o" placeholder:"PATH" type:"path" help:"A YAML file or directory of YAML files specifying the observed state of composed resources."`
@@ -64,7 +71,12 @@ This command shows you what composed resources Crossplane would create by
 printing them to stdout. It also prints any changes that would be made to the
 status of the XR. It doesn't talk to Crossplane. Instead it runs the Composition
 Function pipeline specified by the Composition locally, and uses that to render
-the XR. It only supports Compositions in Pipeline mode.
+the XR.
+
+Compositions in Resources mode (i.e. Patch and Transform Compositions) are
+rendered using the same logic Crossplane uses. No Functions are needed. Use
+--environment-configs to supply the EnvironmentConfigs the Composition's
+environment may select.
 
 Composition Functions are pulled and run using Docker by default. You can add
 the following annotations to each Function to change how they're run:
@@ -109,6 +121,15 @@ Examples:
   # Pass extra resources Functions in the pipeline can request.
   crossplane beta render xr.yaml composition.yaml functions.yaml \
 	--extra-resources=extra-resources.yaml
+
+  # Show what a new version of a Composition would change about an XR that
+  # already exists in the cluster.
+  crossplane beta render xr.yaml composition.yaml functions.yaml --diff
+
+  # Simulate updating an XR that uses a Patch and Transform Composition.
+  crossplane beta render xr.yaml composition.yaml \
+    --observed-resources=existing-observed-resources.yaml \
+    --environment-configs=environment-configs.yaml
 `
 }
 
@@ -140,13 +161,15 @@ func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error { //nolint:gocyclo //
 		return errors.Wrapf(errs.ToAggregate(), "invalid Composition %q", comp.GetName())
 	}
 
-	if m := comp.Spec.Mode; m == nil || *m != v1.CompositionModePipeline {
-		return errors.Errorf("render only supports Composition Function pipelines: Composition %q must use spec.mode: Pipeline", comp.GetName())
-	}
-
-	fns, err := LoadFunctions(c.fs, c.Functions)
-	if err != nil {
-		return errors.Wrapf(err, "cannot load functions from %q", c.Functions)
+	fns := []pkgv1beta1.Function{}
+	if m := comp.Spec.Mode; m != nil && *m == v1.CompositionModePipeline {
+		if c.Functions == "" {
+			return errors.Errorf("Composition %q uses spec.mode: Pipeline - you must specify the Functions to use to render the XR", comp.GetName())
+		}
+		fns, err = LoadFunctions(c.fs, c.Functions)
+		if err != nil {
+			return errors.Wrapf(err, "cannot load functions from %q", c.Functions)
+		}
 	}
 
 	ors := []composed.Unstructured{}
@@ -165,6 +188,14 @@ func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error { //nolint:gocyclo //
 		}
 	}
 
+	ecs := []apiextensionsv1alpha1.EnvironmentConfig{}
+	if c.EnvironmentConfigs != "" {
+		ecs, err = LoadEnvironmentConfigs(c.fs, c.EnvironmentConfigs)
+		if err != nil {
+			return errors.Wrapf(err, "cannot load EnvironmentConfigs from %q", c.EnvironmentConfigs)
+		}
+	}
+
 	fctx := map[string][]byte{}
 	for k, filename := range c.ContextFiles {
 		v, err := afero.ReadFile(c.fs, filename)
@@ -180,18 +211,48 @@ func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error { //nolint:gocyclo //
 	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
 	defer cancel()
 
-	out, err := Render(ctx, Inputs{
-		CompositeResource: xr,
-		Composition:       comp,
-		Functions:         fns,
-		ObservedResources: ors,
-		ExtraResources:    ers,
-		Context:           fctx,
-	})
+	in := Inputs{
+		CompositeResource:  xr,
+		Composition:        comp,
+		Functions:          fns,
+		ObservedResources:  ors,
+		ExtraResources:     ers,
+		Context:            fctx,
+		EnvironmentConfigs: ecs,
+	}
+
+	if c.Diff {
+		cfg, err := ctrl.GetConfig()
+		if err != nil {
+			return errors.Wrap(err, "cannot get kubeconfig")
+		}
+		sch := runtime.NewScheme()
+		if err := apiextensionsv1alpha1.AddToScheme(sch); err != nil {
+			return errors.Wrap(err, "cannot add EnvironmentConfig types to scheme")
+		}
+		kube, err := client.New(cfg, client.Options{Scheme: sch})
+		if err != nil {
+			return errors.Wrap(err, "cannot create kube client")
+		}
+		if in, err = WithLiveState(ctx, kube, in); err != nil {
+			return errors.Wrap(err, "cannot read live state from the cluster")
+		}
+	}
+
+	render := RenderPatchAndTransform
+	if m := comp.Spec.Mode; m != nil && *m == v1.CompositionModePipeline {
+		render = Render
+	}
+
+	out, err := render(ctx, in)
 	if err != nil {
 		return errors.Wrap(err, "cannot render composite resource")
 	}
 
+	if c.Diff {
+		return errors.Wrap(PrintDiff(k.Stdout, Diff(in.CompositeResource, in.ObservedResources, out)), "cannot print diff")
+	}
+
 	// TODO(negz): Right now we're just emitting the desired state, which is an
 	// overlay on the observed state. Would it be more useful to apply the
 	// overlay to show something more like what the final result would be? The
@@ -242,7 +303,7 @@ func (c *Cmd) Run(k *kong.Context, _ logging.Logger) error { //nolint:gocyclo //
 		}
 	}
 
-	if c.IncludeContext {
+	if c.IncludeContext && out.Context != nil {
 		fmt.Fprintln(k.Stdout, "---")
 		if err := s.Encode(out.Context, os.Stdout); err != nil {
 			return errors.Wrap(err, "cannot marshal context to YAML")
@@ -0,0 +1,350 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
+	ucomposite "github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+
+	apiextensionsv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/composite"
+)
+
+// A DiffType is the type of change rendering would make to a resource.
+type DiffType string
+
+// Diff types.
+const (
+	DiffTypeCreate DiffType = "Create"
+	DiffTypeUpdate DiffType = "Update"
+	DiffTypeDelete DiffType = "Delete"
+)
+
+// A ResourceDiff is the change rendering would make to a live resource.
+type ResourceDiff struct {
+	Type DiffType
+
+	APIVersion string
+	Kind       string
+	Name       string
+
+	// ResourceName is the composition resource name of a composed resource.
+	// It's empty for the XR.
+	ResourceName string
+
+	// Fields that would change. Empty for deleted resources.
+	Fields []FieldDiff
+}
+
+// A FieldDiff is the change rendering would make to a field of a resource.
+type FieldDiff struct {
+	// Path of the field, in field path syntax.
+	Path string
+
+	// Live value of the field. Nil if the field isn't set.
+	Live any
+
+	// Desired value of the field.
+	Desired any
+}
+
+// GetLiveState gets the supplied XR and the composed resources it references
+// from the API server.
+func GetLiveState(ctx context.Context, c client.Reader, xr *ucomposite.Unstructured) (*ucomposite.Unstructured, []composed.Unstructured, error) {
+	live := ucomposite.New()
+	live.SetGroupVersionKind(xr.GetObjectKind().GroupVersionKind())
+	if err := c.Get(ctx, types.NamespacedName{Namespace: xr.GetNamespace(), Name: xr.GetName()}, live); err != nil {
+		return nil, nil, errors.Wrapf(err, "cannot get composite resource %q", xr.GetName())
+	}
+
+	observed := make([]composed.Unstructured, 0, len(live.GetResourceReferences()))
+	for _, ref := range live.GetResourceReferences() {
+		// The XR references resources that haven't been created yet by name.
+		if ref.Name == "" {
+			continue
+		}
+		cd := composed.New(composed.FromReference(ref))
+		err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, cd)
+		if kerrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "cannot get composed resource %q", ref.Name)
+		}
+		observed = append(observed, *cd)
+	}
+
+	return live, observed, nil
+}
+
+// WithLiveState returns the supplied inputs, updated to use the live state of
+// the supplied XR. The XR and its composed resources are read from the API
+// server, and Functions' extra resources are fetched from the API server. So
+// are the EnvironmentConfigs a Patch and Transform Composition may select.
+func WithLiveState(ctx context.Context, c client.Reader, in Inputs) (Inputs, error) {
+	xr, observed, err := GetLiveState(ctx, c, in.CompositeResource)
+	if err != nil {
+		return Inputs{}, err
+	}
+	in.CompositeResource = xr
+	in.ObservedResources = observed
+	in.ExtraResourcesFetcher = composite.NewExistingExtraResourcesFetcher(c)
+
+	if in.Composition.Spec.Environment != nil {
+		ecs := &apiextensionsv1alpha1.EnvironmentConfigList{}
+		if err := c.List(ctx, ecs); err != nil {
+			return Inputs{}, errors.Wrap(err, "cannot list EnvironmentConfigs")
+		}
+		in.EnvironmentConfigs = ecs.Items
+	}
+
+	return in, nil
+}
+
+// Diff the desired XR and composed resources produced by rendering against the
+// live XR and composed resources. Composed resources are matched using their
+// composition resource name annotation, or if they don't have one their API
+// version, kind, and name. Only fields that rendering would set
+// are compared. Arrays are compared atomically, and the XR's status conditions
+// are ignored.
+func Diff(xr *ucomposite.Unstructured, observed []composed.Unstructured, out Outputs) []ResourceDiff {
+	diffs := make([]ResourceDiff, 0)
+
+	if fields := diffFields(xr.Object, withoutConditions(out.CompositeResource.Object)); len(fields) > 0 {
+		diffs = append(diffs, ResourceDiff{
+			Type:       DiffTypeUpdate,
+			APIVersion: xr.GetAPIVersion(),
+			Kind:       xr.GetKind(),
+			Name:       xr.GetName(),
+			Fields:     fields,
+		})
+	}
+
+	live := make(map[resourceKey]composed.Unstructured, len(observed))
+	for _, cd := range observed {
+		live[keyOf(cd)] = cd
+	}
+
+	desired := make(map[resourceKey]bool, len(out.ComposedResources))
+	for _, cd := range out.ComposedResources {
+		name := cd.GetAnnotations()[AnnotationKeyCompositionResourceName]
+		k := keyOf(cd)
+		desired[k] = true
+
+		d := ResourceDiff{
+			APIVersion:   cd.GetAPIVersion(),
+			Kind:         cd.GetKind(),
+			Name:         cd.GetName(),
+			ResourceName: name,
+		}
+
+		lcd, ok := live[k]
+		if !ok {
+			d.Type = DiffTypeCreate
+			d.Fields = diffFields(nil, cd.Object)
+			diffs = append(diffs, d)
+			continue
+		}
+
+		d.Type = DiffTypeUpdate
+		d.Name = lcd.GetName()
+		d.Fields = diffFields(lcd.Object, cd.Object)
+		if len(d.Fields) > 0 {
+			diffs = append(diffs, d)
+		}
+	}
+
+	for _, cd := range observed {
+		if desired[keyOf(cd)] {
+			continue
+		}
+		diffs = append(diffs, ResourceDiff{
+			Type:         DiffTypeDelete,
+			APIVersion:   cd.GetAPIVersion(),
+			Kind:         cd.GetKind(),
+			Name:         cd.GetName(),
+			ResourceName: cd.GetAnnotations()[AnnotationKeyCompositionResourceName],
+		})
+	}
+
+	return diffs
+}
+
+// A resourceKey identifies a composed resource. Composed resources are
+// identified by their composition resource name annotation. Resources without
+// the annotation are identified by their API version, kind, and name.
+type resourceKey struct {
+	resourceName string
+
+	apiVersion string
+	kind       string
+	name       string
+}
+
+func keyOf(cd composed.Unstructured) resourceKey {
+	if name := cd.GetAnnotations()[AnnotationKeyCompositionResourceName]; name != "" {
+		return resourceKey{resourceName: name}
+	}
+	return resourceKey{apiVersion: cd.GetAPIVersion(), kind: cd.GetKind(), name: cd.GetName()}
+}
+
+// PrintDiff prints the supplied diffs in a human readable format.
+func PrintDiff(w io.Writer, diffs []ResourceDiff) error {
+	if len(diffs) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	symbols := map[DiffType]string{DiffTypeCreate: "+", DiffTypeUpdate: "~", DiffTypeDelete: "-"}
+
+	for _, d := range diffs {
+		id := d.Kind
+		if d.Name != "" {
+			id += "/" + d.Name
+		}
+		if d.ResourceName != "" {
+			id += fmt.Sprintf(" (resource %q)", d.ResourceName)
+		}
+		if _, err := fmt.Fprintf(w, "%s %s\n", symbols[d.Type], id); err != nil {
+			return err
+		}
+		for _, f := range d.Fields {
+			desired, err := json.Marshal(f.Desired)
+			if err != nil {
+				return errors.Wrapf(err, "cannot marshal desired value of field %q", f.Path)
+			}
+			if f.Live == nil {
+				if _, err := fmt.Fprintf(w, "    + %s: %s\n", f.Path, desired); err != nil {
+					return err
+				}
+				continue
+			}
+			live, err := json.Marshal(f.Live)
+			if err != nil {
+				return errors.Wrapf(err, "cannot marshal live value of field %q", f.Path)
+			}
+			if _, err := fmt.Fprintf(w, "    ~ %s: %s => %s\n", f.Path, live, desired); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// diffFields returns the leaf fields of desired whose values differ from live,
+// sorted by path.
+func diffFields(live, desired map[string]any) []FieldDiff {
+	live, desired = normalize(live), normalize(desired)
+	fields := make([]FieldDiff, 0)
+	walk(nil, desired, func(path []string, v any) {
+		lv, ok := lookup(live, path)
+		if ok && reflect.DeepEqual(lv, v) {
+			return
+		}
+		fields = append(fields, FieldDiff{Path: strings.Join(path, "."), Live: lv, Desired: v})
+	})
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Path < fields[j].Path })
+	return fields
+}
+
+// normalize returns a copy of the supplied object with its numbers represented
+// consistently. Live objects read from the API server have int64 integers,
+// while rendered objects may have float64 integers. Decoding numbers as
+// json.Number keeps large integers exact.
+func normalize(o map[string]any) map[string]any {
+	if o == nil {
+		return nil
+	}
+	j, err := json.Marshal(o)
+	if err != nil {
+		return o
+	}
+	d := json.NewDecoder(bytes.NewReader(j))
+	d.UseNumber()
+	out := make(map[string]any)
+	if err := d.Decode(&out); err != nil {
+		return o
+	}
+	return out
+}
+
+// walk calls fn for every leaf of the supplied object. Arrays are leaves.
+func walk(path []string, o map[string]any, fn func(path []string, v any)) {
+	for k, v := range o {
+		p := append(append(make([]string, 0, len(path)+1), path...), k)
+		if m, ok := v.(map[string]any); ok && len(m) > 0 {
+			walk(p, m, fn)
+			continue
+		}
+		fn(p, v)
+	}
+}
+
+// lookup returns the value at the supplied path of the supplied object.
+func lookup(o map[string]any, path []string) (any, bool) {
+	var v any = o
+	for _, k := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[k]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// withoutConditions returns a copy of the supplied object without status
+// conditions, which always differ because of their transition times.
+func withoutConditions(o map[string]any) map[string]any {
+	out := make(map[string]any, len(o))
+	for k, v := range o {
+		out[k] = v
+	}
+	status, ok := o["status"].(map[string]any)
+	if !ok {
+		return out
+	}
+	s := make(map[string]any, len(status))
+	for k, v := range status {
+		if k == "conditions" {
+			continue
+		}
+		s[k] = v
+	}
+	delete(out, "status")
+	if len(s) > 0 {
+		out["status"] = s
+	}
+	return out
+}
@@ -0,0 +1,563 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+
+	fnv1beta1 "github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+func TestDiff(t *testing.T) {
+	xr := &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+		"apiVersion": "test.crossplane.io/v1",
+		"kind": "CoolComposite",
+		"metadata": {
+			"name": "test-render"
+		},
+		"spec": {
+			"coolField": "I'm cool!"
+		},
+		"status": {
+			"coolerField": "I'm cool!",
+			"conditions": [{
+				"type": "Ready",
+				"status": "True"
+			}]
+		}
+	}`)}}
+
+	observed := []composed.Unstructured{
+		{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+			"apiVersion": "test.crossplane.io/v1",
+			"kind": "Composed",
+			"metadata": {
+				"name": "test-render-a",
+				"annotations": {
+					"crossplane.io/composition-resource-name": "a"
+				}
+			},
+			"spec": {
+				"coolerField": "I'm cool!",
+				"tags": ["a"]
+			}
+		}`)}},
+		{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+			"apiVersion": "test.crossplane.io/v1",
+			"kind": "Composed",
+			"metadata": {
+				"name": "test-render-b",
+				"annotations": {
+					"crossplane.io/composition-resource-name": "b"
+				}
+			},
+			"spec": {
+				"coolerField": "I'm cool!"
+			}
+		}`)}},
+		{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+			"apiVersion": "test.crossplane.io/v1",
+			"kind": "Composed",
+			"metadata": {
+				"name": "test-render-c",
+				"annotations": {
+					"crossplane.io/composition-resource-name": "c"
+				}
+			}
+		}`)}},
+	}
+
+	type args struct {
+		xr       *composite.Unstructured
+		observed []composed.Unstructured
+		out      Outputs
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []ResourceDiff
+	}{
+		"NoChanges": {
+			reason: "We shouldn't return any diffs if rendering wouldn't change anything.",
+			args: args{
+				xr:       xr,
+				observed: observed[:1],
+				out: Outputs{
+					CompositeResource: &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "CoolComposite",
+						"metadata": {
+							"name": "test-render"
+						},
+						"status": {
+							"conditions": [{
+								"type": "Ready",
+								"status": "False"
+							}]
+						}
+					}`)}},
+					ComposedResources: observed[:1],
+				},
+			},
+			want: []ResourceDiff{},
+		},
+		"Changes": {
+			reason: "We should return a diff for every resource rendering would create, update, or delete.",
+			args: args{
+				xr:       xr,
+				observed: observed,
+				out: Outputs{
+					CompositeResource: &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "CoolComposite",
+						"metadata": {
+							"name": "test-render"
+						},
+						"status": {
+							"coolerField": "I'm cooler!"
+						}
+					}`)}},
+					ComposedResources: []composed.Unstructured{
+						{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Composed",
+							"metadata": {
+								"annotations": {
+									"crossplane.io/composition-resource-name": "a"
+								}
+							},
+							"spec": {
+								"coolerField": "I'm cooler!",
+								"tags": ["a", "b"]
+							}
+						}`)}},
+						{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Composed",
+							"metadata": {
+								"annotations": {
+									"crossplane.io/composition-resource-name": "b"
+								}
+							},
+							"spec": {
+								"coolerField": "I'm cool!"
+							}
+						}`)}},
+						{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Composed",
+							"metadata": {
+								"annotations": {
+									"crossplane.io/composition-resource-name": "d"
+								}
+							},
+							"spec": {
+								"coolField": "I'm new!"
+							}
+						}`)}},
+					},
+				},
+			},
+			want: []ResourceDiff{
+				{
+					Type:       DiffTypeUpdate,
+					APIVersion: "test.crossplane.io/v1",
+					Kind:       "CoolComposite",
+					Name:       "test-render",
+					Fields: []FieldDiff{
+						{Path: "status.coolerField", Live: "I'm cool!", Desired: "I'm cooler!"},
+					},
+				},
+				{
+					Type:         DiffTypeUpdate,
+					APIVersion:   "test.crossplane.io/v1",
+					Kind:         "Composed",
+					Name:         "test-render-a",
+					ResourceName: "a",
+					Fields: []FieldDiff{
+						{Path: "spec.coolerField", Live: "I'm cool!", Desired: "I'm cooler!"},
+						{Path: "spec.tags", Live: []any{"a"}, Desired: []any{"a", "b"}},
+					},
+				},
+				{
+					Type:         DiffTypeCreate,
+					APIVersion:   "test.crossplane.io/v1",
+					Kind:         "Composed",
+					ResourceName: "d",
+					Fields: []FieldDiff{
+						{Path: "apiVersion", Desired: "test.crossplane.io/v1"},
+						{Path: "kind", Desired: "Composed"},
+						{Path: "metadata.annotations.crossplane.io/composition-resource-name", Desired: "d"},
+						{Path: "spec.coolField", Desired: "I'm new!"},
+					},
+				},
+				{
+					Type:         DiffTypeDelete,
+					APIVersion:   "test.crossplane.io/v1",
+					Kind:         "Composed",
+					Name:         "test-render-c",
+					ResourceName: "c",
+				},
+			},
+		},
+		"ObservedWithoutResourceName": {
+			reason: "We should match composed resources without a composition resource name annotation by their API version, kind, and name.",
+			args: args{
+				xr: xr,
+				observed: []composed.Unstructured{
+					{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "Composed",
+						"metadata": {
+							"name": "test-render-x"
+						},
+						"spec": {
+							"coolerField": "I'm cool!"
+						}
+					}`)}},
+					{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "Composed",
+						"metadata": {
+							"name": "test-render-y"
+						}
+					}`)}},
+				},
+				out: Outputs{
+					CompositeResource: &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "CoolComposite",
+						"metadata": {
+							"name": "test-render"
+						}
+					}`)}},
+					ComposedResources: []composed.Unstructured{
+						{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Composed",
+							"metadata": {
+								"name": "test-render-x"
+							},
+							"spec": {
+								"coolerField": "I'm cooler!"
+							}
+						}`)}},
+					},
+				},
+			},
+			want: []ResourceDiff{
+				{
+					Type:       DiffTypeUpdate,
+					APIVersion: "test.crossplane.io/v1",
+					Kind:       "Composed",
+					Name:       "test-render-x",
+					Fields: []FieldDiff{
+						{Path: "spec.coolerField", Live: "I'm cool!", Desired: "I'm cooler!"},
+					},
+				},
+				{
+					Type:       DiffTypeDelete,
+					APIVersion: "test.crossplane.io/v1",
+					Kind:       "Composed",
+					Name:       "test-render-y",
+				},
+			},
+		},
+		"Numbers": {
+			reason: "We should compare integers read from the API server with the float64 integers rendering produces by value.",
+			args: args{
+				xr: &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "test.crossplane.io/v1",
+					"kind":       "CoolComposite",
+					"metadata":   map[string]any{"name": "test-render"},
+					"status":     map[string]any{"widgets": int64(9001)},
+				}}},
+				observed: []composed.Unstructured{{Unstructured: unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "test.crossplane.io/v1",
+					"kind":       "Composed",
+					"metadata": map[string]any{
+						"name":        "test-render-a",
+						"annotations": map[string]any{"crossplane.io/composition-resource-name": "a"},
+					},
+					"spec": map[string]any{
+						"replicas": int64(3),
+						"widgets":  int64(9002),
+						"ratio":    0.5,
+						"ports":    []any{int64(80), int64(443)},
+					},
+				}}}},
+				out: Outputs{
+					CompositeResource: &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "CoolComposite",
+						"metadata": {
+							"name": "test-render"
+						},
+						"status": {
+							"widgets": 9001
+						}
+					}`)}},
+					ComposedResources: []composed.Unstructured{{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "Composed",
+						"metadata": {
+							"annotations": {
+								"crossplane.io/composition-resource-name": "a"
+							}
+						},
+						"spec": {
+							"replicas": 3,
+							"widgets": 9003,
+							"ratio": 0.5,
+							"ports": [80, 443]
+						}
+					}`)}}},
+				},
+			},
+			want: []ResourceDiff{
+				{
+					Type:         DiffTypeUpdate,
+					APIVersion:   "test.crossplane.io/v1",
+					Kind:         "Composed",
+					Name:         "test-render-a",
+					ResourceName: "a",
+					Fields: []FieldDiff{
+						{Path: "spec.widgets", Live: json.Number("9002"), Desired: json.Number("9003")},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Diff(tc.args.xr, tc.args.observed, tc.args.out)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("%s\nDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPrintDiff(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		diffs  []ResourceDiff
+		want   string
+	}{
+		"NoChanges": {
+			reason: "We should say so if there are no changes.",
+			want:   "No changes.\n",
+		},
+		"Changes": {
+			reason: "We should print each change, and each changed field.",
+			diffs: []ResourceDiff{
+				{
+					Type:         DiffTypeUpdate,
+					Kind:         "Composed",
+					Name:         "test-render-a",
+					ResourceName: "a",
+					Fields: []FieldDiff{
+						{Path: "spec.coolerField", Live: "I'm cool!", Desired: "I'm cooler!"},
+					},
+				},
+				{
+					Type:         DiffTypeCreate,
+					Kind:         "Composed",
+					ResourceName: "d",
+					Fields: []FieldDiff{
+						{Path: "spec.coolField", Desired: "I'm new!"},
+					},
+				},
+				{
+					Type:         DiffTypeDelete,
+					Kind:         "Composed",
+					Name:         "test-render-c",
+					ResourceName: "c",
+				},
+			},
+			want: `~ Composed/test-render-a (resource "a")
+    ~ spec.coolerField: "I'm cool!" => "I'm cooler!"
++ Composed (resource "d")
+    + spec.coolField: "I'm new!"
+- Composed/test-render-c (resource "c")
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			if err := PrintDiff(b, tc.diffs); err != nil {
+				t.Fatalf("PrintDiff(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, b.String()); diff != "" {
+				t.Errorf("%s\nPrintDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// asLive returns the supplied object as the API server would return it, with
+// integers decoded as int64.
+func asLive(t *testing.T, o map[string]any) unstructured.Unstructured {
+	t.Helper()
+	j, err := json.Marshal(o)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u := unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(j); err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+func TestDiffRendered(t *testing.T) {
+	pipeline := apiextensionsv1.CompositionModePipeline
+
+	lis := NewFunction(t, &fnv1beta1.RunFunctionResponse{
+		Desired: &fnv1beta1.State{
+			Composite: &fnv1beta1.Resource{
+				Resource: MustStructJSON(`{
+					"status": {
+						"widgets": 9001
+					}
+				}`),
+			},
+			Resources: map[string]*fnv1beta1.Resource{
+				"a": {
+					Resource: MustStructJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "Composed",
+						"spec": {
+							"replicas": 3,
+							"ports": [80, 443]
+						}
+					}`),
+				},
+			},
+		},
+	})
+	defer lis.Close()
+
+	xr := func() *composite.Unstructured {
+		return &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+			"apiVersion": "test.crossplane.io/v1",
+			"kind": "CoolComposite",
+			"metadata": {
+				"name": "test-render",
+				"uid": "cool-uid"
+			},
+			"spec": {
+				"replicas": 3
+			}
+		}`)}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		in     Inputs
+		render func(context.Context, Inputs) (Outputs, error)
+	}{
+		"PatchAndTransform": {
+			reason: "Re-rendering a Patch and Transform Composition whose output was applied as-is should produce no changes.",
+			in: Inputs{
+				CompositeResource: xr(),
+				Composition: &apiextensionsv1.Composition{
+					ObjectMeta: metav1.ObjectMeta{Name: "cool-composition"},
+					Spec: apiextensionsv1.CompositionSpec{
+						CompositeTypeRef: apiextensionsv1.TypeReference{APIVersion: "test.crossplane.io/v1", Kind: "CoolComposite"},
+						Resources: []apiextensionsv1.ComposedTemplate{{
+							Name: ptr.To("a"),
+							Base: runtime.RawExtension{Raw: []byte(`{"apiVersion":"test.crossplane.io/v1","kind":"Composed","spec":{"ports":[80,443]}}`)},
+							Patches: []apiextensionsv1.Patch{{
+								Type:          apiextensionsv1.PatchTypeFromCompositeFieldPath,
+								FromFieldPath: ptr.To("spec.replicas"),
+								ToFieldPath:   ptr.To("spec.replicas"),
+							}},
+						}},
+					},
+				},
+			},
+			render: RenderPatchAndTransform,
+		},
+		"Pipeline": {
+			reason: "Re-rendering a Composition Function pipeline whose output was applied as-is should produce no changes.",
+			in: Inputs{
+				CompositeResource: xr(),
+				Composition: &apiextensionsv1.Composition{
+					ObjectMeta: metav1.ObjectMeta{Name: "cool-composition"},
+					Spec: apiextensionsv1.CompositionSpec{
+						CompositeTypeRef: apiextensionsv1.TypeReference{APIVersion: "test.crossplane.io/v1", Kind: "CoolComposite"},
+						Mode:             &pipeline,
+						Pipeline: []apiextensionsv1.PipelineStep{{
+							Step:        "test",
+							FunctionRef: apiextensionsv1.FunctionReference{Name: "function-test"},
+						}},
+					},
+				},
+				Functions: []pkgv1beta1.Function{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "function-test",
+						Annotations: map[string]string{
+							AnnotationKeyRuntime:                  string(AnnotationValueRuntimeDevelopment),
+							AnnotationKeyRuntimeDevelopmentTarget: lis.Addr().String(),
+						},
+					},
+				}},
+			},
+			render: Render,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			out, err := tc.render(context.Background(), tc.in)
+			if err != nil {
+				t.Fatalf("%s\nrender(...): %v", tc.reason, err)
+			}
+
+			// Pretend the rendered resources were applied, and read back
+			// from the API server.
+			live := &composite.Unstructured{Unstructured: asLive(t, out.CompositeResource.Object)}
+			observed := make([]composed.Unstructured, 0, len(out.ComposedResources))
+			for _, cd := range out.ComposedResources {
+				observed = append(observed, composed.Unstructured{Unstructured: asLive(t, cd.Object)})
+			}
+
+			got := Diff(live, observed, out)
+			if diff := cmp.Diff([]ResourceDiff{}, got); diff != "" {
+				t.Errorf("%s\nDiff(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
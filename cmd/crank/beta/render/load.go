@@ -30,6 +30,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
 
 	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	apiextensionsv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 )
 
@@ -111,6 +112,30 @@ func LoadExtraResources(fs afero.Fs, file string) ([]unstructured.Unstructured,
 	return resources, nil
 }
 
+// LoadEnvironmentConfigs from a stream of YAML manifests.
+func LoadEnvironmentConfigs(fs afero.Fs, file string) ([]apiextensionsv1alpha1.EnvironmentConfig, error) {
+	stream, err := LoadYAMLStream(fs, file)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load YAML stream from file")
+	}
+
+	configs := make([]apiextensionsv1alpha1.EnvironmentConfig, 0, len(stream))
+	for _, y := range stream {
+		ec := &apiextensionsv1alpha1.EnvironmentConfig{}
+		if err := yaml.Unmarshal(y, ec); err != nil {
+			return nil, errors.Wrap(err, "cannot parse YAML EnvironmentConfig manifest")
+		}
+		switch gvk := ec.GroupVersionKind(); gvk {
+		case apiextensionsv1alpha1.EnvironmentConfigGroupVersionKind:
+			configs = append(configs, *ec)
+		default:
+			return nil, errors.Errorf("not an EnvironmentConfig: %s/%s", gvk.Kind, ec.GetName())
+		}
+	}
+
+	return configs, nil
+}
+
 // LoadObservedResources from a stream of YAML manifests.
 func LoadObservedResources(fs afero.Fs, file string) ([]composed.Unstructured, error) {
 	stream, err := LoadYAMLStream(fs, file)
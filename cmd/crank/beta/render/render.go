@@ -26,8 +26,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/structpb"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -36,6 +38,7 @@ import (
 
 	fnv1beta1 "github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
 	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	apiextensionsv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 	"github.com/crossplane/crossplane/internal/controller/apiextensions/composite"
 )
@@ -67,6 +70,14 @@ type Inputs struct {
 	ExtraResources    []unstructured.Unstructured
 	Context           map[string][]byte
 
+	// EnvironmentConfigs are only used to render Compositions that use
+	// spec.mode: Resources.
+	EnvironmentConfigs []apiextensionsv1alpha1.EnvironmentConfig
+
+	// ExtraResourcesFetcher fetches the extra resources requested by
+	// Functions. Extra resources are selected from ExtraResources if it's nil.
+	ExtraResourcesFetcher composite.ExtraResourcesFetcher
+
 	// TODO(negz): Allow supplying observed XR and composed resource connection
 	// details. Maybe as Secrets? What if secret stores are in use?
 }
@@ -129,10 +140,18 @@ func Render(ctx context.Context, in Inputs) (Outputs, error) { //nolint:gocyclo
 		return Outputs{}, errors.Wrap(err, "cannot build observed composite and composed resources for RunFunctionRequest")
 	}
 
+	extra := in.ExtraResourcesFetcher
+	if extra == nil {
+		extra = composite.ExtraResourcesFetcherFn(func(_ context.Context, rs *fnv1beta1.ResourceSelector) (*fnv1beta1.Resources, error) {
+			return filterExtraResources(in.ExtraResources, rs)
+		})
+	}
+
 	// The Function pipeline starts with empty desired state.
 	d := &fnv1beta1.State{}
 
 	results := make([]unstructured.Unstructured, 0)
+	conditions := make([]composite.TargetedCondition, 0)
 
 	// The Function context starts empty.
 	fctx := &structpb.Struct{Fields: map[string]*structpb.Value{}}
@@ -202,9 +221,9 @@ func Render(ctx context.Context, in Inputs) (Outputs, error) { //nolint:gocyclo
 
 			// Fetch the requested resources and add them to the desired state.
 			for name, selector := range newRequirements.GetExtraResources() {
-				newExtraResources, err := filterExtraResources(in.ExtraResources, selector)
+				newExtraResources, err := extra.Fetch(ctx, selector)
 				if err != nil {
-					return Outputs{}, errors.Wrapf(err, "cannot filter extra resources for pipeline step %q", fn.Step)
+					return Outputs{}, errors.Wrapf(err, "cannot fetch extra resources for pipeline step %q", fn.Step)
 				}
 
 				// Resources would be nil in case of not found resources.
@@ -237,6 +256,10 @@ func Render(ctx context.Context, in Inputs) (Outputs, error) { //nolint:gocyclo
 				}})
 			}
 		}
+
+		for _, c := range rsp.GetConditions() {
+			conditions = append(conditions, composite.AsTargetedCondition(c))
+		}
 	}
 
 	desired := make([]composed.Unstructured, 0, len(d.GetResources()))
@@ -278,6 +301,22 @@ func Render(ctx context.Context, in Inputs) (Outputs, error) { //nolint:gocyclo
 	xr.SetKind(in.CompositeResource.GetKind())
 	xr.SetName(in.CompositeResource.GetName())
 
+	// Set any conditions returned by the Function pipeline, the same way
+	// Crossplane would.
+	claimTypes := make([]xpv1.ConditionType, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Type == "" || c.Type == xpv1.TypeReady || c.Type == xpv1.TypeSynced {
+			continue
+		}
+		xr.SetConditions(c.Condition)
+		if c.Target == composite.CompositionTargetCompositeAndClaim {
+			claimTypes = append(claimTypes, c.Type)
+		}
+	}
+	if err := composite.SetClaimConditionTypes(xr, claimTypes...); err != nil {
+		return Outputs{}, errors.Wrap(err, "cannot render desired composite resource claim condition types")
+	}
+
 	out := Outputs{CompositeResource: xr, ComposedResources: desired, Results: results}
 	if fctx != nil {
 		out.Context = &unstructured.Unstructured{Object: map[string]any{
@@ -315,6 +354,10 @@ func filterExtraResources(ers []unstructured.Unstructured, selector *fnv1beta1.R
 	if len(ers) == 0 || selector == nil {
 		return nil, nil
 	}
+	fs, err := fields.ParseSelector(selector.GetFieldSelector())
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse extra resources field selector")
+	}
 	out := &fnv1beta1.Resources{}
 	for _, er := range ers {
 		er := er
@@ -324,6 +367,12 @@ func filterExtraResources(ers []unstructured.Unstructured, selector *fnv1beta1.R
 		if selector.GetKind() != er.GetKind() {
 			continue
 		}
+		if ns := selector.GetNamespace(); ns != "" && ns != er.GetNamespace() {
+			continue
+		}
+		if !composite.MatchesFieldSelector(&er, fs) {
+			continue
+		}
 		if selector.GetMatchName() == er.GetName() {
 			o, err := composite.AsStruct(&er)
 			if err != nil {
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	xpunstructured "github.com/crossplane/crossplane-runtime/pkg/resource/unstructured"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
+	ucomposite "github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	apiextensionsv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/composite"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/composition"
+	"github.com/crossplane/crossplane/internal/names"
+)
+
+// RenderPatchAndTransform renders the desired XR and composed resources of a
+// Composition that uses spec.mode: Resources, given the supplied inputs.
+// Composed resources are returned in the order of the Composition's resource
+// templates.
+//
+// It uses the same Patch and Transform (P&T) Composer that Crossplane uses. The
+// Composer runs against an in-memory API server that is seeded with the XR,
+// its observed composed resources, and any EnvironmentConfigs.
+func RenderPatchAndTransform(ctx context.Context, in Inputs) (Outputs, error) { //nolint:gocyclo // Only a touch over.
+	s := runtime.NewScheme()
+	if err := corev1.AddToScheme(s); err != nil {
+		return Outputs{}, errors.Wrap(err, "cannot add core types to scheme")
+	}
+	if err := apiextensionsv1alpha1.AddToScheme(s); err != nil {
+		return Outputs{}, errors.Wrap(err, "cannot add EnvironmentConfig types to scheme")
+	}
+
+	xr := ucomposite.New()
+	xr.Object = in.CompositeResource.GetUnstructured().DeepCopy().Object
+
+	// Crossplane uses the XR's resource references to find its existing
+	// composed resources. We infer them from the observed resources if the
+	// supplied XR doesn't have them.
+	if len(xr.GetResourceReferences()) == 0 {
+		refs := make([]corev1.ObjectReference, 0, len(in.ObservedResources))
+		for i := range in.ObservedResources {
+			cd := &in.ObservedResources[i]
+			refs = append(refs, *meta.ReferenceTo(cd, cd.GetObjectKind().GroupVersionKind()))
+		}
+		xr.SetResourceReferences(refs)
+	}
+
+	objs := make([]client.Object, 0, 1+len(in.ObservedResources)+len(in.EnvironmentConfigs))
+	objs = append(objs, xr.GetUnstructured().DeepCopy())
+	for i := range in.ObservedResources {
+		objs = append(objs, in.ObservedResources[i].GetUnstructured().DeepCopy())
+	}
+	for i := range in.EnvironmentConfigs {
+		objs = append(objs, in.EnvironmentConfigs[i].DeepCopy())
+	}
+
+	kube := &recordingClient{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).Build(), xr: xr}
+
+	// Read the XR back so we have the resource version the in-memory API
+	// server assigned it.
+	if err := kube.Get(ctx, client.ObjectKeyFromObject(xr), xr.GetUnstructured()); err != nil {
+		return Outputs{}, errors.Wrap(err, "cannot prepare composite resource")
+	}
+
+	// Crossplane derives the names of composed resources from this label.
+	if err := composite.NewAPINamingConfigurator(xpunstructured.NewClient(kube)).Configure(ctx, xr, nil); err != nil {
+		return Outputs{}, errors.Wrap(err, "cannot configure composite resource")
+	}
+
+	rev := &apiextensionsv1.CompositionRevision{Spec: composition.NewCompositionRevisionSpec(in.Composition.Spec, 1)}
+
+	var env *composite.Environment
+	if rev.Spec.Environment != nil {
+		if err := composite.NewAPIEnvironmentSelector(kube).SelectEnvironment(ctx, xr, rev); err != nil {
+			return Outputs{}, errors.Wrap(err, "cannot select environment")
+		}
+		e, err := composite.NewAPIEnvironmentFetcher(kube).Fetch(ctx, composite.EnvironmentFetcherRequest{
+			Composite: xr,
+			Revision:  rev,
+			Required:  rev.Spec.Environment.IsRequired(),
+		})
+		if err != nil {
+			return Outputs{}, errors.Wrap(err, "cannot fetch environment")
+		}
+		env = e
+	}
+
+	// Don't generate names for new composed resources. Crossplane would, but
+	// we want render's output to be deterministic.
+	c := composite.NewPTComposer(kube, composite.WithComposedNameGenerator(names.NameGeneratorFn(func(_ context.Context, _ resource.Object) error { return nil })))
+	res, err := c.Compose(ctx, xr, composite.CompositionRequest{Revision: rev, Environment: env})
+	if err != nil {
+		return Outputs{}, errors.Wrap(err, "cannot compose resources")
+	}
+
+	results := make([]kunstructured.Unstructured, 0, len(res.Events))
+	for _, e := range res.Events {
+		severity := "SEVERITY_NORMAL"
+		if e.Type == event.TypeWarning {
+			severity = "SEVERITY_WARNING"
+		}
+		results = append(results, kunstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "render.crossplane.io/v1beta1",
+			"kind":       "Result",
+			"severity":   severity,
+			"message":    e.Message,
+		}})
+	}
+
+	// Set the XR's Ready condition the same way Crossplane would.
+	unready := make([]string, 0, len(res.Composed))
+	for _, cd := range res.Composed {
+		if !cd.Ready {
+			unready = append(unready, string(cd.ResourceName))
+		}
+	}
+	xr.SetConditions(xpv1.Available())
+	if len(unready) > 0 {
+		xr.SetConditions(xpv1.Creating().WithMessage(fmt.Sprintf("Unready resources: %s", resource.StableNAndSomeMore(resource.DefaultFirstN, unready))))
+	}
+
+	// Like a Function pipeline, only return the desired status of the XR.
+	out := ucomposite.New()
+	out.SetAPIVersion(xr.GetAPIVersion())
+	out.SetKind(xr.GetKind())
+	out.SetName(xr.GetName())
+	status, err := fieldpath.Pave(xr.Object).GetValue("status")
+	if err != nil {
+		return Outputs{}, errors.Wrap(err, "cannot render desired composite resource status")
+	}
+	out.Object["status"] = status
+
+	return Outputs{CompositeResource: out, ComposedResources: kube.applied, Results: results}, nil
+}
+
+// A recordingClient records the desired state of the composed resources the
+// P&T Composer applies.
+type recordingClient struct {
+	client.Client
+
+	xr      *ucomposite.Unstructured
+	applied []composed.Unstructured
+}
+
+// Create records the desired state of a composed resource before creating it.
+func (c *recordingClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if u, ok := obj.(*kunstructured.Unstructured); ok {
+		c.record(u.DeepCopy().Object)
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+// Patch records the desired state of a composed resource before patching it.
+func (c *recordingClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+	desired := map[string]any{}
+	if err := json.Unmarshal(data, &desired); err != nil {
+		return errors.Wrap(err, "cannot unmarshal patch")
+	}
+	c.record(desired)
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *recordingClient) record(o map[string]any) {
+	cd := composed.New()
+	cd.Object = o
+	if cd.GetObjectKind().GroupVersionKind() == c.xr.GetObjectKind().GroupVersionKind() && cd.GetName() == c.xr.GetName() {
+		return
+	}
+	c.applied = append(c.applied, *cd)
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	apiextensionsv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+)
+
+func TestRenderPatchAndTransform(t *testing.T) {
+	xr := func() *composite.Unstructured {
+		return &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+			"apiVersion": "test.crossplane.io/v1",
+			"kind": "CoolComposite",
+			"metadata": {
+				"name": "test-render",
+				"uid": "cool-uid"
+			},
+			"spec": {
+				"coolField": "I'm cool!"
+			}
+		}`)}}
+	}
+
+	comp := &apiextensionsv1.Composition{
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-composition"},
+		Spec: apiextensionsv1.CompositionSpec{
+			CompositeTypeRef: apiextensionsv1.TypeReference{APIVersion: "test.crossplane.io/v1", Kind: "CoolComposite"},
+			Environment: &apiextensionsv1.EnvironmentConfiguration{
+				EnvironmentConfigs: []apiextensionsv1.EnvironmentSource{{
+					Type: apiextensionsv1.EnvironmentSourceTypeReference,
+					Ref:  &apiextensionsv1.EnvironmentSourceReference{Name: "cool-environment"},
+				}},
+			},
+			Resources: []apiextensionsv1.ComposedTemplate{
+				{
+					Name: ptr.To("a"),
+					Base: runtime.RawExtension{Raw: []byte(`{"apiVersion":"test.crossplane.io/v1","kind":"Composed"}`)},
+					Patches: []apiextensionsv1.Patch{
+						{
+							Type:          apiextensionsv1.PatchTypeFromCompositeFieldPath,
+							FromFieldPath: ptr.To("spec.coolField"),
+							ToFieldPath:   ptr.To("spec.coolerField"),
+						},
+						{
+							Type:          apiextensionsv1.PatchTypeFromEnvironmentFieldPath,
+							FromFieldPath: ptr.To("region"),
+							ToFieldPath:   ptr.To("spec.region"),
+						},
+						{
+							Type:          apiextensionsv1.PatchTypeToCompositeFieldPath,
+							FromFieldPath: ptr.To("status.coolerField"),
+							ToFieldPath:   ptr.To("status.coolerField"),
+						},
+					},
+				},
+				{
+					Name: ptr.To("b"),
+					Base: runtime.RawExtension{Raw: []byte(`{"apiVersion":"test.crossplane.io/v1","kind":"Composed"}`)},
+					Patches: []apiextensionsv1.Patch{
+						{
+							Type:          apiextensionsv1.PatchTypeFromCompositeFieldPath,
+							FromFieldPath: ptr.To("spec.missingField"),
+							ToFieldPath:   ptr.To("spec.coolerField"),
+							Policy:        &apiextensionsv1.PatchPolicy{FromFieldPath: ptr.To(apiextensionsv1.FromFieldPathPolicyRequired)},
+						},
+					},
+				},
+				{
+					Name: ptr.To("c"),
+					Base: runtime.RawExtension{Raw: []byte(`{"apiVersion":"test.crossplane.io/v1","kind":"Composed"}`)},
+				},
+			},
+		},
+	}
+
+	observed := []composed.Unstructured{{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+		"apiVersion": "test.crossplane.io/v1",
+		"kind": "Composed",
+		"metadata": {
+			"name": "test-render-a",
+			"annotations": {
+				"crossplane.io/composition-resource-name": "a"
+			}
+		},
+		"status": {
+			"coolerField": "I'm cooler!",
+			"conditions": [{
+				"type": "Ready",
+				"status": "True",
+				"reason": "Available",
+				"lastTransitionTime": "2024-01-01T00:00:00Z"
+			}]
+		}
+	}`)}}}
+
+	envs := []apiextensionsv1alpha1.EnvironmentConfig{{
+		TypeMeta:   metav1.TypeMeta{APIVersion: apiextensionsv1alpha1.SchemeGroupVersion.String(), Kind: apiextensionsv1alpha1.EnvironmentConfigKind},
+		ObjectMeta: metav1.ObjectMeta{Name: "cool-environment"},
+		Data:       map[string]extv1.JSON{"region": {Raw: []byte(`"us-cool-1"`)}},
+	}}
+
+	type args struct {
+		in Inputs
+	}
+	type want struct {
+		out Outputs
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Success": {
+			reason: "We should render the composed resources and XR status produced by patches and transforms.",
+			args: args{
+				in: Inputs{
+					CompositeResource:  xr(),
+					Composition:        comp,
+					ObservedResources:  observed,
+					EnvironmentConfigs: envs,
+				},
+			},
+			want: want{
+				out: Outputs{
+					CompositeResource: &composite.Unstructured{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+						"apiVersion": "test.crossplane.io/v1",
+						"kind": "CoolComposite",
+						"metadata": {
+							"name": "test-render"
+						},
+						"status": {
+							"coolerField": "I'm cooler!",
+							"conditions": [{
+								"type": "Ready",
+								"status": "False",
+								"reason": "Creating",
+								"message": "Unready resources: b, c",
+								"lastTransitionTime": "2024-01-01T00:00:00Z"
+							}]
+						}
+					}`)}},
+					ComposedResources: []composed.Unstructured{
+						{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Composed",
+							"metadata": {
+								"name": "test-render-a",
+								"generateName": "test-render-",
+								"labels": {
+									"crossplane.io/composite": "test-render",
+									"crossplane.io/claim-name": "",
+									"crossplane.io/claim-namespace": ""
+								},
+								"annotations": {
+									"crossplane.io/composition-resource-name": "a"
+								},
+								"ownerReferences": [{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "CoolComposite",
+									"name": "test-render",
+									"uid": "cool-uid",
+									"blockOwnerDeletion": true,
+									"controller": true
+								}]
+							},
+							"spec": {
+								"coolerField": "I'm cool!",
+								"region": "us-cool-1"
+							}
+						}`)}},
+						{Unstructured: unstructured.Unstructured{Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Composed",
+							"metadata": {
+								"generateName": "test-render-",
+								"labels": {
+									"crossplane.io/composite": "test-render",
+									"crossplane.io/claim-name": "",
+									"crossplane.io/claim-namespace": ""
+								},
+								"annotations": {
+									"crossplane.io/composition-resource-name": "c"
+								},
+								"ownerReferences": [{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind": "CoolComposite",
+									"name": "test-render",
+									"uid": "cool-uid",
+									"blockOwnerDeletion": true,
+									"controller": true
+								}]
+							}
+						}`)}},
+					},
+					Results: []unstructured.Unstructured{
+						{Object: MustLoadJSON(`{
+							"apiVersion": "render.crossplane.io/v1beta1",
+							"kind": "Result",
+							"severity": "SEVERITY_WARNING",
+							"message": "cannot render FromComposite or environment patches for composed resource \"b\": cannot apply the \"FromCompositeFieldPath\" patch at index 0: spec.missingField: no such field"
+						}`)},
+					},
+				},
+			},
+		},
+		"InvalidBase": {
+			reason: "We should return an error if a resource template's base can't be parsed.",
+			args: args{
+				in: Inputs{
+					CompositeResource: xr(),
+					Composition: &apiextensionsv1.Composition{
+						Spec: apiextensionsv1.CompositionSpec{
+							Resources: []apiextensionsv1.ComposedTemplate{{
+								Name: ptr.To("a"),
+								Base: runtime.RawExtension{Raw: []byte(`{`)},
+							}},
+						},
+					},
+				},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			out, err := RenderPatchAndTransform(context.Background(), tc.args.in)
+
+			if diff := cmp.Diff(tc.want.out, out, cmpopts.EquateEmpty(), cmpopts.IgnoreMapEntries(func(k string, _ any) bool {
+				return k == "lastTransitionTime"
+			})); diff != "" {
+				t.Errorf("%s\nRenderPatchAndTransform(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("%s\nRenderPatchAndTransform(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -31,6 +31,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
@@ -821,6 +822,97 @@ func TestFilterExtraResources(t *testing.T) {
 				err: nil,
 			},
 		},
+		"MatchLabelsInNamespaceWithFieldSelector": {
+			reason: "Should return only matching resources in the selected namespace that match the field selector",
+			args: args{
+				ers: []unstructured.Unstructured{
+					{
+						Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Bar",
+							"metadata": {
+								"name": "extra-resource-wrong-namespace",
+								"namespace": "other",
+								"labels": {
+									"right": "true"
+								}
+							},
+							"spec": {
+								"tier": "gold"
+							}
+						}`),
+					},
+					{
+						Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Bar",
+							"metadata": {
+								"name": "extra-resource-wrong-field",
+								"namespace": "default",
+								"labels": {
+									"right": "true"
+								}
+							},
+							"spec": {
+								"tier": "silver"
+							}
+						}`),
+					},
+					{
+						Object: MustLoadJSON(`{
+							"apiVersion": "test.crossplane.io/v1",
+							"kind": "Bar",
+							"metadata": {
+								"name": "extra-resource-right",
+								"namespace": "default",
+								"labels": {
+									"right": "true"
+								}
+							},
+							"spec": {
+								"tier": "gold"
+							}
+						}`),
+					},
+				},
+				selector: &fnv1beta1.ResourceSelector{
+					ApiVersion:    "test.crossplane.io/v1",
+					Kind:          "Bar",
+					Namespace:     ptr.To("default"),
+					FieldSelector: ptr.To("spec.tier=gold"),
+					Match: &fnv1beta1.ResourceSelector_MatchLabels{
+						MatchLabels: &fnv1beta1.MatchLabels{
+							Labels: map[string]string{
+								"right": "true",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				out: &fnv1beta1.Resources{
+					Items: []*fnv1beta1.Resource{
+						{
+							Resource: MustStructJSON(`{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind": "Bar",
+								"metadata": {
+									"name": "extra-resource-right",
+									"namespace": "default",
+									"labels": {
+										"right": "true"
+									}
+								},
+								"spec": {
+									"tier": "gold"
+								}
+							}`),
+						},
+					},
+				},
+				err: nil,
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -0,0 +1,222 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drift detects resources in a resource tree that have drifted from
+// what their Composition would render now.
+package drift
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	ucomposite "github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/cmd/crank/beta/render"
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
+)
+
+const (
+	errFmtCheckComposite = "cannot check drift of composite resource %q"
+	errGetComposition    = "cannot get Composition"
+	errFmtGetFunction    = "cannot get Function %q"
+	errLiveState         = "cannot read live state from the cluster"
+	errRender            = "cannot render composite resource"
+)
+
+// A RenderFn renders the desired state of an XR and its composed resources.
+type RenderFn func(ctx context.Context, in render.Inputs) (render.Outputs, error)
+
+// A Checker checks whether the resources in a resource tree have drifted from
+// what their Composition would render now.
+type Checker struct {
+	client client.Reader
+
+	renderPT       RenderFn
+	renderPipeline RenderFn
+}
+
+// A CheckerOption configures a Checker.
+type CheckerOption func(*Checker)
+
+// WithPatchAndTransformRenderFn configures how a Checker renders Compositions
+// that use spec.mode: Resources.
+func WithPatchAndTransformRenderFn(fn RenderFn) CheckerOption {
+	return func(c *Checker) {
+		c.renderPT = fn
+	}
+}
+
+// WithPipelineRenderFn configures how a Checker renders Compositions that use
+// spec.mode: Pipeline.
+func WithPipelineRenderFn(fn RenderFn) CheckerOption {
+	return func(c *Checker) {
+		c.renderPipeline = fn
+	}
+}
+
+// NewChecker returns a Checker that renders XRs using the same machinery as
+// crossplane beta render. The supplied client must be able to read
+// Compositions, Functions, and EnvironmentConfigs.
+func NewChecker(c client.Reader, opts ...CheckerOption) *Checker {
+	ch := &Checker{
+		client:         c,
+		renderPT:       render.RenderPatchAndTransform,
+		renderPipeline: render.Render,
+	}
+	for _, o := range opts {
+		o(ch)
+	}
+	return ch
+}
+
+// Check every XR in the supplied resource tree. Each XR is rendered using its
+// Composition, and compared with its live state. Each checked XR and composed
+// resource is annotated with the fields that have drifted.
+func (c *Checker) Check(ctx context.Context, root *resource.Resource) error {
+	queue := []*resource.Resource{root}
+	for len(queue) > 0 {
+		r := queue[0]
+		queue = queue[1:]
+		queue = append(queue, r.Children...)
+
+		if !isComposite(r) {
+			continue
+		}
+		if err := c.checkComposite(ctx, r); err != nil {
+			return errors.Wrapf(err, errFmtCheckComposite, r.Unstructured.GetName())
+		}
+	}
+	return nil
+}
+
+func (c *Checker) checkComposite(ctx context.Context, r *resource.Resource) error {
+	xr := &ucomposite.Unstructured{Unstructured: *r.Unstructured.DeepCopy()}
+
+	comp := &apiextensionsv1.Composition{}
+	if err := c.client.Get(ctx, types.NamespacedName{Name: xr.GetCompositionReference().Name}, comp); err != nil {
+		return errors.Wrap(err, errGetComposition)
+	}
+
+	fn := c.renderPT
+	in := render.Inputs{CompositeResource: xr, Composition: comp}
+	if m := comp.Spec.Mode; m != nil && *m == apiextensionsv1.CompositionModePipeline {
+		fn = c.renderPipeline
+		fns, err := c.getFunctions(ctx, comp)
+		if err != nil {
+			return err
+		}
+		in.Functions = fns
+	}
+
+	in, err := render.WithLiveState(ctx, c.client, in)
+	if err != nil {
+		return errors.Wrap(err, errLiveState)
+	}
+
+	out, err := fn(ctx, in)
+	if err != nil {
+		return errors.Wrap(err, errRender)
+	}
+
+	Annotate(r, render.Diff(in.CompositeResource, in.ObservedResources, out))
+	return nil
+}
+
+// getFunctions gets the Functions the supplied Composition's pipeline uses.
+func (c *Checker) getFunctions(ctx context.Context, comp *apiextensionsv1.Composition) ([]pkgv1beta1.Function, error) {
+	fns := make([]pkgv1beta1.Function, 0, len(comp.Spec.Pipeline))
+	seen := map[string]bool{}
+	for _, s := range comp.Spec.Pipeline {
+		name := s.FunctionRef.Name
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		fn := pkgv1beta1.Function{}
+		if err := c.client.Get(ctx, types.NamespacedName{Name: name}, &fn); err != nil {
+			return nil, errors.Wrapf(err, errFmtGetFunction, name)
+		}
+		fns = append(fns, fn)
+	}
+	return fns, nil
+}
+
+// Annotate the supplied XR and its children with the supplied diffs. The XR
+// and each of its children that exist are annotated, even if they haven't
+// drifted. Diffs for resources that rendering would create or delete are
+// ignored; they don't have drifted fields.
+func Annotate(xr *resource.Resource, diffs []render.ResourceDiff) {
+	xr.Drift = &resource.Drift{Fields: []resource.DriftedField{}}
+	for _, child := range xr.Children {
+		if child.Error != nil || isConnectionSecret(child) {
+			continue
+		}
+		child.Drift = &resource.Drift{Fields: []resource.DriftedField{}}
+	}
+
+	for _, d := range diffs {
+		if d.Type != render.DiffTypeUpdate {
+			continue
+		}
+		r := find(xr, d)
+		if r == nil || r.Drift == nil {
+			continue
+		}
+		for _, f := range d.Fields {
+			r.Drift.Fields = append(r.Drift.Fields, resource.DriftedField{Path: f.Path, Live: f.Live, Desired: f.Desired})
+		}
+	}
+}
+
+// find the supplied XR, or the child of the supplied XR, that the supplied
+// diff is for.
+func find(xr *resource.Resource, d render.ResourceDiff) *resource.Resource {
+	if matches(xr, d) {
+		return xr
+	}
+	for _, child := range xr.Children {
+		if matches(child, d) {
+			return child
+		}
+	}
+	return nil
+}
+
+func matches(r *resource.Resource, d render.ResourceDiff) bool {
+	return r.Unstructured.GetAPIVersion() == d.APIVersion && r.Unstructured.GetKind() == d.Kind && r.Unstructured.GetName() == d.Name
+}
+
+// isComposite returns true if the supplied resource is an XR. Claims are
+// namespaced, while XRs aren't. Only XRs reference a Composition without
+// referencing another resource.
+func isComposite(r *resource.Resource) bool {
+	if r.Error != nil || r.Unstructured.GetNamespace() != "" {
+		return false
+	}
+	xr := &ucomposite.Unstructured{Unstructured: r.Unstructured}
+	ref := xr.GetCompositionReference()
+	return ref != nil && ref.Name != ""
+}
+
+func isConnectionSecret(r *resource.Resource) bool {
+	return r.Unstructured.GetAPIVersion() == "v1" && r.Unstructured.GetKind() == "Secret"
+}
@@ -0,0 +1,296 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package drift
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
+	ucomposite "github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/cmd/crank/beta/render"
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
+)
+
+func xr() unstructured.Unstructured {
+	u := ucomposite.New()
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("XCoolComposite")
+	u.SetName("cool-xr")
+	u.SetCompositionReference(&corev1.ObjectReference{Name: "cool-composition"})
+	u.SetResourceReferences([]corev1.ObjectReference{{APIVersion: "example.org/v1", Kind: "Cool", Name: "cool-xr-a"}})
+	return u.Unstructured
+}
+
+func cd() unstructured.Unstructured {
+	u := composed.New()
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("Cool")
+	u.SetName("cool-xr-a")
+	u.SetAnnotations(map[string]string{render.AnnotationKeyCompositionResourceName: "a"})
+	u.Object["spec"] = map[string]any{"region": "us-cool-1"}
+	return u.Unstructured
+}
+
+func secret() unstructured.Unstructured {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind("Secret")
+	u.SetName("cool-secret")
+	return u
+}
+
+func tree() *resource.Resource {
+	claim := unstructured.Unstructured{}
+	claim.SetAPIVersion("example.org/v1")
+	claim.SetKind("CoolComposite")
+	claim.SetName("cool-claim")
+	claim.SetNamespace("default")
+	claim.Object["spec"] = map[string]any{"compositionRef": map[string]any{"name": "cool-composition"}}
+
+	return &resource.Resource{
+		Unstructured: claim,
+		Children: []*resource.Resource{{
+			Unstructured: xr(),
+			Children: []*resource.Resource{
+				{Unstructured: cd()},
+				{Unstructured: secret()},
+			},
+		}},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// Get returns the live XR and composed resource, and the supplied
+	// Composition.
+	get := func(comp *apiextensionsv1.Composition) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *apiextensionsv1.Composition:
+				comp.DeepCopyInto(o)
+			case *ucomposite.Unstructured:
+				o.Unstructured = xr()
+			case *composed.Unstructured:
+				o.Unstructured = cd()
+			case *pkgv1beta1.Function:
+				return errBoom
+			}
+			return nil
+		}
+	}
+
+	// renderDrifted renders a composed resource in a different region.
+	renderDrifted := func(_ context.Context, in render.Inputs) (render.Outputs, error) {
+		out := cd()
+		out.SetName("")
+		out.Object["spec"] = map[string]any{"region": "us-cooler-1"}
+		return render.Outputs{
+			CompositeResource: &ucomposite.Unstructured{Unstructured: unstructured.Unstructured{Object: map[string]any{
+				"apiVersion": "example.org/v1",
+				"kind":       "XCoolComposite",
+				"metadata":   map[string]any{"name": "cool-xr"},
+			}}},
+			ComposedResources: []composed.Unstructured{{Unstructured: out}},
+		}, nil
+	}
+
+	// getReplicas is like get, but returns a live composed resource with the
+	// number of replicas the API server would return.
+	getReplicas := func(comp *apiextensionsv1.Composition) test.MockGetFn {
+		return func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+			if o, ok := obj.(*composed.Unstructured); ok {
+				o.Unstructured = cd()
+				o.Object["spec"] = map[string]any{"region": "us-cool-1", "replicas": int64(3)}
+				return nil
+			}
+			return get(comp)(ctx, key, obj)
+		}
+	}
+
+	// renderReplicas renders a composed resource with the supplied number of
+	// replicas, as a function would.
+	renderReplicas := func(replicas float64) RenderFn {
+		return func(_ context.Context, in render.Inputs) (render.Outputs, error) {
+			out := cd()
+			out.SetName("")
+			out.Object["spec"] = map[string]any{"region": "us-cool-1", "replicas": replicas}
+			return render.Outputs{
+				CompositeResource: &ucomposite.Unstructured{Unstructured: unstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1",
+					"kind":       "XCoolComposite",
+					"metadata":   map[string]any{"name": "cool-xr"},
+				}}},
+				ComposedResources: []composed.Unstructured{{Unstructured: out}},
+			}, nil
+		}
+	}
+
+	type params struct {
+		client client.Reader
+		opts   []CheckerOption
+	}
+	type want struct {
+		root *resource.Resource
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		params params
+		root   *resource.Resource
+		want   want
+	}{
+		"NoComposites": {
+			reason: "We shouldn't check drift of a tree that doesn't contain any XRs.",
+			params: params{
+				client: &test.MockClient{},
+			},
+			root: &resource.Resource{Unstructured: cd()},
+			want: want{
+				root: &resource.Resource{Unstructured: cd()},
+			},
+		},
+		"GetCompositionError": {
+			reason: "We should return any error encountered getting an XR's Composition.",
+			params: params{
+				client: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			},
+			root: tree(),
+			want: want{
+				root: tree(),
+				err:  errors.Wrapf(errors.Wrap(errBoom, errGetComposition), errFmtCheckComposite, "cool-xr"),
+			},
+		},
+		"GetFunctionError": {
+			reason: "We should return any error encountered getting the Functions of a pipeline Composition.",
+			params: params{
+				client: &test.MockClient{MockGet: get(&apiextensionsv1.Composition{
+					Spec: apiextensionsv1.CompositionSpec{
+						Mode:     ptr.To(apiextensionsv1.CompositionModePipeline),
+						Pipeline: []apiextensionsv1.PipelineStep{{Step: "cool", FunctionRef: apiextensionsv1.FunctionReference{Name: "function-cool"}}},
+					},
+				})},
+			},
+			root: tree(),
+			want: want{
+				root: tree(),
+				err:  errors.Wrapf(errors.Wrapf(errBoom, errFmtGetFunction, "function-cool"), errFmtCheckComposite, "cool-xr"),
+			},
+		},
+		"RenderError": {
+			reason: "We should return any error encountered rendering an XR.",
+			params: params{
+				client: &test.MockClient{MockGet: get(&apiextensionsv1.Composition{})},
+				opts: []CheckerOption{WithPatchAndTransformRenderFn(func(_ context.Context, _ render.Inputs) (render.Outputs, error) {
+					return render.Outputs{}, errBoom
+				})},
+			},
+			root: tree(),
+			want: want{
+				root: tree(),
+				err:  errors.Wrapf(errors.Wrap(errBoom, errRender), errFmtCheckComposite, "cool-xr"),
+			},
+		},
+		"Drifted": {
+			reason: "We should annotate the XR and its composed resources with the fields that have drifted.",
+			params: params{
+				client: &test.MockClient{MockGet: get(&apiextensionsv1.Composition{})},
+				opts:   []CheckerOption{WithPatchAndTransformRenderFn(renderDrifted)},
+			},
+			root: tree(),
+			want: want{
+				root: func() *resource.Resource {
+					r := tree()
+					r.Children[0].Drift = &resource.Drift{Fields: []resource.DriftedField{}}
+					r.Children[0].Children[0].Drift = &resource.Drift{Fields: []resource.DriftedField{
+						{Path: "spec.region", Live: "us-cool-1", Desired: "us-cooler-1"},
+					}}
+					return r
+				}(),
+			},
+		},
+		"NumbersNotDrifted": {
+			reason: "We shouldn't annotate a number that's rendered as a float but is an integer in the cluster, since they're equal.",
+			params: params{
+				client: &test.MockClient{MockGet: getReplicas(&apiextensionsv1.Composition{
+					Spec: apiextensionsv1.CompositionSpec{
+						Mode: ptr.To(apiextensionsv1.CompositionModePipeline),
+					},
+				})},
+				opts: []CheckerOption{WithPipelineRenderFn(renderReplicas(3))},
+			},
+			root: tree(),
+			want: want{
+				root: func() *resource.Resource {
+					r := tree()
+					r.Children[0].Drift = &resource.Drift{Fields: []resource.DriftedField{}}
+					r.Children[0].Children[0].Drift = &resource.Drift{Fields: []resource.DriftedField{}}
+					return r
+				}(),
+			},
+		},
+		"NumbersDrifted": {
+			reason: "We should annotate a number that's rendered with a different value than it has in the cluster.",
+			params: params{
+				client: &test.MockClient{MockGet: getReplicas(&apiextensionsv1.Composition{
+					Spec: apiextensionsv1.CompositionSpec{
+						Mode: ptr.To(apiextensionsv1.CompositionModePipeline),
+					},
+				})},
+				opts: []CheckerOption{WithPipelineRenderFn(renderReplicas(4))},
+			},
+			root: tree(),
+			want: want{
+				root: func() *resource.Resource {
+					r := tree()
+					r.Children[0].Drift = &resource.Drift{Fields: []resource.DriftedField{}}
+					r.Children[0].Children[0].Drift = &resource.Drift{Fields: []resource.DriftedField{
+						{Path: "spec.replicas", Live: json.Number("3"), Desired: json.Number("4")},
+					}}
+					return r
+				}(),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewChecker(tc.params.client, tc.params.opts...)
+			err := c.Check(context.Background(), tc.root)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.root, tc.root); diff != "" {
+				t.Errorf("\n%s\nCheck(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package printer
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
@@ -27,6 +28,7 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
 )
 
@@ -36,9 +38,15 @@ const (
 	errFlushTabWriter = "cannot flush tab writer"
 )
 
+// typeAvailable is the type of a Deployment's Available condition.
+const typeAvailable xpv1.ConditionType = "Available"
+
 // DefaultPrinter defines the DefaultPrinter configuration
 type DefaultPrinter struct {
 	wide bool
+
+	// watch is nil unless the printer is in watch mode.
+	watch *watchState
 }
 
 var _ Printer = &DefaultPrinter{}
@@ -48,20 +56,41 @@ type defaultPrinterRow struct {
 	synced string
 	ready  string
 	status string
+
+	// drift is only printed if drift was checked for any resource in the
+	// tree.
+	drift string
+
+	// changed is only printed in watch mode.
+	changed string
 }
 
 func (r *defaultPrinterRow) String() string {
-	return strings.Join([]string{
+	cols := []string{
 		r.name,
 		r.synced,
 		r.ready,
 		r.status,
-	}, "\t") + "\t"
+	}
+	if r.drift != "" {
+		cols = append(cols, r.drift)
+	}
+	if r.changed != "" {
+		cols = append(cols, r.changed)
+	}
+	return strings.Join(cols, "\t") + "\t"
 }
 
 // Print implements the Printer interface by prints the resource tree in a
 // human-readable format.
-func (p *DefaultPrinter) Print(w io.Writer, root *resource.Resource) error {
+func (p *DefaultPrinter) Print(w io.Writer, root *resource.Resource) error { //nolint:gocyclo // Only a touch over.
+	if p.watch != nil {
+		// Redraw the tree in place.
+		if _, err := fmt.Fprint(w, clearScreen); err != nil {
+			return errors.Wrap(err, errWriteHeader)
+		}
+	}
+
 	tw := printers.GetNewTabWriter(w)
 
 	headers := defaultPrinterRow{
@@ -70,6 +99,17 @@ func (p *DefaultPrinter) Print(w io.Writer, root *resource.Resource) error {
 		ready:  "READY",
 		status: "STATUS",
 	}
+	if resource.IsPackage(root) {
+		headers.synced = "INSTALLED"
+		headers.ready = "HEALTHY"
+	}
+	checkedDrift := hasDrift(root)
+	if checkedDrift {
+		headers.drift = "DRIFT"
+	}
+	if p.watch != nil {
+		headers.changed = "LAST CHANGE"
+	}
 	if _, err := fmt.Fprintln(tw, headers.String()); err != nil {
 		return errors.Wrap(err, errWriteHeader)
 	}
@@ -121,6 +161,12 @@ func (p *DefaultPrinter) Print(w io.Writer, root *resource.Resource) error {
 			synced: synced,
 			status: status,
 		}
+		if checkedDrift {
+			row.drift = getResourceDrift(item.resource, p.wide)
+		}
+		if p.watch != nil {
+			row.synced, row.ready, row.changed = p.watch.observe(item.resource, synced, ready)
+		}
 		if _, err := fmt.Fprintln(tw, row.String()); err != nil {
 			return errors.Wrap(err, errWriteRow)
 		}
@@ -143,8 +189,9 @@ func (p *DefaultPrinter) Print(w io.Writer, root *resource.Resource) error {
 
 // getResourceStatus returns the status of the resource.
 func getResourceStatus(r *resource.Resource, wide bool) (ready string, synced string, status string) {
-	readyCond := r.GetCondition(xpv1.TypeReady)
-	syncedCond := r.GetCondition(xpv1.TypeSynced)
+	syncedType, readyType := ConditionTypes(r)
+	readyCond := r.GetCondition(readyType)
+	syncedCond := r.GetCondition(syncedType)
 	var m string
 	switch {
 	case r.Error != nil:
@@ -184,6 +231,73 @@ func getResourceStatus(r *resource.Resource, wide bool) (ready string, synced st
 	return mapEmptyStatusToDash(readyCond.Status), mapEmptyStatusToDash(syncedCond.Status), status
 }
 
+// getResourceDrift returns the fields of the resource that have drifted. Only
+// their paths are returned unless we are in wide mode.
+func getResourceDrift(r *resource.Resource, wide bool) string {
+	switch {
+	case r.Drift == nil:
+		return "-"
+	case len(r.Drift.Fields) == 0:
+		return "None"
+	}
+
+	fields := make([]string, 0, len(r.Drift.Fields))
+	for _, f := range r.Drift.Fields {
+		if !wide {
+			fields = append(fields, f.Path)
+			continue
+		}
+		if f.Live == nil {
+			fields = append(fields, fmt.Sprintf("%s: %s", f.Path, toJSON(f.Desired)))
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s => %s", f.Path, toJSON(f.Live), toJSON(f.Desired)))
+	}
+	d := strings.Join(fields, ", ")
+
+	// Crop the drifted fields to the first 64 characters if they're too long
+	// and we are not in wide mode
+	if !wide && len(d) > 64 {
+		d = d[:64] + "..."
+	}
+	return d
+}
+
+// hasDrift returns true if drift was checked for any resource in the tree.
+func hasDrift(r *resource.Resource) bool {
+	if r.Drift != nil {
+		return true
+	}
+	for _, c := range r.Children {
+		if hasDrift(c) {
+			return true
+		}
+	}
+	return false
+}
+
+func toJSON(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// ConditionTypes returns the types of the conditions to show as the supplied
+// resource's synced and ready status. Packages and their revisions report
+// whether they're installed and healthy, while Deployments report whether
+// they're available.
+func ConditionTypes(r *resource.Resource) (synced xpv1.ConditionType, ready xpv1.ConditionType) {
+	switch r.Unstructured.GroupVersionKind().Group {
+	case pkgv1.Group:
+		return pkgv1.TypeInstalled, pkgv1.TypeHealthy
+	case "apps":
+		return "", typeAvailable
+	}
+	return xpv1.TypeSynced, xpv1.TypeReady
+}
+
 func mapEmptyStatusToDash(s corev1.ConditionStatus) string {
 	if s == "" {
 		return "-"
@@ -23,6 +23,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
@@ -31,6 +32,7 @@ import (
 func TestDefaultPrinter(t *testing.T) {
 	type args struct {
 		resource *resource.Resource
+		wide     bool
 	}
 
 	type want struct {
@@ -65,11 +67,42 @@ ObjectStorage/test-resource (default)                  True      True
 				err: nil,
 			},
 		},
+		"ResourceWithDrift": {
+			reason: "Should print the drifted fields of each resource drift was checked for.",
+			args: args{
+				resource: getDriftedResource(),
+			},
+			want: want{
+				output: `
+NAME                                     SYNCED   READY   STATUS   DRIFT                                                                 
+ObjectStorage/test-resource (default)    True     True             -                                                                     
+└─ XObjectStorage/test-resource-hash     True     True             None                                                                  
+   ├─ Bucket/test-resource-bucket-hash   True     True             spec.forProvider.region, spec.forProvider.tags                        
+   └─ User/test-resource-user-hash       True     True             spec.forProvider.aVeryLongFieldNameThatWillBeCropped, spec.forPr...   
+`,
+			},
+		},
+		"ResourceWithDriftWide": {
+			reason: "Should print the live and desired values of drifted fields in wide mode.",
+			args: args{
+				resource: getDriftedResource(),
+				wide:     true,
+			},
+			want: want{
+				output: `
+NAME                                     SYNCED   READY   STATUS   DRIFT                                                                                             
+ObjectStorage/test-resource (default)    True     True             -                                                                                                 
+└─ XObjectStorage/test-resource-hash     True     True             None                                                                                              
+   ├─ Bucket/test-resource-bucket-hash   True     True             spec.forProvider.region: "us-east-1" => "eu-west-1", spec.forProvider.tags: ["cool"]              
+   └─ User/test-resource-user-hash       True     True             spec.forProvider.aVeryLongFieldNameThatWillBeCropped: true => false, spec.forProvider.path: "/"   
+`,
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			p := DefaultPrinter{}
+			p := DefaultPrinter{wide: tc.args.wide}
 			var buf bytes.Buffer
 			err := p.Print(&buf, tc.args.resource)
 			got := buf.String()
@@ -86,3 +119,32 @@ ObjectStorage/test-resource (default)                  True      True
 	}
 
 }
+
+func getDriftedResource() *resource.Resource {
+	conds := []xpv1.Condition{{Type: "Synced", Status: "True"}, {Type: "Ready", Status: "True"}}
+	return &resource.Resource{
+		Unstructured: DummyManifest("ObjectStorage", "test-resource", "default", conds...),
+		Children: []*resource.Resource{
+			{
+				Unstructured: DummyManifest("XObjectStorage", "test-resource-hash", "", conds...),
+				Drift:        &resource.Drift{Fields: []resource.DriftedField{}},
+				Children: []*resource.Resource{
+					{
+						Unstructured: DummyManifest("Bucket", "test-resource-bucket-hash", "", conds...),
+						Drift: &resource.Drift{Fields: []resource.DriftedField{
+							{Path: "spec.forProvider.region", Live: "us-east-1", Desired: "eu-west-1"},
+							{Path: "spec.forProvider.tags", Desired: []any{"cool"}},
+						}},
+					},
+					{
+						Unstructured: DummyManifest("User", "test-resource-user-hash", "", conds...),
+						Drift: &resource.Drift{Fields: []resource.DriftedField{
+							{Path: "spec.forProvider.aVeryLongFieldNameThatWillBeCropped", Live: true, Desired: false},
+							{Path: "spec.forProvider.path", Desired: "/"},
+						}},
+					},
+				},
+			},
+		},
+	}
+}
@@ -8,8 +8,6 @@ import (
 	"github.com/emicklei/dot"
 	"github.com/pkg/errors"
 
-	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-
 	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
 )
 
@@ -74,12 +72,13 @@ func (p *DotPrinter) Print(w io.Writer, root *resource.Resource) error {
 			g.Edge(*item.parent, node)
 		}
 
+		syncedType, readyType := ConditionTypes(item.resource)
 		label := &dotLabel{
 			namespace:  item.resource.Unstructured.GetNamespace(),
 			apiVersion: item.resource.Unstructured.GetObjectKind().GroupVersionKind().GroupVersion().String(),
 			name:       fmt.Sprintf("%s/%s", item.resource.Unstructured.GetKind(), item.resource.Unstructured.GetName()),
-			ready:      string(item.resource.GetCondition(xpv1.TypeReady).Status),
-			synced:     string(item.resource.GetCondition(xpv1.TypeSynced).Status),
+			ready:      string(item.resource.GetCondition(readyType).Status),
+			synced:     string(item.resource.GetCondition(syncedType).Status),
 		}
 
 		node.Label(label.String())
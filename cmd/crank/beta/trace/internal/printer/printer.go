@@ -46,8 +46,23 @@ type Printer interface {
 	Print(io.Writer, *resource.Resource) error
 }
 
+// An Option configures a Printer.
+type Option func(Printer)
+
+// WithWatch configures the default and wide printers to redraw the resource
+// tree in place each time they print it. They highlight transitions of each
+// resource's conditions, and show the time elapsed since each resource's
+// conditions last changed. Other printers are unaffected.
+func WithWatch() Option {
+	return func(p Printer) {
+		if dp, ok := p.(*DefaultPrinter); ok {
+			dp.watch = newWatchState()
+		}
+	}
+}
+
 // New creates a new printer based on the specified type.
-func New(typeStr string) (Printer, error) {
+func New(typeStr string, opts ...Option) (Printer, error) {
 	var p Printer
 
 	switch Type(typeStr) {
@@ -65,5 +80,9 @@ func New(typeStr string) (Printer, error) {
 		return nil, errors.Errorf(errFmtUnknownPrinterType, typeStr)
 	}
 
+	for _, o := range opts {
+		o(p)
+	}
+
 	return p, nil
 }
@@ -0,0 +1,106 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
+)
+
+// clearScreen moves the cursor to the top left of the terminal, then clears
+// the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// How long a transition of a resource's conditions stays highlighted.
+const transitionHighlight = 10 * time.Second
+
+// A transition of a resource's Synced or Ready condition.
+type transition struct {
+	from string
+	to   string
+	at   time.Time
+}
+
+// The status of a resource's Synced and Ready conditions, and their most
+// recent transitions.
+type watchedStatus struct {
+	synced string
+	ready  string
+
+	syncedTransition *transition
+	readyTransition  *transition
+}
+
+// watchState is the state a DefaultPrinter keeps between prints in watch mode.
+type watchState struct {
+	now      func() time.Time
+	resource map[string]*watchedStatus
+}
+
+func newWatchState() *watchState {
+	return &watchState{now: time.Now, resource: map[string]*watchedStatus{}}
+}
+
+// observe the supplied status of a resource. It returns the synced and ready
+// status to print, highlighting any recent transitions, and the time elapsed
+// since the resource's conditions last changed.
+func (s *watchState) observe(r *resource.Resource, synced, ready string) (string, string, string) {
+	now := s.now()
+	key := fmt.Sprintf("%s/%s/%s/%s", r.Unstructured.GetAPIVersion(), r.Unstructured.GetKind(), r.Unstructured.GetNamespace(), r.Unstructured.GetName())
+
+	ws, ok := s.resource[key]
+	if !ok {
+		ws = &watchedStatus{synced: synced, ready: ready}
+		s.resource[key] = ws
+	}
+	if ws.synced != synced {
+		ws.syncedTransition = &transition{from: ws.synced, to: synced, at: now}
+		ws.synced = synced
+	}
+	if ws.ready != ready {
+		ws.readyTransition = &transition{from: ws.ready, to: ready, at: now}
+		ws.ready = ready
+	}
+
+	return highlight(ws.syncedTransition, synced, now), highlight(ws.readyTransition, ready, now), lastChange(r, now)
+}
+
+// highlight the supplied transition if it's recent.
+func highlight(t *transition, status string, now time.Time) string {
+	if t == nil || now.Sub(t.at) > transitionHighlight {
+		return status
+	}
+	return fmt.Sprintf("%s→%s", t.from, t.to)
+}
+
+// lastChange returns the time elapsed since the resource's synced or ready
+// condition last transitioned.
+func lastChange(r *resource.Resource, now time.Time) string {
+	syncedType, readyType := ConditionTypes(r)
+	last := r.GetCondition(syncedType).LastTransitionTime
+	if ready := r.GetCondition(readyType).LastTransitionTime; ready.After(last.Time) {
+		last = ready
+	}
+	if last.IsZero() {
+		return "-"
+	}
+	return duration.HumanDuration(now.Sub(last.Time))
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
+)
+
+func TestDefaultPrinterWatch(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tree := func(ready corev1.ConditionStatus, transitioned time.Time) *resource.Resource {
+		return &resource.Resource{
+			Unstructured: DummyManifest("ObjectStorage", "test-resource", "default",
+				xpv1.Condition{Type: "Synced", Status: "True", LastTransitionTime: metav1.NewTime(start)},
+				xpv1.Condition{Type: "Ready", Status: "True", LastTransitionTime: metav1.NewTime(start)},
+			),
+			Children: []*resource.Resource{
+				{
+					Unstructured: DummyManifest("Bucket", "test-resource-bucket", "",
+						xpv1.Condition{Type: "Synced", Status: "True", LastTransitionTime: metav1.NewTime(start)},
+						xpv1.Condition{Type: "Ready", Status: "False", LastTransitionTime: metav1.NewTime(transitioned)},
+					),
+				},
+				{
+					Unstructured: DummyManifest("User", "test-resource-user", "",
+						xpv1.Condition{Type: "Synced", Status: "True", LastTransitionTime: metav1.NewTime(start)},
+						xpv1.Condition{Type: "Ready", Status: ready, LastTransitionTime: metav1.NewTime(transitioned)},
+					),
+				},
+			},
+		}
+	}
+
+	type print struct {
+		root *resource.Resource
+		now  time.Time
+	}
+	cases := map[string]struct {
+		reason string
+		prints []print
+		want   string
+	}{
+		"FirstPrint": {
+			reason: "Should clear the screen, then show the time elapsed since each resource last changed.",
+			prints: []print{
+				{root: tree("False", start.Add(time.Minute)), now: start.Add(5 * time.Minute)},
+			},
+			want: `
+NAME                                    SYNCED   READY   STATUS   LAST CHANGE   
+ObjectStorage/test-resource (default)   True     True             5m            
+├─ Bucket/test-resource-bucket          True     False            4m            
+└─ User/test-resource-user              True     False            4m            
+`,
+		},
+		"Transition": {
+			reason: "Should highlight conditions that recently transitioned.",
+			prints: []print{
+				{root: tree("False", start.Add(time.Minute)), now: start.Add(5 * time.Minute)},
+				{root: tree("True", start.Add(5*time.Minute)), now: start.Add(5*time.Minute + time.Second)},
+			},
+			want: `
+NAME                                    SYNCED   READY        STATUS   LAST CHANGE   
+ObjectStorage/test-resource (default)   True     True                  5m1s          
+├─ Bucket/test-resource-bucket          True     False                 1s            
+└─ User/test-resource-user              True     False→True            1s            
+`,
+		},
+		"TransitionExpired": {
+			reason: "Should stop highlighting transitions once they're no longer recent.",
+			prints: []print{
+				{root: tree("False", start.Add(time.Minute)), now: start.Add(5 * time.Minute)},
+				{root: tree("True", start.Add(5*time.Minute)), now: start.Add(5*time.Minute + time.Second)},
+				{root: tree("True", start.Add(5*time.Minute)), now: start.Add(6 * time.Minute)},
+			},
+			want: `
+NAME                                    SYNCED   READY   STATUS   LAST CHANGE   
+ObjectStorage/test-resource (default)   True     True             6m            
+├─ Bucket/test-resource-bucket          True     False            60s           
+└─ User/test-resource-user              True     True             60s           
+`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			now := start
+			p := &DefaultPrinter{watch: newWatchState()}
+			p.watch.now = func() time.Time { return now }
+
+			var buf bytes.Buffer
+			for _, pr := range tc.prints {
+				buf.Reset()
+				now = pr.now
+				if err := p.Print(&buf, pr.root); err != nil {
+					t.Fatalf("Print(...): %v", err)
+				}
+			}
+
+			got := buf.String()
+			if !strings.HasPrefix(got, clearScreen) {
+				t.Errorf("%s\nPrint(...): want output to start by clearing the screen, got %q", tc.reason, got)
+			}
+			if diff := cmp.Diff(strings.TrimSpace(tc.want), strings.TrimSpace(strings.TrimPrefix(got, clearScreen))); diff != "" {
+				t.Errorf("%s\nPrint(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
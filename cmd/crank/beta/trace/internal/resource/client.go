@@ -24,6 +24,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpmeta "github.com/crossplane/crossplane-runtime/pkg/meta"
@@ -83,22 +84,48 @@ func (kc *Client) GetResourceTree(ctx context.Context, rootRef *v1.ObjectReferen
 	// Set up a FIFO queue to traverse the resource tree breadth first.
 	queue := []*Resource{root}
 
+	// Packages can be depended on by more than one package in the tree. We
+	// only get the children of each package once.
+	expanded := map[types.UID]bool{}
+
 	for len(queue) > 0 {
 		// Pop the first element from the queue.
 		res := queue[0]
 		queue = queue[1:]
 
-		refs := getResourceChildrenRefs(res, kc.getConnectionSecrets)
+		children, err := kc.getChildren(ctx, res, expanded)
+		if err != nil {
+			return nil, err
+		}
+
+		res.Children = append(res.Children, children...)
+		queue = append(queue, children...)
+	}
 
-		for i := range refs {
-			child := kc.getResource(ctx, &refs[i])
+	return root, nil
+}
 
-			res.Children = append(res.Children, child)
-			queue = append(queue, child)
+// getChildren returns the children of the supplied Resource.
+func (kc *Client) getChildren(ctx context.Context, r *Resource, expanded map[types.UID]bool) ([]*Resource, error) {
+	switch {
+	case r.Error != nil:
+		return nil, nil
+	case IsPackage(r):
+		if expanded[r.Unstructured.GetUID()] {
+			return nil, nil
 		}
+		expanded[r.Unstructured.GetUID()] = true
+		return kc.getPackageChildren(ctx, r)
+	case IsPackageRevision(r):
+		return kc.getPackageRevisionChildren(ctx, r)
 	}
 
-	return root, nil
+	refs := getResourceChildrenRefs(r, kc.getConnectionSecrets)
+	children := make([]*Resource, 0, len(refs))
+	for i := range refs {
+		children = append(children, kc.getResource(ctx, &refs[i]))
+	}
+	return children, nil
 }
 
 // getResource returns the requested Resource, setting any error as Resource.Error.
@@ -128,6 +155,7 @@ func getResourceChildrenRefs(r *Resource, getConnectionSecrets bool) []v1.Object
 	switch obj.GroupVersionKind().GroupKind() {
 	case schema.GroupKind{Group: "", Kind: "Secret"},
 		v1alpha1.UsageGroupVersionKind.GroupKind(),
+		v1alpha1.NamespacedUsageGroupVersionKind.GroupKind(),
 		v1alpha1.EnvironmentConfigGroupVersionKind.GroupKind():
 		// nothing to do here, it's a resource we know not to have any reference
 		return nil
@@ -0,0 +1,210 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+const (
+	// lockName is the name of the Lock that tracks package dependencies.
+	lockName = "lock"
+
+	errFmtListRevisions          = "cannot list revisions of package %q"
+	errFmtListDeployments        = "cannot list runtime deployments of package revision %q"
+	errGetLock                   = "cannot get package lock"
+	errConvertLock               = "cannot convert package lock"
+	errFmtDependencyNotInstalled = "dependency %q is not installed"
+)
+
+// deploymentGroupVersionKind is the GVK of a package runtime Deployment.
+var deploymentGroupVersionKind = schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+
+// packageRevisions maps each kind of package to the kind of its revisions.
+var packageRevisions = map[schema.GroupKind]schema.GroupVersionKind{
+	pkgv1.ConfigurationGroupVersionKind.GroupKind(): pkgv1.ConfigurationRevisionGroupVersionKind,
+	pkgv1.ProviderGroupVersionKind.GroupKind():      pkgv1.ProviderRevisionGroupVersionKind,
+	pkgv1beta1.FunctionGroupVersionKind.GroupKind(): pkgv1beta1.FunctionRevisionGroupVersionKind,
+}
+
+// packageGVKs maps each type of package to the GVK of its package and
+// revision kinds.
+var packageGVKs = map[pkgv1beta1.PackageType]struct {
+	pkg schema.GroupVersionKind
+	rev schema.GroupVersionKind
+}{
+	pkgv1beta1.ConfigurationPackageType: {pkg: pkgv1.ConfigurationGroupVersionKind, rev: pkgv1.ConfigurationRevisionGroupVersionKind},
+	pkgv1beta1.ProviderPackageType:      {pkg: pkgv1.ProviderGroupVersionKind, rev: pkgv1.ProviderRevisionGroupVersionKind},
+	pkgv1beta1.FunctionPackageType:      {pkg: pkgv1beta1.FunctionGroupVersionKind, rev: pkgv1beta1.FunctionRevisionGroupVersionKind},
+}
+
+// IsPackage returns true if the supplied resource is a Configuration, Provider,
+// or Function.
+func IsPackage(r *Resource) bool {
+	_, ok := packageRevisions[r.Unstructured.GroupVersionKind().GroupKind()]
+	return ok
+}
+
+// IsPackageRevision returns true if the supplied resource is a revision of a
+// Configuration, Provider, or Function.
+func IsPackageRevision(r *Resource) bool {
+	gk := r.Unstructured.GroupVersionKind().GroupKind()
+	for _, rev := range packageRevisions {
+		if rev.GroupKind() == gk {
+			return true
+		}
+	}
+	return false
+}
+
+// getPackageChildren returns the revisions of the supplied package, followed
+// by its dependencies. Dependencies are read from the Lock.
+func (kc *Client) getPackageChildren(ctx context.Context, r *Resource) ([]*Resource, error) {
+	rev := packageRevisions[r.Unstructured.GroupVersionKind().GroupKind()]
+
+	revs := &unstructured.UnstructuredList{}
+	revs.SetGroupVersionKind(rev.GroupVersion().WithKind(rev.Kind + "List"))
+	if err := kc.client.List(ctx, revs, client.MatchingLabels{pkgv1.LabelParentPackage: r.Unstructured.GetName()}); err != nil {
+		return nil, errors.Wrapf(err, errFmtListRevisions, r.Unstructured.GetName())
+	}
+
+	// Show the oldest revision first.
+	sort.SliceStable(revs.Items, func(i, j int) bool {
+		ri, _, _ := unstructured.NestedInt64(revs.Items[i].Object, "spec", "revision")
+		rj, _, _ := unstructured.NestedInt64(revs.Items[j].Object, "spec", "revision")
+		return ri < rj
+	})
+
+	children := make([]*Resource, 0, len(revs.Items))
+	for i := range revs.Items {
+		children = append(children, &Resource{Unstructured: revs.Items[i]})
+	}
+
+	current, _, _ := unstructured.NestedString(r.Unstructured.Object, "status", "currentRevision")
+	if current == "" {
+		// The package hasn't been installed yet, so it doesn't have any
+		// dependencies in the Lock.
+		return children, nil
+	}
+
+	lock, err := kc.getLock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, lp := range lock.Packages {
+		if lp.Name != current {
+			continue
+		}
+		for _, d := range lp.Dependencies {
+			children = append(children, kc.getDependency(ctx, lock, d))
+		}
+	}
+
+	return children, nil
+}
+
+// getDependency returns the installed package that satisfies the supplied
+// dependency. It returns a Resource with an error if the dependency isn't
+// installed.
+func (kc *Client) getDependency(ctx context.Context, lock *pkgv1beta1.Lock, d pkgv1beta1.Dependency) *Resource {
+	for _, lp := range lock.Packages {
+		if lp.Source != d.Package {
+			continue
+		}
+		gvks := packageGVKs[lp.Type]
+		rev := kc.getResource(ctx, &v1.ObjectReference{APIVersion: gvks.rev.GroupVersion().String(), Kind: gvks.rev.Kind, Name: lp.Name})
+		if rev.Error != nil {
+			return rev
+		}
+		return kc.getResource(ctx, &v1.ObjectReference{APIVersion: gvks.pkg.GroupVersion().String(), Kind: gvks.pkg.Kind, Name: rev.Unstructured.GetLabels()[pkgv1.LabelParentPackage]})
+	}
+
+	// The dependency isn't in the Lock. Name the missing package after its
+	// source, which is all we know about it.
+	u := unstructured.Unstructured{}
+	u.SetGroupVersionKind(packageGVKs[d.Type].pkg)
+	u.SetName(d.Package)
+	return &Resource{Unstructured: u, Error: errors.Errorf(errFmtDependencyNotInstalled, d.Package)}
+}
+
+// getLock returns the Lock that tracks package dependencies.
+func (kc *Client) getLock(ctx context.Context) (*pkgv1beta1.Lock, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(pkgv1beta1.LockGroupVersionKind)
+	if err := kc.client.Get(ctx, types.NamespacedName{Name: lockName}, u); err != nil {
+		return nil, errors.Wrap(err, errGetLock)
+	}
+	lock := &pkgv1beta1.Lock{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, lock); err != nil {
+		return nil, errors.Wrap(err, errConvertLock)
+	}
+	return lock, nil
+}
+
+// getPackageRevisionChildren returns the runtime Deployment of the supplied
+// Provider or Function revision, followed by the objects the revision
+// establishes.
+func (kc *Client) getPackageRevisionChildren(ctx context.Context, r *Resource) ([]*Resource, error) {
+	children := make([]*Resource, 0)
+
+	// Configurations don't have a runtime.
+	if r.Unstructured.GroupVersionKind().GroupKind() != pkgv1.ConfigurationRevisionGroupVersionKind.GroupKind() {
+		// The runtime Deployment's name and namespace are configurable, so we
+		// find it by its controller reference.
+		deps := &unstructured.UnstructuredList{}
+		deps.SetGroupVersionKind(deploymentGroupVersionKind.GroupVersion().WithKind(deploymentGroupVersionKind.Kind + "List"))
+		if err := kc.client.List(ctx, deps); err != nil {
+			return nil, errors.Wrapf(err, errFmtListDeployments, r.Unstructured.GetName())
+		}
+		for i := range deps.Items {
+			if ref := metav1.GetControllerOf(&deps.Items[i]); ref != nil && ref.UID == r.Unstructured.GetUID() {
+				children = append(children, &Resource{Unstructured: deps.Items[i]})
+			}
+		}
+	}
+
+	objs, _, _ := unstructured.NestedSlice(r.Unstructured.Object, "status", "objectRefs")
+	for _, o := range objs {
+		m, ok := o.(map[string]any)
+		if !ok {
+			continue
+		}
+		ref := xpv1.TypedReference{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, &ref); err != nil {
+			continue
+		}
+		children = append(children, kc.getResource(ctx, &v1.ObjectReference{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name}))
+	}
+
+	return children, nil
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+type objOpt func(u *unstructured.Unstructured)
+
+func withUID(uid string) objOpt {
+	return func(u *unstructured.Unstructured) {
+		u.SetUID(types.UID(uid))
+	}
+}
+
+func withLabels(l map[string]string) objOpt {
+	return func(u *unstructured.Unstructured) {
+		u.SetLabels(l)
+	}
+}
+
+func withField(v any, fields ...string) objOpt {
+	return func(u *unstructured.Unstructured) {
+		_ = unstructured.SetNestedField(u.Object, v, fields...)
+	}
+}
+
+func withController(gvk schema.GroupVersionKind, name, uid string) objOpt {
+	return func(u *unstructured.Unstructured) {
+		u.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       name,
+			UID:        types.UID(uid),
+			Controller: ptr.To(true),
+		}})
+	}
+}
+
+func buildObj(gvk schema.GroupVersionKind, name string, opts ...objOpt) unstructured.Unstructured {
+	u := unstructured.Unstructured{Object: map[string]any{}}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(name)
+	for _, f := range opts {
+		f(&u)
+	}
+	return u
+}
+
+func TestGetResourceTreePackage(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	xrdGVK := schema.GroupVersionKind{Group: "apiextensions.crossplane.io", Version: "v1", Kind: "CompositeResourceDefinition"}
+
+	cfg := buildObj(pkgv1.ConfigurationGroupVersionKind, "platform", withUID("cfg"), withField("platform-new", "status", "currentRevision"))
+	cfgRevOld := buildObj(pkgv1.ConfigurationRevisionGroupVersionKind, "platform-old", withField(int64(1), "spec", "revision"))
+	cfgRevNew := buildObj(pkgv1.ConfigurationRevisionGroupVersionKind, "platform-new",
+		withField(int64(2), "spec", "revision"),
+		withField([]any{map[string]any{"apiVersion": "apiextensions.crossplane.io/v1", "kind": "CompositeResourceDefinition", "name": "xcools.example.org"}}, "status", "objectRefs"),
+	)
+	xrd := buildObj(xrdGVK, "xcools.example.org")
+
+	prov := buildObj(pkgv1.ProviderGroupVersionKind, "provider-cool", withUID("prov"), withField("provider-cool-rev", "status", "currentRevision"))
+	provRev := buildObj(pkgv1.ProviderRevisionGroupVersionKind, "provider-cool-rev", withUID("prov-rev"), withLabels(map[string]string{pkgv1.LabelParentPackage: "provider-cool"}))
+	provDeploy := buildObj(deploymentGroupVersionKind, "provider-cool-rev", withController(pkgv1.ProviderRevisionGroupVersionKind, "provider-cool-rev", "prov-rev"))
+	otherDeploy := buildObj(deploymentGroupVersionKind, "something-else")
+
+	lock := buildObj(pkgv1beta1.LockGroupVersionKind, lockName, withField([]any{
+		map[string]any{
+			"name":    "platform-new",
+			"type":    "Configuration",
+			"source":  "xpkg.example.org/platform",
+			"version": "v1.0.0",
+			"dependencies": []any{
+				map[string]any{"package": "xpkg.example.org/provider-cool", "type": "Provider", "constraints": ">=v1.0.0"},
+				map[string]any{"package": "xpkg.example.org/provider-missing", "type": "Provider", "constraints": ">=v1.0.0"},
+			},
+		},
+		map[string]any{
+			"name":         "provider-cool-rev",
+			"type":         "Provider",
+			"source":       "xpkg.example.org/provider-cool",
+			"version":      "v1.0.0",
+			"dependencies": []any{},
+		},
+	}, "packages"))
+
+	objs := []unstructured.Unstructured{cfg, xrd, prov, provRev, lock}
+	get := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		u := obj.(*unstructured.Unstructured)
+		for _, o := range objs {
+			if o.GroupVersionKind() == u.GroupVersionKind() && o.GetName() == key.Name {
+				o.DeepCopyInto(u)
+				return nil
+			}
+		}
+		return errBoom
+	}
+	list := func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		l := obj.(*unstructured.UnstructuredList)
+		switch l.GroupVersionKind().Kind {
+		case pkgv1.ConfigurationRevisionKind + "List":
+			// Return the revisions out of order.
+			l.Items = []unstructured.Unstructured{cfgRevNew, cfgRevOld}
+		case pkgv1.ProviderRevisionKind + "List":
+			l.Items = []unstructured.Unstructured{provRev}
+		case "DeploymentList":
+			l.Items = []unstructured.Unstructured{otherDeploy, provDeploy}
+		}
+		return nil
+	}
+
+	missing := buildObj(pkgv1.ProviderGroupVersionKind, "xpkg.example.org/provider-missing")
+
+	type args struct {
+		client client.Client
+	}
+	type want struct {
+		root *Resource
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"PackageWithDependencies": {
+			reason: "Should return a package's revisions, the objects they establish, their runtime Deployments, and the package's dependencies.",
+			args: args{
+				client: &test.MockClient{MockGet: get, MockList: list},
+			},
+			want: want{
+				root: &Resource{
+					Unstructured: cfg,
+					Children: []*Resource{
+						{Unstructured: cfgRevOld},
+						{Unstructured: cfgRevNew, Children: []*Resource{{Unstructured: xrd}}},
+						{Unstructured: prov, Children: []*Resource{
+							{Unstructured: provRev, Children: []*Resource{{Unstructured: provDeploy}}},
+						}},
+						{Unstructured: missing, Error: errors.Errorf(errFmtDependencyNotInstalled, "xpkg.example.org/provider-missing")},
+					},
+				},
+			},
+		},
+		"ListRevisionsError": {
+			reason: "Should return any error encountered listing a package's revisions.",
+			args: args{
+				client: &test.MockClient{MockGet: get, MockList: test.NewMockListFn(errBoom)},
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtListRevisions, "platform"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c, _ := NewClient(tc.args.client, nil)
+			got, err := c.GetResourceTree(context.Background(), &v1.ObjectReference{
+				APIVersion: pkgv1.ConfigurationGroupVersionKind.GroupVersion().String(),
+				Kind:       pkgv1.ConfigurationKind,
+				Name:       "platform",
+			})
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGetResourceTree(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.root, got, test.EquateErrors(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nGetResourceTree(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -30,6 +30,30 @@ type Resource struct {
 	Unstructured unstructured.Unstructured `json:"object"`
 	Error        error                     `json:"error,omitempty"`
 	Children     []*Resource               `json:"children,omitempty"`
+
+	// Drift of the resource from what its Composition would render now. Nil
+	// if drift wasn't checked for the resource.
+	Drift *Drift `json:"drift,omitempty"`
+}
+
+// Drift of a resource from what its Composition would render now.
+type Drift struct {
+	// Fields whose live values differ from the rendered values. Empty if the
+	// resource hasn't drifted.
+	Fields []DriftedField `json:"fields"`
+}
+
+// A DriftedField is a field whose live value differs from the value its
+// Composition would render.
+type DriftedField struct {
+	// Path of the field, in field path syntax.
+	Path string `json:"path"`
+
+	// Live value of the field. Omitted if the field isn't set.
+	Live any `json:"live,omitempty"`
+
+	// Desired value of the field.
+	Desired any `json:"desired"`
 }
 
 // GetCondition of this resource.
@@ -20,20 +20,27 @@ package trace
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/alecthomas/kong"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/discovery/cached/memory"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/restmapper"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	apiextensionsv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/drift"
 	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/printer"
 	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
 )
@@ -50,6 +57,10 @@ const (
 	errNameDoubled            = "name provided twice, must be provided separately 'TYPE[.VERSION][.GROUP] [NAME]' or in the 'TYPE[.VERSION][.GROUP][/NAME]' format"
 	errInvalidResource        = "invalid resource, must be provided in the 'TYPE[.VERSION][.GROUP][/NAME]' format"
 	errInvalidResourceAndName = "invalid resource and name"
+	errBuildScheme            = "cannot build scheme"
+	errCheckDrift             = "cannot check drift"
+	errInitCache              = "cannot init informer cache"
+	errStartCache             = "cannot start informer cache"
 )
 
 // Cmd builds the trace tree for a Crossplane resource.
@@ -59,9 +70,12 @@ type Cmd struct {
 
 	// TODO(phisco): add support for all the usual kubectl flags; configFlags := genericclioptions.NewConfigFlags(true).AddFlags(...)
 	// TODO(phisco): move to namespace defaulting to "" and use the current context's namespace
-	Namespace             string `short:"n" name:"namespace" help:"Namespace of the resource." default:"default"`
-	Output                string `short:"o" name:"output" help:"Output format. One of: default, wide, json, dot." enum:"default,wide,json,dot" default:"default"`
-	ShowConnectionSecrets bool   `short:"s" name:"show-connection-secrets" help:"Show connection secrets in the output."`
+	Namespace             string        `short:"n" name:"namespace" help:"Namespace of the resource." default:"default"`
+	Output                string        `short:"o" name:"output" help:"Output format. One of: default, wide, json, dot." enum:"default,wide,json,dot" default:"default"`
+	ShowConnectionSecrets bool          `short:"s" name:"show-connection-secrets" help:"Show connection secrets in the output."`
+	Watch                 bool          `short:"w" name:"watch" help:"Watch the resource tree, redrawing it as resources change. Exits once the root resource is Ready, or Healthy if it's a package." xor:"watch-drift"`
+	Timeout               time.Duration `name:"timeout" help:"How long to watch before exiting with an error. Watches until the root resource is Ready (or Healthy) if zero. Only used with --watch." default:"0s"`
+	CheckDrift            bool          `name:"check-drift" help:"Render each composite resource locally using its Composition, and show the fields of each resource that have drifted from what would be rendered. Can't be used with --watch, because it would render every composite resource each time a resource changes." xor:"watch-drift"`
 }
 
 // Help returns help message for the trace command.
@@ -70,6 +84,11 @@ func (c *Cmd) Help() string {
 This command trace a Crossplane resource (Claim, Composite, or Managed Resource)
 to get a detailed output of its relationships, helpful for troubleshooting.
 
+It can also trace a package (Configuration, Provider, or Function). It shows
+the package's revisions, the runtime Deployment of each Provider and Function
+revision, the objects each revision establishes, and each of the package's
+dependencies from the Lock.
+
 If needed the resource kind can be also specified further,
 'TYPE[.VERSION][.GROUP]', e.g. mykind.example.org or
 mykind.v1alpha1.example.org.
@@ -81,6 +100,9 @@ Examples:
   # Output wide format, showing full errors and condition messages
   crossplane beta trace mykind my-res -n my-ns -o wide
 
+  # Trace a Configuration, its revisions and its dependencies
+  crossplane beta trace configuration.pkg.crossplane.io/platform
+
   # Show connection secrets in the output
   crossplane beta trace mykind my-res -n my-ns --show-connection-secrets
 
@@ -90,6 +112,15 @@ Examples:
   # Output all retrieved resources to json and pipe to jq to have it coloured
   crossplane beta trace mykind my-res -n my-ns -o json | jq
 
+  # Show the fields of each composed resource that have drifted from what its
+  # Composition would render now. Functions are run using Docker, like
+  # crossplane beta render does.
+  crossplane beta trace mykind my-res -n my-ns --check-drift
+
+  # Watch the resource tree until the root resource is Ready, highlighting
+  # condition transitions. Exits with an error if it isn't Ready in 10 minutes.
+  crossplane beta trace mykind my-res -n my-ns --watch --timeout 10m
+
   # Output debug logs to stderr while redirecting a dot formatted graph to dot
   crossplane beta trace mykind my-res -n my-ns -o dot --verbose | dot -Tpng -o output.png
 `
@@ -100,7 +131,11 @@ func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error { //nolint:gocyc
 	logger = logger.WithValues("Resource", c.Resource, "Name", c.Name)
 
 	// Init new printer
-	p, err := printer.New(c.Output)
+	var popts []printer.Option
+	if c.Watch {
+		popts = append(popts, printer.WithWatch())
+	}
+	p, err := printer.New(c.Output, popts...)
 	if err != nil {
 		return errors.Wrap(err, errInitPrinter)
 	}
@@ -112,7 +147,7 @@ func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error { //nolint:gocyc
 	}
 	logger.Debug("Found kubeconfig")
 
-	client, err := client.New(kubeconfig, client.Options{
+	kubeClient, err := client.New(kubeconfig, client.Options{
 		Scheme: scheme.Scheme,
 	})
 	if err != nil {
@@ -130,18 +165,18 @@ func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error { //nolint:gocyc
 	rmapper := restmapper.NewShortcutExpander(restmapper.NewDeferredDiscoveryRESTMapper(d), d)
 
 	// Get client for k8s package
-	resClient, err := resource.NewClient(client, rmapper, resource.WithConnectionSecrets(c.ShowConnectionSecrets))
+	resClient, err := resource.NewClient(kubeClient, rmapper, resource.WithConnectionSecrets(c.ShowConnectionSecrets))
 	if err != nil {
 		return errors.Wrap(err, errInitKubeClient)
 	}
 	logger.Debug("Built client")
 
-	resource, name, err := c.getResourceAndName()
+	res, name, err := c.getResourceAndName()
 	if err != nil {
 		return errors.Wrap(err, errInvalidResourceAndName)
 	}
 
-	mapping, err := resClient.MappingFor(resource)
+	mapping, err := resClient.MappingFor(res)
 	if err != nil {
 		return errors.Wrap(err, errGetMapping)
 	}
@@ -156,13 +191,68 @@ func (c *Cmd) Run(k *kong.Context, logger logging.Logger) error { //nolint:gocyc
 		logger.Debug("Requested resource is namespaced", "namespace", c.Namespace)
 		rootRef.Namespace = c.Namespace
 	}
-	logger.Debug("Getting resource tree", "rootRef", rootRef.String())
-	root, err := resClient.GetResourceTree(context.Background(), rootRef)
+	var checker *drift.Checker
+	if c.CheckDrift {
+		s := runtime.NewScheme()
+		for _, add := range []func(*runtime.Scheme) error{scheme.AddToScheme, apiextensionsv1.AddToScheme, apiextensionsv1alpha1.AddToScheme, pkgv1beta1.AddToScheme} {
+			if err := add(s); err != nil {
+				return errors.Wrap(err, errBuildScheme)
+			}
+		}
+		dc, err := client.New(kubeconfig, client.Options{Scheme: s})
+		if err != nil {
+			return errors.Wrap(err, errInitKubeClient)
+		}
+		checker = drift.NewChecker(dc)
+	}
+
+	getTree := func(ctx context.Context) (*resource.Resource, error) {
+		logger.Debug("Getting resource tree", "rootRef", rootRef.String())
+		root, err := resClient.GetResourceTree(ctx, rootRef)
+		if err != nil {
+			logger.Debug(errGetResource, "error", err)
+			return nil, errors.Wrap(err, errGetResource)
+		}
+		logger.Debug("Got resource tree", "root", root)
+
+		if checker != nil {
+			if err := checker.Check(ctx, root); err != nil {
+				return nil, errors.Wrap(err, errCheckDrift)
+			}
+			logger.Debug("Checked drift")
+		}
+		return root, nil
+	}
+
+	if c.Watch {
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if c.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(context.Background(), c.Timeout)
+		} else {
+			ctx, cancel = context.WithCancel(context.Background())
+		}
+		defer cancel()
+
+		ca, err := cache.New(kubeconfig, cache.Options{Scheme: scheme.Scheme, Mapper: rmapper})
+		if err != nil {
+			return errors.Wrap(err, errInitCache)
+		}
+		go func() {
+			if err := ca.Start(ctx); err != nil {
+				logger.Debug(errStartCache, "error", err)
+			}
+		}()
+		// Only the default and wide printers show the time elapsed since each
+		// resource changed, so only they need redrawing when nothing changed.
+		redraw := c.Output == string(printer.TypeDefault) || c.Output == string(printer.TypeWide)
+		return watchTree(ctx, k.Stdout, p, getTree, newInformerWatcher(ca), redraw)
+	}
+
+	root, err := getTree(context.Background())
 	if err != nil {
-		logger.Debug(errGetResource, "error", err)
-		return errors.Wrap(err, errGetResource)
+		return err
 	}
-	logger.Debug("Got resource tree", "root", root)
 
 	// Print resources
 	err = p.Print(k.Stdout, root)
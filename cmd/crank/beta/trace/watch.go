@@ -0,0 +1,187 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kcache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/printer"
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
+)
+
+const (
+	errFmtGetInformer = "cannot get informer for %s"
+	errFmtAddHandler  = "cannot add event handler to informer for %s"
+	errFmtTimeout     = "timed out waiting for %s/%s to become %s"
+	errWatch          = "cannot watch resource tree"
+)
+
+// How often the default and wide printers redraw the resource tree while
+// watching it, even if nothing changed, so that the time elapsed since each
+// resource last changed stays accurate.
+const redrawInterval = 1 * time.Second
+
+// A treeFn gets a resource tree.
+type treeFn func(ctx context.Context) (*resource.Resource, error)
+
+// A treeWatcher watches the resources in a resource tree.
+type treeWatcher interface {
+	// Watch the supplied resource tree's resources.
+	Watch(ctx context.Context, root *resource.Resource) error
+
+	// Changed returns a channel that receives when a watched resource
+	// changes.
+	Changed() <-chan struct{}
+}
+
+// An informerGetter gets informers. A controller-runtime cache satisfies this
+// interface.
+type informerGetter interface {
+	GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error)
+}
+
+// An informerWatcher watches the resources in a resource tree using an
+// informer per type of resource.
+type informerWatcher struct {
+	informers informerGetter
+	watching  map[schema.GroupVersionKind]bool
+	changed   chan struct{}
+}
+
+func newInformerWatcher(i informerGetter) *informerWatcher {
+	return &informerWatcher{
+		informers: i,
+		watching:  map[schema.GroupVersionKind]bool{},
+		changed:   make(chan struct{}, 1),
+	}
+}
+
+// Watch starts an informer for each type of resource in the supplied tree
+// that isn't already being watched.
+func (w *informerWatcher) Watch(ctx context.Context, root *resource.Resource) error {
+	queue := []*resource.Resource{root}
+	for len(queue) > 0 {
+		r := queue[0]
+		queue = queue[1:]
+		queue = append(queue, r.Children...)
+
+		gvk := r.Unstructured.GroupVersionKind()
+		if w.watching[gvk] {
+			continue
+		}
+
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		inf, err := w.informers.GetInformer(ctx, u)
+		if err != nil {
+			return errors.Wrapf(err, errFmtGetInformer, gvk)
+		}
+		if _, err := inf.AddEventHandler(kcache.ResourceEventHandlerFuncs{
+			AddFunc:    func(_ interface{}) { w.notify() },
+			UpdateFunc: func(_, _ interface{}) { w.notify() },
+			DeleteFunc: func(_ interface{}) { w.notify() },
+		}); err != nil {
+			return errors.Wrapf(err, errFmtAddHandler, gvk)
+		}
+		w.watching[gvk] = true
+	}
+	return nil
+}
+
+// Changed returns a channel that receives when a watched resource changes.
+func (w *informerWatcher) Changed() <-chan struct{} {
+	return w.changed
+}
+
+// notify that a watched resource changed, unless a notification is already
+// pending.
+func (w *informerWatcher) notify() {
+	select {
+	case w.changed <- struct{}{}:
+	default:
+	}
+}
+
+// watchTree prints the resource tree each time one of its resources changes,
+// unless the printed tree is unchanged. If redraw is true it also prints the
+// tree periodically, for printers that show the time elapsed since each
+// resource changed. It returns once the root of the tree is Ready (or Healthy,
+// if it's a package), or an error if the supplied context is done first.
+func watchTree(ctx context.Context, w io.Writer, p printer.Printer, getTree treeFn, tw treeWatcher, redraw bool) error { //nolint:gocyclo // Only slightly over.
+	// A nil channel never receives, so we never redraw if redraw is false.
+	var tick <-chan time.Time
+	if redraw {
+		t := time.NewTicker(redrawInterval)
+		defer t.Stop()
+		tick = t.C
+	}
+
+	var root *resource.Resource
+	var printed []byte
+	for {
+		if root == nil {
+			r, err := getTree(ctx)
+			if err != nil {
+				return err
+			}
+			root = r
+			if err := tw.Watch(ctx, root); err != nil {
+				return errors.Wrap(err, errWatch)
+			}
+		}
+
+		// Watched resources may change in ways that don't affect the printed
+		// tree, for example when an unrelated resource of a watched type
+		// changes.
+		buf := &bytes.Buffer{}
+		if err := p.Print(buf, root); err != nil {
+			return errors.Wrap(err, errCliOutput)
+		}
+		if !bytes.Equal(buf.Bytes(), printed) {
+			printed = buf.Bytes()
+			if _, err := w.Write(printed); err != nil {
+				return errors.Wrap(err, errCliOutput)
+			}
+		}
+
+		if _, ready := printer.ConditionTypes(root); root.GetCondition(ready).Status == corev1.ConditionTrue {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			_, ready := printer.ConditionTypes(root)
+			return errors.Errorf(errFmtTimeout, root.Unstructured.GetKind(), root.Unstructured.GetName(), ready)
+		case <-tw.Changed():
+			// Get the tree again the next time around.
+			root = nil
+		case <-tick:
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/printer"
+	"github.com/crossplane/crossplane/cmd/crank/beta/trace/internal/resource"
+)
+
+type mockWatcher struct {
+	err     error
+	changed chan struct{}
+}
+
+func (w *mockWatcher) Watch(_ context.Context, _ *resource.Resource) error { return w.err }
+func (w *mockWatcher) Changed() <-chan struct{}                            { return w.changed }
+
+// A mockPrinter prints the kind of the root of a tree and the status of its
+// ready condition.
+type mockPrinter struct{}
+
+func (p *mockPrinter) Print(w io.Writer, r *resource.Resource) error {
+	_, ready := printer.ConditionTypes(r)
+	_, err := fmt.Fprintf(w, "%s %s\n", r.Unstructured.GetKind(), r.GetCondition(ready).Status)
+	return err
+}
+
+func withReady(s string) *resource.Resource {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("Cool")
+	u.SetName("cool")
+	u.Object["status"] = map[string]any{"conditions": []any{map[string]any{"type": string(xpv1.TypeReady), "status": s}}}
+	return &resource.Resource{Unstructured: u}
+}
+
+func withHealthy(s string) *resource.Resource {
+	u := unstructured.Unstructured{}
+	u.SetAPIVersion(pkgv1.SchemeGroupVersion.String())
+	u.SetKind(pkgv1.ConfigurationKind)
+	u.SetName("cool")
+	u.Object["status"] = map[string]any{"conditions": []any{
+		map[string]any{"type": string(pkgv1.TypeInstalled), "status": "True"},
+		map[string]any{"type": string(pkgv1.TypeHealthy), "status": s},
+	}}
+	return &resource.Resource{Unstructured: u}
+}
+
+func TestWatchTree(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		timeout time.Duration
+		trees   []*resource.Resource
+		watcher *mockWatcher
+	}
+	type want struct {
+		output string
+		err    error
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"AlreadyReady": {
+			reason: "We should print the tree once and return if its root is already Ready.",
+			args: args{
+				trees:   []*resource.Resource{withReady("True")},
+				watcher: &mockWatcher{changed: make(chan struct{})},
+			},
+			want: want{
+				output: "Cool True\n",
+			},
+		},
+		"BecomesReady": {
+			reason: "We should print the tree again each time it changes, until its root is Ready.",
+			args: args{
+				trees: []*resource.Resource{withReady("False"), withReady("Unknown"), withReady("True")},
+				watcher: &mockWatcher{changed: func() chan struct{} {
+					c := make(chan struct{}, 2)
+					c <- struct{}{}
+					c <- struct{}{}
+					return c
+				}()},
+			},
+			want: want{
+				output: "Cool False\nCool Unknown\nCool True\n",
+			},
+		},
+		"UnchangedTree": {
+			reason: "We shouldn't print the tree again if it hasn't changed since we last printed it.",
+			args: args{
+				trees: []*resource.Resource{withReady("False"), withReady("False"), withReady("True")},
+				watcher: &mockWatcher{changed: func() chan struct{} {
+					c := make(chan struct{}, 2)
+					c <- struct{}{}
+					c <- struct{}{}
+					return c
+				}()},
+			},
+			want: want{
+				output: "Cool False\nCool True\n",
+			},
+		},
+		"PackageBecomesHealthy": {
+			reason: "We should print a package's tree again each time it changes, until the package is Healthy.",
+			args: args{
+				trees: []*resource.Resource{withHealthy("False"), withHealthy("True")},
+				watcher: &mockWatcher{changed: func() chan struct{} {
+					c := make(chan struct{}, 1)
+					c <- struct{}{}
+					return c
+				}()},
+			},
+			want: want{
+				output: "Configuration False\nConfiguration True\n",
+			},
+		},
+		"WatchError": {
+			reason: "We should return any error encountered watching the tree.",
+			args: args{
+				trees:   []*resource.Resource{withReady("False")},
+				watcher: &mockWatcher{err: errBoom},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errWatch),
+			},
+		},
+		"Timeout": {
+			reason: "We should return an error if the root doesn't become Ready before the context is done.",
+			args: args{
+				timeout: 10 * time.Millisecond,
+				trees:   []*resource.Resource{withReady("False")},
+				watcher: &mockWatcher{changed: make(chan struct{})},
+			},
+			want: want{
+				output: "Cool False\n",
+				err:    errors.Errorf(errFmtTimeout, "Cool", "cool", xpv1.TypeReady),
+			},
+		},
+		"PackageTimeout": {
+			reason: "We should return an error if a package doesn't become Healthy before the context is done.",
+			args: args{
+				timeout: 10 * time.Millisecond,
+				trees:   []*resource.Resource{withHealthy("False")},
+				watcher: &mockWatcher{changed: make(chan struct{})},
+			},
+			want: want{
+				output: "Configuration False\n",
+				err:    errors.Errorf(errFmtTimeout, pkgv1.ConfigurationKind, "cool", pkgv1.TypeHealthy),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.args.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.args.timeout)
+				defer cancel()
+			}
+
+			i := 0
+			getTree := func(_ context.Context) (*resource.Resource, error) {
+				r := tc.args.trees[i]
+				if i < len(tc.args.trees)-1 {
+					i++
+				}
+				return r, nil
+			}
+
+			out := &bytes.Buffer{}
+			err := watchTree(ctx, out, &mockPrinter{}, getTree, tc.args.watcher, false)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nwatchTree(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.output, out.String()); diff != "" {
+				t.Errorf("\n%s\nwatchTree(...): -want output, +got output:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -84,7 +84,7 @@ func (c *LocalCache) Init() error {
 
 // Flush removes the cache directory
 func (c *LocalCache) Flush() error {
-	fmt.Println("flushing cache directory: ", c.cacheDir)
+	fmt.Fprintln(os.Stderr, "flushing cache directory: ", c.cacheDir)
 	return c.fs.RemoveAll(c.cacheDir)
 }
 
@@ -0,0 +1,257 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// celPerCallLimit is the cost limit of evaluating a single rule. It matches
+// the limit enforced by the API server.
+const celPerCallLimit = 1000000
+
+// A celProgram is a compiled x-kubernetes-validations rule.
+type celProgram struct {
+	rule    cel.Program
+	message cel.Program
+
+	// transition is true if the rule refers to oldSelf. Transition rules are
+	// only evaluated when there's an old object to compare to.
+	transition bool
+}
+
+// A celValidator evaluates the x-kubernetes-validations rules of a structural
+// schema, like the API server does.
+//
+// The API server's CEL environment includes Kubernetes specific libraries
+// (e.g. for quantities and URLs) that we don't support. Rules that use them
+// fail to compile and are reported as warnings rather than errors.
+type celValidator struct {
+	self    *cel.Env
+	oldSelf *cel.Env
+
+	programs map[string]celProgram
+	failed   map[string]error
+}
+
+func newCELValidator() (*celValidator, error) {
+	libs := []cel.EnvOption{
+		cel.Variable("self", cel.DynType),
+		ext.Encoders(),
+		ext.Lists(),
+		ext.Sets(),
+		ext.Strings(),
+	}
+	self, err := cel.NewEnv(libs...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CEL environment")
+	}
+	oldSelf, err := self.Extend(cel.Variable("oldSelf", cel.DynType))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create CEL environment")
+	}
+	return &celValidator{self: self, oldSelf: oldSelf, programs: map[string]celProgram{}, failed: map[string]error{}}, nil
+}
+
+// Validate the supplied object against the rules of the supplied structural
+// schema and the schemas it contains. The old object may be nil, in which case
+// transition rules are skipped. It returns warnings for rules that can't be
+// compiled, and errors for rules that aren't satisfied.
+func (v *celValidator) Validate(fldPath *field.Path, s *structuralschema.Structural, obj, old interface{}) (warns []string, errs field.ErrorList) { //nolint:gocyclo // Walks objects and arrays, which is easier to follow in one function.
+	if s == nil || obj == nil {
+		return nil, nil
+	}
+
+	for _, r := range s.XValidations {
+		w, err := v.validateRule(fldPath, s, r, obj, old)
+		if w != "" {
+			warns = append(warns, w)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	switch o := obj.(type) {
+	case map[string]interface{}:
+		om, _ := old.(map[string]interface{})
+		for k, val := range o {
+			var ps *structuralschema.Structural
+			var p *field.Path
+			if prop, ok := s.Properties[k]; ok {
+				ps, p = &prop, fldPath.Child(k)
+			} else if s.AdditionalProperties != nil {
+				ps, p = s.AdditionalProperties.Structural, fldPath.Key(k)
+			}
+			w, e := v.Validate(p, ps, val, om[k])
+			warns = append(warns, w...)
+			errs = append(errs, e...)
+		}
+	case []interface{}:
+		ol, _ := old.([]interface{})
+		for i, val := range o {
+			w, e := v.Validate(fldPath.Index(i), s.Items, val, correlate(s, val, ol))
+			warns = append(warns, w...)
+			errs = append(errs, e...)
+		}
+	}
+
+	return warns, errs
+}
+
+// validateRule evaluates the supplied rule. It returns a warning if the rule
+// can't be compiled, or an error if the rule isn't satisfied.
+func (v *celValidator) validateRule(fldPath *field.Path, s *structuralschema.Structural, r extv1.ValidationRule, obj, old interface{}) (string, *field.Error) { //nolint:gocyclo // Mostly building the error the API server would return.
+	prg, err := v.compile(r)
+	if err != nil {
+		return fmt.Sprintf("cannot evaluate rule %q at %s: %s", r.Rule, fldPath, err), nil
+	}
+	if prg.transition && old == nil {
+		return "", nil
+	}
+
+	vars := map[string]interface{}{"self": obj, "oldSelf": old}
+	out, _, err := prg.rule.Eval(vars)
+	if err != nil {
+		return "", field.Invalid(fldPath, obj, fmt.Sprintf("%s: %s", ruleString(r), err))
+	}
+	if ok, isBool := out.Value().(bool); isBool && ok {
+		return "", nil
+	}
+
+	msg := r.Message
+	if prg.message != nil {
+		if m, _, err := prg.message.Eval(vars); err == nil {
+			if s, ok := m.Value().(string); ok && s != "" {
+				msg = s
+			}
+		}
+	}
+	if msg == "" {
+		msg = ruleString(r)
+	}
+
+	if r.FieldPath != "" {
+		for _, c := range strings.Split(strings.TrimPrefix(r.FieldPath, "."), ".") {
+			fldPath = fldPath.Child(c)
+		}
+	}
+
+	if r.Reason == nil {
+		return "", field.Invalid(fldPath, s.Type, msg)
+	}
+	switch *r.Reason {
+	case extv1.FieldValueRequired:
+		return "", field.Required(fldPath, msg)
+	case extv1.FieldValueForbidden:
+		return "", field.Forbidden(fldPath, msg)
+	case extv1.FieldValueDuplicate:
+		return "", field.Duplicate(fldPath, msg)
+	case extv1.FieldValueInvalid:
+	}
+	return "", field.Invalid(fldPath, s.Type, msg)
+}
+
+// compile the supplied rule, caching the result.
+func (v *celValidator) compile(r extv1.ValidationRule) (celProgram, error) {
+	key := r.Rule + "\x00" + r.MessageExpression
+	if err, ok := v.failed[key]; ok {
+		return celProgram{}, err
+	}
+	if p, ok := v.programs[key]; ok {
+		return p, nil
+	}
+
+	p := celProgram{}
+	env := v.self
+	ast, iss := env.Compile(r.Rule)
+	if iss.Err() != nil {
+		// Rules that fail to compile without oldSelf, but compile with it,
+		// are transition rules.
+		env = v.oldSelf
+		ast, iss = env.Compile(r.Rule)
+		if iss.Err() != nil {
+			v.failed[key] = iss.Err()
+			return celProgram{}, iss.Err()
+		}
+		p.transition = true
+	}
+
+	var err error
+	if p.rule, err = env.Program(ast, cel.CostLimit(celPerCallLimit)); err != nil {
+		v.failed[key] = err
+		return celProgram{}, err
+	}
+
+	if r.MessageExpression != "" {
+		ast, iss := env.Compile(r.MessageExpression)
+		if iss.Err() != nil {
+			v.failed[key] = iss.Err()
+			return celProgram{}, iss.Err()
+		}
+		if p.message, err = env.Program(ast, cel.CostLimit(celPerCallLimit)); err != nil {
+			v.failed[key] = err
+			return celProgram{}, err
+		}
+	}
+
+	v.programs[key] = p
+	return p, nil
+}
+
+// correlate returns the item of the old list that corresponds to the supplied
+// item of the new list. Like the API server, we only correlate the items of
+// map lists, using their keys.
+func correlate(s *structuralschema.Structural, item interface{}, old []interface{}) interface{} {
+	if s.XListType == nil || *s.XListType != "map" || len(s.XListMapKeys) == 0 {
+		return nil
+	}
+	im, ok := item.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	for _, o := range old {
+		om, ok := o.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		match := true
+		for _, k := range s.XListMapKeys {
+			if fmt.Sprint(im[k]) != fmt.Sprint(om[k]) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return om
+		}
+	}
+	return nil
+}
+
+func ruleString(r extv1.ValidationRule) string {
+	return fmt.Sprintf("failed rule: %s", r.Rule)
+}
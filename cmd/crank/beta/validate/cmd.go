@@ -23,6 +23,7 @@ import (
 
 	"github.com/alecthomas/kong"
 	"github.com/spf13/afero"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -37,6 +38,8 @@ type Cmd struct {
 	// Flags. Keep them in alphabetical order.
 	CacheDir           string `help:"Absolute path to the cache directory where downloaded schemas are stored." default:".crossplane/cache"`
 	CleanCache         bool   `help:"Clean the cache directory before downloading package schemas."`
+	OldResources       string `help:"Previous versions of the resources, used to evaluate CEL transition rules. Can be a file or directory." placeholder:"PATH" type:"path"`
+	Output             string `short:"o" help:"Output format. One of: text, json, junit, sarif." enum:"text,json,junit,sarif" default:"text"`
 	SkipSuccessResults bool   `help:"Skip printing success results."`
 
 	fs afero.Fs
@@ -54,7 +57,17 @@ validation. If the cache directory is not provided, it will default to ".crosspl
 Cache directory can be cleaned before downloading schemas by setting the "clean-cache" flag.
 
 All validation is performed offline locally using the Kubernetes API server's validation library, so it does not require 
-any Crossplane instance or control plane to be running or configured.
+any Crossplane instance or control plane to be running or configured. Like the API server, validate prunes unknown
+fields and applies schema defaults before validating a resource, then evaluates its CEL validation rules. Unknown fields
+that would be pruned are reported as warnings. CEL transition rules are only evaluated for resources with a previous
+version supplied using the "old-resources" flag.
+
+Results can be printed as text, JSON, JUnit XML, or SARIF using the "output" flag. Each finding includes the file, YAML
+document index, and line and column of the offending field, so CI systems can annotate it inline.
+
+Compositions provided as resources are validated the same way Crossplane's Composition webhook validates them. Their
+patches are checked against the schemas of the composite and composed resources, using the CRDs from the provided
+extensions and the packages downloaded to the cache directory.
 
 Examples:
 
@@ -65,6 +78,16 @@ Examples:
   # success logs
   crossplane beta validate extensionsDir/ resourceDir/ --skip-success-results
  
+  # Validate changes to the resources in the resourceDir folder, including CEL transition rules, against the previous
+  # versions of the resources in the oldResourceDir folder
+  crossplane beta validate extensionsDir/ resourceDir/ --old-resources oldResourceDir/
+
+  # Validate all resources in the resourceDir folder and write a SARIF report for code scanning tools
+  crossplane beta validate extensionsDir/ resourceDir/ --output sarif > validate.sarif
+
+  # Validate the Compositions in the compositionsDir folder against the XRDs and providers in the extensionsDir folder
+  crossplane beta validate extensionsDir/ compositionsDir/
+
   # Validate the output of the render command against the extensions in the extensionsDir folder
   crossplane beta render xr.yaml composition.yaml func.yaml --include-full-xr | crossplane beta validate extensionsDir/ -
 
@@ -108,6 +131,20 @@ func (c *Cmd) Run(_ *kong.Context, _ logging.Logger) error { //nolint:gocyclo //
 		return errors.Wrapf(err, "cannot load resources from %q", c.Resources)
 	}
 
+	// Load the previous versions of the resources, if any
+	var old []*unstructured.Unstructured
+	if c.OldResources != "" {
+		oldLoader, err := NewLoader(c.OldResources)
+		if err != nil {
+			return errors.Wrapf(err, "cannot load old resources from %q", c.OldResources)
+		}
+
+		old, err = oldLoader.Load()
+		if err != nil {
+			return errors.Wrapf(err, "cannot load old resources from %q", c.OldResources)
+		}
+	}
+
 	// Update default cache directory to absolute path based on the current working directory
 	if c.CacheDir == defaultCacheDir {
 		currentPath, err := os.Getwd()
@@ -129,8 +166,13 @@ func (c *Cmd) Run(_ *kong.Context, _ logging.Logger) error { //nolint:gocyclo //
 		return errors.Wrapf(err, "cannot download and load cache")
 	}
 
+	p, err := NewPrinter(Output(c.Output), c.SkipSuccessResults)
+	if err != nil {
+		return errors.Wrapf(err, "cannot create printer")
+	}
+
 	// Validate resources against schemas
-	if err := SchemaValidation(resources, m.crds, c.SkipSuccessResults); err != nil {
+	if err := SchemaValidation(resources, old, m.crds, c.SkipSuccessResults, WithPrinter(p), WithSources(resourceLoader.Sources())); err != nil {
 		return errors.Wrapf(err, "cannot validate resources")
 	}
 
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"context"
+	"fmt"
+
+	ext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/pkg/validation/apiextensions/v1/composition"
+)
+
+// isComposition returns true if the supplied resource is a Composition.
+func isComposition(u *unstructured.Unstructured) bool {
+	return u.GroupVersionKind().GroupKind() == v1.CompositionGroupVersionKind.GroupKind()
+}
+
+// A compositionValidator validates Compositions offline. It runs the same
+// checks as the Composition validating webhook, using the supplied CRDs rather
+// than the CRDs installed in an API server.
+type compositionValidator struct {
+	crds map[schema.GroupKind]ext.CustomResourceDefinition
+}
+
+func newCompositionValidator(crds []*extv1.CustomResourceDefinition) (*compositionValidator, error) {
+	v := &compositionValidator{crds: make(map[schema.GroupKind]ext.CustomResourceDefinition, len(crds))}
+	for i := range crds {
+		internal := ext.CustomResourceDefinition{}
+		if err := extv1.Convert_v1_CustomResourceDefinition_To_apiextensions_CustomResourceDefinition(crds[i], &internal, nil); err != nil {
+			return nil, err
+		}
+		v.crds[schema.GroupKind{Group: internal.Spec.Group, Kind: internal.Spec.Names.Kind}] = internal
+	}
+	return v, nil
+}
+
+// Validate the supplied Composition. It returns any warnings and errors.
+//
+// Like the webhook, schema-aware validation is skipped with a warning if the
+// CRD of the composite resource or of any composed resource is missing,
+// unless the Composition's schema-aware validation mode is strict. Unlike the
+// webhook, schema-aware validation failures are errors unless the Composition
+// explicitly sets its validation mode to warn.
+func (v *compositionValidator) Validate(ctx context.Context, u *unstructured.Unstructured) (warns []string, errs []error) {
+	comp := &v1.Composition{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, comp); err != nil {
+		return nil, []error{errors.Wrap(err, "cannot convert to Composition")}
+	}
+
+	warns, el := comp.Validate()
+	el = append(el, composition.ValidateCELExpressions(comp)...)
+	if len(el) != 0 {
+		return warns, toErrors(el)
+	}
+
+	mode, err := comp.GetSchemaAwareValidationMode()
+	if err != nil {
+		return warns, []error{err}
+	}
+
+	missing, err := v.missingCRDs(comp)
+	if err != nil {
+		return warns, []error{err}
+	}
+	if len(missing) != 0 {
+		for _, gk := range missing {
+			msg := fmt.Sprintf("could not find CRD/XRD for: %s", gk)
+			if mode == v1.SchemaAwareCompositionValidationModeStrict {
+				errs = append(errs, errors.New(msg))
+				continue
+			}
+			warns = append(warns, msg+", skipping schema-aware validation")
+		}
+		return warns, errs
+	}
+
+	cv, err := composition.NewValidator(
+		composition.WithCRDGetterFromMap(v.crds),
+		// We already validated the Composition itself above.
+		composition.WithoutLogicalValidation(),
+	)
+	if err != nil {
+		return warns, []error{err}
+	}
+
+	sw, el := cv.Validate(ctx, comp)
+	warns = append(warns, sw...)
+	if _, explicit := comp.GetAnnotations()[v1.SchemaAwareCompositionValidationModeAnnotation]; explicit && mode == v1.SchemaAwareCompositionValidationModeWarn {
+		for _, e := range el {
+			warns = append(warns, e.Error())
+		}
+		return warns, nil
+	}
+	return warns, toErrors(el)
+}
+
+// missingCRDs returns the group kinds of the composite resource and composed
+// resources of the supplied Composition that we don't have a CRD for.
+func (v *compositionValidator) missingCRDs(comp *v1.Composition) ([]schema.GroupKind, error) {
+	needed := []schema.GroupKind{schema.FromAPIVersionAndKind(comp.Spec.CompositeTypeRef.APIVersion, comp.Spec.CompositeTypeRef.Kind).GroupKind()}
+	for i := range comp.Spec.Resources {
+		gvk, err := composition.GetBaseObjectGVK(&comp.Spec.Resources[i])
+		if err != nil {
+			return nil, err
+		}
+		needed = append(needed, gvk.GroupKind())
+	}
+
+	missing := make([]schema.GroupKind, 0)
+	seen := map[schema.GroupKind]bool{}
+	for _, gk := range needed {
+		if _, ok := v.crds[gk]; ok || seen[gk] {
+			continue
+		}
+		seen[gk] = true
+		missing = append(missing, gk)
+	}
+	return missing, nil
+}
+
+func toErrors(el field.ErrorList) []error {
+	if len(el) == 0 {
+		return nil
+	}
+	errs := make([]error, len(el))
+	for i := range el {
+		errs[i] = el[i]
+	}
+	return errs
+}
@@ -18,6 +18,7 @@ package validate
 
 import (
 	"bufio"
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
@@ -31,6 +32,26 @@ import (
 // Loader interface defines the contract for different input sources
 type Loader interface {
 	Load() ([]*unstructured.Unstructured, error)
+
+	// Sources returns where each resource returned by Load was loaded from.
+	Sources() Sources
+}
+
+// sourceRecorder records where a loader loaded resources from.
+type sourceRecorder struct {
+	sources Sources
+}
+
+// Sources returns where each loaded resource was loaded from.
+func (r *sourceRecorder) Sources() Sources {
+	return r.sources
+}
+
+func (r *sourceRecorder) record(file string, content []byte, stream [][]byte, resources []*unstructured.Unstructured) {
+	if r.sources == nil {
+		r.sources = Sources{}
+	}
+	r.sources.record(file, content, stream, resources)
 }
 
 // NewLoader returns a Loader based on the input source
@@ -52,52 +73,79 @@ func NewLoader(input string) (Loader, error) {
 }
 
 // StdinLoader implements the Loader interface for reading from stdin
-type StdinLoader struct{}
+type StdinLoader struct {
+	sourceRecorder
+}
 
 // Load reads the contents from stdin
 func (s *StdinLoader) Load() ([]*unstructured.Unstructured, error) {
-	stream, err := load(os.Stdin)
+	content, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read stdin")
+	}
+
+	stream, err := load(bytes.NewReader(content))
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot load YAML stream from stdin")
 	}
 
-	return streamToUnstructured(stream)
+	resources, err := streamToUnstructured(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	s.record("-", content, stream, resources)
+	return resources, nil
 }
 
 // FileLoader implements the Loader interface for reading from a file and converting input to unstructured objects
 type FileLoader struct {
+	sourceRecorder
 	path string
 }
 
 // Load reads the contents from a file
 func (f *FileLoader) Load() ([]*unstructured.Unstructured, error) {
-	stream, err := readFile(f.path)
+	content, stream, err := readFile(f.path)
 	if err != nil {
 		return nil, errors.Wrap(err, "cannot read file")
 	}
 
-	return streamToUnstructured(stream)
+	resources, err := streamToUnstructured(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	f.record(f.path, content, stream, resources)
+	return resources, nil
 }
 
 // FolderLoader implements the Loader interface for reading from a folder
 type FolderLoader struct {
+	sourceRecorder
 	path string
 }
 
 // Load reads the contents from all files in a folder
 func (f *FolderLoader) Load() ([]*unstructured.Unstructured, error) {
-	var stream [][]byte
+	var resources []*unstructured.Unstructured
 	err := filepath.Walk(f.path, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		if isYamlFile(info) {
-			s, err := readFile(path)
+			content, stream, err := readFile(path)
 			if err != nil {
 				return err
 			}
 
-			stream = append(stream, s...)
+			rs, err := streamToUnstructured(stream)
+			if err != nil {
+				return err
+			}
+
+			f.record(path, content, stream, rs)
+			resources = append(resources, rs...)
 		}
 		return nil
 	})
@@ -105,22 +153,23 @@ func (f *FolderLoader) Load() ([]*unstructured.Unstructured, error) {
 		return nil, errors.Wrap(err, "cannot read folder")
 	}
 
-	return streamToUnstructured(stream)
+	return resources, nil
 }
 
 func isYamlFile(info os.FileInfo) bool {
 	return !info.IsDir() && (filepath.Ext(info.Name()) == ".yaml" || filepath.Ext(info.Name()) == ".yml")
 }
 
-func readFile(path string) ([][]byte, error) {
-	f, err := os.Open(filepath.Clean(path))
-
+// readFile returns the content of the supplied file, and the YAML documents
+// it contains.
+func readFile(path string) ([]byte, [][]byte, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot open file")
+		return nil, nil, errors.Wrap(err, "cannot open file")
 	}
-	defer f.Close() //nolint:errcheck // Only open for reading.
 
-	return load(f)
+	stream, err := load(bytes.NewReader(content))
+	return content, stream, err
 }
 
 func load(r io.Reader) ([][]byte, error) {
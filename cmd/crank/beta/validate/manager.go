@@ -18,6 +18,7 @@ package validate
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/spf13/afero"
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -218,7 +219,9 @@ func (m *Manager) cacheDependencies() error {
 			continue
 		}
 
-		fmt.Printf("package schemas does not exist, downloading: %s\n", image)
+		// Write progress to stderr so it doesn't mix with machine-readable
+		// validation results on stdout.
+		fmt.Fprintf(os.Stderr, "package schemas does not exist, downloading: %s\n", image)
 
 		layer, err := m.fetcher.FetchBaseLayer(image)
 		if err != nil {
@@ -0,0 +1,480 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+const (
+	errFmtUnknownOutput = "unknown output format: %s"
+)
+
+// Output is the format validation results are printed in.
+type Output string
+
+// Supported output formats.
+const (
+	OutputText  Output = "text"
+	OutputJSON  Output = "json"
+	OutputJUnit Output = "junit"
+	OutputSARIF Output = "sarif"
+)
+
+// Status is the overall result of validating a resource.
+type Status string
+
+// Resource validation statuses.
+const (
+	StatusSuccess Status = "success"
+	StatusWarning Status = "warning"
+	StatusFailure Status = "failure"
+)
+
+// Severity is the severity of a finding.
+type Severity string
+
+// Finding severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule identifies the kind of check that produced a finding.
+type Rule string
+
+// Finding rules.
+const (
+	// RuleMissingSchema findings are produced when there's no CRD or XRD for
+	// a resource.
+	RuleMissingSchema Rule = "missing-schema"
+
+	// RuleSchema findings are produced when a resource doesn't satisfy its
+	// OpenAPI schema or CEL validation rules.
+	RuleSchema Rule = "schema"
+
+	// RuleUnknownField findings are produced when a resource has fields that
+	// the API server would prune.
+	RuleUnknownField Rule = "unknown-field"
+
+	// RuleComposition findings are produced when a Composition is invalid.
+	RuleComposition Rule = "composition"
+)
+
+// A Finding is a problem found while validating a resource.
+type Finding struct {
+	Rule     Rule     `json:"rule"`
+	Severity Severity `json:"severity"`
+
+	// Field is the path to the offending field, if any. For example
+	// spec.forProvider.region.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+
+	// File, Document, Line, and Column identify where the offending field
+	// was loaded from. Line and Column are zero if they're unknown.
+	File     string `json:"file,omitempty"`
+	Document int    `json:"document"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// A Result is the result of validating a resource.
+type Result struct {
+	APIVersion string    `json:"apiVersion"`
+	Kind       string    `json:"kind"`
+	Namespace  string    `json:"namespace,omitempty"`
+	Name       string    `json:"name,omitempty"`
+	File       string    `json:"file,omitempty"`
+	Document   int       `json:"document"`
+	Status     Status    `json:"status"`
+	Findings   []Finding `json:"findings,omitempty"`
+
+	// label identifies the resource in text output. It's the resource's
+	// composition resource name annotation, or a Composition's name.
+	label string
+
+	// src is where the resource was loaded from, if known.
+	src *Source
+}
+
+// A Summary counts validation results by status.
+type Summary struct {
+	Errors    int `json:"errors"`
+	Warnings  int `json:"warnings"`
+	Successes int `json:"successes"`
+}
+
+// Summarize the supplied results.
+func Summarize(results []Result) Summary {
+	s := Summary{}
+	for _, r := range results {
+		switch r.Status {
+		case StatusFailure:
+			s.Errors++
+		case StatusWarning:
+			s.Warnings++
+		case StatusSuccess:
+			s.Successes++
+		}
+	}
+	return s
+}
+
+func (r Result) gvk() string {
+	return schema.FromAPIVersionAndKind(r.APIVersion, r.Kind).String()
+}
+
+// A Printer prints validation results.
+type Printer interface {
+	Print(w io.Writer, results []Result) error
+}
+
+// NewPrinter returns a printer for the supplied output format.
+func NewPrinter(o Output, skipSuccessResults bool) (Printer, error) {
+	switch o {
+	case OutputText:
+		return &TextPrinter{skipSuccessResults: skipSuccessResults}, nil
+	case OutputJSON:
+		return &JSONPrinter{}, nil
+	case OutputJUnit:
+		return &JUnitPrinter{}, nil
+	case OutputSARIF:
+		return &SARIFPrinter{}, nil
+	}
+	return nil, errors.Errorf(errFmtUnknownOutput, o)
+}
+
+// A TextPrinter prints human-readable validation results.
+type TextPrinter struct {
+	skipSuccessResults bool
+}
+
+// Print the supplied results.
+func (p *TextPrinter) Print(w io.Writer, results []Result) error {
+	for _, r := range results {
+		for _, f := range r.Findings {
+			var err error
+			switch {
+			case f.Rule == RuleMissingSchema:
+				_, err = fmt.Fprintln(w, "[!] "+f.Message)
+			case f.Severity == SeverityWarning:
+				_, err = fmt.Fprintf(w, "[!] %s, %s : %s\n", r.gvk(), r.label, f.Message)
+			default:
+				_, err = fmt.Fprintf(w, "[x] validation error %s, %s : %s\n", r.gvk(), r.label, f.Message)
+			}
+			if err != nil {
+				return errors.Wrap(err, "cannot write results")
+			}
+		}
+		if r.Status == StatusSuccess && !p.skipSuccessResults {
+			if _, err := fmt.Fprintf(w, "[✓] %s, %s validated successfully\n", r.gvk(), r.label); err != nil {
+				return errors.Wrap(err, "cannot write results")
+			}
+		}
+	}
+
+	s := Summarize(results)
+	_, err := fmt.Fprintf(w, "%d error, %d warning, %d success cases\n", s.Errors, s.Warnings, s.Successes)
+	return errors.Wrap(err, "cannot write results")
+}
+
+// A JSONPrinter prints validation results as JSON.
+type JSONPrinter struct{}
+
+// Print the supplied results.
+func (p *JSONPrinter) Print(w io.Writer, results []Result) error {
+	out := struct {
+		Summary Summary  `json:"summary"`
+		Results []Result `json:"results"`
+	}{
+		Summary: Summarize(results),
+		Results: results,
+	}
+	if out.Results == nil {
+		out.Results = []Result{}
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal results to JSON")
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return errors.Wrap(err, "cannot write results")
+}
+
+// A JUnitPrinter prints validation results as a JUnit XML report. Each
+// resource is a test case, grouped into a test suite per file. Resources that
+// fail validation are failed test cases, and resources without a CRD or XRD
+// are skipped test cases. Warnings are included in the test case's output.
+type JUnitPrinter struct{}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// Print the supplied results.
+func (p *JUnitPrinter) Print(w io.Writer, results []Result) error { //nolint:gocyclo // Mostly tallying test cases.
+	out := junitTestSuites{Name: "crossplane beta validate"}
+	suites := map[string]*junitTestSuite{}
+	order := make([]string, 0)
+
+	for _, r := range results {
+		file := resultFile(r)
+		s, ok := suites[file]
+		if !ok {
+			s = &junitTestSuite{Name: file}
+			suites[file] = s
+			order = append(order, file)
+		}
+
+		tc := junitTestCase{Name: resultName(r), ClassName: file, File: r.File, Line: firstLine(r)}
+
+		var errs, warns []string
+		for _, f := range r.Findings {
+			switch {
+			case f.Rule == RuleMissingSchema:
+				tc.Skipped = &junitMessage{Message: f.Message}
+			case f.Severity == SeverityError:
+				errs = append(errs, location(f)+f.Message)
+			default:
+				warns = append(warns, location(f)+f.Message)
+			}
+		}
+		if len(errs) > 0 {
+			tc.Failure = &junitMessage{Message: errs[0], Type: string(SeverityError), Text: strings.Join(errs, "\n")}
+			s.Failures++
+			out.Failures++
+		}
+		if tc.Skipped != nil {
+			s.Skipped++
+			out.Skipped++
+		}
+		tc.SystemOut = strings.Join(warns, "\n")
+
+		s.Cases = append(s.Cases, tc)
+		s.Tests++
+		out.Tests++
+	}
+
+	for _, f := range order {
+		out.Suites = append(out.Suites, *suites[f])
+	}
+
+	b, err := xml.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal results to JUnit XML")
+	}
+	_, err = fmt.Fprintf(w, "%s%s\n", xml.Header, b)
+	return errors.Wrap(err, "cannot write results")
+}
+
+// A SARIFPrinter prints validation results as a SARIF 2.1.0 log.
+type SARIFPrinter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+var sarifRules = map[Rule]string{
+	RuleMissingSchema: "The resource's CRD or XRD could not be found, so it could not be validated.",
+	RuleSchema:        "The resource does not satisfy its schema or validation rules.",
+	RuleUnknownField:  "The resource has fields that are not in its schema and would be pruned.",
+	RuleComposition:   "The Composition is invalid.",
+}
+
+// Print the supplied results.
+func (p *SARIFPrinter) Print(w io.Writer, results []Result) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "crossplane-beta-validate",
+			InformationURI: "https://docs.crossplane.io",
+		}},
+		Results: []sarifResult{},
+	}
+
+	used := map[Rule]bool{}
+	for _, r := range results {
+		for _, f := range r.Findings {
+			used[f.Rule] = true
+			sr := sarifResult{
+				RuleID:  string(f.Rule),
+				Level:   string(f.Severity),
+				Message: sarifMessage{Text: fmt.Sprintf("%s: %s", resultName(r), f.Message)},
+			}
+			if f.File != "" {
+				l := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(f.File)}}}
+				if f.Line > 0 {
+					l.PhysicalLocation.Region = &sarifRegion{StartLine: f.Line, StartColumn: f.Column}
+				}
+				sr.Locations = []sarifLocation{l}
+			}
+			run.Results = append(run.Results, sr)
+		}
+	}
+
+	run.Tool.Driver.Rules = []sarifRule{}
+	for r := range used {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: string(r), ShortDescription: sarifMessage{Text: sarifRules[r]}})
+	}
+	sort.Slice(run.Tool.Driver.Rules, func(i, j int) bool { return run.Tool.Driver.Rules[i].ID < run.Tool.Driver.Rules[j].ID })
+
+	out := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "cannot marshal results to SARIF")
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return errors.Wrap(err, "cannot write results")
+}
+
+// resultFile returns the file the supplied result's resource was loaded from,
+// if it's known.
+func resultFile(r Result) string {
+	if r.File == "" {
+		return "unknown"
+	}
+	return r.File
+}
+
+// firstLine returns the line of the supplied result's first error, or of its
+// first finding if it has no errors.
+func firstLine(r Result) int {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return f.Line
+		}
+	}
+	if len(r.Findings) > 0 {
+		return r.Findings[0].Line
+	}
+	return 0
+}
+
+// resultName returns a name that identifies the supplied result's resource.
+func resultName(r Result) string {
+	n := r.Name
+	if r.Namespace != "" {
+		n = r.Namespace + "/" + n
+	}
+	return fmt.Sprintf("%s %s", r.gvk(), n)
+}
+
+// location returns a file:line:column prefix for the supplied finding, or an
+// empty string if its location is unknown.
+func location(f Finding) string {
+	switch {
+	case f.File == "":
+		return ""
+	case f.Line == 0:
+		return f.File + ": "
+	}
+	return fmt.Sprintf("%s:%d:%d: ", f.File, f.Line, f.Column)
+}
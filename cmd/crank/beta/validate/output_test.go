@@ -0,0 +1,254 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+var testResults = []Result{
+	{
+		APIVersion: "test.org/v1alpha1",
+		Kind:       "Test",
+		Name:       "valid",
+		File:       "resources.yaml",
+		Document:   0,
+		Status:     StatusSuccess,
+		label:      "resource-a",
+	},
+	{
+		APIVersion: "test.org/v1alpha1",
+		Kind:       "Test",
+		Name:       "invalid",
+		File:       "resources.yaml",
+		Document:   1,
+		Status:     StatusFailure,
+		label:      "resource-b",
+		Findings: []Finding{
+			{
+				Rule:     RuleUnknownField,
+				Severity: SeverityWarning,
+				Field:    "spec.unknown",
+				Message:  `unknown field "spec.unknown" would be pruned`,
+				File:     "resources.yaml",
+				Document: 1,
+				Line:     9,
+				Column:   3,
+			},
+			{
+				Rule:     RuleSchema,
+				Severity: SeverityError,
+				Field:    "spec.replicas",
+				Message:  `spec.replicas: Invalid value: "string": spec.replicas in body must be of type integer: "string"`,
+				File:     "resources.yaml",
+				Document: 1,
+				Line:     8,
+				Column:   3,
+			},
+		},
+	},
+	{
+		APIVersion: "other.org/v1",
+		Kind:       "Other",
+		Name:       "missing",
+		File:       "resources.yaml",
+		Document:   2,
+		Status:     StatusWarning,
+		Findings: []Finding{
+			{
+				Rule:     RuleMissingSchema,
+				Severity: SeverityWarning,
+				Message:  "could not find CRD/XRD for: other.org/v1, Kind=Other",
+				File:     "resources.yaml",
+				Document: 2,
+				Line:     12,
+				Column:   1,
+			},
+		},
+	},
+}
+
+func TestTextPrinter(t *testing.T) {
+	cases := map[string]struct {
+		reason             string
+		skipSuccessResults bool
+		want               string
+	}{
+		"AllResults": {
+			reason: "Should print every finding, every successful resource, and a summary",
+			want: `[✓] test.org/v1alpha1, Kind=Test, resource-a validated successfully
+[!] test.org/v1alpha1, Kind=Test, resource-b : unknown field "spec.unknown" would be pruned
+[x] validation error test.org/v1alpha1, Kind=Test, resource-b : spec.replicas: Invalid value: "string": spec.replicas in body must be of type integer: "string"
+[!] could not find CRD/XRD for: other.org/v1, Kind=Other
+1 error, 1 warning, 1 success cases
+`,
+		},
+		"SkipSuccessResults": {
+			reason:             "Should not print successful resources when asked to skip them",
+			skipSuccessResults: true,
+			want: `[!] test.org/v1alpha1, Kind=Test, resource-b : unknown field "spec.unknown" would be pruned
+[x] validation error test.org/v1alpha1, Kind=Test, resource-b : spec.replicas: Invalid value: "string": spec.replicas in body must be of type integer: "string"
+[!] could not find CRD/XRD for: other.org/v1, Kind=Other
+1 error, 1 warning, 1 success cases
+`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			p := &TextPrinter{skipSuccessResults: tc.skipSuccessResults}
+			if err := p.Print(b, testResults); err != nil {
+				t.Fatalf("Print(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, b.String()); diff != "" {
+				t.Errorf("%s\nPrint(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestJSONPrinter(t *testing.T) {
+	b := &bytes.Buffer{}
+	if err := (&JSONPrinter{}).Print(b, testResults); err != nil {
+		t.Fatalf("Print(...): %s", err)
+	}
+
+	got := struct {
+		Summary Summary  `json:"summary"`
+		Results []Result `json:"results"`
+	}{}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal(...): %s", err)
+	}
+
+	if diff := cmp.Diff(Summary{Errors: 1, Warnings: 1, Successes: 1}, got.Summary); diff != "" {
+		t.Errorf("Print(...): -want summary, +got summary:\n%s", diff)
+	}
+	if diff := cmp.Diff(testResults, got.Results, cmpopts.IgnoreUnexported(Result{})); diff != "" {
+		t.Errorf("Print(...): -want results, +got results:\n%s", diff)
+	}
+}
+
+func TestJUnitPrinter(t *testing.T) {
+	b := &bytes.Buffer{}
+	if err := (&JUnitPrinter{}).Print(b, testResults); err != nil {
+		t.Fatalf("Print(...): %s", err)
+	}
+
+	got := junitTestSuites{}
+	if err := xml.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("xml.Unmarshal(...): %s", err)
+	}
+
+	want := junitTestSuites{
+		XMLName:  xml.Name{Local: "testsuites"},
+		Name:     "crossplane beta validate",
+		Tests:    3,
+		Failures: 1,
+		Skipped:  1,
+		Suites: []junitTestSuite{{
+			Name:     "resources.yaml",
+			Tests:    3,
+			Failures: 1,
+			Skipped:  1,
+			Cases: []junitTestCase{
+				{
+					Name:      "test.org/v1alpha1, Kind=Test valid",
+					ClassName: "resources.yaml",
+					File:      "resources.yaml",
+				},
+				{
+					Name:      "test.org/v1alpha1, Kind=Test invalid",
+					ClassName: "resources.yaml",
+					File:      "resources.yaml",
+					Line:      8,
+					Failure: &junitMessage{
+						Message: `resources.yaml:8:3: spec.replicas: Invalid value: "string": spec.replicas in body must be of type integer: "string"`,
+						Type:    "error",
+						Text:    `resources.yaml:8:3: spec.replicas: Invalid value: "string": spec.replicas in body must be of type integer: "string"`,
+					},
+					SystemOut: `resources.yaml:9:3: unknown field "spec.unknown" would be pruned`,
+				},
+				{
+					Name:      "other.org/v1, Kind=Other missing",
+					ClassName: "resources.yaml",
+					File:      "resources.yaml",
+					Line:      12,
+					Skipped:   &junitMessage{Message: "could not find CRD/XRD for: other.org/v1, Kind=Other"},
+				},
+			},
+		}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Print(...): -want, +got:\n%s", diff)
+	}
+}
+
+func TestSARIFPrinter(t *testing.T) {
+	b := &bytes.Buffer{}
+	if err := (&SARIFPrinter{}).Print(b, testResults); err != nil {
+		t.Fatalf("Print(...): %s", err)
+	}
+
+	got := sarifLog{}
+	if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal(...): %s", err)
+	}
+
+	location := func(line, column int) []sarifLocation {
+		return []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: "resources.yaml"},
+			Region:           &sarifRegion{StartLine: line, StartColumn: column},
+		}}}
+	}
+	want := []sarifResult{
+		{
+			RuleID:    "unknown-field",
+			Level:     "warning",
+			Message:   sarifMessage{Text: `test.org/v1alpha1, Kind=Test invalid: unknown field "spec.unknown" would be pruned`},
+			Locations: location(9, 3),
+		},
+		{
+			RuleID:    "schema",
+			Level:     "error",
+			Message:   sarifMessage{Text: `test.org/v1alpha1, Kind=Test invalid: spec.replicas: Invalid value: "string": spec.replicas in body must be of type integer: "string"`},
+			Locations: location(8, 3),
+		},
+		{
+			RuleID:    "missing-schema",
+			Level:     "warning",
+			Message:   sarifMessage{Text: "other.org/v1, Kind=Other missing: could not find CRD/XRD for: other.org/v1, Kind=Other"},
+			Locations: location(12, 1),
+		},
+	}
+
+	if diff := cmp.Diff("2.1.0", got.Version); diff != "" {
+		t.Errorf("Print(...): -want version, +got version:\n%s", diff)
+	}
+	if len(got.Runs) != 1 {
+		t.Fatalf("Print(...): want 1 run, got %d", len(got.Runs))
+	}
+	if diff := cmp.Diff(want, got.Runs[0].Results); diff != "" {
+		t.Errorf("Print(...): -want results, +got results:\n%s", diff)
+	}
+}
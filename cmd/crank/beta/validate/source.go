@@ -0,0 +1,189 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// A Source is where a resource was loaded from.
+type Source struct {
+	// File the resource was loaded from. It's "-" for standard input.
+	File string
+
+	// Document is the index of the YAML document within the file that the
+	// resource was loaded from, starting at 0.
+	Document int
+
+	// node is the parsed YAML document. It's nil if we couldn't parse it.
+	node *yaml.Node
+
+	// line is the number of lines in the file that precede the document.
+	line int
+}
+
+// Sources maps resources to where they were loaded from.
+type Sources map[*unstructured.Unstructured]*Source
+
+// Position returns the line and column of the supplied field path within the
+// resource's YAML document. Paths use the format of a field.Path, for example
+// spec.resources[0].base. If the field doesn't exist Position returns the
+// position of the deepest ancestor that does. Lines and columns start at 1.
+// Position returns 0, 0 if the position is unknown.
+func (s *Source) Position(path string) (line, column int) {
+	if s == nil || s.node == nil || len(s.node.Content) == 0 {
+		return 0, 0
+	}
+
+	n := s.node.Content[0]
+	pos := n
+	for _, seg := range splitPath(path) {
+		next, at := child(n, seg)
+		if next == nil {
+			break
+		}
+		n, pos = next, at
+	}
+	return s.line + pos.Line, pos.Column
+}
+
+// child returns the supplied node's child at the supplied path segment, and
+// the node that marks the child's position (i.e. its key within a mapping). It
+// returns nil if there's no such child.
+func child(n *yaml.Node, seg string) (value, at *yaml.Node) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == seg {
+				return n.Content[i+1], n.Content[i]
+			}
+		}
+	case yaml.SequenceNode:
+		i, err := strconv.Atoi(seg)
+		if err != nil || i < 0 || i >= len(n.Content) {
+			return nil, nil
+		}
+		return n.Content[i], n.Content[i]
+	case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+	}
+	return nil, nil
+}
+
+// splitPath splits a field path like spec.items[0].name into its segments,
+// i.e. spec, items, 0, and name.
+func splitPath(path string) []string {
+	segs := make([]string, 0)
+	cur := strings.Builder{}
+	flush := func() {
+		if cur.Len() > 0 {
+			segs = append(segs, cur.String())
+			cur.Reset()
+		}
+	}
+	inBracket := false
+	for _, r := range path {
+		switch {
+		case r == '[' && !inBracket:
+			flush()
+			inBracket = true
+		case r == ']' && inBracket:
+			flush()
+			inBracket = false
+		case r == '.' && !inBracket:
+			flush()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush()
+	return segs
+}
+
+// record where the supplied resources were loaded from. The resources must
+// have been parsed from the supplied stream of YAML documents, which must have
+// been read from the supplied content.
+func (s Sources) record(file string, content []byte, stream [][]byte, resources []*unstructured.Unstructured) {
+	offset := 0
+	for i, r := range resources {
+		src := &Source{File: file, Document: i}
+		s[r] = src
+
+		if i >= len(stream) {
+			continue
+		}
+
+		// The YAML reader returns each document verbatim, except that it
+		// may add a trailing newline, so we can find where it starts in
+		// the content.
+		needle := bytes.TrimRight(stream[i], "\n")
+		at := bytes.Index(content[offset:], needle)
+		if at < 0 {
+			continue
+		}
+		start := offset + at
+		offset = start + len(needle)
+
+		// A document may be preceded by separators, for example if the
+		// previous document was empty.
+		doc := stream[i]
+		for bytes.HasPrefix(doc, []byte("---")) {
+			eol := bytes.IndexByte(doc, '\n')
+			if eol < 0 {
+				break
+			}
+			doc, start = doc[eol+1:], start+eol+1
+		}
+
+		src.Document = documentIndex(content[:start])
+		src.line = bytes.Count(content[:start], []byte("\n"))
+
+		n := &yaml.Node{}
+		if err := yaml.Unmarshal(doc, n); err == nil {
+			src.node = n
+		}
+	}
+}
+
+// documentIndex returns the index of the YAML document that starts after the
+// supplied content, by counting the document separators that precede it.
+func documentIndex(preceding []byte) int {
+	seps, leading := 0, true
+	for _, l := range bytes.Split(preceding, []byte("\n")) {
+		t := bytes.TrimSpace(l)
+		if len(t) == 0 {
+			continue
+		}
+		if bytes.HasPrefix(l, []byte("---")) {
+			seps++
+			continue
+		}
+		if seps == 0 {
+			leading = false
+		}
+	}
+	// A separator at the very start of the content starts the first
+	// document, rather than separating it from a previous one.
+	if leading && seps > 0 {
+		seps--
+	}
+	return seps
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSourcePosition(t *testing.T) {
+	type want struct {
+		file     string
+		document int
+		line     int
+		column   int
+	}
+	cases := map[string]struct {
+		reason   string
+		resource int
+		path     string
+		want     want
+	}{
+		"Root": {
+			reason:   "An empty path should return the position of the document",
+			resource: 0,
+			path:     "",
+			want:     want{file: "testdata/resources.yaml", document: 0, line: 2, column: 1},
+		},
+		"Field": {
+			reason:   "A path to a field should return the position of its key",
+			resource: 0,
+			path:     "spec.coolField",
+			want:     want{file: "testdata/resources.yaml", document: 0, line: 9, column: 3},
+		},
+		"MapKey": {
+			reason:   "A path may use brackets to refer to map keys",
+			resource: 1,
+			path:     "metadata.annotations[crossplane.io/composition-resource-name]",
+			want:     want{file: "testdata/resources.yaml", document: 1, line: 16, column: 5},
+		},
+		"MissingField": {
+			reason:   "A path to a field that doesn't exist should return the position of its deepest ancestor that does",
+			resource: 1,
+			path:     "spec.missing.field",
+			want:     want{file: "testdata/resources.yaml", document: 1, line: 17, column: 1},
+		},
+	}
+
+	l := &FileLoader{path: "testdata/resources.yaml"}
+	resources, err := l.Load()
+	if err != nil {
+		t.Fatalf("Load(...): %s", err)
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := l.Sources()[resources[tc.resource]]
+			line, column := s.Position(tc.path)
+			got := want{file: s.File, document: s.Document, line: line, column: column}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("%s\nPosition(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSourcesRecord(t *testing.T) {
+	type want struct {
+		document int
+		line     int
+	}
+	cases := map[string]struct {
+		reason  string
+		content string
+		want    []want
+	}{
+		"LeadingSeparator": {
+			reason:  "A separator at the start of the file should not count as a document",
+			content: "---\n{apiVersion: v1, kind: A}\n---\n{apiVersion: v1, kind: B}\n",
+			want:    []want{{document: 0, line: 2}, {document: 1, line: 4}},
+		},
+		"EmptyDocument": {
+			reason:  "Empty documents should count towards the document index",
+			content: "{apiVersion: v1, kind: A}\n---\n---\n{apiVersion: v1, kind: B}\n",
+			want:    []want{{document: 0, line: 1}, {document: 2, line: 4}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stream, err := load(strings.NewReader(tc.content))
+			if err != nil {
+				t.Fatalf("load(...): %s", err)
+			}
+			resources, err := streamToUnstructured(stream)
+			if err != nil {
+				t.Fatalf("streamToUnstructured(...): %s", err)
+			}
+
+			s := Sources{}
+			s.record("f.yaml", []byte(tc.content), stream, resources)
+
+			got := make([]want, len(resources))
+			for i, r := range resources {
+				line, _ := s[r].Position("")
+				got[i] = want{document: s[r].Document, line: line}
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("%s\nrecord(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -17,21 +17,145 @@ limitations under the License.
 package validate
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 
 	ext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
 	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	"github.com/crossplane/crossplane/internal/controller/apiextensions/composite"
 )
 
-func newValidators(crds []*extv1.CustomResourceDefinition) (map[schema.GroupVersionKind][]validation.SchemaValidator, error) {
-	validators := map[schema.GroupVersionKind][]validation.SchemaValidator{}
+// A validator validates resources of one version of a kind the way the API
+// server would when they're created or updated.
+type validator struct {
+	// schemas are the OpenAPI schema validators for the version.
+	schemas []validation.SchemaValidator
+
+	// structural is the structural schema of the version. It's used to prune
+	// unknown fields, apply defaults, and evaluate CEL validation rules. It's
+	// nil if the version doesn't have a structural schema.
+	structural *structuralschema.Structural
+
+	// cel evaluates the x-kubernetes-validations rules of the structural
+	// schema.
+	cel *celValidator
+}
+
+// validate the supplied resource. The supplied old resource, if any, is used
+// to evaluate CEL transition rules. Neither resource is modified. It returns
+// the paths of unknown fields that would be pruned, warnings for CEL rules
+// that can't be evaluated, and any validation errors.
+func (v *validator) validate(r, old *unstructured.Unstructured) (pruned, warns []string, errs field.ErrorList) {
+	obj, err := roundTrip(r)
+	if err != nil {
+		return nil, nil, field.ErrorList{field.InternalError(nil, err)}
+	}
+
+	// The API server prunes unknown fields and applies defaults before it
+	// validates a resource.
+	if v.structural != nil {
+		pruned = pruning.PruneWithOptions(obj.Object, v.structural, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+		applyDefaults(obj.Object, v.structural)
+	}
+
+	for _, sv := range v.schemas {
+		errs = append(errs, validation.ValidateCustomResource(nil, obj.Object, sv)...)
+	}
+
+	if v.structural == nil {
+		return pruned, nil, errs
+	}
+
+	// An old resource only matters to CEL transition rules, which are
+	// skipped when oldObj is nil.
+	var oldObj interface{}
+	if old != nil {
+		o, err := roundTrip(old)
+		if err != nil {
+			return pruned, nil, append(errs, field.InternalError(nil, errors.Wrap(err, "cannot copy old resource")))
+		}
+		pruning.Prune(o.Object, v.structural, true)
+		applyDefaults(o.Object, v.structural)
+		oldObj = o.Object
+	}
+
+	warns, ce := v.cel.Validate(nil, v.structural, obj.Object, oldObj)
+	return pruned, warns, append(errs, ce...)
+}
+
+// roundTrip returns a copy of the supplied resource, encoded and decoded as
+// JSON the way the API server would receive it.
+func roundTrip(r *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	b, err := r.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{}
+	return u, u.UnmarshalJSON(b)
+}
+
+// applyDefaults applies the defaults of the supplied structural schema to the
+// supplied object, the way the API server does. It's equivalent to the
+// apiextensions-apiserver defaulting package, which we can't import because it
+// depends on an incompatible version of cel-go.
+func applyDefaults(x interface{}, s *structuralschema.Structural) { //nolint:gocyclo // Kept close to the upstream implementation.
+	if s == nil {
+		return
+	}
+
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, prop := range s.Properties {
+			if prop.Default.Object == nil {
+				continue
+			}
+			if _, found := x[k]; !found || isNonNullableNull(x[k], &prop) {
+				x[k] = runtime.DeepCopyJSONValue(prop.Default.Object)
+			}
+		}
+		for k := range x {
+			if prop, found := s.Properties[k]; found {
+				applyDefaults(x[k], &prop)
+			} else if s.AdditionalProperties != nil {
+				if isNonNullableNull(x[k], s.AdditionalProperties.Structural) && s.AdditionalProperties.Structural.Default.Object != nil {
+					x[k] = runtime.DeepCopyJSONValue(s.AdditionalProperties.Structural.Default.Object)
+				}
+				applyDefaults(x[k], s.AdditionalProperties.Structural)
+			}
+		}
+	case []interface{}:
+		for i := range x {
+			if isNonNullableNull(x[i], s.Items) && s.Items.Default.Object != nil {
+				x[i] = runtime.DeepCopyJSONValue(s.Items.Default.Object)
+			}
+			applyDefaults(x[i], s.Items)
+		}
+	}
+}
+
+func isNonNullableNull(x interface{}, s *structuralschema.Structural) bool {
+	return x == nil && s != nil && !s.Generic.Nullable
+}
+
+func newValidators(crds []*extv1.CustomResourceDefinition) (map[schema.GroupVersionKind]*validator, error) {
+	validators := map[schema.GroupVersionKind]*validator{}
+
+	cv, err := newCELValidator()
+	if err != nil {
+		return nil, err
+	}
 
 	for i := range crds {
 		internal := &ext.CustomResourceDefinition{}
@@ -41,78 +165,211 @@ func newValidators(crds []*extv1.CustomResourceDefinition) (map[schema.GroupVers
 
 		// Top-level and per-version schemas are mutually exclusive. Therefore, we will use both if they are present.
 		for _, ver := range internal.Spec.Versions {
-			var sv validation.SchemaValidator
-			var err error
-
 			gvk := schema.GroupVersionKind{
 				Group:   internal.Spec.Group,
 				Version: ver.Name,
 				Kind:    internal.Spec.Names.Kind,
 			}
 
+			v := &validator{cel: cv}
+			var props *ext.JSONSchemaProps
+
 			// Version specific validation rules
 			if ver.Schema != nil && ver.Schema.OpenAPIV3Schema != nil {
-				sv, _, err = validation.NewSchemaValidator(ver.Schema.OpenAPIV3Schema)
+				sv, _, err := validation.NewSchemaValidator(ver.Schema.OpenAPIV3Schema)
 				if err != nil {
 					return nil, err
 				}
 
-				validators[gvk] = append(validators[gvk], sv)
+				v.schemas = append(v.schemas, sv)
+				props = ver.Schema.OpenAPIV3Schema
 			}
 
 			// Top level validation rules
 			if internal.Spec.Validation != nil {
-				sv, _, err = validation.NewSchemaValidator(internal.Spec.Validation.OpenAPIV3Schema)
+				sv, _, err := validation.NewSchemaValidator(internal.Spec.Validation.OpenAPIV3Schema)
 				if err != nil {
 					return nil, err
 				}
 
-				validators[gvk] = append(validators[gvk], sv)
+				v.schemas = append(v.schemas, sv)
+				props = internal.Spec.Validation.OpenAPIV3Schema
 			}
+
+			// The API server refuses CRDs without a structural schema, but we
+			// might be given one anyway. We can still validate resources
+			// against its OpenAPI schema, so we don't return an error.
+			if props != nil {
+				if ss, err := structuralschema.NewStructural(props); err == nil {
+					v.structural = ss
+				}
+			}
+
+			validators[gvk] = v
 		}
 	}
 
 	return validators, nil
 }
 
-// SchemaValidation validates the resources against the given CRDs
-func SchemaValidation(resources []*unstructured.Unstructured, crds []*extv1.CustomResourceDefinition, skipSuccessLogs bool) error {
-	schemaValidators, err := newValidators(crds)
+// A ValidationOption configures SchemaValidation.
+type ValidationOption func(*validationOptions)
+
+type validationOptions struct {
+	w       io.Writer
+	printer Printer
+	sources Sources
+}
+
+// WithWriter configures where SchemaValidation prints its results. Results
+// are printed to stdout by default.
+func WithWriter(w io.Writer) ValidationOption {
+	return func(o *validationOptions) {
+		o.w = w
+	}
+}
+
+// WithPrinter configures how SchemaValidation prints its results. Results are
+// printed as text by default, omitting successful results if SchemaValidation
+// is asked to skip them.
+func WithPrinter(p Printer) ValidationOption {
+	return func(o *validationOptions) {
+		o.printer = p
+	}
+}
+
+// WithSources configures where the validated resources were loaded from, so
+// that SchemaValidation can report the file, line, and column of each finding.
+func WithSources(s Sources) ValidationOption {
+	return func(o *validationOptions) {
+		o.sources = s
+	}
+}
+
+// SchemaValidation validates the resources against the given CRDs and prints
+// the results. The old resources, if any, are matched to resources by kind,
+// namespace, and name and are used to evaluate CEL transition rules.
+func SchemaValidation(resources, old []*unstructured.Unstructured, crds []*extv1.CustomResourceDefinition, skipSuccessLogs bool, opts ...ValidationOption) error {
+	o := &validationOptions{
+		w:       os.Stdout,
+		printer: &TextPrinter{skipSuccessResults: skipSuccessLogs},
+	}
+	for _, fn := range opts {
+		fn(o)
+	}
+
+	results, err := Validate(resources, old, crds, o.sources)
 	if err != nil {
-		return errors.Wrap(err, "cannot create schema validators")
+		return err
 	}
 
-	failure, warning := 0, 0
+	if err := o.printer.Print(o.w, results); err != nil {
+		return errors.Wrap(err, "cannot print validation results")
+	}
 
-	for i, r := range resources {
-		resourceValidators, ok := schemaValidators[r.GetObjectKind().GroupVersionKind()]
-		if !ok {
-			warning++
-			fmt.Println("[!] could not find CRD/XRD for: " + r.GroupVersionKind().String())
-			continue
-		}
+	if Summarize(results).Errors > 0 {
+		return errors.New("could not validate all resources")
+	}
+
+	return nil
+}
+
+// Validate the resources against the given CRDs and return the results. The
+// old resources, if any, are matched to resources by kind, namespace, and name
+// and are used to evaluate CEL transition rules. The sources, if any, are used
+// to report where each finding was loaded from.
+func Validate(resources, old []*unstructured.Unstructured, crds []*extv1.CustomResourceDefinition, sources Sources) ([]Result, error) { //nolint:gocyclo // Only slightly over, and easier to follow as a single loop.
+	validators, err := newValidators(crds)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create schema validators")
+	}
 
-		rf := 0
-		for _, v := range resourceValidators {
-			re := v.Validate(&resources[i])
-			for _, e := range re.Errors {
-				rf++
-				fmt.Printf("[x] validation error %s, %s : %s\n", r.GroupVersionKind().String(), r.GetAnnotations()[composite.AnnotationKeyCompositionResourceName], e.Error())
+	compositionValidator, err := newCompositionValidator(crds)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create composition validator")
+	}
+
+	olds := make(map[objectKey]*unstructured.Unstructured, len(old))
+	for _, o := range old {
+		olds[keyOf(o)] = o
+	}
+
+	results := make([]Result, 0, len(resources))
+	for _, r := range resources {
+		res := newResult(r, sources[r])
+
+		switch v, ok := validators[r.GroupVersionKind()]; {
+		case isComposition(r):
+			res.label = r.GetName()
+			warns, errs := compositionValidator.Validate(context.Background(), r)
+			for _, w := range warns {
+				res.add(RuleComposition, SeverityWarning, "", w)
+			}
+			for _, e := range errs {
+				fe := &field.Error{}
+				if errors.As(e, &fe) {
+					res.add(RuleComposition, SeverityError, fe.Field, e.Error())
+					continue
+				}
+				res.add(RuleComposition, SeverityError, "", e.Error())
+			}
+		case !ok:
+			res.add(RuleMissingSchema, SeverityWarning, "", "could not find CRD/XRD for: "+r.GroupVersionKind().String())
+		default:
+			pruned, warns, errs := v.validate(r, olds[keyOf(r)])
+			for _, p := range pruned {
+				res.add(RuleUnknownField, SeverityWarning, p, fmt.Sprintf("unknown field %q would be pruned", p))
+			}
+			for _, w := range warns {
+				res.add(RuleSchema, SeverityWarning, "", w)
+			}
+			for _, e := range errs {
+				res.add(RuleSchema, SeverityError, e.Field, e.Error())
 			}
 		}
 
-		if rf == 0 && !skipSuccessLogs {
-			fmt.Printf("[✓] %s, %s validated successfully\n", r.GroupVersionKind().String(), r.GetAnnotations()[composite.AnnotationKeyCompositionResourceName])
-		} else {
-			failure++
-		}
+		results = append(results, res)
 	}
 
-	fmt.Printf("%d error, %d warning, %d success cases\n", failure, warning, len(resources)-failure-warning)
+	return results, nil
+}
 
-	if failure > 0 {
-		return errors.New("could not validate all resources")
+func newResult(r *unstructured.Unstructured, src *Source) Result {
+	res := Result{
+		APIVersion: r.GetAPIVersion(),
+		Kind:       r.GetKind(),
+		Namespace:  r.GetNamespace(),
+		Name:       r.GetName(),
+		Status:     StatusSuccess,
+		label:      r.GetAnnotations()[composite.AnnotationKeyCompositionResourceName],
+		src:        src,
+	}
+	if src != nil {
+		res.File, res.Document = src.File, src.Document
 	}
+	return res
+}
 
-	return nil
+// add a finding to the result, updating its status.
+func (r *Result) add(rule Rule, sev Severity, path, msg string) {
+	f := Finding{Rule: rule, Severity: sev, Field: path, Message: msg, File: r.File, Document: r.Document}
+	f.Line, f.Column = r.src.Position(path)
+	r.Findings = append(r.Findings, f)
+
+	switch {
+	case sev == SeverityError:
+		r.Status = StatusFailure
+	case r.Status == StatusSuccess:
+		r.Status = StatusWarning
+	}
+}
+
+type objectKey struct {
+	gk        schema.GroupKind
+	namespace string
+	name      string
+}
+
+func keyOf(u *unstructured.Unstructured) objectKey {
+	return objectKey{gk: u.GroupVersionKind().GroupKind(), namespace: u.GetNamespace(), name: u.GetName()}
 }
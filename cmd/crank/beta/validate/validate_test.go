@@ -76,6 +76,74 @@ var (
 	}
 )
 
+var testCELCRD = &extv1.CustomResourceDefinition{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "celtests.test.org",
+	},
+	Spec: extv1.CustomResourceDefinitionSpec{
+		Group: "test.org",
+		Names: extv1.CustomResourceDefinitionNames{
+			Kind:     "CELTest",
+			ListKind: "CELTestList",
+			Plural:   "celtests",
+			Singular: "celtest",
+		},
+		Scope: "Cluster",
+		Versions: []extv1.CustomResourceDefinitionVersion{
+			{
+				Name:    "v1alpha1",
+				Served:  true,
+				Storage: true,
+				Schema: &extv1.CustomResourceValidation{
+					OpenAPIV3Schema: &extv1.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]extv1.JSONSchemaProps{
+							"spec": {
+								Type: "object",
+								Properties: map[string]extv1.JSONSchemaProps{
+									"replicas": {
+										Type: "integer",
+										XValidations: extv1.ValidationRules{
+											{
+												Rule:    "self >= oldSelf",
+												Message: "replicas cannot be decreased",
+											},
+										},
+									},
+									"mode": {
+										Type:    "string",
+										Default: &extv1.JSON{Raw: []byte(`"Auto"`)},
+									},
+								},
+								XValidations: extv1.ValidationRules{
+									{
+										// Fails to evaluate if mode isn't defaulted.
+										Rule:    "self.mode == 'Auto' || self.replicas == 1",
+										Message: "only one replica is supported when mode isn't Auto",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func testCELResource(spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "test.org/v1alpha1",
+			"kind":       "CELTest",
+			"metadata": map[string]interface{}{
+				"name": "test",
+			},
+			"spec": spec,
+		},
+	}
+}
+
 func TestConvertToCRDs(t *testing.T) {
 	type args struct {
 		schemas []*unstructured.Unstructured
@@ -473,6 +541,26 @@ func TestConvertToCRDs(t *testing.T) {
 																"lastPublishedTime": {Type: "string", Format: "date-time"},
 															},
 														},
+														"claimConditionTypes": {
+															Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+															Type:        "array",
+															XListType:   ptr.To("set"),
+															Items: &extv1.JSONSchemaPropsOrArray{
+																Schema: &extv1.JSONSchemaProps{
+																	Type: "string",
+																},
+															},
+														},
+														"compositionConditionTypes": {
+															Description: "Types of the composite resource's conditions that were set by its Composition.",
+															Type:        "array",
+															XListType:   ptr.To("set"),
+															Items: &extv1.JSONSchemaPropsOrArray{
+																Schema: &extv1.JSONSchemaProps{
+																	Type: "string",
+																},
+															},
+														},
 													},
 												},
 											},
@@ -816,6 +904,26 @@ func TestConvertToCRDs(t *testing.T) {
 																"lastPublishedTime": {Type: "string", Format: "date-time"},
 															},
 														},
+														"claimConditionTypes": {
+															Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+															Type:        "array",
+															XListType:   ptr.To("set"),
+															Items: &extv1.JSONSchemaPropsOrArray{
+																Schema: &extv1.JSONSchemaProps{
+																	Type: "string",
+																},
+															},
+														},
+														"compositionConditionTypes": {
+															Description: "Types of the composite resource's conditions that were set by its Composition.",
+															Type:        "array",
+															XListType:   ptr.To("set"),
+															Items: &extv1.JSONSchemaPropsOrArray{
+																Schema: &extv1.JSONSchemaProps{
+																	Type: "string",
+																},
+															},
+														},
 													},
 												},
 											},
@@ -1060,6 +1168,26 @@ func TestConvertToCRDs(t *testing.T) {
 																"lastPublishedTime": {Type: "string", Format: "date-time"},
 															},
 														},
+														"claimConditionTypes": {
+															Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+															Type:        "array",
+															XListType:   ptr.To("set"),
+															Items: &extv1.JSONSchemaPropsOrArray{
+																Schema: &extv1.JSONSchemaProps{
+																	Type: "string",
+																},
+															},
+														},
+														"compositionConditionTypes": {
+															Description: "Types of the composite resource's conditions that were set by its Composition.",
+															Type:        "array",
+															XListType:   ptr.To("set"),
+															Items: &extv1.JSONSchemaPropsOrArray{
+																Schema: &extv1.JSONSchemaProps{
+																	Type: "string",
+																},
+															},
+														},
 													},
 												},
 											},
@@ -1111,6 +1239,7 @@ func TestConvertToCRDs(t *testing.T) {
 func TestValidateResources(t *testing.T) {
 	type args struct {
 		resources []*unstructured.Unstructured
+		old       []*unstructured.Unstructured
 		crds      []*extv1.CustomResourceDefinition
 	}
 	type want struct {
@@ -1188,10 +1317,145 @@ func TestValidateResources(t *testing.T) {
 				crds: []*extv1.CustomResourceDefinition{},
 			},
 		},
+		"UnknownField": {
+			reason: "Should not return an error if a resource has unknown fields that would be pruned",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(1), "unknown": "field"}),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCELCRD,
+				},
+			},
+		},
+		"ValidCELRules": {
+			reason: "Should not return an error if a resource satisfies its CEL rules once defaults are applied",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(3)}),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCELCRD,
+				},
+			},
+		},
+		"InvalidCELRule": {
+			reason: "Should return an error if a resource doesn't satisfy its CEL rules",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(3), "mode": "Manual"}),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCELCRD,
+				},
+			},
+			want: want{
+				err: errors.New("could not validate all resources"),
+			},
+		},
+		"TransitionRuleWithoutOldResource": {
+			reason: "Should not evaluate CEL transition rules if no old resource is supplied",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(1)}),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCELCRD,
+				},
+			},
+		},
+		"ValidTransitionRule": {
+			reason: "Should not return an error if a resource satisfies its CEL transition rules",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(3)}),
+				},
+				old: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(2)}),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCELCRD,
+				},
+			},
+		},
+		"InvalidTransitionRule": {
+			reason: "Should return an error if a resource doesn't satisfy its CEL transition rules",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(1)}),
+				},
+				old: []*unstructured.Unstructured{
+					testCELResource(map[string]interface{}{"replicas": int64(2)}),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCELCRD,
+				},
+			},
+			want: want{
+				err: errors.New("could not validate all resources"),
+			},
+		},
+		"ValidComposition": {
+			reason: "Should not return an error if the Composition is valid",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testComposition("spec.replicas", ""),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCRD,
+				},
+			},
+		},
+		"InvalidCompositionPatch": {
+			reason: "Should return an error if a Composition patches a field that does not exist in the composed resource's schema",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testComposition("spec.doesNotExist", ""),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCRD,
+				},
+			},
+			want: want{
+				err: errors.New("could not validate all resources"),
+			},
+		},
+		"InvalidCompositionPatchWarnMode": {
+			reason: "Should not return an error if a Composition that explicitly asks for warnings patches a field that does not exist",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testComposition("spec.doesNotExist", "warn"),
+				},
+				crds: []*extv1.CustomResourceDefinition{
+					testCRD,
+				},
+			},
+		},
+		"CompositionMissingCRD": {
+			reason: "Should not return an error if the CRD/XRD a Composition references is missing",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testComposition("spec.replicas", ""),
+				},
+				crds: []*extv1.CustomResourceDefinition{},
+			},
+		},
+		"CompositionMissingCRDStrictMode": {
+			reason: "Should return an error if the CRD/XRD a strict Composition references is missing",
+			args: args{
+				resources: []*unstructured.Unstructured{
+					testComposition("spec.replicas", "strict"),
+				},
+				crds: []*extv1.CustomResourceDefinition{},
+			},
+			want: want{
+				err: errors.New("could not validate all resources"),
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := SchemaValidation(tc.args.resources, tc.args.crds, false)
+			got := SchemaValidation(tc.args.resources, tc.args.old, tc.args.crds, false)
 
 			if diff := cmp.Diff(tc.want.err, got, test.EquateErrors()); diff != "" {
 				t.Errorf("%s\nvalidateResources(...): -want error, +got error:\n%s", tc.reason, diff)
@@ -1199,3 +1463,44 @@ func TestValidateResources(t *testing.T) {
 		})
 	}
 }
+
+func testComposition(toFieldPath, mode string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apiextensions.crossplane.io/v1",
+			"kind":       "Composition",
+			"metadata": map[string]interface{}{
+				"name": "test",
+			},
+			"spec": map[string]interface{}{
+				"compositeTypeRef": map[string]interface{}{
+					"apiVersion": "test.org/v1alpha1",
+					"kind":       "Test",
+				},
+				"resources": []interface{}{
+					map[string]interface{}{
+						"name": "test",
+						"base": map[string]interface{}{
+							"apiVersion": "test.org/v1alpha1",
+							"kind":       "Test",
+							"spec": map[string]interface{}{
+								"replicas": int64(1),
+							},
+						},
+						"patches": []interface{}{
+							map[string]interface{}{
+								"type":          "FromCompositeFieldPath",
+								"fromFieldPath": "spec.replicas",
+								"toFieldPath":   toFieldPath,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if mode != "" {
+		u.SetAnnotations(map[string]string{"crossplane.io/composition-schema-aware-validation-mode": mode})
+	}
+	return u
+}
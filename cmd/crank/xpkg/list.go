@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/printers"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+// lockName is the name of the Lock Crossplane uses to track installed
+// packages and their dependencies.
+const lockName = "lock"
+
+// listCmd lists installed packages.
+type listCmd struct{}
+
+func (c *listCmd) Help() string {
+	return `
+This command lists the packages installed in a Crossplane control plane, with
+their current revision, health, and dependencies. It uses ~/.kube/config to
+connect to the control plane. You can override this using the KUBECONFIG
+environment variable.
+
+Examples:
+
+  # List all installed Providers, Configurations, and Functions
+  crossplane xpkg list
+`
+}
+
+// Run the package list cmd.
+func (c *listCmd) Run(k *kong.Context, logger logging.Logger) error {
+	kube, err := newPackageClient(logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pkgs, _, err := getInstalledPackages(ctx, kube)
+	if err != nil {
+		return err
+	}
+	logger.Debug("Found installed packages", "count", len(pkgs))
+
+	return printPackages(k.Stdout, pkgs)
+}
+
+// An installedPackage is a package installed in a control plane.
+type installedPackage struct {
+	// Kind of package; provider, configuration, or function.
+	Kind string
+
+	// Package installed in the control plane.
+	Package v1.Package
+
+	// Lock entry of the package's current revision. Nil if the package
+	// doesn't have one yet.
+	Lock *v1beta1.LockPackage
+}
+
+// newPackageClient returns a client that can read and write packages.
+func newPackageClient(logger logging.Logger) (client.Client, error) {
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, errKubeConfig)
+	}
+	logger.Debug("Found kubeconfig")
+
+	s := runtime.NewScheme()
+	_ = extv1.AddToScheme(s)
+	_ = apiextensionsv1.AddToScheme(s)
+	_ = v1.AddToScheme(s)
+	_ = v1beta1.AddToScheme(s)
+
+	kube, err := client.New(cfg, client.Options{Scheme: s})
+	if err != nil {
+		return nil, errors.Wrap(err, errKubeClient)
+	}
+	logger.Debug("Created kubernetes client")
+	return kube, nil
+}
+
+// getInstalledPackages returns all installed Providers, Configurations, and
+// Functions, and the package Lock. Packages are sorted by kind, then name.
+func getInstalledPackages(ctx context.Context, kube client.Reader) ([]installedPackage, *v1beta1.Lock, error) {
+	lock := &v1beta1.Lock{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: lockName}, lock); resource.IgnoreNotFound(err) != nil {
+		return nil, nil, errors.Wrap(err, "cannot get package lock")
+	}
+
+	byRevision := make(map[string]*v1beta1.LockPackage, len(lock.Packages))
+	for i := range lock.Packages {
+		byRevision[lock.Packages[i].Name] = &lock.Packages[i]
+	}
+
+	pkgs := make([]installedPackage, 0)
+
+	providers := &v1.ProviderList{}
+	if err := kube.List(ctx, providers); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot list providers")
+	}
+	for i := range providers.Items {
+		p := &providers.Items[i]
+		pkgs = append(pkgs, installedPackage{Kind: "provider", Package: p, Lock: byRevision[p.GetCurrentRevision()]})
+	}
+
+	configurations := &v1.ConfigurationList{}
+	if err := kube.List(ctx, configurations); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot list configurations")
+	}
+	for i := range configurations.Items {
+		p := &configurations.Items[i]
+		pkgs = append(pkgs, installedPackage{Kind: "configuration", Package: p, Lock: byRevision[p.GetCurrentRevision()]})
+	}
+
+	functions := &v1beta1.FunctionList{}
+	if err := kube.List(ctx, functions); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot list functions")
+	}
+	for i := range functions.Items {
+		p := &functions.Items[i]
+		pkgs = append(pkgs, installedPackage{Kind: "function", Package: p, Lock: byRevision[p.GetCurrentRevision()]})
+	}
+
+	sort.SliceStable(pkgs, func(i, j int) bool {
+		if pkgs[i].Kind != pkgs[j].Kind {
+			return pkgs[i].Kind < pkgs[j].Kind
+		}
+		return pkgs[i].Package.GetName() < pkgs[j].Package.GetName()
+	})
+
+	return pkgs, lock, nil
+}
+
+// printPackages prints the supplied packages as a table.
+func printPackages(w io.Writer, pkgs []installedPackage) error {
+	tw := printers.GetNewTabWriter(w)
+
+	if _, err := fmt.Fprintln(tw, strings.Join([]string{"KIND", "NAME", "PACKAGE", "REVISION", "INSTALLED", "HEALTHY", "DEPENDENCIES"}, "\t")); err != nil {
+		return errors.Wrap(err, "cannot write header")
+	}
+
+	for _, p := range pkgs {
+		deps := make([]string, 0)
+		if p.Lock != nil {
+			for _, d := range p.Lock.Dependencies {
+				dep := d.Package
+				if d.Constraints != "" {
+					dep = fmt.Sprintf("%s (%s)", d.Package, d.Constraints)
+				}
+				deps = append(deps, dep)
+			}
+		}
+		row := []string{
+			p.Kind,
+			p.Package.GetName(),
+			p.Package.GetSource(),
+			p.Package.GetCurrentRevision(),
+			string(p.Package.GetCondition(v1.TypeInstalled).Status),
+			string(p.Package.GetCondition(v1.TypeHealthy).Status),
+			strings.Join(deps, ", "),
+		}
+		if _, err := fmt.Fprintln(tw, strings.Join(row, "\t")); err != nil {
+			return errors.Wrap(err, "cannot write row")
+		}
+	}
+
+	return errors.Wrap(tw.Flush(), "cannot flush output")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+func TestPrintPackages(t *testing.T) {
+	cfg := &v1.Configuration{
+		ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+		Spec:       v1.ConfigurationSpec{PackageSpec: v1.PackageSpec{Package: "xpkg.upbound.io/acme/platform:v1.0.0"}},
+	}
+	cfg.SetCurrentRevision("platform-abc")
+	cfg.SetConditions(xpv1.Condition{Type: v1.TypeInstalled, Status: corev1.ConditionTrue}, xpv1.Condition{Type: v1.TypeHealthy, Status: corev1.ConditionFalse})
+
+	fn := &v1beta1.Function{
+		ObjectMeta: metav1.ObjectMeta{Name: "function-go"},
+		Spec:       v1beta1.FunctionSpec{PackageSpec: v1.PackageSpec{Package: "xpkg.upbound.io/acme/function-go:v0.1.0"}},
+	}
+
+	pkgs := []installedPackage{
+		{
+			Kind:    "configuration",
+			Package: cfg,
+			Lock: &v1beta1.LockPackage{
+				Name:         "platform-abc",
+				Dependencies: []v1beta1.Dependency{{Package: "xpkg.upbound.io/acme/function-go", Constraints: ">=v0.1.0"}},
+			},
+		},
+		{
+			Kind:    "function",
+			Package: fn,
+		},
+	}
+
+	want := `KIND            NAME          PACKAGE                                   REVISION       INSTALLED   HEALTHY   DEPENDENCIES
+configuration   platform      xpkg.upbound.io/acme/platform:v1.0.0      platform-abc   True        False     xpkg.upbound.io/acme/function-go (>=v0.1.0)
+function        function-go   xpkg.upbound.io/acme/function-go:v0.1.0                  Unknown     Unknown   
+`
+
+	b := &bytes.Buffer{}
+	if err := printPackages(b, pkgs); err != nil {
+		t.Fatalf("printPackages(...): %v", err)
+	}
+	if diff := cmp.Diff(want, b.String()); diff != "" {
+		t.Errorf("printPackages(...): -want, +got:\n%s", diff)
+	}
+}
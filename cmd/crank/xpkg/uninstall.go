@@ -0,0 +1,281 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/alecthomas/kong"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apiextensionsv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+// uninstallCmd uninstalls a package.
+type uninstallCmd struct {
+	// Arguments.
+	Kind string `arg:"" help:"The kind of package to uninstall. One of \"provider\", \"configuration\", or \"function\"." enum:"provider,configuration,function"`
+	Name string `arg:"" help:"The name of the package to uninstall in the Crossplane API."`
+
+	// Flags. Keep sorted alphabetically.
+	Force            bool `help:"Uninstall the package even if other packages depend on it or its custom resources are in use."`
+	KeepDependencies bool `help:"Don't uninstall the package's dependencies, even if no other package depends on them."`
+}
+
+func (c *uninstallCmd) Help() string {
+	return `
+This command uninstalls a package from a Crossplane control plane. It uses
+~/.kube/config to connect to the control plane. You can override this using the
+KUBECONFIG environment variable.
+
+The command refuses to uninstall a package that other packages depend on, or
+whose custom resources are in use, unless you pass --force. It also uninstalls
+the package's dependencies if no other package depends on them and their custom
+resources aren't in use. Pass --keep-dependencies to keep them.
+
+Examples:
+
+  # Uninstall the Provider named provider-aws and its unneeded dependencies
+  crossplane xpkg uninstall provider provider-aws
+
+  # Uninstall the Configuration named platform-ref-aws, even if it's in use
+  crossplane xpkg uninstall configuration platform-ref-aws --force
+`
+}
+
+// Run the package uninstall cmd.
+func (c *uninstallCmd) Run(k *kong.Context, logger logging.Logger) error { //nolint:gocyclo // Mostly a sequence of checks.
+	logger = logger.WithValues("kind", c.Kind, "name", c.Name)
+
+	kube, err := newPackageClient(logger)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	pkgs, lock, err := getInstalledPackages(ctx, kube)
+	if err != nil {
+		return err
+	}
+
+	var target *installedPackage
+	for i := range pkgs {
+		if pkgs[i].Kind == c.Kind && pkgs[i].Package.GetName() == c.Name {
+			target = &pkgs[i]
+			break
+		}
+	}
+	if target == nil {
+		return errors.Errorf("cannot find %s/%s", c.Kind, c.Name)
+	}
+	logger.Debug("Found package to uninstall", "revision", target.Package.GetCurrentRevision())
+
+	if target.Lock != nil {
+		if d := dependents(lock, target.Lock.Source); len(d) > 0 && !c.Force {
+			return errors.Errorf("cannot uninstall %s/%s: packages %s depend on it (use --force to uninstall anyway)", c.Kind, c.Name, strings.Join(d, ", "))
+		}
+	}
+
+	inUse, err := resourcesInUse(ctx, kube, *target)
+	if err != nil {
+		return err
+	}
+	if len(inUse) > 0 && !c.Force {
+		return errors.Errorf("cannot uninstall %s/%s: instances of %s exist (use --force to uninstall anyway)", c.Kind, c.Name, strings.Join(inUse, ", "))
+	}
+
+	remove := []installedPackage{*target}
+	if target.Lock != nil && !c.KeepDependencies {
+		byRevision := make(map[string]installedPackage, len(pkgs))
+		for _, p := range pkgs {
+			byRevision[p.Package.GetCurrentRevision()] = p
+		}
+
+		var checkErr error
+		removable := func(lp v1beta1.LockPackage) bool {
+			p, ok := byRevision[lp.Name]
+			if !ok {
+				return false
+			}
+			inUse, err := resourcesInUse(ctx, kube, p)
+			if err != nil {
+				checkErr = err
+				return false
+			}
+			if len(inUse) > 0 {
+				logger.Debug("Keeping dependency with custom resources in use", "dependency", p.Package.GetName(), "inUse", inUse)
+				return false
+			}
+			return true
+		}
+
+		for _, lp := range unneededDependencies(lock, target.Lock.Source, removable) {
+			remove = append(remove, byRevision[lp.Name])
+		}
+		if checkErr != nil {
+			return checkErr
+		}
+	}
+
+	for _, p := range remove {
+		if err := kube.Delete(ctx, p.Package); resource.IgnoreNotFound(err) != nil {
+			return errors.Wrapf(err, "cannot uninstall %s/%s", p.Kind, p.Package.GetName())
+		}
+		if _, err := fmt.Fprintf(k.Stdout, "%s/%s uninstalled\n", p.Kind, p.Package.GetName()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dependents returns the names of the Lock packages that depend on the
+// supplied package source.
+func dependents(lock *v1beta1.Lock, source string) []string {
+	out := make([]string, 0)
+	for _, lp := range lock.Packages {
+		for _, d := range lp.Dependencies {
+			if d.Package == source {
+				out = append(out, lp.Source)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// unneededDependencies returns the Lock packages that won't be needed once the
+// package with the supplied source is uninstalled. A package isn't needed if
+// only packages that are being uninstalled depend on it. Packages that the
+// supplied function doesn't consider removable are kept, along with their
+// dependencies.
+func unneededDependencies(lock *v1beta1.Lock, source string, removable func(lp v1beta1.LockPackage) bool) []v1beta1.LockPackage {
+	removed := map[string]bool{source: true}
+	out := make([]v1beta1.LockPackage, 0)
+
+	for changed := true; changed; {
+		changed = false
+		for _, lp := range lock.Packages {
+			if removed[lp.Source] {
+				continue
+			}
+
+			// Only consider dependencies of packages we're uninstalling.
+			needed, dependency := false, false
+			for _, d := range dependents(lock, lp.Source) {
+				if removed[d] {
+					dependency = true
+					continue
+				}
+				needed = true
+			}
+			if !dependency || needed || !removable(lp) {
+				continue
+			}
+
+			removed[lp.Source] = true
+			out = append(out, lp)
+			changed = true
+		}
+	}
+
+	return out
+}
+
+// resourcesInUse returns the custom resources defined by the supplied
+// package's current revision that have instances.
+func resourcesInUse(ctx context.Context, kube client.Reader, p installedPackage) ([]string, error) { //nolint:gocyclo // Only slightly over.
+	name := p.Package.GetCurrentRevision()
+	if name == "" {
+		return nil, nil
+	}
+
+	var rev v1.PackageRevision
+	switch p.Kind {
+	case "provider":
+		rev = &v1.ProviderRevision{}
+	case "configuration":
+		rev = &v1.ConfigurationRevision{}
+	case "function":
+		rev = &v1beta1.FunctionRevision{}
+	default:
+		return nil, errors.Errorf("unsupported package kind %q", p.Kind)
+	}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name}, rev); err != nil {
+		return nil, errors.Wrapf(resource.IgnoreNotFound(err), "cannot get package revision %q", name)
+	}
+
+	gvks := make([]schema.GroupVersionKind, 0)
+	for _, ref := range rev.GetObjects() {
+		switch ref.Kind {
+		case "CustomResourceDefinition":
+			crd := &extv1.CustomResourceDefinition{}
+			if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, crd); err != nil {
+				if resource.IgnoreNotFound(err) == nil {
+					continue
+				}
+				return nil, errors.Wrapf(err, "cannot get CustomResourceDefinition %q", ref.Name)
+			}
+			for _, v := range crd.Spec.Versions {
+				if v.Storage {
+					gvks = append(gvks, schema.GroupVersionKind{Group: crd.Spec.Group, Version: v.Name, Kind: crd.Spec.Names.Kind})
+				}
+			}
+		case apiextensionsv1.CompositeResourceDefinitionKind:
+			xrd := &apiextensionsv1.CompositeResourceDefinition{}
+			if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, xrd); err != nil {
+				if resource.IgnoreNotFound(err) == nil {
+					continue
+				}
+				return nil, errors.Wrapf(err, "cannot get CompositeResourceDefinition %q", ref.Name)
+			}
+			gvks = append(gvks, xrd.GetCompositeGroupVersionKind())
+			if xrd.OffersClaim() {
+				gvks = append(gvks, xrd.GetClaimGroupVersionKind())
+			}
+		}
+	}
+
+	inUse := make([]string, 0)
+	for _, gvk := range gvks {
+		l := &unstructured.UnstructuredList{}
+		l.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+		if err := kube.List(ctx, l, client.Limit(1)); err != nil {
+			return nil, errors.Wrapf(err, "cannot list %s", gvk.GroupKind())
+		}
+		if len(l.Items) > 0 {
+			inUse = append(inUse, gvk.GroupKind().String())
+		}
+	}
+
+	return inUse, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+)
+
+func TestUnneededDependencies(t *testing.T) {
+	// platform depends on provider-aws and function-go. app depends on
+	// function-go. provider-aws depends on provider-family.
+	lock := &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+		{Name: "platform-abc", Source: "platform", Dependencies: []v1beta1.Dependency{{Package: "provider-aws"}, {Package: "function-go"}}},
+		{Name: "app-abc", Source: "app", Dependencies: []v1beta1.Dependency{{Package: "function-go"}}},
+		{Name: "provider-aws-abc", Source: "provider-aws", Dependencies: []v1beta1.Dependency{{Package: "provider-family"}}},
+		{Name: "provider-family-abc", Source: "provider-family"},
+		{Name: "function-go-abc", Source: "function-go"},
+		{Name: "unrelated-abc", Source: "unrelated"},
+	}}
+
+	all := func(_ v1beta1.LockPackage) bool { return true }
+
+	type args struct {
+		source    string
+		removable func(lp v1beta1.LockPackage) bool
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []string
+	}{
+		"TransitiveDependencies": {
+			reason: "We should return dependencies, and their dependencies, that nothing else depends on.",
+			args: args{
+				source:    "platform",
+				removable: all,
+			},
+			want: []string{"provider-aws", "provider-family"},
+		},
+		"NoDependencies": {
+			reason: "We shouldn't return anything for a package without dependencies.",
+			args: args{
+				source:    "unrelated",
+				removable: all,
+			},
+			want: []string{},
+		},
+		"KeepUnremovable": {
+			reason: "We should keep dependencies that aren't removable, and their dependencies.",
+			args: args{
+				source:    "platform",
+				removable: func(lp v1beta1.LockPackage) bool { return lp.Source != "provider-aws" },
+			},
+			want: []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := make([]string, 0)
+			for _, lp := range unneededDependencies(lock, tc.args.source, tc.args.removable) {
+				got = append(got, lp.Source)
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("\n%s\nunneededDependencies(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDependents(t *testing.T) {
+	lock := &v1beta1.Lock{Packages: []v1beta1.LockPackage{
+		{Source: "platform", Dependencies: []v1beta1.Dependency{{Package: "function-go"}}},
+		{Source: "app", Dependencies: []v1beta1.Dependency{{Package: "function-go"}}},
+		{Source: "function-go"},
+	}}
+
+	cases := map[string]struct {
+		reason string
+		source string
+		want   []string
+	}{
+		"Dependents": {
+			reason: "We should return every package that depends on the supplied package.",
+			source: "function-go",
+			want:   []string{"platform", "app"},
+		},
+		"NoDependents": {
+			reason: "We should return nothing if no package depends on the supplied package.",
+			source: "platform",
+			want:   []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := dependents(lock, tc.source)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ndependents(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -22,12 +22,14 @@ package xpkg
 // Cmd contains commands for interacting with xpkgs.
 type Cmd struct {
 	// Keep subcommands sorted alphabetically.
-	Build   buildCmd   `cmd:"" help:"Build a new package."`
-	Install installCmd `cmd:"" help:"Install a package in a control plane."`
-	Login   loginCmd   `cmd:"" help:"Login to the default package registry."`
-	Logout  logoutCmd  `cmd:"" help:"Logout of the default package registry."`
-	Push    pushCmd    `cmd:"" help:"Push a package to a registry."`
-	Update  updateCmd  `cmd:"" help:"Update a package in a control plane."`
+	Build     buildCmd     `cmd:"" help:"Build a new package."`
+	Install   installCmd   `cmd:"" help:"Install a package in a control plane."`
+	List      listCmd      `cmd:"" help:"List the packages installed in a control plane."`
+	Login     loginCmd     `cmd:"" help:"Login to the default package registry."`
+	Logout    logoutCmd    `cmd:"" help:"Logout of the default package registry."`
+	Push      pushCmd      `cmd:"" help:"Push a package to a registry."`
+	Uninstall uninstallCmd `cmd:"" help:"Uninstall a package from a control plane."`
+	Update    updateCmd    `cmd:"" help:"Update a package in a control plane."`
 }
 
 // Help prints out the help for the xpkg command.
@@ -28,6 +28,7 @@ import (
 	"github.com/alecthomas/kong"
 	"github.com/spf13/afero"
 	corev1 "k8s.io/api/core/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -49,6 +50,7 @@ import (
 	apiextensionscontroller "github.com/crossplane/crossplane/internal/controller/apiextensions/controller"
 	"github.com/crossplane/crossplane/internal/controller/pkg"
 	pkgcontroller "github.com/crossplane/crossplane/internal/controller/pkg/controller"
+	"github.com/crossplane/crossplane/internal/conversion"
 	"github.com/crossplane/crossplane/internal/features"
 	"github.com/crossplane/crossplane/internal/initializer"
 	"github.com/crossplane/crossplane/internal/metrics"
@@ -91,23 +93,38 @@ type startCommand struct {
 	CABundlePath   string `help:"Additional CA bundle to use when fetching packages from registry." env:"CA_BUNDLE_PATH"`
 	UserAgent      string `help:"The User-Agent header that will be set on all package requests." default:"${default_user_agent}" env:"USER_AGENT"`
 
+	DependencyPullSecrets   []string `help:"Names of Secrets in the Crossplane namespace used to pull package dependencies that don't inherit pull secrets from the package that depends on them." env:"DEPENDENCY_PULL_SECRETS"`
+	DependencyPullPolicy    string   `help:"Pull policy of package dependencies that don't inherit a pull policy from the package that depends on them." enum:"Always,IfNotPresent,Never," default:"" env:"DEPENDENCY_PULL_POLICY"`
+	DependencyRuntimeConfig string   `help:"Name of the DeploymentRuntimeConfig used by Provider and Function dependencies that don't inherit one from a package of the same type that depends on them." env:"DEPENDENCY_RUNTIME_CONFIG"`
+
 	PackageRuntime string `helm:"The package runtime to use for packages with a runtime (e.g. Providers and Functions)" default:"Deployment" env:"PACKAGE_RUNTIME"`
 
 	SyncInterval     time.Duration `short:"s" help:"How often all resources will be double-checked for drift from the desired state." default:"1h"`
 	PollInterval     time.Duration `help:"How often individual resources will be checked for drift from the desired state." default:"1m"`
 	MaxReconcileRate int           `help:"The global maximum rate per second at which resources may checked for drift from the desired state." default:"10"`
 
-	WebhookEnabled bool `help:"Enable webhook configuration." default:"true" env:"WEBHOOK_ENABLED"`
+	WebhookEnabled          bool   `help:"Enable webhook configuration." default:"true" env:"WEBHOOK_ENABLED"`
+	WebhookServiceName      string `help:"The name of the Service object that the webhook service will be run. Required to convert composite resources and claims between versions." env:"WEBHOOK_SERVICE_NAME"`
+	WebhookServiceNamespace string `help:"The namespace of the Service object that the webhook service will be run." env:"WEBHOOK_SERVICE_NAMESPACE"`
+	WebhookServicePort      int32  `help:"The port of the Service that the webhook service will be run." env:"WEBHOOK_SERVICE_PORT"`
 
 	TLSServerSecretName string `help:"The name of the TLS Secret that will store Crossplane's server certificate." env:"TLS_SERVER_SECRET_NAME"`
 	TLSServerCertsDir   string `help:"The path of the folder which will store TLS server certificate of Crossplane." env:"TLS_SERVER_CERTS_DIR"`
 	TLSClientSecretName string `help:"The name of the TLS Secret that will be store Crossplane's client certificate." env:"TLS_CLIENT_SECRET_NAME"`
 	TLSClientCertsDir   string `help:"The path of the folder which will store TLS client certificate of Crossplane." env:"TLS_CLIENT_CERTS_DIR"`
 
-	EnableEnvironmentConfigs   bool `group:"Alpha Features:" help:"Enable support for EnvironmentConfigs."`
-	EnableExternalSecretStores bool `group:"Alpha Features:" help:"Enable support for External Secret Stores."`
-	EnableUsages               bool `group:"Alpha Features:" help:"Enable support for deletion ordering and resource protection with Usages."`
-	EnableRealtimeCompositions bool `group:"Alpha Features:" help:"Enable support for realtime compositions, i.e. watching composed resources and reconciling compositions immediately when any of the composed resources is updated."`
+	FunctionResponseCacheMaxEntries int           `help:"The maximum number of Composition Function responses to cache in memory. Zero means unbounded." default:"10000"`
+	FunctionResponseCacheMaxTTL     time.Duration `help:"The maximum amount of time a Composition Function response will be cached, regardless of the TTL the Function requests." default:"24h"`
+	FunctionResponseCacheDir        string        `help:"Directory used to store Composition Function responses that are evicted from the in-memory cache. Evicted responses are discarded if unset." env:"FUNCTION_RESPONSE_CACHE_DIR"`
+
+	EnableEnvironmentConfigs    bool `group:"Alpha Features:" help:"Enable support for EnvironmentConfigs."`
+	EnableExternalSecretStores  bool `group:"Alpha Features:" help:"Enable support for External Secret Stores."`
+	EnableUsages                bool `group:"Alpha Features:" help:"Enable support for deletion ordering and resource protection with Usages."`
+	EnableRealtimeCompositions  bool `group:"Alpha Features:" help:"Enable support for realtime compositions, i.e. watching composed resources and reconciling compositions immediately when any of the composed resources is updated."`
+	EnableFunctionResponseCache bool `group:"Alpha Features:" help:"Enable support for caching Composition Function responses. Only respected if --enable-composition-functions is set to true."`
+	EnableDependencyUpgrades    bool `group:"Alpha Features:" help:"Enable support for automatically upgrading package dependencies to satisfy the version constraints of the packages that depend on them."`
+	EnablePackageVerification   bool `group:"Alpha Features:" help:"Enable support for verifying the signatures and attestations of package images that reference a VerificationPolicy."`
+	EnableCompositionRollouts   bool `group:"Alpha Features:" help:"Enable support for progressively rolling out new Composition revisions to composite resources."`
 
 	EnableCompositionFunctions               bool `group:"Beta Features:" default:"true" help:"Enable support for Composition Functions."`
 	EnableCompositionFunctionsExtraResources bool `group:"Beta Features:" default:"true" help:"Enable support for Composition Functions Extra Resources. Only respected if --enable-composition-functions is set to true."`
@@ -193,7 +210,7 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		log.Info("CompositionRevisions feature is GA and cannot be disabled. The --enable-composition-revisions flag will be removed in a future release.")
 	}
 
-	var functionRunner *xfn.PackagedFunctionRunner
+	var functionRunner xfn.FunctionRunner
 	if c.EnableCompositionFunctions {
 		o.Features.Enable(features.EnableBetaCompositionFunctions)
 		log.Info("Beta feature enabled", "flag", features.EnableBetaCompositionFunctions)
@@ -216,16 +233,54 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		metrics.Registry.MustRegister(m)
 
 		// We want all XR controllers to share the same gRPC clients.
-		functionRunner = xfn.NewPackagedFunctionRunner(mgr.GetClient(),
+		fo := []xfn.PackagedFunctionRunnerOption{
 			xfn.WithLogger(log),
 			xfn.WithTLSConfig(clienttls),
 			xfn.WithInterceptorCreators(m),
-		)
+		}
+
+		// We only generate a client certificate for each Function when we
+		// run Functions ourselves. We call each Function using its client
+		// certificate, falling back to our own client certificate for
+		// Functions that don't have one yet.
+		if c.PackageRuntime == string(pkgcontroller.PackageRuntimeDeployment) {
+			fo = append(fo, xfn.WithFunctionTLSSecrets(c.Namespace))
+		}
+
+		pfr := xfn.NewPackagedFunctionRunner(mgr.GetClient(), fo...)
+		functionRunner = pfr
 
 		// Periodically remove clients for Functions that no longer exist.
 		ctx, cancel := context.WithCancel(context.Background())
 		defer cancel()
-		go functionRunner.GarbageCollectConnections(ctx, 10*time.Minute)
+		go pfr.GarbageCollectConnections(ctx, 10*time.Minute)
+
+		if c.EnableFunctionResponseCache {
+			o.Features.Enable(features.EnableAlphaFunctionResponseCache)
+			log.Info("Alpha feature enabled", "flag", features.EnableAlphaFunctionResponseCache)
+
+			cm := xfn.NewCacheMetrics()
+			metrics.Registry.MustRegister(cm)
+
+			copts := []xfn.CachingFunctionRunnerOption{
+				xfn.WithCacheLogger(log),
+				xfn.WithCacheRecorder(cm),
+				xfn.WithCacheMaxEntries(c.FunctionResponseCacheMaxEntries),
+				xfn.WithCacheMaxTTL(c.FunctionResponseCacheMaxTTL),
+			}
+			if c.FunctionResponseCacheDir != "" {
+				if err := os.MkdirAll(c.FunctionResponseCacheDir, 0o700); err != nil {
+					return errors.Wrap(err, "cannot create Composition Function response cache directory")
+				}
+				copts = append(copts, xfn.WithCacheFilesystem(afero.NewBasePathFs(afero.NewOsFs(), c.FunctionResponseCacheDir)))
+			}
+
+			cfr := xfn.NewCachingFunctionRunner(pfr, copts...)
+			functionRunner = cfr
+
+			// Periodically remove cached responses that have expired.
+			go cfr.GarbageCollectEntries(ctx, 1*time.Minute)
+		}
 	}
 	if c.EnableEnvironmentConfigs {
 		o.Features.Enable(features.EnableAlphaEnvironmentConfigs)
@@ -260,6 +315,18 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		o.Features.Enable(features.EnableRealtimeCompositions)
 		log.Info("Alpha feature enabled", "flag", features.EnableRealtimeCompositions)
 	}
+	if c.EnableDependencyUpgrades {
+		o.Features.Enable(features.EnableAlphaDependencyUpgrades)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaDependencyUpgrades)
+	}
+	if c.EnablePackageVerification {
+		o.Features.Enable(features.EnableAlphaPackageVerification)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaPackageVerification)
+	}
+	if c.EnableCompositionRollouts {
+		o.Features.Enable(features.EnableAlphaCompositionRollouts)
+		log.Info("Alpha feature enabled", "flag", features.EnableAlphaCompositionRollouts)
+	}
 	if c.EnableDeploymentRuntimeConfigs {
 		o.Features.Enable(features.EnableBetaDeploymentRuntimeConfigs)
 		log.Info("Beta feature enabled", "flag", features.EnableBetaDeploymentRuntimeConfigs)
@@ -272,6 +339,25 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		FunctionRunner: functionRunner,
 	}
 
+	if c.WebhookEnabled && c.WebhookServiceName != "" {
+		// The webhook server's certificate is signed by the root CA Crossplane
+		// generates when it starts. Like the init container does for webhook
+		// configurations, we use the server certificate itself as the CA
+		// bundle. The API server trusts a certificate that's in its CA bundle.
+		ca, err := os.ReadFile(filepath.Join(c.TLSServerCertsDir, "tls.crt"))
+		if err != nil {
+			return errors.Wrap(err, "cannot read webhook server certificate")
+		}
+		ao.ConversionWebhook = &extv1.WebhookClientConfig{
+			Service: &extv1.ServiceReference{
+				Name:      c.WebhookServiceName,
+				Namespace: c.WebhookServiceNamespace,
+				Port:      &c.WebhookServicePort,
+			},
+			CABundle: ca,
+		}
+	}
+
 	if err := apiextensions.Setup(mgr, ao); err != nil {
 		return errors.Wrap(err, "cannot setup API extension controllers")
 	}
@@ -288,13 +374,16 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 	}
 
 	po := pkgcontroller.Options{
-		Options:         o,
-		Cache:           xpkg.NewFsPackageCache(c.CacheDir, afero.NewOsFs()),
-		Namespace:       c.Namespace,
-		ServiceAccount:  c.ServiceAccount,
-		DefaultRegistry: c.Registry,
-		FetcherOptions:  []xpkg.FetcherOpt{xpkg.WithUserAgent(c.UserAgent)},
-		PackageRuntime:  pr,
+		Options:                 o,
+		Cache:                   xpkg.NewFsPackageCache(c.CacheDir, afero.NewOsFs()),
+		Namespace:               c.Namespace,
+		ServiceAccount:          c.ServiceAccount,
+		DefaultRegistry:         c.Registry,
+		DependencyPullSecrets:   c.DependencyPullSecrets,
+		DependencyPullPolicy:    corev1.PullPolicy(c.DependencyPullPolicy),
+		DependencyRuntimeConfig: c.DependencyRuntimeConfig,
+		FetcherOptions:          []xpkg.FetcherOpt{xpkg.WithUserAgent(c.UserAgent)},
+		PackageRuntime:          pr,
 	}
 
 	if c.CABundlePath != "" {
@@ -321,6 +410,7 @@ func (c *startCommand) Run(s *runtime.Scheme, log logging.Logger) error { //noli
 		if err := composition.SetupWebhookWithManager(mgr, o); err != nil {
 			return errors.Wrap(err, "cannot setup webhook for compositions")
 		}
+		conversion.SetupWebhookWithManager(mgr, ao)
 		if o.Features.Enabled(features.EnableAlphaUsages) {
 			if err := usage.SetupWebhookWithManager(mgr, o); err != nil {
 				return errors.Wrap(err, "cannot setup webhook for usages")
@@ -62,7 +62,7 @@ func (c *initCommand) Run(s *runtime.Scheme, log logging.Logger) error {
 	}
 	var steps []initializer.Step
 	tlsGeneratorOpts := []initializer.TLSCertificateGeneratorOption{
-		initializer.TLSCertificateGeneratorWithClientSecretName(c.TLSClientSecretName, []string{fmt.Sprintf("%s.%s", c.ServiceAccount, c.Namespace)}),
+		initializer.TLSCertificateGeneratorWithClientSecretName(c.TLSClientSecretName, []string{initializer.DNSNameForServiceAccount(c.ServiceAccount, c.Namespace)}),
 		initializer.TLSCertificateGeneratorWithLogger(log.WithValues("Step", "TLSCertificateGenerator")),
 	}
 	if c.WebhookEnabled {
@@ -24,6 +24,7 @@ import (
 	"github.com/crossplane/crossplane/internal/controller/apiextensions/controller"
 	"github.com/crossplane/crossplane/internal/controller/apiextensions/definition"
 	"github.com/crossplane/crossplane/internal/controller/apiextensions/offered"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/rollout"
 	"github.com/crossplane/crossplane/internal/controller/apiextensions/usage"
 	"github.com/crossplane/crossplane/internal/features"
 )
@@ -44,5 +45,11 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 		}
 	}
 
+	if o.Features.Enabled(features.EnableAlphaCompositionRollouts) {
+		if err := rollout.Setup(mgr, o); err != nil {
+			return err
+		}
+	}
+
 	return offered.Setup(mgr, o)
 }
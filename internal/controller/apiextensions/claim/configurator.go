@@ -28,7 +28,9 @@ import (
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/claim"
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
 
@@ -43,6 +45,8 @@ const (
 
 	errMergeClaimSpec   = "unable to merge claim spec"
 	errMergeClaimStatus = "unable to merge claim status"
+	errGetClaimConds    = "cannot get the types of composite resource conditions to propagate to the claim"
+	errSetClaimConds    = "cannot set the types of composite resource conditions propagated to the claim"
 )
 
 var (
@@ -249,6 +253,11 @@ func configureClaim(_ context.Context, cmObserved *claim.Unstructured, cmPatch *
 			return errors.Wrap(err, errMergeClaimStatus)
 		}
 	}
+
+	if err := propagateConditions(cmObserved, cmPatch, cpObserved); err != nil {
+		return err
+	}
+
 	// Propagate the actual external name back from the composite to the
 	// claim if it's set. The name we're propagating here will may be a name
 	// the XR must enforce (i.e. overriding any requested by the claim) but
@@ -285,6 +294,69 @@ func configureClaim(_ context.Context, cmObserved *claim.Unstructured, cmPatch *
 	return nil
 }
 
+// propagateConditions copies the composite's conditions that its Composition
+// targeted at the claim to the supplied claim patch. It records which types it
+// propagated in the claim's status, so that it can remove conditions that are
+// no longer targeted at the claim.
+func propagateConditions(cmObserved, cmPatch *claim.Unstructured, cpObserved *composite.Unstructured) error {
+	prev, err := fieldpath.Pave(cmObserved.Object).GetStringArray(xcrd.FieldClaimConditionTypes)
+	if resource.Ignore(fieldpath.IsNotFound, err) != nil {
+		return errors.Wrap(err, errGetClaimConds)
+	}
+	types, err := fieldpath.Pave(cpObserved.Object).GetStringArray(xcrd.FieldClaimConditionTypes)
+	if resource.Ignore(fieldpath.IsNotFound, err) != nil {
+		return errors.Wrap(err, errGetClaimConds)
+	}
+
+	propagated := make([]any, 0, len(types))
+	set := make(map[xpv1.ConditionType]bool, len(types))
+	for _, t := range types {
+		ct := xpv1.ConditionType(t)
+
+		// The claim controller owns the claim's own Ready and Synced
+		// conditions.
+		if ct == xpv1.TypeReady || ct == xpv1.TypeSynced {
+			continue
+		}
+
+		c := cpObserved.GetCondition(ct)
+		if c.Status == corev1.ConditionUnknown && c.Reason == "" {
+			// The composite doesn't actually have this condition.
+			continue
+		}
+		cmPatch.SetConditions(c)
+		set[ct] = true
+		propagated = append(propagated, t)
+	}
+
+	stale := make(map[xpv1.ConditionType]bool, len(prev))
+	for _, t := range prev {
+		ct := xpv1.ConditionType(t)
+		if !set[ct] && ct != xpv1.TypeReady && ct != xpv1.TypeSynced {
+			stale[ct] = true
+		}
+	}
+	if len(stale) > 0 {
+		p := fieldpath.Pave(cmPatch.Object)
+		cs := xpv1.ConditionedStatus{}
+		// The path is directly `status` because conditions are inline.
+		_ = p.GetValueInto("status", &cs)
+		keep := make([]xpv1.Condition, 0, len(cs.Conditions))
+		for _, c := range cs.Conditions {
+			if !stale[c.Type] {
+				keep = append(keep, c)
+			}
+		}
+		_ = p.SetValue("status.conditions", keep)
+	}
+
+	// Avoid writing an empty array to claims that never had any.
+	if len(propagated) == 0 && prev == nil {
+		return nil
+	}
+	return errors.Wrap(fieldpath.Pave(cmPatch.Object).SetValue(xcrd.FieldClaimConditionTypes, propagated), errSetClaimConds)
+}
+
 // merge a src map into dst map
 func merge(dst, src any, opts ...func(*mergo.Config)) error {
 	if dst == nil || src == nil {
@@ -887,6 +887,243 @@ func TestClaimConfigure(t *testing.T) {
 				},
 			},
 		},
+		"ConfigureScaleStatus": {
+			reason: "Scale status of the composite should be propagated to the claim, even when it is scaled to zero",
+			args: args{
+				client: test.NewMockClient(),
+				cm: &claim.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"metadata": map[string]any{
+								"namespace": ns,
+								"name":      name,
+							},
+							"spec": map[string]any{
+								"replicas": int64(0),
+							},
+							"status": map[string]any{
+								"replicas": int64(3),
+								"selector": "app=cool",
+							},
+						},
+					},
+				},
+				cp: &composite.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"metadata": map[string]any{
+								"name": name + "-12345",
+							},
+							"spec": map[string]any{
+								"replicas": int64(0),
+							},
+							"status": map[string]any{
+								"replicas": int64(0),
+								"selector": "app=cool",
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				cm: &claim.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"spec": map[string]any{
+								"resourceRef": map[string]any{"name": string("cool-12345")},
+							},
+							"status": map[string]any{
+								"replicas": int64(0),
+								"selector": "app=cool",
+							},
+						},
+					},
+				},
+			},
+		},
+		"PropagateClaimConditions": {
+			reason: "Conditions of the composite that target the claim should be propagated to the claim",
+			args: args{
+				client: test.NewMockClient(),
+				cm: &claim.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"metadata": map[string]any{
+								"namespace": ns,
+								"name":      name,
+							},
+							"status": map[string]any{
+								"conditions": []any{
+									map[string]any{
+										"type":               "Synced",
+										"status":             "True",
+										"reason":             "ReconcileSuccess",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+								},
+							},
+						},
+					},
+				},
+				cp: &composite.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"metadata": map[string]any{
+								"name": name + "-12345",
+							},
+							"status": map[string]any{
+								"claimConditionTypes": []any{"DatabaseReady", "Ready", "Missing"},
+								"conditions": []any{
+									map[string]any{
+										"type":               "Ready",
+										"status":             "False",
+										"reason":             "Creating",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+									map[string]any{
+										"type":               "DatabaseReady",
+										"status":             "False",
+										"reason":             "QuotaExceeded",
+										"message":            "The database quota was exceeded",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+									map[string]any{
+										"type":               "InternalCheck",
+										"status":             "True",
+										"reason":             "Checked",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				cm: &claim.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"spec": map[string]any{
+								"resourceRef": map[string]any{"name": string("cool-12345")},
+							},
+							"status": map[string]any{
+								"claimConditionTypes": []any{"DatabaseReady"},
+								"conditions": []any{
+									map[string]any{
+										"type":               "Synced",
+										"status":             "True",
+										"reason":             "ReconcileSuccess",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+									map[string]any{
+										"type":               "DatabaseReady",
+										"status":             "False",
+										"reason":             "QuotaExceeded",
+										"message":            "The database quota was exceeded",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"RemoveStaleClaimConditions": {
+			reason: "Conditions previously propagated to the claim should be removed once the composite no longer targets them at the claim",
+			args: args{
+				client: test.NewMockClient(),
+				cm: &claim.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"metadata": map[string]any{
+								"namespace": ns,
+								"name":      name,
+							},
+							"status": map[string]any{
+								"claimConditionTypes": []any{"DatabaseReady", "OldCheck"},
+								"conditions": []any{
+									map[string]any{
+										"type":               "Synced",
+										"status":             "True",
+										"reason":             "ReconcileSuccess",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+									map[string]any{
+										"type":               "OldCheck",
+										"status":             "False",
+										"reason":             "Stale",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+								},
+							},
+						},
+					},
+				},
+				cp: &composite.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"metadata": map[string]any{
+								"name": name + "-12345",
+							},
+							"status": map[string]any{
+								"claimConditionTypes": []any{"DatabaseReady", "Ready", "Missing"},
+								"conditions": []any{
+									map[string]any{
+										"type":               "Ready",
+										"status":             "False",
+										"reason":             "Creating",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+									map[string]any{
+										"type":               "DatabaseReady",
+										"status":             "False",
+										"reason":             "QuotaExceeded",
+										"message":            "The database quota was exceeded",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+									map[string]any{
+										"type":               "InternalCheck",
+										"status":             "True",
+										"reason":             "Checked",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				cm: &claim.Unstructured{
+					Unstructured: unstructured.Unstructured{
+						Object: map[string]any{
+							"spec": map[string]any{
+								"resourceRef": map[string]any{"name": string("cool-12345")},
+							},
+							"status": map[string]any{
+								"claimConditionTypes": []any{"DatabaseReady"},
+								"conditions": []any{
+									map[string]any{
+										"type":               "Synced",
+										"status":             "True",
+										"reason":             "ReconcileSuccess",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+									map[string]any{
+										"type":               "DatabaseReady",
+										"status":             "False",
+										"reason":             "QuotaExceeded",
+										"message":            "The database quota was exceeded",
+										"lastTransitionTime": "2024-01-01T00:00:00Z",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"UpdatePolicyManual": {
 			reason: "CompositionRevision of claim should NOT overwritten by the composite",
 			args: args{
@@ -25,6 +25,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -35,6 +36,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/rollout"
 	"github.com/crossplane/crossplane/internal/xcrd"
 )
 
@@ -126,12 +128,31 @@ func (a *APIFilteredSecretPublisher) UnpublishConnection(_ context.Context, _ re
 // are in alpha.
 type APIRevisionFetcher struct {
 	ca resource.ClientApplicator
+
+	rollouts bool
+}
+
+// An APIRevisionFetcherOption configures an APIRevisionFetcher.
+type APIRevisionFetcherOption func(f *APIRevisionFetcher)
+
+// WithRollouts configures an APIRevisionFetcher to honor the rollout strategy
+// of Compositions. Composite resources that are progressively rolled out are
+// left on their current revision. The rollout controller moves them to new
+// revisions.
+func WithRollouts() APIRevisionFetcherOption {
+	return func(f *APIRevisionFetcher) {
+		f.rollouts = true
+	}
 }
 
 // NewAPIRevisionFetcher returns a RevisionFetcher that fetches the
 // Revision referenced by a composite resource.
-func NewAPIRevisionFetcher(ca resource.ClientApplicator) *APIRevisionFetcher {
-	return &APIRevisionFetcher{ca: ca}
+func NewAPIRevisionFetcher(ca resource.ClientApplicator, opts ...APIRevisionFetcherOption) *APIRevisionFetcher {
+	f := &APIRevisionFetcher{ca: ca}
+	for _, fn := range opts {
+		fn(f)
+	}
+	return f
 }
 
 // Fetch the appropriate CompositionRevision for the supplied XR. Panics if the
@@ -157,6 +178,12 @@ func (f *APIRevisionFetcher) Fetch(ctx context.Context, cr resource.Composite) (
 		return nil, errors.Wrap(err, errGetComposition)
 	}
 
+	if f.rollouts && comp.Spec.Rollout != nil && rollout.IsRolledOut(cr, comp) {
+		if name := rolloutRevision(current, comp.Status.Rollout); name != "" {
+			return f.fetchRevision(ctx, cr, name)
+		}
+	}
+
 	rl, err := f.getCompositionRevisionList(ctx, cr, comp)
 	if err != nil {
 		return nil, errors.Wrap(err, errFetchCompositionRevision)
@@ -177,6 +204,43 @@ func (f *APIRevisionFetcher) Fetch(ctx context.Context, cr resource.Composite) (
 	return latest, nil
 }
 
+// rolloutRevision returns the name of the revision a composite resource that
+// is progressively rolled out should use, or an empty string if it should use
+// the latest revision.
+func rolloutRevision(current *corev1.ObjectReference, s *v1.RolloutStatus) string {
+	// The rollout controller is responsible for moving composite resources
+	// that have already selected a revision.
+	if current != nil {
+		return current.Name
+	}
+
+	// New composite resources use the previous revision if rolling out the
+	// latest revision failed.
+	if s != nil && (s.Phase == v1.RolloutPhasePaused || s.Phase == v1.RolloutPhaseRolledBack) {
+		return s.PreviousRevision
+	}
+
+	return ""
+}
+
+// fetchRevision fetches the named revision, and makes it the supplied
+// composite resource's revision.
+func (f *APIRevisionFetcher) fetchRevision(ctx context.Context, cr resource.Composite, name string) (*v1.CompositionRevision, error) {
+	rev := &v1.CompositionRevision{}
+	if err := f.ca.Get(ctx, types.NamespacedName{Name: name}, rev); err != nil {
+		return nil, errors.Wrap(err, errGetCompositionRevision)
+	}
+
+	if current := cr.GetCompositionRevisionReference(); current == nil || current.Name != name {
+		cr.SetCompositionRevisionReference(meta.ReferenceTo(rev, v1.CompositionRevisionGroupVersionKind))
+		if err := f.ca.Apply(ctx, cr); err != nil {
+			return nil, errors.Wrap(err, errUpdate)
+		}
+	}
+
+	return rev, nil
+}
+
 func (f *APIRevisionFetcher) getCompositionRevisionList(ctx context.Context, cr resource.Composite, comp *v1.Composition) (*v1.CompositionRevisionList, error) {
 	rl := &v1.CompositionRevisionList{}
 	ml := client.MatchingLabels{}
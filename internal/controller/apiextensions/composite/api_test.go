@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -211,9 +212,28 @@ func TestFetchRevision(t *testing.T) {
 		err error
 	}
 
+	compRollout := comp.DeepCopy()
+	compRollout.Spec.Rollout = &v1.RolloutStrategy{BatchSize: intstr.FromInt32(1)}
+	compRollout.Status.Rollout = &v1.RolloutStatus{
+		Phase:            v1.RolloutPhasePaused,
+		Revision:         rev2.GetName(),
+		PreviousRevision: rev1.GetName(),
+	}
+
+	getRollout := test.NewMockGetFn(nil, func(obj client.Object) error {
+		switch o := obj.(type) {
+		case *v1.Composition:
+			*o = *compRollout
+		case *v1.CompositionRevision:
+			*o = *rev1
+		}
+		return nil
+	})
+
 	cases := map[string]struct {
 		reason string
 		client resource.ClientApplicator
+		opts   []APIRevisionFetcherOption
 		args   args
 		want   want
 	}{
@@ -489,11 +509,76 @@ func TestFetchRevision(t *testing.T) {
 				err: errors.Wrap(errBoom, errUpdate),
 			},
 		},
+		"RolloutKeepsCurrentRevision": {
+			reason: "We should not move a composite resource that is progressively rolled out to the latest revision.",
+			client: resource.ClientApplicator{
+				Client: &test.MockClient{
+					MockGet: getRollout,
+					// This should not be called.
+					MockList: test.NewMockListFn(errBoom),
+				},
+				// This should not be called.
+				Applicator: resource.ApplyFn(func(c context.Context, o client.Object, ao ...resource.ApplyOption) error { return errBoom }),
+			},
+			opts: []APIRevisionFetcherOption{WithRollouts()},
+			args: args{
+				cr: &fake.Composite{
+					CompositionReferencer: fake.CompositionReferencer{
+						Ref: &corev1.ObjectReference{Name: comp.GetName()},
+					},
+					CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+						Ref: &corev1.ObjectReference{Name: rev1.GetName()},
+					},
+				},
+			},
+			want: want{
+				rev: rev1,
+			},
+		},
+		"RolloutFailedUsesPreviousRevision": {
+			reason: "A new composite resource should use the previous revision if rolling out the latest revision failed.",
+			client: resource.ClientApplicator{
+				Client: &test.MockClient{
+					MockGet: getRollout,
+					// This should not be called.
+					MockList: test.NewMockListFn(errBoom),
+				},
+				Applicator: resource.ApplyFn(func(c context.Context, o client.Object, ao ...resource.ApplyOption) error {
+					want := &fake.Composite{
+						CompositionReferencer: fake.CompositionReferencer{
+							Ref: &corev1.ObjectReference{Name: comp.GetName()},
+						},
+						CompositionRevisionReferencer: fake.CompositionRevisionReferencer{
+							Ref: &corev1.ObjectReference{
+								APIVersion: v1.SchemeGroupVersion.String(),
+								Kind:       v1.CompositionRevisionKind,
+								Name:       rev1.GetName(),
+							},
+						},
+					}
+					if diff := cmp.Diff(want, o); diff != "" {
+						t.Errorf("Apply(): -want, +got: %s", diff)
+					}
+					return nil
+				}),
+			},
+			opts: []APIRevisionFetcherOption{WithRollouts()},
+			args: args{
+				cr: &fake.Composite{
+					CompositionReferencer: fake.CompositionReferencer{
+						Ref: &corev1.ObjectReference{Name: comp.GetName()},
+					},
+				},
+			},
+			want: want{
+				rev: rev1,
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			f := NewAPIRevisionFetcher(tc.client)
+			f := NewAPIRevisionFetcher(tc.client, tc.opts...)
 			got, err := f.Fetch(tc.args.ctx, tc.args.cr)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
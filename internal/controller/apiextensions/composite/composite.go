@@ -19,14 +19,19 @@ package composite
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
 
 	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/xcrd"
 )
 
 // Annotation keys.
 const (
 	AnnotationKeyCompositionResourceName = "crossplane.io/composition-resource-name"
+	AnnotationKeyNotReadySince           = "crossplane.io/not-ready-since"
 )
 
 // SetCompositionResourceName sets the name of the composition template used to
@@ -41,6 +46,75 @@ func GetCompositionResourceName(o metav1.Object) ResourceName {
 	return ResourceName(o.GetAnnotations()[AnnotationKeyCompositionResourceName])
 }
 
+// SetClaimConditionTypes sets the types of the composite resource's status
+// conditions that should be propagated to its claim. It replaces any types that
+// were previously set.
+func SetClaimConditionTypes(xr *composite.Unstructured, types ...xpv1.ConditionType) error {
+	return setConditionTypes(xr, xcrd.FieldClaimConditionTypes, types...)
+}
+
+// GetCompositionConditionTypes returns the types of the composite resource's
+// status conditions that were set by the composition process.
+func GetCompositionConditionTypes(xr *composite.Unstructured) ([]xpv1.ConditionType, error) {
+	ts, err := fieldpath.Pave(xr.Object).GetStringArray(xcrd.FieldCompositionConditionTypes)
+	if fieldpath.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	types := make([]xpv1.ConditionType, len(ts))
+	for i := range ts {
+		types[i] = xpv1.ConditionType(ts[i])
+	}
+	return types, nil
+}
+
+// SetCompositionConditionTypes sets the types of the composite resource's
+// status conditions that were set by the composition process. It replaces any
+// types that were previously set.
+func SetCompositionConditionTypes(xr *composite.Unstructured, types ...xpv1.ConditionType) error {
+	return setConditionTypes(xr, xcrd.FieldCompositionConditionTypes, types...)
+}
+
+func setConditionTypes(xr *composite.Unstructured, path string, types ...xpv1.ConditionType) error {
+	p := fieldpath.Pave(xr.Object)
+	if len(types) == 0 {
+		// Avoid writing an empty array to XRs that never had any.
+		if _, err := p.GetValue(path); fieldpath.IsNotFound(err) {
+			return nil
+		}
+	}
+	ts := make([]any, len(types))
+	for i := range types {
+		ts[i] = string(types[i])
+	}
+	return p.SetValue(path, ts)
+}
+
+// RemoveConditions removes the status conditions of the supplied types from
+// the composite resource.
+func RemoveConditions(xr *composite.Unstructured, types ...xpv1.ConditionType) {
+	if len(types) == 0 {
+		return
+	}
+	remove := make(map[xpv1.ConditionType]bool, len(types))
+	for _, t := range types {
+		remove[t] = true
+	}
+	p := fieldpath.Pave(xr.Object)
+	cs := xpv1.ConditionedStatus{}
+	// The path is directly `status` because conditions are inline.
+	_ = p.GetValueInto("status", &cs)
+	keep := make([]xpv1.Condition, 0, len(cs.Conditions))
+	for _, c := range cs.Conditions {
+		if !remove[c.Type] {
+			keep = append(keep, c)
+		}
+	}
+	_ = p.SetValue("status.conditions", keep)
+}
+
 // Returns types of patches that are from a composed resource _to_ a composite resource.
 func patchTypesToXR() []v1.PatchType {
 	return []v1.PatchType{v1.PatchTypeToCompositeFieldPath, v1.PatchTypeCombineToComposite}
@@ -27,13 +27,16 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
@@ -63,6 +66,7 @@ const (
 	errExtraResourceAsStruct    = "cannot encode extra resource to protocol buffer Struct well-known type"
 	errUnknownResourceSelector  = "cannot get extra resource by name: unknown resource selector type"
 	errListExtraResources       = "cannot list extra resources"
+	errParseFieldSelector       = "cannot parse extra resources field selector"
 
 	errFmtApplyCD                    = "cannot apply composed resource %q"
 	errFmtFetchCDConnectionDetails   = "cannot fetch connection details for composed resource %q (a %s named %s)"
@@ -273,6 +277,7 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 	d := &v1beta1.State{}
 
 	events := []event.Event{}
+	conditions := []TargetedCondition{}
 
 	// The Function context starts empty...
 	fctx := &structpb.Struct{Fields: map[string]*structpb.Value{}}
@@ -375,6 +380,16 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 				events = append(events, event.Warning(reasonCompose, errors.Errorf("Pipeline step %q returned a result of unknown severity (assuming warning): %s", fn.Step, rs.GetMessage())))
 			}
 		}
+
+		// Conditions are accumulated to be set on the XR (and potentially its
+		// claim) by the Reconciler.
+		for _, c := range rsp.GetConditions() {
+			if c.GetType() == "" {
+				events = append(events, event.Warning(reasonCompose, errors.Errorf("Pipeline step %q returned a condition without a type (ignoring it)", fn.Step)))
+				continue
+			}
+			conditions = append(conditions, AsTargetedCondition(c))
+		}
 	}
 
 	// Load our desired composed resources from the Function pipeline.
@@ -495,7 +510,37 @@ func (c *FunctionComposer) Compose(ctx context.Context, xr *composite.Unstructur
 		resources = append(resources, ComposedResource{ResourceName: name, Ready: cd.Ready})
 	}
 
-	return CompositionResult{ConnectionDetails: d.GetComposite().GetConnectionDetails(), Composed: resources, Events: events}, nil
+	return CompositionResult{ConnectionDetails: d.GetComposite().GetConnectionDetails(), Composed: resources, Events: events, Conditions: conditions}, nil
+}
+
+// AsTargetedCondition converts a condition returned by a Composition Function
+// to a TargetedCondition.
+func AsTargetedCondition(c *v1beta1.Condition) TargetedCondition {
+	status := corev1.ConditionUnknown
+	switch c.GetStatus() {
+	case v1beta1.Status_STATUS_CONDITION_TRUE:
+		status = corev1.ConditionTrue
+	case v1beta1.Status_STATUS_CONDITION_FALSE:
+		status = corev1.ConditionFalse
+	case v1beta1.Status_STATUS_CONDITION_UNKNOWN, v1beta1.Status_STATUS_CONDITION_UNSPECIFIED:
+		status = corev1.ConditionUnknown
+	}
+
+	target := CompositionTargetComposite
+	if c.GetTarget() == v1beta1.Target_TARGET_COMPOSITE_AND_CLAIM {
+		target = CompositionTargetCompositeAndClaim
+	}
+
+	return TargetedCondition{
+		Condition: xpv1.Condition{
+			Type:               xpv1.ConditionType(c.GetType()),
+			Status:             status,
+			LastTransitionTime: metav1.Now(),
+			Reason:             xpv1.ConditionReason(c.GetReason()),
+			Message:            c.GetMessage(),
+		},
+		Target: target,
+	}
 }
 
 // ExistingExtraResourcesFetcher fetches extra resources requested by
@@ -514,13 +559,19 @@ func (e *ExistingExtraResourcesFetcher) Fetch(ctx context.Context, rs *v1beta1.R
 	if rs == nil {
 		return nil, errors.New(errNilResourceSelector)
 	}
+
+	fs, err := fields.ParseSelector(rs.GetFieldSelector())
+	if err != nil {
+		return nil, errors.Wrap(err, errParseFieldSelector)
+	}
+
 	switch match := rs.GetMatch().(type) {
 	case *v1beta1.ResourceSelector_MatchName:
 		// Fetch a single resource.
 		r := &kunstructured.Unstructured{}
 		r.SetAPIVersion(rs.GetApiVersion())
 		r.SetKind(rs.GetKind())
-		nn := types.NamespacedName{Name: rs.GetMatchName()}
+		nn := types.NamespacedName{Namespace: rs.GetNamespace(), Name: rs.GetMatchName()}
 		err := e.client.Get(ctx, nn, r)
 		if kerrors.IsNotFound(err) {
 			// The resource doesn't exist. We'll return nil, which the Functions
@@ -530,6 +581,11 @@ func (e *ExistingExtraResourcesFetcher) Fetch(ctx context.Context, rs *v1beta1.R
 		if err != nil {
 			return nil, errors.Wrap(err, errGetExtraResourceByName)
 		}
+		if !MatchesFieldSelector(r, fs) {
+			// The resource exists, but doesn't match. We treat this the same
+			// way we treat a resource that doesn't exist.
+			return nil, nil
+		}
 		o, err := AsStruct(r)
 		if err != nil {
 			return nil, errors.Wrap(err, errExtraResourceAsStruct)
@@ -541,18 +597,28 @@ func (e *ExistingExtraResourcesFetcher) Fetch(ctx context.Context, rs *v1beta1.R
 		list.SetAPIVersion(rs.GetApiVersion())
 		list.SetKind(rs.GetKind())
 
-		if err := e.client.List(ctx, list, client.MatchingLabels(match.MatchLabels.GetLabels())); err != nil {
+		opts := []client.ListOption{client.MatchingLabels(match.MatchLabels.GetLabels())}
+		if ns := rs.GetNamespace(); ns != "" {
+			opts = append(opts, client.InNamespace(ns))
+		}
+
+		if err := e.client.List(ctx, list, opts...); err != nil {
 			return nil, errors.Wrap(err, errListExtraResources)
 		}
 
-		resources := make([]*v1beta1.Resource, len(list.Items))
-		for i, r := range list.Items {
-			r := r
-			o, err := AsStruct(&r)
+		resources := make([]*v1beta1.Resource, 0, len(list.Items))
+		for i := range list.Items {
+			// We filter by field client side. The API server only supports a
+			// handful of fields per kind of resource, and our cache doesn't
+			// support field selectors without an index.
+			if !MatchesFieldSelector(&list.Items[i], fs) {
+				continue
+			}
+			o, err := AsStruct(&list.Items[i])
 			if err != nil {
 				return nil, errors.Wrap(err, errExtraResourceAsStruct)
 			}
-			resources[i] = &v1beta1.Resource{Resource: o}
+			resources = append(resources, &v1beta1.Resource{Resource: o})
 		}
 
 		return &v1beta1.Resources{Items: resources}, nil
@@ -560,6 +626,28 @@ func (e *ExistingExtraResourcesFetcher) Fetch(ctx context.Context, rs *v1beta1.R
 	return nil, errors.New(errUnknownResourceSelector)
 }
 
+// MatchesFieldSelector returns true if the supplied resource matches the
+// supplied Kubernetes field selector. Fields are field paths within the
+// resource, for example status.phase. A field that doesn't exist is treated as
+// an empty string. An empty selector matches any resource.
+func MatchesFieldSelector(u *kunstructured.Unstructured, s fields.Selector) bool {
+	if s.Empty() {
+		return true
+	}
+
+	p := fieldpath.Pave(u.Object)
+	set := fields.Set{}
+	for _, r := range s.Requirements() {
+		v, err := p.GetValue(r.Field)
+		if err != nil {
+			set[r.Field] = ""
+			continue
+		}
+		set[r.Field] = fmt.Sprint(v)
+	}
+	return s.Matches(set)
+}
+
 // An ExistingComposedResourceObserver uses an XR's resource references to load
 // any existing composed resources from the API server. It also loads their
 // connection details.
@@ -35,6 +35,7 @@ import (
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
@@ -559,6 +560,24 @@ func TestFunctionCompose(t *testing.T) {
 								Message:  "A result of unspecified severity",
 							},
 						},
+						Conditions: []*v1beta1.Condition{
+							{
+								Type:    "DatabaseReady",
+								Status:  v1beta1.Status_STATUS_CONDITION_FALSE,
+								Reason:  "QuotaExceeded",
+								Message: ptr.To("The database quota was exceeded"),
+								Target:  v1beta1.Target_TARGET_COMPOSITE_AND_CLAIM,
+							},
+							{
+								Type:   "InternalCheck",
+								Status: v1beta1.Status_STATUS_CONDITION_TRUE,
+								Reason: "Checked",
+							},
+							{
+								Status: v1beta1.Status_STATUS_CONDITION_TRUE,
+								Reason: "Untyped",
+							},
+						},
 					}
 					return rsp, nil
 				}),
@@ -636,6 +655,30 @@ func TestFunctionCompose(t *testing.T) {
 							Reason:  "ComposeResources",
 							Message: "Pipeline step \"run-cool-function\" returned a result of unknown severity (assuming warning): A result of unspecified severity",
 						},
+						{
+							Type:    "Warning",
+							Reason:  "ComposeResources",
+							Message: "Pipeline step \"run-cool-function\" returned a condition without a type (ignoring it)",
+						},
+					},
+					Conditions: []TargetedCondition{
+						{
+							Condition: xpv1.Condition{
+								Type:    "DatabaseReady",
+								Status:  corev1.ConditionFalse,
+								Reason:  "QuotaExceeded",
+								Message: "The database quota was exceeded",
+							},
+							Target: CompositionTargetCompositeAndClaim,
+						},
+						{
+							Condition: xpv1.Condition{
+								Type:   "InternalCheck",
+								Status: corev1.ConditionTrue,
+								Reason: "Checked",
+							},
+							Target: CompositionTargetComposite,
+						},
 					},
 				},
 				err: nil,
@@ -856,7 +899,7 @@ func TestFunctionCompose(t *testing.T) {
 
 			// We iterate over a map to produce ComposedResources, so they're
 			// returned in random order.
-			if diff := cmp.Diff(tc.want.res, res, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(i, j ComposedResource) bool { return i.ResourceName < j.ResourceName })); diff != "" {
+			if diff := cmp.Diff(tc.want.res, res, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(i, j ComposedResource) bool { return i.ResourceName < j.ResourceName }), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
 				t.Errorf("\n%s\nCompose(...): -want, +got:\n%s", tc.reason, diff)
 			}
 		})
@@ -1530,6 +1573,156 @@ func TestExistingExtraResourcesFetcherFetch(t *testing.T) {
 				},
 			},
 		},
+		"SuccessMatchNameInNamespace": {
+			reason: "We should return a valid Resources when a namespaced resource is found by name",
+			args: args{
+				rs: &v1beta1.ResourceSelector{
+					ApiVersion: "v1",
+					Kind:       "ConfigMap",
+					Match: &v1beta1.ResourceSelector_MatchName{
+						MatchName: "cool-resource",
+					},
+					Namespace: ptr.To("cool-namespace"),
+				},
+				c: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if key.Namespace != "cool-namespace" {
+							return errBoom
+						}
+						obj.SetName(key.Name)
+						obj.SetNamespace(key.Namespace)
+						return nil
+					},
+				},
+			},
+			want: want{
+				res: &v1beta1.Resources{
+					Items: []*v1beta1.Resource{
+						{
+							Resource: MustStruct(map[string]any{
+								"apiVersion": "v1",
+								"kind":       "ConfigMap",
+								"metadata": map[string]any{
+									"name":      "cool-resource",
+									"namespace": "cool-namespace",
+								},
+							}),
+						},
+					},
+				},
+			},
+		},
+		"SuccessMatchLabelsInNamespaceWithFieldSelector": {
+			reason: "We should only return resources in the requested namespace that match the field selector",
+			args: args{
+				rs: &v1beta1.ResourceSelector{
+					ApiVersion: "test.crossplane.io/v1",
+					Kind:       "Foo",
+					Match: &v1beta1.ResourceSelector_MatchLabels{
+						MatchLabels: &v1beta1.MatchLabels{
+							Labels: map[string]string{
+								"cool": "resource",
+							},
+						},
+					},
+					Namespace:     ptr.To("cool-namespace"),
+					FieldSelector: ptr.To("status.phase=Ready,spec.replicas!=0"),
+				},
+				c: &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if lo.Namespace != "cool-namespace" {
+							return errBoom
+						}
+						obj.(*kunstructured.UnstructuredList).Items = []kunstructured.Unstructured{
+							{
+								Object: map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "Foo",
+									"metadata":   map[string]any{"name": "ready-resource", "namespace": "cool-namespace"},
+									"spec":       map[string]any{"replicas": int64(3)},
+									"status":     map[string]any{"phase": "Ready"},
+								},
+							},
+							{
+								Object: map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "Foo",
+									"metadata":   map[string]any{"name": "scaled-down-resource", "namespace": "cool-namespace"},
+									"spec":       map[string]any{"replicas": int64(0)},
+									"status":     map[string]any{"phase": "Ready"},
+								},
+							},
+							{
+								Object: map[string]any{
+									"apiVersion": "test.crossplane.io/v1",
+									"kind":       "Foo",
+									"metadata":   map[string]any{"name": "pending-resource", "namespace": "cool-namespace"},
+									"status":     map[string]any{"phase": "Pending"},
+								},
+							},
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				res: &v1beta1.Resources{
+					Items: []*v1beta1.Resource{
+						{
+							Resource: MustStruct(map[string]any{
+								"apiVersion": "test.crossplane.io/v1",
+								"kind":       "Foo",
+								"metadata":   map[string]any{"name": "ready-resource", "namespace": "cool-namespace"},
+								"spec":       map[string]any{"replicas": int64(3)},
+								"status":     map[string]any{"phase": "Ready"},
+							}),
+						},
+					},
+				},
+			},
+		},
+		"MatchNameFieldSelectorMismatch": {
+			reason: "We should return no resources when a resource is found by name but doesn't match the field selector",
+			args: args{
+				rs: &v1beta1.ResourceSelector{
+					ApiVersion: "test.crossplane.io/v1",
+					Kind:       "Foo",
+					Match: &v1beta1.ResourceSelector_MatchName{
+						MatchName: "cool-resource",
+					},
+					FieldSelector: ptr.To("status.phase=Ready"),
+				},
+				c: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.SetName("cool-resource")
+						return nil
+					}),
+				},
+			},
+			want: want{
+				res: nil,
+				err: nil,
+			},
+		},
+		"InvalidFieldSelector": {
+			reason: "We should return an error if the field selector can't be parsed",
+			args: args{
+				rs: &v1beta1.ResourceSelector{
+					ApiVersion: "test.crossplane.io/v1",
+					Kind:       "Foo",
+					Match: &v1beta1.ResourceSelector_MatchName{
+						MatchName: "cool-resource",
+					},
+					FieldSelector: ptr.To("status.phase"),
+				},
+			},
+			want: want{
+				res: nil,
+				err: cmpopts.AnyError,
+			},
+		},
 		"NotFoundMatchName": {
 			reason: "We should return no error when a resource is not found by name",
 			args: args{
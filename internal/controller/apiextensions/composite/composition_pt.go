@@ -19,6 +19,7 @@ package composite
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -57,6 +58,7 @@ const (
 	errFmtGenerateName               = "cannot generate a name for composed resource %q"
 	errFmtExtractDetails             = "cannot extract composite resource connection details from composed resource %q"
 	errFmtCheckReadiness             = "cannot check whether composed resource %q is ready"
+	errFmtUpdateReadiness            = "cannot record when composed resource %q became not ready"
 )
 
 // TODO(negz): Move P&T Composition logic into its own package?
@@ -286,6 +288,7 @@ func (c *PTComposer) Compose(ctx context.Context, xr *composite.Unstructured, re
 	// in tas - i.e. a resources resource for every resource template.
 	resources := make([]ComposedResource, len(tas))
 	xrConnDetails := managed.ConnectionDetails{}
+	var requeueAfter time.Duration
 	for i := range tas {
 		t := tas[i].Template
 		cd := cds[i]
@@ -328,6 +331,24 @@ func (c *PTComposer) Compose(ctx context.Context, xr *composite.Unstructured, re
 			return CompositionResult{}, errors.Wrapf(err, errFmtCheckReadiness, name)
 		}
 
+		// Give a composed resource that was ready time to become ready again
+		// before we consider it not ready. We persist when it became not ready
+		// so we know when its grace period ends, and make sure we check it
+		// again once it has.
+		if gp := t.ReadinessGracePeriod; gp != nil {
+			var changed bool
+			var remaining time.Duration
+			ready, changed, remaining = IsReadyWithGracePeriod(xr, cd, ready, gp.Duration, time.Now())
+			if changed {
+				if err := c.client.Update(ctx, cd); err != nil {
+					return CompositionResult{}, errors.Wrapf(err, errFmtUpdateReadiness, name)
+				}
+			}
+			if remaining > 0 && (requeueAfter == 0 || remaining < requeueAfter) {
+				requeueAfter = remaining
+			}
+		}
+
 		resources[i] = ComposedResource{ResourceName: name, Ready: ready}
 	}
 
@@ -349,7 +370,7 @@ func (c *PTComposer) Compose(ctx context.Context, xr *composite.Unstructured, re
 		return CompositionResult{}, errors.Wrap(err, errUpdate)
 	}
 
-	return CompositionResult{ConnectionDetails: xrConnDetails, Composed: resources, Events: events}, nil
+	return CompositionResult{ConnectionDetails: xrConnDetails, Composed: resources, Events: events, RequeueAfter: requeueAfter}, nil
 }
 
 // toXRPatchesFromTAs selects patches defined in composed templates,
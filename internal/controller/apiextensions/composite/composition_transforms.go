@@ -25,10 +25,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/adler32"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
+	"text/template"
 
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	"github.com/google/cel-go/common/types/ref"
+	"github.com/google/cel-go/common/types/traits"
+	"google.golang.org/protobuf/types/known/structpb"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
@@ -36,6 +43,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/xcel"
 )
 
 const (
@@ -70,6 +78,14 @@ const (
 	errStringTransformTypeRegexpNoMatch = "regexp %q had no matches for group %d"
 	errStringConvertTypeFailed          = "type %s is not supported for string convert"
 
+	errExpressionCompile           = "cannot compile expression"
+	errExpressionEval              = "cannot evaluate expression"
+	errExpressionParseOutput       = "cannot parse output of expression as JSON"
+	errFmtExpressionOutputType     = "expression output %v is not of type %s"
+	errFmtExpressionLanguage       = "unknown expression language %s"
+	errFmtExpressionOutputMismatch = "expression output type %s is not compatible with declared output type %s"
+	errFmtExpressionMapKey         = "expression output has a map key %v that is not a string"
+
 	errDecodeString = "string is not valid base64"
 	errMarshalJSON  = "cannot marshal to JSON"
 	errHash         = "cannot generate hash"
@@ -107,6 +123,11 @@ func Resolve(t v1.Transform, input any) (any, error) { //nolint:gocyclo // This
 			return nil, errors.Errorf(errFmtTransformConfigMissing, t.Type)
 		}
 		out, err = ResolveConvert(*t.Convert, input)
+	case v1.TransformTypeExpression:
+		if t.Expression == nil {
+			return nil, errors.Errorf(errFmtTransformConfigMissing, t.Type)
+		}
+		out, err = ResolveExpression(*t.Expression, input)
 	default:
 		return nil, errors.Errorf(errFmtTypeNotSupported, string(t.Type))
 	}
@@ -497,3 +518,187 @@ var conversions = map[conversionPair]func(any) (any, error){
 		return o, json.Unmarshal([]byte(i.(string)), &o)
 	},
 }
+
+// expressionPrograms caches compiled CEL expression transforms, so that each is
+// compiled once rather than every time it's resolved.
+var expressionPrograms = xcel.NewCache()
+
+// ResolveExpression resolves an Expression transform by evaluating its CEL
+// expression or Go template against the input, then checking that the output is
+// of the declared output type.
+func ResolveExpression(t v1.ExpressionTransform, input any) (any, error) {
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	var out any
+	switch t.GetLanguage() {
+	case v1.ExpressionLanguageCEL:
+		prg, err := expressionPrograms.Program(string(t.OutputType)+"/"+t.Expression, func() (cel.Program, error) {
+			return CompileExpression(t, "")
+		})
+		if err != nil {
+			return nil, err
+		}
+		val, _, err := prg.Eval(map[string]any{"input": input})
+		if err != nil {
+			return nil, errors.Wrap(err, errExpressionEval)
+		}
+		if out, err = nativeValue(val); err != nil {
+			return nil, errors.Wrap(err, errExpressionEval)
+		}
+	case v1.ExpressionLanguageGoTemplate:
+		tmpl, err := template.New("").Option("missingkey=error").Parse(t.Expression)
+		if err != nil {
+			return nil, errors.Wrap(err, errExpressionCompile)
+		}
+		b := &strings.Builder{}
+		if err := tmpl.Execute(b, input); err != nil {
+			return nil, errors.Wrap(err, errExpressionEval)
+		}
+		if t.OutputType == v1.TransformIOTypeString {
+			return b.String(), nil
+		}
+		if err := json.Unmarshal([]byte(b.String()), &out); err != nil {
+			return nil, errors.Wrap(err, errExpressionParseOutput)
+		}
+	default:
+		return nil, errors.Errorf(errFmtExpressionLanguage, t.GetLanguage())
+	}
+
+	return expressionOutput(out, t.OutputType)
+}
+
+// CompileExpression compiles the supplied CEL expression transform, given the
+// type of its input. The input type may be empty if it's unknown. It returns an
+// error if the expression doesn't compile, or if it's known not to output the
+// declared output type.
+func CompileExpression(t v1.ExpressionTransform, in v1.TransformIOType) (cel.Program, error) {
+	env, err := xcel.NewTransformEnv(celType(in))
+	if err != nil {
+		return nil, errors.Wrap(err, errExpressionCompile)
+	}
+	ast, iss := env.Compile(t.Expression)
+	if iss.Err() != nil {
+		return nil, errors.Wrap(iss.Err(), errExpressionCompile)
+	}
+	if out, want := ast.OutputType(), celType(t.OutputType); !isAssignable(want, out) {
+		return nil, errors.Errorf(errFmtExpressionOutputMismatch, out, t.OutputType)
+	}
+	prg, err := env.Program(ast, cel.CostLimit(xcel.PerCallLimit))
+	return prg, errors.Wrap(err, errExpressionCompile)
+}
+
+// nativeValue returns the JSON representation of the supplied CEL value, e.g.
+// map[string]any rather than map[ref.Val]ref.Val. Unlike JSON it represents
+// integers as int64 rather than float64.
+func nativeValue(v ref.Val) (any, error) {
+	switch o := v.(type) {
+	case types.Int:
+		return int64(o), nil
+	case traits.Mapper:
+		m := make(map[string]any)
+		for it := o.Iterator(); it.HasNext() == types.True; {
+			k := it.Next()
+			ks, ok := k.Value().(string)
+			if !ok {
+				return nil, errors.Errorf(errFmtExpressionMapKey, k.Value())
+			}
+			val, err := nativeValue(o.Get(k))
+			if err != nil {
+				return nil, err
+			}
+			m[ks] = val
+		}
+		return m, nil
+	case traits.Lister:
+		size, _ := o.Size().Value().(int64)
+		l := make([]any, size)
+		for i := int64(0); i < size; i++ {
+			val, err := nativeValue(o.Get(types.Int(i)))
+			if err != nil {
+				return nil, err
+			}
+			l[i] = val
+		}
+		return l, nil
+	}
+	pv, err := v.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, err
+	}
+	return pv.(*structpb.Value).AsInterface(), nil
+}
+
+// isAssignable returns true if an expression that outputs the supplied CEL type
+// may be assigned to the wanted CEL type. Integers may be assigned to doubles,
+// because expressionOutput converts them.
+func isAssignable(want, out *cel.Type) bool {
+	if want == cel.DynType || out == cel.DynType {
+		return true
+	}
+	if want == cel.DoubleType && out == cel.IntType {
+		return true
+	}
+	return want.IsAssignableType(out)
+}
+
+// celType returns the CEL type equivalent to the supplied transform IO type.
+func celType(t v1.TransformIOType) *cel.Type {
+	switch t {
+	case v1.TransformIOTypeString:
+		return cel.StringType
+	case v1.TransformIOTypeBool:
+		return cel.BoolType
+	case v1.TransformIOTypeInt, v1.TransformIOTypeInt64:
+		return cel.IntType
+	case v1.TransformIOTypeFloat64:
+		return cel.DoubleType
+	case v1.TransformIOTypeObject:
+		return cel.MapType(cel.StringType, cel.DynType)
+	case v1.TransformIOTypeArray:
+		return cel.ListType(cel.DynType)
+	}
+	return cel.DynType
+}
+
+// expressionOutput returns the supplied JSON value as the supplied transform
+// IO type. JSON represents all numbers as float64, so whole numbers may be
+// returned as int64. Integers may be returned as float64.
+func expressionOutput(v any, t v1.TransformIOType) (any, error) { //nolint:gocyclo // Just a type switch.
+	switch o := v.(type) {
+	case string:
+		if t == v1.TransformIOTypeString {
+			return o, nil
+		}
+	case bool:
+		if t == v1.TransformIOTypeBool {
+			return o, nil
+		}
+	case int64:
+		switch t { //nolint:exhaustive // Other types aren't numbers.
+		case v1.TransformIOTypeInt, v1.TransformIOTypeInt64:
+			return o, nil
+		case v1.TransformIOTypeFloat64:
+			return float64(o), nil
+		}
+	case float64:
+		switch t { //nolint:exhaustive // Other types aren't numbers.
+		case v1.TransformIOTypeFloat64:
+			return o, nil
+		case v1.TransformIOTypeInt, v1.TransformIOTypeInt64:
+			if o == float64(int64(o)) {
+				return int64(o), nil
+			}
+		}
+	case map[string]any:
+		if t == v1.TransformIOTypeObject {
+			return o, nil
+		}
+	case []any:
+		if t == v1.TransformIOTypeArray {
+			return o, nil
+		}
+	}
+	return nil, errors.Errorf(errFmtExpressionOutputType, v, t)
+}
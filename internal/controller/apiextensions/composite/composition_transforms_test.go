@@ -1325,3 +1325,252 @@ func TestConvertTransformGetConversionFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestExpressionResolve(t *testing.T) {
+	type args struct {
+		t v1.ExpressionTransform
+		i any
+	}
+	type want struct {
+		o   any
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"CELSplit": {
+			reason: "A CEL expression should be able to split a string into an array.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input.split(',')",
+					OutputType: v1.TransformIOTypeArray,
+				},
+				i: "a,b,c",
+			},
+			want: want{
+				o: []any{"a", "b", "c"},
+			},
+		},
+		"CELJoin": {
+			reason: "A CEL expression should be able to join an array into a string.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input.join('-')",
+					OutputType: v1.TransformIOTypeString,
+				},
+				i: []any{"a", "b", "c"},
+			},
+			want: want{
+				o: "a-b-c",
+			},
+		},
+		"CELLookup": {
+			reason: "A CEL expression should be able to look up a field of an object.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input.tiers.filter(t, t.name == 'gold')[0].size",
+					OutputType: v1.TransformIOTypeInt64,
+				},
+				i: map[string]any{"tiers": []any{
+					map[string]any{"name": "silver", "size": int64(2)},
+					map[string]any{"name": "gold", "size": int64(4)},
+				}},
+			},
+			want: want{
+				o: int64(4),
+			},
+		},
+		"CELLargeInteger": {
+			reason: "A CEL expression that outputs an integer too large to represent exactly as a float64 should output it exactly.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input + 1",
+					OutputType: v1.TransformIOTypeInt64,
+				},
+				i: int64(9007199254740992),
+			},
+			want: want{
+				o: int64(9007199254740993),
+			},
+		},
+		"CELIntegerToFloat": {
+			reason: "A CEL expression that outputs an integer should be able to declare a float64 output.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input * 2",
+					OutputType: v1.TransformIOTypeFloat64,
+				},
+				i: int64(21),
+			},
+			want: want{
+				o: float64(42),
+			},
+		},
+		"CELNestedIntegers": {
+			reason: "A CEL expression that outputs an object should keep the object's integers as int64.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "{'replicas': input, 'ports': [80, 443], 'ratio': 0.5}",
+					OutputType: v1.TransformIOTypeObject,
+				},
+				i: int64(3),
+			},
+			want: want{
+				o: map[string]any{"replicas": int64(3), "ports": []any{int64(80), int64(443)}, "ratio": 0.5},
+			},
+		},
+		"CELCostLimit": {
+			reason: "A CEL expression that exceeds the cost limit should return an error.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input.all(a, input.all(b, input.all(c, input.all(d, input.all(e, input.all(f, a+b+c+d+e+f > 0))))))",
+					OutputType: v1.TransformIOTypeBool,
+				},
+				i: []any{int64(1), int64(2), int64(3), int64(4), int64(5), int64(6), int64(7), int64(8), int64(9), int64(10)},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("operation cancelled: actual cost limit exceeded"), errExpressionEval),
+			},
+		},
+		"CELTimestamp": {
+			reason: "A CEL expression should be able to format a date.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "string(timestamp(input).getFullYear())",
+					OutputType: v1.TransformIOTypeString,
+				},
+				i: "2024-01-02T03:04:05Z",
+			},
+			want: want{
+				o: "2024",
+			},
+		},
+		"CELWrongOutputType": {
+			reason: "A CEL expression that outputs a value of the wrong type should return an error.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input",
+					OutputType: v1.TransformIOTypeInt64,
+				},
+				i: "a",
+			},
+			want: want{
+				err: errors.Errorf(errFmtExpressionOutputType, "a", v1.TransformIOTypeInt64),
+			},
+		},
+		"CELEvalError": {
+			reason: "A CEL expression that can't be evaluated should return an error.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Expression: "input.missing",
+					OutputType: v1.TransformIOTypeString,
+				},
+				i: map[string]any{},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("no such key: missing"), errExpressionEval),
+			},
+		},
+		"GoTemplateString": {
+			reason: "A Go template should render a string.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Language:   v1.ExpressionLanguageGoTemplate,
+					Expression: "{{ .name }}.example.org",
+					OutputType: v1.TransformIOTypeString,
+				},
+				i: map[string]any{"name": "cool"},
+			},
+			want: want{
+				o: "cool.example.org",
+			},
+		},
+		"GoTemplateObject": {
+			reason: "A Go template that doesn't output a string should be parsed as JSON.",
+			args: args{
+				t: v1.ExpressionTransform{
+					Language:   v1.ExpressionLanguageGoTemplate,
+					Expression: `{"name": "{{ . }}"}`,
+					OutputType: v1.TransformIOTypeObject,
+				},
+				i: "cool",
+			},
+			want: want{
+				o: map[string]any{"name": "cool"},
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ResolveExpression(tc.args.t, tc.args.i)
+
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("%s\nResolveExpression(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("%s\nResolveExpression(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompileExpression(t *testing.T) {
+	type args struct {
+		t  v1.ExpressionTransform
+		in v1.TransformIOType
+	}
+
+	cases := map[string]struct {
+		reason  string
+		args    args
+		wantErr bool
+	}{
+		"UnknownInputType": {
+			reason: "An expression should compile if we don't know its input type.",
+			args: args{
+				t: v1.ExpressionTransform{Expression: "input.split(',')", OutputType: v1.TransformIOTypeArray},
+			},
+		},
+		"KnownInputType": {
+			reason: "An expression should compile if it's valid for its input type.",
+			args: args{
+				t:  v1.ExpressionTransform{Expression: "input.split(',')", OutputType: v1.TransformIOTypeArray},
+				in: v1.TransformIOTypeString,
+			},
+		},
+		"WrongInputType": {
+			reason: "An expression shouldn't compile if it's invalid for its input type.",
+			args: args{
+				t:  v1.ExpressionTransform{Expression: "input.split(',')", OutputType: v1.TransformIOTypeArray},
+				in: v1.TransformIOTypeInt64,
+			},
+			wantErr: true,
+		},
+		"WrongOutputType": {
+			reason: "An expression shouldn't compile if it doesn't output its declared output type.",
+			args: args{
+				t:  v1.ExpressionTransform{Expression: "input.split(',')", OutputType: v1.TransformIOTypeString},
+				in: v1.TransformIOTypeString,
+			},
+			wantErr: true,
+		},
+		"IntegerOutputAsFloat": {
+			reason: "An expression that outputs an integer should compile if its declared output type is float64.",
+			args: args{
+				t:  v1.ExpressionTransform{Expression: "input * 2", OutputType: v1.TransformIOTypeFloat64},
+				in: v1.TransformIOTypeInt64,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := CompileExpression(tc.args.t, tc.args.in)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("%s\nCompileExpression(...): -want error, +got error:\n%s\n%v", tc.reason, diff, err)
+			}
+		})
+	}
+}
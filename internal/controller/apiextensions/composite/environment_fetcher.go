@@ -19,21 +19,37 @@ package composite
 import (
 	"context"
 	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
 	v1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 )
 
 const (
-	errGetEnvironmentConfig    = "failed to get config set from reference"
-	errFetchEnvironmentConfigs = "cannot fetch environment configs"
-	errMergeData               = "failed to merge data"
+	errGetEnvironmentConfig        = "failed to get config set from reference"
+	errGetEnvironmentResource      = "failed to get environment resource from reference"
+	errFmtEnvironmentFieldPath     = "environment field path %q of %s %q is not an object"
+	errFmtEnvironmentConfigRefKind = "environment config references may only be to EnvironmentConfigs, not %s %q"
+	errFmtSecretNotSource          = "cannot read Secret %s/%s into the environment unless it's labeled %s: \"true\""
+	errFmtParseMergePath           = "cannot parse merge strategy field path %q"
+	errFmtMergeConflict            = "conflicting values for environment field %q"
+	errFetchEnvironmentConfigs     = "cannot fetch environment configs"
+	errMergeData                   = "failed to merge data"
 
 	environmentGroup   = "internal.crossplane.io"
 	environmentVersion = "v1alpha1"
@@ -92,15 +108,31 @@ func (f *APIEnvironmentFetcher) Fetch(ctx context.Context, req EnvironmentFetche
 }
 
 func (f *APIEnvironmentFetcher) fetchEnvironment(ctx context.Context, req EnvironmentFetcherRequest) (*Environment, error) {
-	loadedConfigs, err := f.fetchEnvironmentConfigs(ctx, req)
+	loadedData, err := f.fetchEnvironmentData(ctx, req)
 	if err != nil {
 		return nil, errors.Wrap(err, errFetchEnvironmentConfigs)
 	}
 
-	mergedData, err := mergeEnvironmentData(loadedConfigs)
+	defaultData := map[string]interface{}{}
+	var strategy *v1.EnvironmentMergeStrategy
+	if req.Revision != nil && req.Revision.Spec.Environment != nil {
+		strategy = req.Revision.Spec.Environment.MergeStrategy
+		if req.Revision.Spec.Environment.DefaultData != nil {
+			if defaultData, err = unmarshalData(req.Revision.Spec.Environment.DefaultData); err != nil {
+				return nil, errors.Wrap(err, errMergeData)
+			}
+		}
+	}
+
+	mergedData, err := mergeEnvironmentData(loadedData, strategy)
 	if err != nil {
 		return nil, errors.Wrap(err, errMergeData)
 	}
+
+	// The default data is always overwritten by the selected sources,
+	// regardless of the merge strategy.
+	mergedData = mergeMaps(defaultData, mergedData)
+
 	return &Environment{
 		unstructured.Unstructured{
 			Object: mergedData,
@@ -108,51 +140,331 @@ func (f *APIEnvironmentFetcher) fetchEnvironment(ctx context.Context, req Enviro
 	}, nil
 }
 
-func (f *APIEnvironmentFetcher) fetchEnvironmentConfigs(ctx context.Context, req EnvironmentFetcherRequest) ([]*v1alpha1.EnvironmentConfig, error) {
-	loadedConfigs := []*v1alpha1.EnvironmentConfig{}
-
-	// If the user provides a default environment with the composition, add it
-	// as a dummy environment config that is overwritten by all others.
-	if req.Revision != nil && req.Revision.Spec.Environment != nil && req.Revision.Spec.Environment.DefaultData != nil {
-		loadedConfigs = append(loadedConfigs, &v1alpha1.EnvironmentConfig{
-			Data: req.Revision.Spec.Environment.DefaultData,
-		})
+// fetchEnvironmentData returns the data of every resource the environment is
+// read from, in the order the composition declares its sources. The composite
+// resource may only reference EnvironmentConfigs. Anyone who can edit it can
+// edit its references, so ConfigMaps, Secrets, and other resources may only be
+// read if the composition configures them. They're resolved every time.
+func (f *APIEnvironmentFetcher) fetchEnvironmentData(ctx context.Context, req EnvironmentFetcherRequest) ([]map[string]interface{}, error) {
+	refs, err := f.environmentRefs(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	refs := req.Composite.GetEnvironmentConfigReferences()
+	loaded := []map[string]interface{}{}
 	for _, ref := range refs {
-		config := &v1alpha1.EnvironmentConfig{}
-		nn := types.NamespacedName{
-			Name: ref.Name,
-		}
-		err := f.kube.Get(ctx, nn, config)
+		data, err := f.fetchData(ctx, ref)
 		if err != nil {
 			// skip if resolution policy is optional
 			if req.Required {
-				return nil, errors.Wrap(err, errGetEnvironmentConfig)
+				return nil, err
 			}
 			continue
 		}
-		loadedConfigs = append(loadedConfigs, config)
+		loaded = append(loaded, data)
 	}
-	return loadedConfigs, nil
+	return loaded, nil
 }
 
-func mergeEnvironmentData(configs []*v1alpha1.EnvironmentConfig) (map[string]interface{}, error) {
-	merged := map[string]interface{}{}
-	for _, e := range configs {
-		if e == nil || e.Data == nil {
+// environmentRefs returns references to every resource the environment is
+// read from, in the order the composition declares its sources. The composite
+// resource stores the EnvironmentConfigs its sources selected in that order,
+// so each EnvironmentConfig source claims the next of the stored references.
+func (f *APIEnvironmentFetcher) environmentRefs(ctx context.Context, req EnvironmentFetcherRequest) ([]corev1.ObjectReference, error) {
+	stored := make([]corev1.ObjectReference, 0)
+	for _, ref := range req.Composite.GetEnvironmentConfigReferences() {
+		if ref.Kind != "" && schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).GroupKind() != v1alpha1.EnvironmentConfigGroupVersionKind.GroupKind() {
+			return nil, errors.Errorf(errFmtEnvironmentConfigRefKind, ref.Kind, ref.Name)
+		}
+		stored = append(stored, corev1.ObjectReference{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.EnvironmentConfigKind,
+			Name:       ref.Name,
+		})
+	}
+
+	if req.Revision == nil || req.Revision.Spec.Environment == nil {
+		return stored, nil
+	}
+
+	refs := make([]corev1.ObjectReference, 0, len(stored))
+	for i, src := range req.Revision.Spec.Environment.EnvironmentConfigs {
+		if src.GetKind() == v1.EnvironmentSourceKindEnvironmentConfig {
+			n, err := f.selected(ctx, req.Composite, src, stored)
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtReferenceEnvironmentConfig, i)
+			}
+			refs = append(refs, stored[:n]...)
+			stored = stored[n:]
 			continue
 		}
-		data, err := unmarshalData(e.Data)
+		r, err := resolveEnvironmentSource(ctx, f.kube, req.Composite, src)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtReferenceEnvironmentConfig, i)
+		}
+		refs = append(refs, r...)
+	}
+
+	// Any references the sources didn't claim, for example because they
+	// were added to the composite resource by hand, are merged last.
+	return append(refs, stored...), nil
+}
+
+// selected returns how many of the supplied EnvironmentConfig references,
+// starting from the first, were selected by the supplied source. A reference,
+// or a selector in Single mode, always selects one EnvironmentConfig. A
+// selector in Multi mode selects every following EnvironmentConfig that has
+// its labels.
+func (f *APIEnvironmentFetcher) selected(ctx context.Context, cr resource.Composite, src v1.EnvironmentSource, refs []corev1.ObjectReference) (int, error) {
+	if len(refs) == 0 {
+		return 0, nil
+	}
+	if src.Type != v1.EnvironmentSourceTypeSelector || src.Selector == nil || src.Selector.Mode != v1.EnvironmentSourceSelectorMultiMode {
+		return 1, nil
+	}
+
+	ml, err := selectorLabels(cr, src)
+	if err != nil {
+		return 0, err
+	}
+	if len(ml) == 0 {
+		return 0, nil
+	}
+	sel := labels.SelectorFromSet(labels.Set(ml))
+
+	n := 0
+	for _, ref := range refs {
+		if mm := src.Selector.MaxMatch; mm != nil && n >= int(*mm) {
+			break
+		}
+		ec := &v1alpha1.EnvironmentConfig{}
+		err := f.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, ec)
+		if resource.IgnoreNotFound(err) != nil {
+			return 0, errors.Wrap(err, errGetEnvironmentConfig)
+		}
+		// We can't tell whether an EnvironmentConfig that no longer
+		// exists was selected, so we keep it in place. Fetching its
+		// data will fail, per the resolution policy.
+		if err == nil && !sel.Matches(labels.Set(ec.GetLabels())) {
+			break
+		}
+		n++
+	}
+	return n, nil
+}
+
+// fetchData returns the environment data of the referenced resource. A
+// reference with a field path may be to any kind of resource Crossplane is
+// allowed to get. Otherwise it's to an EnvironmentConfig, a ConfigMap, or a
+// Secret.
+func (f *APIEnvironmentFetcher) fetchData(ctx context.Context, ref corev1.ObjectReference) (map[string]interface{}, error) {
+	nn := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+
+	switch {
+	case ref.FieldPath != "":
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(ref.APIVersion)
+		u.SetKind(ref.Kind)
+		if err := f.kube.Get(ctx, nn, u); err != nil {
+			return nil, errors.Wrap(err, errGetEnvironmentResource)
+		}
+		if u.GroupVersionKind().GroupKind() == corev1.SchemeGroupVersion.WithKind("Secret").GroupKind() && !isEnvironmentSource(u) {
+			return nil, errors.Errorf(errFmtSecretNotSource, ref.Namespace, ref.Name, v1.LabelEnvironmentSource)
+		}
+		v, err := fieldpath.Pave(u.Object).GetValue(ref.FieldPath)
 		if err != nil {
+			return nil, errors.Wrap(err, errGetEnvironmentResource)
+		}
+		data, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf(errFmtEnvironmentFieldPath, ref.FieldPath, ref.Kind, ref.Name)
+		}
+		return data, nil
+
+	case ref.APIVersion == "v1" && ref.Kind == "ConfigMap":
+		cm := &corev1.ConfigMap{}
+		if err := f.kube.Get(ctx, nn, cm); err != nil {
+			return nil, errors.Wrap(err, errGetEnvironmentResource)
+		}
+		data := make(map[string]interface{}, len(cm.Data))
+		for k, v := range cm.Data {
+			data[k] = v
+		}
+		return data, nil
+
+	case ref.APIVersion == "v1" && ref.Kind == "Secret":
+		s := &corev1.Secret{}
+		if err := f.kube.Get(ctx, nn, s); err != nil {
+			return nil, errors.Wrap(err, errGetEnvironmentResource)
+		}
+		if !isEnvironmentSource(s) {
+			return nil, errors.Errorf(errFmtSecretNotSource, ref.Namespace, ref.Name, v1.LabelEnvironmentSource)
+		}
+		data := make(map[string]interface{}, len(s.Data))
+		for k, v := range s.Data {
+			data[k] = string(v)
+		}
+		return data, nil
+	}
+
+	config := &v1alpha1.EnvironmentConfig{}
+	if err := f.kube.Get(ctx, nn, config); err != nil {
+		return nil, errors.Wrap(err, errGetEnvironmentConfig)
+	}
+	data, err := unmarshalData(config.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, errMergeData)
+	}
+	return data, nil
+}
+
+// isEnvironmentSource returns true if the supplied object, i.e. a Secret, is
+// labeled as a source that may be read into the environment.
+func isEnvironmentSource(o metav1.Object) bool {
+	return o.GetLabels()[v1.LabelEnvironmentSource] == "true"
+}
+
+// mergeEnvironmentData merges the supplied data in order, using the supplied
+// merge strategy. Objects are always merged deeply. By default the values of
+// later data replace the values of earlier data.
+func mergeEnvironmentData(data []map[string]interface{}, s *v1.EnvironmentMergeStrategy) (map[string]interface{}, error) {
+	m := &environmentMerger{def: s.GetType()}
+	if s != nil {
+		for _, p := range s.Paths {
+			segments, err := fieldpath.Parse(p.FieldPath)
+			if err != nil {
+				return nil, errors.Wrapf(err, errFmtParseMergePath, p.FieldPath)
+			}
+			m.paths = append(m.paths, pathMergeStrategy{segments: segments, strategy: p.Type})
+		}
+	}
+
+	merged := map[string]interface{}{}
+	for _, d := range data {
+		var err error
+		if merged, err = m.merge(nil, m.def, merged, d); err != nil {
 			return nil, err
 		}
-		merged = mergeMaps(merged, data)
 	}
 	return merged, nil
 }
 
+type pathMergeStrategy struct {
+	segments fieldpath.Segments
+	strategy v1.EnvironmentMergeStrategyType
+}
+
+// An environmentMerger merges environment data using a default merge strategy,
+// and the merge strategies of specific field paths.
+type environmentMerger struct {
+	def   v1.EnvironmentMergeStrategyType
+	paths []pathMergeStrategy
+}
+
+// strategyAt returns the merge strategy of the supplied path, or the inherited
+// strategy of its parent if none is configured.
+func (m *environmentMerger) strategyAt(path []string, inherited v1.EnvironmentMergeStrategyType) v1.EnvironmentMergeStrategyType {
+	for _, p := range m.paths {
+		if len(p.segments) != len(path) {
+			continue
+		}
+		match := true
+		for i := range path {
+			if p.segments[i].Field != path[i] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return p.strategy
+		}
+	}
+	return inherited
+}
+
+// merge b into a. Fields are merged in a deterministic order, so that merge
+// conflicts are reported consistently.
+func (m *environmentMerger) merge(path []string, inherited v1.EnvironmentMergeStrategyType, a, b map[string]interface{}) (map[string]interface{}, error) {
+	out := make(map[string]interface{}, len(a))
+	for k, v := range a {
+		out[k] = v
+	}
+
+	keys := make([]string, 0, len(b))
+	for k := range b {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fp := append(append(make([]string, 0, len(path)+1), path...), k)
+		s := m.strategyAt(fp, inherited)
+
+		bv := b[k]
+		av, ok := out[k]
+		if !ok {
+			out[k] = bv
+			continue
+		}
+
+		am, aIsMap := av.(map[string]interface{})
+		bm, bIsMap := bv.(map[string]interface{})
+		if aIsMap && bIsMap {
+			merged, err := m.merge(fp, s, am, bm)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = merged
+			continue
+		}
+
+		v, err := mergeValues(s, fp, av, bv)
+		if err != nil {
+			return nil, err
+		}
+		out[k] = v
+	}
+	return out, nil
+}
+
+// mergeValues merges value b into value a using the supplied strategy.
+func mergeValues(s v1.EnvironmentMergeStrategyType, path []string, a, b interface{}) (interface{}, error) {
+	switch s { //nolint:exhaustive // Replace is the default.
+	case v1.EnvironmentMergeStrategyKeepFirst:
+		return a, nil
+	case v1.EnvironmentMergeStrategyFail:
+		if !reflect.DeepEqual(a, b) {
+			return nil, errors.Errorf(errFmtMergeConflict, strings.Join(path, "."))
+		}
+		return a, nil
+	case v1.EnvironmentMergeStrategyAppend, v1.EnvironmentMergeStrategyUnion:
+		al, aIsList := a.([]interface{})
+		bl, bIsList := b.([]interface{})
+		if !aIsList || !bIsList {
+			return b, nil
+		}
+		out := make([]interface{}, 0, len(al)+len(bl))
+		out = append(out, al...)
+		for _, v := range bl {
+			if s == v1.EnvironmentMergeStrategyUnion && containsValue(out, v) {
+				continue
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	return b, nil
+}
+
+func containsValue(l []interface{}, v interface{}) bool {
+	for _, e := range l {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
 func unmarshalData(data map[string]extv1.JSON) (map[string]interface{}, error) {
 	res := map[string]interface{}{}
 	raw, err := json.Marshal(data)
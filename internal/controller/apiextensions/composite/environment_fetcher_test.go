@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -243,6 +244,378 @@ func TestFetch(t *testing.T) {
 				env: makeEnvironment(testDataMerged),
 			},
 		},
+		"MergeConfigMapsSecretsAndResources": {
+			reason: "It should merge the data of ConfigMaps, the decoded data of Secrets, and the objects at the field paths of other resources in the order the composition lists them, along with EnvironmentConfigs.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+						switch obj := o.(type) {
+						case *v1alpha1.EnvironmentConfig:
+							obj.Data = makeJSON(map[string]interface{}{"region": "eu-west-1", "tier": "gold"})
+							return nil
+						case *corev1.ConfigMap:
+							obj.Data = map[string]string{"region": "us-east-1", "zone": "a"}
+						case *corev1.Secret:
+							obj.SetLabels(map[string]string{v1.LabelEnvironmentSource: "true"})
+							obj.Data = map[string][]byte{"zone": []byte("b")}
+						case *unstructured.Unstructured:
+							if obj.GetKind() != "Cluster" {
+								return errors.Errorf("unexpected kind %q", obj.GetKind())
+							}
+							obj.Object["status"] = map[string]interface{}{
+								"atProvider": map[string]interface{}{"endpoint": "cool.example.org"},
+							}
+						default:
+							return errors.New("unexpected object type")
+						}
+						if key.Namespace != "cool-namespace" {
+							return errors.Errorf("unexpected namespace %q", key.Namespace)
+						}
+						return nil
+					},
+				},
+				cr: composite(
+					withEnvironmentRefs(
+						corev1.ObjectReference{APIVersion: "apiextensions.crossplane.io/v1alpha1", Kind: "EnvironmentConfig", Name: "a"},
+					),
+				),
+				revision: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindConfigMap,
+									Ref:  &v1.EnvironmentSourceReference{Name: "a", Namespace: "cool-namespace"},
+								},
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Ref:  &v1.EnvironmentSourceReference{Name: "a"},
+								},
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindSecret,
+									Ref:  &v1.EnvironmentSourceReference{Name: "b", Namespace: "cool-namespace"},
+								},
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindResource,
+									Ref:  &v1.EnvironmentSourceReference{Name: "c", Namespace: "cool-namespace"},
+									Resource: &v1.EnvironmentSourceResource{
+										APIVersion: "example.org/v1",
+										Kind:       "Cluster",
+										FieldPath:  "status.atProvider",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				env: makeEnvironment(map[string]interface{}{
+					"region":   "eu-west-1",
+					"tier":     "gold",
+					"zone":     "b",
+					"endpoint": "cool.example.org",
+				}),
+			},
+		},
+		"MergeSourcesInDeclaredOrder": {
+			reason: "It should merge the EnvironmentConfigs each source selected at the source's index, and EnvironmentConfigs that were added to the composite resource by hand last.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+						switch obj := o.(type) {
+						case *v1alpha1.EnvironmentConfig:
+							if key.Name != "d" {
+								obj.SetLabels(map[string]string{"env": "prod"})
+							}
+							obj.Data = makeJSON(map[string]interface{}{key.Name: key.Name, "last": key.Name})
+						case *corev1.ConfigMap:
+							obj.Data = map[string]string{"cm": "cm", "last": "cm"}
+						default:
+							return errors.New("unexpected object type")
+						}
+						return nil
+					},
+				},
+				cr: composite(
+					withEnvironmentRefs(
+						corev1.ObjectReference{Name: "a"},
+						corev1.ObjectReference{Name: "b"},
+						corev1.ObjectReference{Name: "c"},
+						corev1.ObjectReference{Name: "d"},
+					),
+				),
+				revision: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeSelector,
+									Selector: &v1.EnvironmentSourceSelector{
+										Mode: v1.EnvironmentSourceSelectorMultiMode,
+										MatchLabels: []v1.EnvironmentSourceSelectorLabelMatcher{
+											{
+												Type:  v1.EnvironmentSourceSelectorLabelMatcherTypeValue,
+												Key:   "env",
+												Value: ptr.To("prod"),
+											},
+										},
+										MaxMatch: ptr.To[uint64](2),
+									},
+								},
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindConfigMap,
+									Ref:  &v1.EnvironmentSourceReference{Name: "cm", Namespace: "cool-namespace"},
+								},
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Ref:  &v1.EnvironmentSourceReference{Name: "c"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				env: makeEnvironment(map[string]interface{}{
+					"a":    "a",
+					"b":    "b",
+					"cm":   "cm",
+					"c":    "c",
+					"d":    "d",
+					"last": "d",
+				}),
+			},
+		},
+		"MergeLabelSelectedConfigMaps": {
+			reason: "It should merge the data of the ConfigMaps a composition selects by labels.",
+			args: args{
+				kube: &test.MockClient{
+					MockList: func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						list := obj.(*unstructured.UnstructuredList)
+						if list.GetKind() != "ConfigMapList" {
+							return errors.Errorf("unexpected list kind %q", list.GetKind())
+						}
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if lo.Namespace != "cool-namespace" {
+							return errors.Errorf("unexpected namespace %q", lo.Namespace)
+						}
+						for _, name := range []string{"b", "a"} {
+							cm := unstructured.Unstructured{}
+							cm.SetAPIVersion("v1")
+							cm.SetKind("ConfigMap")
+							cm.SetNamespace("cool-namespace")
+							cm.SetName(name)
+							list.Items = append(list.Items, cm)
+						}
+						return nil
+					},
+					MockGet: func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+						o.(*corev1.ConfigMap).Data = map[string]string{"zone": key.Name}
+						return nil
+					},
+				},
+				cr: composite(),
+				revision: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeSelector,
+									Kind: v1.EnvironmentSourceKindConfigMap,
+									Selector: &v1.EnvironmentSourceSelector{
+										Mode:            v1.EnvironmentSourceSelectorMultiMode,
+										SortByFieldPath: "metadata.name",
+										Namespace:       "cool-namespace",
+										MatchLabels: []v1.EnvironmentSourceSelectorLabelMatcher{
+											{
+												Type:  v1.EnvironmentSourceSelectorLabelMatcherTypeValue,
+												Key:   "foo",
+												Value: ptr.To("bar"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				env: makeEnvironment(map[string]interface{}{
+					"zone": "b",
+				}),
+			},
+		},
+		"SelectOnlyLabeledSecrets": {
+			reason: "It should only select Secrets that are labeled as environment sources.",
+			args: args{
+				kube: &test.MockClient{
+					MockList: func(ctx context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						list := obj.(*unstructured.UnstructuredList)
+						if list.GetKind() != "SecretList" {
+							return errors.Errorf("unexpected list kind %q", list.GetKind())
+						}
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						want := labels.SelectorFromSet(labels.Set{"foo": "bar", v1.LabelEnvironmentSource: "true"})
+						if lo.LabelSelector.String() != want.String() {
+							return errors.Errorf("unexpected label selector %q", lo.LabelSelector.String())
+						}
+						s := unstructured.Unstructured{}
+						s.SetAPIVersion("v1")
+						s.SetKind("Secret")
+						s.SetNamespace("cool-namespace")
+						s.SetName("a")
+						list.Items = append(list.Items, s)
+						return nil
+					},
+					MockGet: func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+						o.(*corev1.Secret).SetLabels(map[string]string{"foo": "bar", v1.LabelEnvironmentSource: "true"})
+						o.(*corev1.Secret).Data = map[string][]byte{"password": []byte(key.Name)}
+						return nil
+					},
+				},
+				cr: composite(),
+				revision: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeSelector,
+									Kind: v1.EnvironmentSourceKindSecret,
+									Selector: &v1.EnvironmentSourceSelector{
+										Mode:      v1.EnvironmentSourceSelectorSingleMode,
+										Namespace: "cool-namespace",
+										MatchLabels: []v1.EnvironmentSourceSelectorLabelMatcher{
+											{
+												Type:  v1.EnvironmentSourceSelectorLabelMatcherTypeValue,
+												Key:   "foo",
+												Value: ptr.To("bar"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				env: makeEnvironment(map[string]interface{}{
+					"password": "a",
+				}),
+			},
+		},
+		"ErrorOnReferenceToOtherKind": {
+			reason: "It should return an error if the composite resource references anything but an EnvironmentConfig",
+			args: args{
+				cr: composite(
+					withEnvironmentRefs(
+						corev1.ObjectReference{APIVersion: "v1", Kind: "Secret", Name: "b"},
+					),
+				),
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtEnvironmentConfigRefKind, "Secret", "b"), errFetchEnvironmentConfigs),
+			},
+		},
+		"ErrorOnUnlabeledSecret": {
+			reason: "It should return an error if a Secret isn't labeled as an environment source.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				},
+				cr: composite(),
+				revision: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindSecret,
+									Ref:  &v1.EnvironmentSourceReference{Name: "b", Namespace: "cool-namespace"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtSecretNotSource, "cool-namespace", "b", v1.LabelEnvironmentSource), errFetchEnvironmentConfigs),
+			},
+		},
+		"ErrorOnUnlabeledSecretResource": {
+			reason: "It should return an error if a Secret read as any kind of Resource isn't labeled as an environment source.",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+						o.(*unstructured.Unstructured).Object["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+						return nil
+					},
+				},
+				cr: composite(),
+				revision: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindResource,
+									Ref:  &v1.EnvironmentSourceReference{Name: "b", Namespace: "cool-namespace"},
+									Resource: &v1.EnvironmentSourceResource{
+										APIVersion: "v1",
+										Kind:       "Secret",
+										FieldPath:  "data",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtSecretNotSource, "cool-namespace", "b", v1.LabelEnvironmentSource), errFetchEnvironmentConfigs),
+			},
+		},
+		"ErrorOnFieldPathNotAnObject": {
+			reason: "It should return an error if the field path of a resource isn't an object",
+			args: args{
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+						o.(*unstructured.Unstructured).Object["spec"] = "cool"
+						return nil
+					}),
+				},
+				cr: composite(),
+				revision: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindResource,
+									Ref:  &v1.EnvironmentSourceReference{Name: "c"},
+									Resource: &v1.EnvironmentSourceResource{
+										APIVersion: "example.org/v1",
+										Kind:       "Cluster",
+										FieldPath:  "spec",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtEnvironmentFieldPath, "spec", "Cluster", "c"), errFetchEnvironmentConfigs),
+			},
+		},
 		"ErrorOnKubeGetError": {
 			reason: "It should return an error if getting a EnvironmentConfig from a reference fails",
 			args: args{
@@ -300,3 +673,105 @@ func TestFetch(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeEnvironmentData(t *testing.T) {
+	a := map[string]interface{}{
+		"region": "us-east-1",
+		"network": map[string]interface{}{
+			"allowedCIDRs": []interface{}{"10.0.0.0/8", "172.16.0.0/12"},
+		},
+		"tags": []interface{}{"a"},
+	}
+	b := map[string]interface{}{
+		"region": "us-west-2",
+		"network": map[string]interface{}{
+			"allowedCIDRs": []interface{}{"172.16.0.0/12", "192.168.0.0/16"},
+		},
+		"tags": []interface{}{"b"},
+	}
+
+	type args struct {
+		data []map[string]interface{}
+		s    *v1.EnvironmentMergeStrategy
+	}
+	type want struct {
+		merged map[string]interface{}
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"DefaultReplace": {
+			reason: "Later values should replace earlier values by default.",
+			args: args{
+				data: []map[string]interface{}{a, b},
+			},
+			want: want{
+				merged: b,
+			},
+		},
+		"PathStrategies": {
+			reason: "The most specific path strategy should be used for a field.",
+			args: args{
+				data: []map[string]interface{}{a, b},
+				s: &v1.EnvironmentMergeStrategy{
+					Type: v1.EnvironmentMergeStrategyKeepFirst,
+					Paths: []v1.EnvironmentPathMergeStrategy{
+						{FieldPath: "network", Type: v1.EnvironmentMergeStrategyUnion},
+						{FieldPath: "tags", Type: v1.EnvironmentMergeStrategyAppend},
+					},
+				},
+			},
+			want: want{
+				merged: map[string]interface{}{
+					"region": "us-east-1",
+					"network": map[string]interface{}{
+						"allowedCIDRs": []interface{}{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"},
+					},
+					"tags": []interface{}{"a", "b"},
+				},
+			},
+		},
+		"FailOnConflict": {
+			reason: "We should return an error if sources set different values for a field that may not conflict.",
+			args: args{
+				data: []map[string]interface{}{a, b},
+				s: &v1.EnvironmentMergeStrategy{
+					Paths: []v1.EnvironmentPathMergeStrategy{
+						{FieldPath: "region", Type: v1.EnvironmentMergeStrategyFail},
+					},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtMergeConflict, "region"),
+			},
+		},
+		"NoConflictOnEqualValues": {
+			reason: "We shouldn't return an error if sources set equal values for a field that may not conflict.",
+			args: args{
+				data: []map[string]interface{}{a, a},
+				s: &v1.EnvironmentMergeStrategy{
+					Type: v1.EnvironmentMergeStrategyFail,
+				},
+			},
+			want: want{
+				merged: a,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := mergeEnvironmentData(tc.args.data, tc.args.s)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nmergeEnvironmentData(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.merged, got); diff != "" {
+				t.Errorf("\n%s\nmergeEnvironmentData(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
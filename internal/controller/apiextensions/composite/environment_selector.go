@@ -24,6 +24,7 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -40,6 +41,7 @@ const (
 	errListEnvironmentConfigs          = "failed to list environments"
 	errFmtSelectorNotEnoughResults     = "expected at least %d EnvironmentConfig(s) with matching labels, found: %d"
 	errFmtInvalidEnvironmentSourceType = "invalid source type '%s'"
+	errFmtInvalidEnvironmentSourceKind = "invalid source kind '%s'"
 	errFmtInvalidLabelMatcherType      = "invalid label matcher type '%s'"
 	errFmtUnknownSelectorMode          = "unknown mode '%s'"
 	errFmtSortNotMatchingTypes         = "not matching types, got %[1]v (%[1]T), expected %[2]v"
@@ -74,6 +76,7 @@ type APIEnvironmentSelector struct {
 
 // SelectEnvironment for cr using the configuration defined in comp.
 // The computed list of EnvironmentConfig references will be stored in cr.
+// Sources of other kinds are resolved by the environment fetcher instead.
 func (s *APIEnvironmentSelector) SelectEnvironment(ctx context.Context, cr resource.Composite, rev *v1.CompositionRevision) error {
 	if !rev.Spec.Environment.ShouldResolve(cr.GetEnvironmentConfigReferences()) {
 		return nil
@@ -81,40 +84,117 @@ func (s *APIEnvironmentSelector) SelectEnvironment(ctx context.Context, cr resou
 
 	refs := make([]corev1.ObjectReference, 0, len(rev.Spec.Environment.EnvironmentConfigs))
 	for i, src := range rev.Spec.Environment.EnvironmentConfigs {
-		switch src.Type {
-		case v1.EnvironmentSourceTypeReference:
-			refs = append(
-				refs,
-				s.buildEnvironmentConfigRefFromRef(src.Ref),
-			)
-		case v1.EnvironmentSourceTypeSelector:
-			ec, err := s.lookUpConfigs(ctx, cr, src.Selector.MatchLabels)
-			if err != nil {
-				return errors.Wrapf(err, errFmtReferenceEnvironmentConfig, i)
-			}
-			r, err := s.buildEnvironmentConfigRefFromSelector(ec, src.Selector)
-			if err != nil {
-				return errors.Wrapf(err, errFmtReferenceEnvironmentConfig, i)
-			}
-			refs = append(refs, r...)
-		default:
-			return errors.Errorf(errFmtInvalidEnvironmentSourceType, string(src.Type))
+		if src.GetKind() != v1.EnvironmentSourceKindEnvironmentConfig {
+			continue
+		}
+		r, err := resolveEnvironmentSource(ctx, s.kube, cr, src)
+		if err != nil {
+			return errors.Wrapf(err, errFmtReferenceEnvironmentConfig, i)
 		}
+		refs = append(refs, r...)
 	}
 	cr.SetEnvironmentConfigReferences(refs)
 	return nil
 }
 
-func (s *APIEnvironmentSelector) buildEnvironmentConfigRefFromRef(ref *v1.EnvironmentSourceReference) corev1.ObjectReference {
-	return corev1.ObjectReference{
-		Name:       ref.Name,
-		Kind:       v1alpha1.EnvironmentConfigKind,
-		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+// resolveEnvironmentSource returns references to the resources the supplied
+// source reads the environment from.
+func resolveEnvironmentSource(ctx context.Context, kube client.Reader, cr resource.Composite, src v1.EnvironmentSource) ([]corev1.ObjectReference, error) {
+	switch src.Type {
+	case v1.EnvironmentSourceTypeReference:
+		r, err := buildEnvironmentSourceRef(src, src.Ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		return []corev1.ObjectReference{r}, nil
+	case v1.EnvironmentSourceTypeSelector:
+		items, err := lookUpConfigs(ctx, kube, cr, src)
+		if err != nil {
+			return nil, err
+		}
+		return buildEnvironmentConfigRefFromSelector(items, src)
 	}
+	return nil, errors.Errorf(errFmtInvalidEnvironmentSourceType, string(src.Type))
 }
 
-func (s *APIEnvironmentSelector) lookUpConfigs(ctx context.Context, cr resource.Composite, ml []v1.EnvironmentSourceSelectorLabelMatcher) (*v1alpha1.EnvironmentConfigList, error) {
-	res := &v1alpha1.EnvironmentConfigList{}
+// buildEnvironmentSourceRef builds a reference to the named resource the
+// supplied source reads the environment from.
+func buildEnvironmentSourceRef(src v1.EnvironmentSource, name string) (corev1.ObjectReference, error) {
+	ref := corev1.ObjectReference{Name: name, Namespace: src.GetNamespace()}
+	switch k := src.GetKind(); k {
+	case v1.EnvironmentSourceKindEnvironmentConfig:
+		ref.APIVersion = v1alpha1.SchemeGroupVersion.String()
+		ref.Kind = v1alpha1.EnvironmentConfigKind
+	case v1.EnvironmentSourceKindConfigMap, v1.EnvironmentSourceKindSecret:
+		ref.APIVersion = corev1.SchemeGroupVersion.String()
+		ref.Kind = string(k)
+	case v1.EnvironmentSourceKindResource:
+		if src.Resource == nil {
+			return corev1.ObjectReference{}, errors.Errorf(errFmtRequiredField, "resource", string(k))
+		}
+		ref.APIVersion = src.Resource.APIVersion
+		ref.Kind = src.Resource.Kind
+		ref.FieldPath = src.Resource.FieldPath
+	default:
+		return corev1.ObjectReference{}, errors.Errorf(errFmtInvalidEnvironmentSourceKind, string(k))
+	}
+	return ref, nil
+}
+
+// lookUpConfigs returns the resources selected by the supplied source, as
+// unstructured objects.
+func lookUpConfigs(ctx context.Context, kube client.Reader, cr resource.Composite, src v1.EnvironmentSource) ([]kunstructured.Unstructured, error) {
+	matchLabels, err := selectorLabels(cr, src)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchLabels) == 0 {
+		return []kunstructured.Unstructured{}, nil
+	}
+
+	if src.GetKind() == v1.EnvironmentSourceKindEnvironmentConfig {
+		res := &v1alpha1.EnvironmentConfigList{}
+		if err := kube.List(ctx, res, matchLabels); err != nil {
+			return nil, errors.Wrap(err, errListEnvironmentConfigs)
+		}
+		items := make([]kunstructured.Unstructured, len(res.Items))
+		for i := range res.Items {
+			m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&res.Items[i])
+			if err != nil {
+				return nil, err
+			}
+			items[i] = kunstructured.Unstructured{Object: m}
+		}
+		return items, nil
+	}
+
+	// Any resource may be selected, so we list them as unstructured objects.
+	ref, err := buildEnvironmentSourceRef(src, "")
+	if err != nil {
+		return nil, err
+	}
+	// Only Secrets labeled as environment sources may be selected.
+	if ref.APIVersion == corev1.SchemeGroupVersion.String() && ref.Kind == "Secret" {
+		matchLabels[v1.LabelEnvironmentSource] = "true"
+	}
+	res := &kunstructured.UnstructuredList{}
+	res.SetAPIVersion(ref.APIVersion)
+	res.SetKind(ref.Kind + "List")
+	opts := []client.ListOption{matchLabels}
+	if ref.Namespace != "" {
+		opts = append(opts, client.InNamespace(ref.Namespace))
+	}
+	if err := kube.List(ctx, res, opts...); err != nil {
+		return nil, errors.Wrap(err, errListEnvironmentConfigs)
+	}
+	return res.Items, nil
+}
+
+// selectorLabels returns the labels the supplied selector source matches,
+// resolved against the supplied composite resource. Optional labels that can't
+// be resolved are omitted.
+func selectorLabels(cr resource.Composite, src v1.EnvironmentSource) (client.MatchingLabels, error) {
+	ml := src.Selector.MatchLabels
 	matchLabels := make(client.MatchingLabels, len(ml))
 	for i, m := range ml {
 		val, err := ResolveLabelValue(m, cr)
@@ -126,46 +206,37 @@ func (s *APIEnvironmentSelector) lookUpConfigs(ctx context.Context, cr resource.
 		}
 		matchLabels[m.Key] = val
 	}
-	if len(matchLabels) == 0 {
-		return res, nil
-	}
-	if err := s.kube.List(ctx, res, matchLabels); err != nil {
-		return nil, errors.Wrap(err, errListEnvironmentConfigs)
-	}
-	return res, nil
+	return matchLabels, nil
 }
 
-func (s *APIEnvironmentSelector) buildEnvironmentConfigRefFromSelector(cl *v1alpha1.EnvironmentConfigList, selector *v1.EnvironmentSourceSelector) ([]corev1.ObjectReference, error) { //nolint:gocyclo // TODO: refactor
-	ec := make([]v1alpha1.EnvironmentConfig, 0)
-
-	if cl == nil {
-		return []corev1.ObjectReference{}, nil
-	}
+func buildEnvironmentConfigRefFromSelector(items []kunstructured.Unstructured, src v1.EnvironmentSource) ([]corev1.ObjectReference, error) { //nolint:gocyclo // TODO: refactor
+	selector := src.Selector
+	ec := make([]kunstructured.Unstructured, 0)
 
 	switch selector.Mode {
 	case v1.EnvironmentSourceSelectorSingleMode:
-		switch len(cl.Items) {
+		switch len(items) {
 		case 1:
-			ec = append(ec, cl.Items[0])
+			ec = append(ec, items[0])
 		default:
-			return nil, errors.Errorf(errFmtFoundMultipleInSingleMode, len(cl.Items))
+			return nil, errors.Errorf(errFmtFoundMultipleInSingleMode, len(items))
 		}
 	case v1.EnvironmentSourceSelectorMultiMode:
 
-		if selector.MinMatch != nil && len(cl.Items) < int(*selector.MinMatch) {
-			return nil, errors.Errorf(errFmtSelectorNotEnoughResults, *selector.MinMatch, len(cl.Items))
+		if selector.MinMatch != nil && len(items) < int(*selector.MinMatch) {
+			return nil, errors.Errorf(errFmtSelectorNotEnoughResults, *selector.MinMatch, len(items))
 		}
 
-		err := sortConfigs(cl.Items, selector.SortByFieldPath)
+		err := sortConfigs(items, selector.SortByFieldPath)
 		if err != nil {
 			return nil, err
 		}
 
-		if selector.MaxMatch != nil && len(cl.Items) > int(*selector.MaxMatch) {
-			ec = append(ec, cl.Items[:*selector.MaxMatch]...)
+		if selector.MaxMatch != nil && len(items) > int(*selector.MaxMatch) {
+			ec = append(ec, items[:*selector.MaxMatch]...)
 			break
 		}
-		ec = append(ec, cl.Items...)
+		ec = append(ec, items...)
 
 	default:
 		// should never happen
@@ -174,30 +245,25 @@ func (s *APIEnvironmentSelector) buildEnvironmentConfigRefFromSelector(cl *v1alp
 
 	envConfigs := make([]corev1.ObjectReference, len(ec))
 	for i, v := range ec {
-		envConfigs[i] = corev1.ObjectReference{
-			Name:       v.Name,
-			Kind:       v1alpha1.EnvironmentConfigKind,
-			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		ref, err := buildEnvironmentSourceRef(src, v.GetName())
+		if err != nil {
+			return nil, err
 		}
+		envConfigs[i] = ref
 	}
 
 	return envConfigs, nil
 }
 
-func sortConfigs(ec []v1alpha1.EnvironmentConfig, f string) error { //nolint:gocyclo // TODO(phisco): refactor
+func sortConfigs(ec []kunstructured.Unstructured, f string) error { //nolint:gocyclo // TODO(phisco): refactor
 	p := make([]struct {
-		ec  v1alpha1.EnvironmentConfig
+		ec  kunstructured.Unstructured
 		val any
 	}, len(ec))
 
 	var valsKind reflect.Kind
 	for i := 0; i < len(ec); i++ {
-		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&ec[i])
-		if err != nil {
-			return err
-		}
-
-		val, err := fieldpath.Pave(m).GetValue(f)
+		val, err := fieldpath.Pave(ec[i].Object).GetValue(f)
 		if err != nil {
 			return err
 		}
@@ -223,6 +223,51 @@ func TestSelect(t *testing.T) {
 				),
 			},
 		},
+		"NoRefsForOtherKinds": {
+			reason: "It should only create references for EnvironmentConfigs, since other kinds of sources are resolved by the fetcher.",
+			args: args{
+				cr: composite(),
+				rev: &v1.CompositionRevision{
+					Spec: v1.CompositionRevisionSpec{
+						Environment: &v1.EnvironmentConfiguration{
+							EnvironmentConfigs: []v1.EnvironmentSource{
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindSecret,
+									Ref: &v1.EnvironmentSourceReference{
+										Name:      "cool-secret",
+										Namespace: "cool-namespace",
+									},
+								},
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Ref: &v1.EnvironmentSourceReference{
+										Name: "test-1",
+									},
+								},
+								{
+									Type: v1.EnvironmentSourceTypeReference,
+									Kind: v1.EnvironmentSourceKindResource,
+									Ref: &v1.EnvironmentSourceReference{
+										Name: "cool-cluster",
+									},
+									Resource: &v1.EnvironmentSourceResource{
+										APIVersion: "example.org/v1",
+										Kind:       "Cluster",
+										FieldPath:  "status.atProvider",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				cr: composite(
+					withEnvironmentRefs(environmentConfigRef("test-1")),
+				),
+			},
+		},
 		"RefForLabelSelectedObjectWithLabelValueFromFieldPath": {
 			reason: "It should create a name reference for the first selected EnvironmentConfig that matches the labels.",
 			args: args{
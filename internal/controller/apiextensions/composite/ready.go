@@ -18,16 +18,20 @@ package composite
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/cel-go/cel"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/xcel"
 )
 
 // Error strings
@@ -39,10 +43,24 @@ const (
 	errFmtRequiresMatchString     = "type %q requires a match string"
 	errFmtRequiresMatchConditions = "type %q requires a valid match condition"
 	errFmtRequiresMatchInteger    = "type %q requires a match integer"
+	errFmtRequiresExpression      = "type %q requires an expression"
+	errFmtNotBoolean              = "expression must evaluate to a boolean, not %T"
 	errFmtUnknownCheck            = "unknown type %q"
 	errFmtRunCheck                = "cannot run readiness check at index %d"
 )
 
+// readinessPrograms caches compiled CEL readiness check expressions, so that
+// each is compiled once rather than every time a composed resource is checked.
+var readinessPrograms = xcel.NewCache()
+
+// compileReadinessCheck returns the compiled program for the supplied CEL
+// readiness check expression.
+func compileReadinessCheck(expr string) (cel.Program, error) {
+	return readinessPrograms.Program(expr, func() (cel.Program, error) {
+		return xcel.CompileReadinessCheck(expr)
+	})
+}
+
 // ReadinessCheckType is used for readiness check types.
 type ReadinessCheckType string
 
@@ -57,6 +75,7 @@ const (
 	ReadinessCheckTypeMatchFalse     ReadinessCheckType = "MatchFalse"
 	ReadinessCheckTypeMatchCondition ReadinessCheckType = "MatchCondition"
 	ReadinessCheckTypeNone           ReadinessCheckType = "None"
+	ReadinessCheckTypeCEL            ReadinessCheckType = "CEL"
 )
 
 // ReadinessCheck is used to indicate how to tell whether a resource is ready
@@ -76,6 +95,9 @@ type ReadinessCheck struct {
 
 	// MatchCondition is the condition you'd like to match if you're using "MatchCondition" type.
 	MatchCondition *MatchConditionReadinessCheck
+
+	// Expression is the CEL expression you'd like to evaluate if you're using "CEL" type.
+	Expression *string
 }
 
 // MatchConditionReadinessCheck is used to indicate how to tell whether a resource is ready
@@ -109,6 +131,9 @@ func ReadinessCheckFromV1(in *v1.ReadinessCheck) ReadinessCheck {
 	if in.MatchInteger != 0 {
 		out.MatchInteger = ptr.To[int64](in.MatchInteger)
 	}
+	if in.Expression != "" {
+		out.Expression = ptr.To(in.Expression)
+	}
 	if in.MatchCondition != nil {
 		out.MatchCondition = &MatchConditionReadinessCheck{
 			Type:   in.MatchCondition.Type,
@@ -156,6 +181,12 @@ func (c ReadinessCheck) Validate() error {
 			return errors.Errorf(errFmtRequiresMatchConditions, c.Type)
 		}
 		return nil
+	case ReadinessCheckTypeCEL:
+		if c.Expression == nil {
+			return errors.Errorf(errFmtRequiresExpression, c.Type)
+		}
+		_, err := compileReadinessCheck(*c.Expression)
+		return err
 	default:
 		return errors.Errorf(errFmtUnknownCheck, c.Type)
 	}
@@ -209,11 +240,32 @@ func (c ReadinessCheck) IsReady(p *fieldpath.Paved, o ConditionedObject) (bool,
 			return false, resource.Ignore(fieldpath.IsNotFound, err)
 		}
 		return val == true, nil //nolint:gosimple // returning 'val' here as suggested hurts readability
+	case ReadinessCheckTypeCEL:
+		return c.isReadyCEL(p)
 	}
 
 	return false, nil
 }
 
+// isReadyCEL evaluates the readiness check's CEL expression against the
+// supplied object. An expression that can't be evaluated, for example because
+// it references a field that isn't set yet, is considered not ready.
+func (c ReadinessCheck) isReadyCEL(p *fieldpath.Paved) (bool, error) {
+	prg, err := compileReadinessCheck(*c.Expression)
+	if err != nil {
+		return false, err
+	}
+	out, _, err := prg.Eval(map[string]any{"object": p.UnstructuredContent()})
+	if err != nil {
+		return false, nil //nolint:nilerr // An expression we can't evaluate yet isn't an error.
+	}
+	ready, ok := out.Value().(bool)
+	if !ok {
+		return false, errors.Errorf(errFmtNotBoolean, out.Value())
+	}
+	return ready, nil
+}
+
 // A ReadinessChecker checks whether a composed resource is ready or not.
 type ReadinessChecker interface {
 	IsReady(ctx context.Context, o ConditionedObject, rc ...ReadinessCheck) (ready bool, err error)
@@ -255,3 +307,40 @@ func IsReady(_ context.Context, o ConditionedObject, rc ...ReadinessCheck) (bool
 	}
 	return true, nil
 }
+
+// IsReadyWithGracePeriod returns whether a composed resource that is not ready
+// should still be considered ready, because it was ready and hasn't yet been
+// not ready for longer than the supplied grace period. The composite resource
+// was ready, and thus so was the composed resource, if its Ready condition is
+// true. The time the composed resource was first observed to be not ready is
+// recorded using an annotation, which is removed once it's ready again. It
+// returns true if it changed the composed resource's annotations. If the
+// composed resource is only considered ready because it's within its grace
+// period it also returns how much of the grace period remains.
+func IsReadyWithGracePeriod(xr resource.Conditioned, cd resource.Object, ready bool, grace time.Duration, now time.Time) (bool, bool, time.Duration) {
+	since, tracked := cd.GetAnnotations()[AnnotationKeyNotReadySince]
+
+	if ready {
+		if tracked {
+			meta.RemoveAnnotations(cd, AnnotationKeyNotReadySince)
+		}
+		return true, tracked, 0
+	}
+
+	if !tracked {
+		if !resource.IsConditionTrue(xr.GetCondition(xpv1.TypeReady)) {
+			return false, false, 0
+		}
+		meta.AddAnnotations(cd, map[string]string{AnnotationKeyNotReadySince: now.UTC().Format(time.RFC3339)})
+		return grace > 0, true, grace
+	}
+
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return false, false, 0
+	}
+	if remaining := grace - now.Sub(t); remaining > 0 {
+		return true, false, remaining
+	}
+	return false, false, 0
+}
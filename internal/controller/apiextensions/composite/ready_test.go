@@ -19,15 +19,19 @@ package composite
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 )
 
@@ -395,6 +399,83 @@ func TestIsReady(t *testing.T) {
 				ready: false,
 			},
 		},
+		"CELMissingExpression": {
+			reason: "If the CEL type is chosen without an expression, it should return an error",
+			args: args{
+				o:  composed.New(),
+				rc: []ReadinessCheck{{Type: ReadinessCheckTypeCEL}},
+			},
+			want: want{
+				err: errors.Wrapf(errors.Wrap(errors.Errorf(errFmtRequiresExpression, ReadinessCheckTypeCEL), errInvalidCheck), errFmtRunCheck, 0),
+			},
+		},
+		"CELMissingField": {
+			reason: "If the CEL expression references a field that isn't set, it should return false",
+			args: args{
+				o: composed.New(func(r *composed.Unstructured) {
+					r.Object = map[string]any{
+						"spec": map[string]any{
+							"replicas": 3,
+						},
+					}
+				}),
+				rc: []ReadinessCheck{{
+					Type:       ReadinessCheckTypeCEL,
+					Expression: ptr.To("object.spec.replicas == object.status.readyReplicas"),
+				}},
+			},
+			want: want{
+				ready: false,
+			},
+		},
+		"CELReady": {
+			reason: "If the CEL expression evaluates to true, it should return true",
+			args: args{
+				o: composed.New(func(r *composed.Unstructured) {
+					r.Object = map[string]any{
+						"metadata": map[string]any{
+							"generation": 2,
+						},
+						"spec": map[string]any{
+							"replicas": 3,
+						},
+						"status": map[string]any{
+							"readyReplicas":      3,
+							"observedGeneration": 2,
+						},
+					}
+				}),
+				rc: []ReadinessCheck{{
+					Type:       ReadinessCheckTypeCEL,
+					Expression: ptr.To("object.spec.replicas == object.status.readyReplicas && object.status.observedGeneration == object.metadata.generation"),
+				}},
+			},
+			want: want{
+				ready: true,
+			},
+		},
+		"CELNotReady": {
+			reason: "If the CEL expression evaluates to false, it should return false",
+			args: args{
+				o: composed.New(func(r *composed.Unstructured) {
+					r.Object = map[string]any{
+						"spec": map[string]any{
+							"replicas": 3,
+						},
+						"status": map[string]any{
+							"readyReplicas": 1,
+						},
+					}
+				}),
+				rc: []ReadinessCheck{{
+					Type:       ReadinessCheckTypeCEL,
+					Expression: ptr.To("object.spec.replicas == object.status.readyReplicas"),
+				}},
+			},
+			want: want{
+				ready: false,
+			},
+		},
 		"UnknownType": {
 			reason: "If unknown type is chosen, it should return an error",
 			args: args{
@@ -418,3 +499,122 @@ func TestIsReady(t *testing.T) {
 		})
 	}
 }
+
+func TestIsReadyWithGracePeriod(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	since := func(d time.Duration) map[string]string {
+		return map[string]string{AnnotationKeyNotReadySince: now.Add(-d).Format(time.RFC3339)}
+	}
+
+	type args struct {
+		xr    resource.Conditioned
+		cd    *composed.Unstructured
+		ready bool
+		grace time.Duration
+	}
+	type want struct {
+		ready       bool
+		changed     bool
+		remaining   time.Duration
+		annotations map[string]string
+	}
+	cases := map[string]struct {
+		reason string
+		args
+		want
+	}{
+		"Ready": {
+			reason: "A ready composed resource should be ready.",
+			args: args{
+				xr:    composite.New(),
+				cd:    composed.New(),
+				ready: true,
+				grace: time.Minute,
+			},
+			want: want{
+				ready: true,
+			},
+		},
+		"ReadyAgain": {
+			reason: "A composed resource that is ready again should stop tracking when it became not ready.",
+			args: args{
+				xr:    composite.New(),
+				cd:    composed.New(func(cd *composed.Unstructured) { cd.SetAnnotations(since(time.Second)) }),
+				ready: true,
+				grace: time.Minute,
+			},
+			want: want{
+				ready:       true,
+				changed:     true,
+				annotations: map[string]string{},
+			},
+		},
+		"NeverReady": {
+			reason: "A composed resource of an XR that isn't ready shouldn't get a grace period.",
+			args: args{
+				xr:    composite.New(composite.WithConditions(xpv1.Creating())),
+				cd:    composed.New(),
+				grace: time.Minute,
+			},
+			want: want{
+				ready: false,
+			},
+		},
+		"BecameNotReady": {
+			reason: "A composed resource of a ready XR that became not ready should start its grace period.",
+			args: args{
+				xr:    composite.New(composite.WithConditions(xpv1.Available())),
+				cd:    composed.New(),
+				grace: time.Minute,
+			},
+			want: want{
+				ready:       true,
+				changed:     true,
+				remaining:   time.Minute,
+				annotations: since(0),
+			},
+		},
+		"WithinGracePeriod": {
+			reason: "A composed resource that hasn't been not ready for longer than its grace period should be ready.",
+			args: args{
+				xr:    composite.New(composite.WithConditions(xpv1.Available())),
+				cd:    composed.New(func(cd *composed.Unstructured) { cd.SetAnnotations(since(time.Second)) }),
+				grace: time.Minute,
+			},
+			want: want{
+				ready:       true,
+				remaining:   time.Minute - time.Second,
+				annotations: since(time.Second),
+			},
+		},
+		"GracePeriodExpired": {
+			reason: "A composed resource that has been not ready for longer than its grace period should not be ready.",
+			args: args{
+				xr:    composite.New(composite.WithConditions(xpv1.Available())),
+				cd:    composed.New(func(cd *composed.Unstructured) { cd.SetAnnotations(since(2 * time.Minute)) }),
+				grace: time.Minute,
+			},
+			want: want{
+				ready:       false,
+				annotations: since(2 * time.Minute),
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ready, changed, remaining := IsReadyWithGracePeriod(tc.args.xr, tc.args.cd, tc.args.ready, tc.args.grace, now)
+			if diff := cmp.Diff(tc.want.ready, ready); diff != "" {
+				t.Errorf("\n%s\nIsReadyWithGracePeriod(...): -want ready, +got ready:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.changed, changed); diff != "" {
+				t.Errorf("\n%s\nIsReadyWithGracePeriod(...): -want changed, +got changed:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.remaining, remaining); diff != "" {
+				t.Errorf("\n%s\nIsReadyWithGracePeriod(...): -want remaining, +got remaining:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.annotations, tc.args.cd.GetAnnotations(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nIsReadyWithGracePeriod(...): -want annotations, +got annotations:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
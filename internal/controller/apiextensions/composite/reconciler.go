@@ -72,6 +72,7 @@ const (
 	errSelectEnvironment      = "cannot select environment"
 	errCompose                = "cannot compose resources"
 	errRenderCD               = "cannot render composed resource"
+	errSetClaimConditionTypes = "cannot set the types of conditions set by the composition process"
 
 	reconcilePausedMsg = "Reconciliation (including deletion) is paused via the pause annotation"
 )
@@ -181,11 +182,38 @@ type CompositionRequest struct {
 	Environment *Environment
 }
 
+// A CompositionTarget is the target of a composition condition.
+type CompositionTarget string
+
+// Composition condition targets.
+const (
+	// CompositionTargetComposite targets only the composite resource.
+	CompositionTargetComposite CompositionTarget = "Composite"
+
+	// CompositionTargetCompositeAndClaim targets both the composite resource
+	// and its claim, if it has one.
+	CompositionTargetCompositeAndClaim CompositionTarget = "CompositeAndClaim"
+)
+
+// A TargetedCondition is a status condition produced by the composition
+// process. It targets either the composite resource, or both the composite
+// resource and its claim.
+type TargetedCondition struct {
+	xpv1.Condition
+	Target CompositionTarget
+}
+
 // A CompositionResult is the result of the composition process.
 type CompositionResult struct {
 	Composed          []ComposedResource
 	ConnectionDetails managed.ConnectionDetails
 	Events            []event.Event
+	Conditions        []TargetedCondition
+
+	// RequeueAfter is how soon the composite resource should be composed
+	// again, for example because a composed resource's readiness grace period
+	// will expire. Zero means the composer has no preference.
+	RequeueAfter time.Duration
 }
 
 // A Composer composes (i.e. creates, updates, or deletes) resources given the
@@ -658,6 +686,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		}
 	}
 
+	if err := setCompositionConditions(xr, res.Conditions, log); err != nil {
+		log.Debug(errSetClaimConditionTypes, "error", err)
+		err = errors.Wrap(err, errSetClaimConditionTypes)
+		r.record.Event(xr, event.Warning(reasonCompose, err))
+		xr.SetConditions(xpv1.ReconcileError(err))
+		return reconcile.Result{Requeue: true}, errors.Wrap(r.client.Status().Update(ctx, xr), errUpdateStatus)
+	}
+
 	xr.SetConditions(xpv1.ReconcileSuccess())
 
 	// TODO(muvaf): If a resource becomes Unavailable at some point, should we
@@ -677,9 +713,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	// We requeue after our poll interval because we can't watch composed
 	// resources - we can't know what type of resources we might compose
-	// when this controller is started.
+	// when this controller is started. We requeue sooner if the composer
+	// asked us to, e.g. to notice a readiness grace period expiring.
+	after := r.pollInterval
+	if res.RequeueAfter > 0 && (after == 0 || res.RequeueAfter < after) {
+		after = res.RequeueAfter
+	}
 	xr.SetConditions(xpv1.Available())
-	return reconcile.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.client.Status().Update(ctx, xr), errUpdateStatus)
+	return reconcile.Result{RequeueAfter: after}, errors.Wrap(r.client.Status().Update(ctx, xr), errUpdateStatus)
 }
 
 // EnqueueForCompositionRevisionFunc returns a function that enqueues (the
@@ -729,3 +770,48 @@ func EnqueueForCompositionRevisionFunc(of resource.CompositeKind, list func(ctx
 		}
 	}
 }
+
+// setCompositionConditions sets the conditions produced by the composition
+// process on the supplied composite resource, and records which of them should
+// be propagated to its claim. Conditions that a previous composition set but
+// this one didn't are removed.
+func setCompositionConditions(xr *composite.Unstructured, conds []TargetedCondition, log logging.Logger) error {
+	prev, err := GetCompositionConditionTypes(xr)
+	if err != nil {
+		return err
+	}
+
+	xrTypes := make([]xpv1.ConditionType, 0, len(conds))
+	claimTypes := make([]xpv1.ConditionType, 0, len(conds))
+	set := make(map[xpv1.ConditionType]bool, len(conds))
+	claim := make(map[xpv1.ConditionType]bool, len(conds))
+	for _, c := range conds {
+		// Crossplane owns these conditions. Functions may not set them.
+		if c.Type == xpv1.TypeReady || c.Type == xpv1.TypeSynced {
+			log.Debug("Ignoring composition condition of a reserved type", "type", c.Type)
+			continue
+		}
+		xr.SetConditions(c.Condition)
+		if !set[c.Type] {
+			set[c.Type] = true
+			xrTypes = append(xrTypes, c.Type)
+		}
+		if c.Target == CompositionTargetCompositeAndClaim && !claim[c.Type] {
+			claim[c.Type] = true
+			claimTypes = append(claimTypes, c.Type)
+		}
+	}
+
+	stale := make([]xpv1.ConditionType, 0, len(prev))
+	for _, t := range prev {
+		if !set[t] && t != xpv1.TypeReady && t != xpv1.TypeSynced {
+			stale = append(stale, t)
+		}
+	}
+	RemoveConditions(xr, stale...)
+
+	if err := SetCompositionConditionTypes(xr, xrTypes...); err != nil {
+		return err
+	}
+	return SetClaimConditionTypes(xr, claimTypes...)
+}
@@ -592,6 +592,134 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{RequeueAfter: defaultPollInterval},
 			},
 		},
+		"ComposedResourcesReadyWithConditions": {
+			reason: "We should set any conditions the Composer returned, except reserved types, and record which of them target the claim.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithClient(&test.MockClient{
+						MockGet: test.NewMockGetFn(nil),
+						MockStatusUpdate: WantComposite(t, NewComposite(func(cr resource.Composite) {
+							cr.SetCompositionReference(&corev1.ObjectReference{})
+							cr.SetConditions(
+								xpv1.Condition{Type: "DatabaseReady", Status: corev1.ConditionFalse, Reason: "QuotaExceeded", Message: "The database quota was exceeded", LastTransitionTime: now},
+								xpv1.Condition{Type: "InternalCheck", Status: corev1.ConditionTrue, Reason: "Checked", LastTransitionTime: now},
+								xpv1.ReconcileSuccess(),
+								xpv1.Available(),
+							)
+							cr.SetConnectionDetailsLastPublishedTime(&now)
+							_ = SetCompositionConditionTypes(cr.(*composite.Unstructured), "DatabaseReady", "InternalCheck")
+							_ = SetClaimConditionTypes(cr.(*composite.Unstructured), "DatabaseReady")
+						})),
+					}),
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						c := &v1.CompositionRevision{Spec: v1.CompositionRevisionSpec{
+							Resources: []v1.ComposedTemplate{{}},
+						}}
+						return c, nil
+					})),
+					WithCompositionRevisionValidator(CompositionRevisionValidatorFn(func(_ *v1.CompositionRevision) error { return nil })),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(ctx context.Context, xr *composite.Unstructured, req CompositionRequest) (CompositionResult, error) {
+						return CompositionResult{
+							ConnectionDetails: cd,
+							Conditions: []TargetedCondition{
+								{
+									Condition: xpv1.Condition{Type: "DatabaseReady", Status: corev1.ConditionFalse, Reason: "QuotaExceeded", Message: "The database quota was exceeded", LastTransitionTime: now},
+									Target:    CompositionTargetCompositeAndClaim,
+								},
+								{
+									Condition: xpv1.Condition{Type: "InternalCheck", Status: corev1.ConditionTrue, Reason: "Checked", LastTransitionTime: now},
+									Target:    CompositionTargetComposite,
+								},
+								{
+									// Functions may not set reserved conditions.
+									Condition: xpv1.Condition{Type: xpv1.TypeReady, Status: corev1.ConditionFalse, Reason: "Sabotage", LastTransitionTime: now},
+									Target:    CompositionTargetCompositeAndClaim,
+								},
+							},
+						}, nil
+					})),
+					WithConnectionPublishers(managed.ConnectionPublisherFns{
+						PublishConnectionFn: func(ctx context.Context, o resource.ConnectionSecretOwner, got managed.ConnectionDetails) (published bool, err error) {
+							return true, nil
+						},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: defaultPollInterval},
+			},
+		},
+		"ComposedResourcesRemoveStaleConditions": {
+			reason: "We should remove any conditions a previous composition set that the Composer no longer returns.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithClient(&test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							xr := obj.(*composite.Unstructured)
+							xr.SetConditions(xpv1.Condition{Type: "OldCheck", Status: corev1.ConditionFalse, Reason: "Stale", LastTransitionTime: now})
+							_ = SetCompositionConditionTypes(xr, "OldCheck", "DatabaseReady")
+							_ = SetClaimConditionTypes(xr, "OldCheck", "DatabaseReady")
+							return nil
+						}),
+						MockStatusUpdate: WantComposite(t, NewComposite(func(cr resource.Composite) {
+							cr.SetCompositionReference(&corev1.ObjectReference{})
+							cr.SetConditions(
+								xpv1.Condition{Type: "DatabaseReady", Status: corev1.ConditionFalse, Reason: "QuotaExceeded", Message: "The database quota was exceeded", LastTransitionTime: now},
+								xpv1.ReconcileSuccess(),
+								xpv1.Available(),
+							)
+							cr.SetConnectionDetailsLastPublishedTime(&now)
+							_ = SetCompositionConditionTypes(cr.(*composite.Unstructured), "DatabaseReady")
+							_ = SetClaimConditionTypes(cr.(*composite.Unstructured), "DatabaseReady")
+						})),
+					}),
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						c := &v1.CompositionRevision{Spec: v1.CompositionRevisionSpec{
+							Resources: []v1.ComposedTemplate{{}},
+						}}
+						return c, nil
+					})),
+					WithCompositionRevisionValidator(CompositionRevisionValidatorFn(func(_ *v1.CompositionRevision) error { return nil })),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(ctx context.Context, xr *composite.Unstructured, req CompositionRequest) (CompositionResult, error) {
+						return CompositionResult{
+							ConnectionDetails: cd,
+							Conditions: []TargetedCondition{
+								{
+									Condition: xpv1.Condition{Type: "DatabaseReady", Status: corev1.ConditionFalse, Reason: "QuotaExceeded", Message: "The database quota was exceeded", LastTransitionTime: now},
+									Target:    CompositionTargetCompositeAndClaim,
+								},
+							},
+						}, nil
+					})),
+					WithConnectionPublishers(managed.ConnectionPublisherFns{
+						PublishConnectionFn: func(ctx context.Context, o resource.ConnectionSecretOwner, got managed.ConnectionDetails) (published bool, err error) {
+							return true, nil
+						},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: defaultPollInterval},
+			},
+		},
 		"ReconciliationPausedSuccessful": {
 			reason: `If a composite resource has the pause annotation with value "true", there should be no further requeue requests.`,
 			args: args{
@@ -675,6 +803,51 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{RequeueAfter: defaultPollInterval},
 			},
 		},
+		"RequeueWhenComposerAsks": {
+			reason: "We should requeue sooner than our poll interval if the composer asks us to, e.g. because a readiness grace period will expire.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithClient(&test.MockClient{
+						MockGet: WithComposite(t, NewComposite()),
+						MockStatusUpdate: WantComposite(t, NewComposite(func(cr resource.Composite) {
+							cr.SetConditions(xpv1.ReconcileSuccess(), xpv1.Available())
+							cr.SetConnectionDetailsLastPublishedTime(&now)
+							cr.SetCompositionReference(&corev1.ObjectReference{})
+						})),
+					}),
+					WithCompositeFinalizer(resource.NewNopFinalizer()),
+					WithCompositionSelector(CompositionSelectorFn(func(_ context.Context, cr resource.Composite) error {
+						cr.SetCompositionReference(&corev1.ObjectReference{})
+						return nil
+					})),
+					WithCompositionRevisionFetcher(CompositionRevisionFetcherFn(func(_ context.Context, _ resource.Composite) (*v1.CompositionRevision, error) {
+						c := &v1.CompositionRevision{Spec: v1.CompositionRevisionSpec{
+							Resources: []v1.ComposedTemplate{{}},
+						}}
+						return c, nil
+					})),
+					WithCompositionRevisionValidator(CompositionRevisionValidatorFn(func(_ *v1.CompositionRevision) error { return nil })),
+					WithConfigurator(ConfiguratorFn(func(_ context.Context, _ resource.Composite, _ *v1.CompositionRevision) error {
+						return nil
+					})),
+					WithComposer(ComposerFn(func(_ context.Context, _ *composite.Unstructured, _ CompositionRequest) (CompositionResult, error) {
+						return CompositionResult{
+							Composed:     []ComposedResource{{ResourceName: "cool-resource", Ready: true}},
+							RequeueAfter: 5 * time.Second,
+						}, nil
+					})),
+					WithConnectionPublishers(managed.ConnectionPublisherFns{
+						PublishConnectionFn: func(_ context.Context, _ resource.ConnectionSecretOwner, _ managed.ConnectionDetails) (published bool, err error) {
+							return true, nil
+						},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: 5 * time.Second},
+			},
+		},
 		"ReconciliationResumesAfterAnnotationRemoval": {
 			reason: `If a composite resource has the pause annotation removed and the Synced=False/ReconcilePaused status condition, reconciliation should resume with requeueing.`,
 			args: args{
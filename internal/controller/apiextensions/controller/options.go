@@ -18,6 +18,8 @@ limitations under the License.
 package controller
 
 import (
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 
 	"github.com/crossplane/crossplane/internal/xfn"
@@ -36,5 +38,10 @@ type Options struct {
 	ServiceAccount string
 
 	// FunctionRunner used to run Composition Functions.
-	FunctionRunner *xfn.PackagedFunctionRunner
+	FunctionRunner xfn.FunctionRunner
+
+	// ConversionWebhook identifies Crossplane's webhook server. If set, the
+	// CRDs of XRDs that declare conversions are configured to be converted
+	// by Crossplane.
+	ConversionWebhook *extv1.WebhookClientConfig
 }
@@ -124,9 +124,22 @@ func (fn CRDRenderFn) Render(d *v1.CompositeResourceDefinition) (*extv1.CustomRe
 func Setup(mgr ctrl.Manager, o apiextensionscontroller.Options) error {
 	name := "defined/" + strings.ToLower(v1.CompositeResourceDefinitionGroupKind)
 
-	r := NewReconciler(mgr, o,
+	ro := []ReconcilerOption{
 		WithLogger(o.Logger.WithValues("controller", name)),
-		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+	}
+	if cc := o.ConversionWebhook; cc != nil {
+		ro = append(ro, WithCRDRenderer(CRDRenderFn(func(d *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+			crd, err := xcrd.ForCompositeResource(d)
+			if err != nil {
+				return nil, err
+			}
+			xcrd.SetConversionWebhook(crd, d, *cc)
+			return crd, nil
+		})))
+	}
+
+	r := NewReconciler(mgr, o, ro...)
 
 	if o.Features.Enabled(features.EnableRealtimeCompositions) {
 		// Register a runnable regularly checking whether the watch composed
@@ -539,6 +552,15 @@ func CompositeReconcilerOptions(co apiextensionscontroller.Options, d *v1.Compos
 			composite.WithEnvironmentFetcher(composite.NewAPIEnvironmentFetcher(c)))
 	}
 
+	// We only want to honor Composition rollout strategies if the relevant
+	// feature flag is enabled. Otherwise composite resources with an
+	// Automatic update policy always use the latest revision.
+	if co.Features.Enabled(features.EnableAlphaCompositionRollouts) {
+		o = append(o, composite.WithCompositionRevisionFetcher(composite.NewAPIRevisionFetcher(
+			resource.ClientApplicator{Client: c, Applicator: resource.NewAPIPatchingApplicator(c)},
+			composite.WithRollouts())))
+	}
+
 	// If external secret stores aren't enabled we just fetch connection details
 	// from Kubernetes secrets.
 	var fetcher managed.ConnectionDetailsFetcher = composite.NewSecretConnectionDetailsFetcher(c)
@@ -115,10 +115,23 @@ func (fn CRDRenderFn) Render(d *v1.CompositeResourceDefinition) (*extv1.CustomRe
 func Setup(mgr ctrl.Manager, o apiextensionscontroller.Options) error {
 	name := "offered/" + strings.ToLower(v1.CompositeResourceDefinitionGroupKind)
 
-	r := NewReconciler(mgr,
+	ro := []ReconcilerOption{
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		WithOptions(o))
+		WithOptions(o),
+	}
+	if cc := o.ConversionWebhook; cc != nil {
+		ro = append(ro, WithCRDRenderer(CRDRenderFn(func(d *v1.CompositeResourceDefinition) (*extv1.CustomResourceDefinition, error) {
+			crd, err := xcrd.ForCompositeResourceClaim(d)
+			if err != nil {
+				return nil, err
+			}
+			xcrd.SetConversionWebhook(crd, d, *cc)
+			return crd, nil
+		})))
+	}
+
+	r := NewReconciler(mgr, ro...)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
@@ -0,0 +1,445 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rollout progressively rolls out new composition revisions to
+// composite resources.
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/controller"
+)
+
+const (
+	timeout = 2 * time.Minute
+
+	defaultPollInterval = 1 * time.Minute
+
+	defaultProgressDeadline = 10 * time.Minute
+)
+
+// Error strings
+const (
+	errGet              = "cannot get Composition"
+	errListRevs         = "cannot list CompositionRevisions"
+	errListXRs          = "cannot list composite resources"
+	errBatchSize        = "cannot determine rollout batch size"
+	errFailureThreshold = "cannot determine rollout failure threshold"
+	errFmtMoveXR        = "cannot move composite resource %q to CompositionRevision %q"
+	errUpdateStatus     = "cannot update Composition status"
+)
+
+// Event reasons.
+const (
+	reasonRollout event.Reason = "RolloutRevision"
+)
+
+// Setup adds a controller that reconciles Compositions by progressively
+// rolling out their latest CompositionRevision to the composite resources that
+// use them.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := "rollout/" + strings.ToLower(v1.CompositionGroupKind)
+
+	r := NewReconciler(mgr,
+		WithLogger(o.Logger.WithValues("controller", name)),
+		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		WithPollInterval(o.PollInterval))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1.Composition{}).
+		Owns(&v1.CompositionRevision{}).
+		WithOptions(o.ForControllerRuntime()).
+		Complete(ratelimiter.NewReconciler(name, errors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
+}
+
+// ReconcilerOption is used to configure the Reconciler.
+type ReconcilerOption func(*Reconciler)
+
+// WithLogger specifies how the Reconciler should log messages.
+func WithLogger(log logging.Logger) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.log = log
+	}
+}
+
+// WithRecorder specifies how the Reconciler should record Kubernetes events.
+func WithRecorder(er event.Recorder) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.record = er
+	}
+}
+
+// WithPollInterval specifies how often the Reconciler should check on the
+// progress of a rollout. Composite resources aren't watched, so this
+// determines how quickly a rollout proceeds once a batch of composite
+// resources becomes Ready.
+func WithPollInterval(after time.Duration) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.pollInterval = after
+	}
+}
+
+// NewReconciler returns a Reconciler of Compositions.
+func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
+	kube := unstructured.NewClient(mgr.GetClient())
+
+	r := &Reconciler{
+		client:       kube,
+		log:          logging.NewNopLogger(),
+		record:       event.NewNopRecorder(),
+		pollInterval: defaultPollInterval,
+		now:          time.Now,
+	}
+
+	for _, f := range opts {
+		f(r)
+	}
+	return r
+}
+
+// A Reconciler reconciles Compositions by progressively rolling out their
+// latest CompositionRevision to the composite resources that use them.
+type Reconciler struct {
+	client client.Client
+
+	log    logging.Logger
+	record event.Recorder
+
+	pollInterval time.Duration
+	now          func() time.Time
+}
+
+// Reconcile a Composition.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) { //nolint:gocyclo // Only slightly over (10).
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	comp := &v1.Composition{}
+	if err := r.client.Get(ctx, req.NamespacedName, comp); err != nil {
+		log.Debug(errGet, "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGet)
+	}
+
+	if meta.WasDeleted(comp) {
+		return reconcile.Result{}, nil
+	}
+
+	if comp.Spec.Rollout == nil {
+		// Composite resources are moved to new revisions as soon as they're
+		// created. There's no rollout to report.
+		if comp.Status.Rollout == nil {
+			return reconcile.Result{}, nil
+		}
+		comp.Status.Rollout = nil
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, comp), errUpdateStatus)
+	}
+
+	rl := &v1.CompositionRevisionList{}
+	if err := r.client.List(ctx, rl, client.MatchingLabels{v1.LabelCompositionName: comp.GetName()}); err != nil {
+		log.Debug(errListRevs, "error", err)
+		r.record.Event(comp, event.Warning(reasonRollout, errors.Wrap(err, errListRevs)))
+		return reconcile.Result{}, errors.Wrap(err, errListRevs)
+	}
+
+	latest := v1.LatestRevision(comp, rl.Items)
+	if latest == nil {
+		// We'll be queued again when the first revision is created.
+		return reconcile.Result{}, nil
+	}
+
+	xrs, err := r.getCompositeResources(ctx, comp)
+	if err != nil {
+		log.Debug(errListXRs, "error", err)
+		r.record.Event(comp, event.Warning(reasonRollout, errors.Wrap(err, errListXRs)))
+		return reconcile.Result{}, errors.Wrap(err, errListXRs)
+	}
+
+	was := comp.Status.Rollout.DeepCopy()
+	s := newStatus(was, comp.Spec.Rollout.Retry, latest, rl.Items)
+	log = log.WithValues("revision", s.Revision, "previous-revision", s.PreviousRevision)
+
+	if retry(comp.Spec.Rollout, &s, r.now()) {
+		log.Debug("Retrying rollout", "retry", s.LastRetry)
+	}
+
+	if err := r.rollout(ctx, comp.Spec.Rollout, &s, xrs); err != nil {
+		log.Debug("Cannot roll out revision", "error", err)
+		r.record.Event(comp, event.Warning(reasonRollout, err))
+		return reconcile.Result{}, err
+	}
+
+	if was == nil || was.Phase != s.Phase {
+		log.Debug("Rollout phase changed", "phase", s.Phase)
+		e := event.Normal(reasonRollout, s.Message, "revision", s.Revision)
+		if s.Phase == v1.RolloutPhasePaused || s.Phase == v1.RolloutPhaseRolledBack {
+			e = event.Warning(reasonRollout, errors.New(s.Message), "revision", s.Revision)
+		}
+		r.record.Event(comp, e)
+	}
+
+	if cmp.Equal(was, &s) {
+		return reconcile.Result{RequeueAfter: r.pollInterval}, nil
+	}
+
+	comp.Status.Rollout = &s
+	return reconcile.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.client.Status().Update(ctx, comp), errUpdateStatus)
+}
+
+// getCompositeResources returns the composite resources that use the supplied
+// Composition and are rolled out progressively, sorted by name.
+func (r *Reconciler) getCompositeResources(ctx context.Context, comp *v1.Composition) ([]*composite.Unstructured, error) {
+	l := &kunstructured.UnstructuredList{}
+	l.SetGroupVersionKind(schema.FromAPIVersionAndKind(comp.Spec.CompositeTypeRef.APIVersion, comp.Spec.CompositeTypeRef.Kind+"List"))
+	if err := r.client.List(ctx, l); err != nil {
+		return nil, err
+	}
+
+	xrs := make([]*composite.Unstructured, 0, len(l.Items))
+	for i := range l.Items {
+		xr := &composite.Unstructured{Unstructured: l.Items[i]}
+		if !IsRolledOut(xr, comp) {
+			continue
+		}
+		xrs = append(xrs, xr)
+	}
+
+	sort.Slice(xrs, func(i, j int) bool { return xrs[i].GetName() < xrs[j].GetName() })
+	return xrs, nil
+}
+
+// IsRolledOut returns true if new revisions of the supplied Composition are
+// progressively rolled out to the supplied composite resource. This is the
+// case for composite resources that use the Composition and have an
+// Automatic composition update policy, unless they select revisions using a
+// composition revision selector.
+func IsRolledOut(xr resource.Composite, comp *v1.Composition) bool {
+	if meta.WasDeleted(xr) {
+		return false
+	}
+	if ref := xr.GetCompositionReference(); ref == nil || ref.Name != comp.GetName() {
+		return false
+	}
+	if p := xr.GetCompositionUpdatePolicy(); p != nil && *p == xpv1.UpdateManual {
+		return false
+	}
+	return xr.GetCompositionRevisionSelector() == nil
+}
+
+// newStatus returns the status of the rollout of the supplied latest revision.
+// A new rollout starts when a new revision is created. It rolls back to the
+// last revision that was completely rolled out. The supplied retry value is
+// recorded as already handled, so a new rollout isn't retried until it
+// changes.
+func newStatus(s *v1.RolloutStatus, retry string, latest *v1.CompositionRevision, revs []v1.CompositionRevision) v1.RolloutStatus {
+	if s != nil && s.Revision == latest.GetName() {
+		return *s.DeepCopy()
+	}
+
+	ns := v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: latest.GetName(), LastRetry: retry}
+	switch {
+	case s != nil && s.Phase == v1.RolloutPhaseComplete:
+		ns.PreviousRevision = s.Revision
+	case s != nil:
+		ns.PreviousRevision = s.PreviousRevision
+	default:
+		// We don't know which revision was last completely rolled out, so we
+		// assume it's the one before the latest.
+		var prev *v1.CompositionRevision
+		for i := range revs {
+			rev := &revs[i]
+			if rev.Spec.Revision >= latest.Spec.Revision {
+				continue
+			}
+			if prev == nil || rev.Spec.Revision > prev.Spec.Revision {
+				prev = rev
+			}
+		}
+		if prev != nil {
+			ns.PreviousRevision = prev.GetName()
+		}
+	}
+	return ns
+}
+
+// retry the supplied failed rollout if the supplied strategy's retry value
+// changed since it was last retried. It returns true if the rollout was
+// retried.
+func retry(rs *v1.RolloutStrategy, s *v1.RolloutStatus, now time.Time) bool {
+	if s.Phase != v1.RolloutPhasePaused && s.Phase != v1.RolloutPhaseRolledBack {
+		return false
+	}
+	if rs.Retry == "" || rs.Retry == s.LastRetry {
+		return false
+	}
+	s.Phase = v1.RolloutPhaseProgressing
+	s.LastRetry = rs.Retry
+	s.LastUpdateTime = &metav1.Time{Time: now}
+	return true
+}
+
+// rollout moves the supplied composite resources between revisions according
+// to the supplied strategy, and updates the supplied status to reflect the
+// progress of the rollout.
+func (r *Reconciler) rollout(ctx context.Context, rs *v1.RolloutStrategy, s *v1.RolloutStatus, xrs []*composite.Unstructured) error { //nolint:gocyclo // Only slightly over (10).
+	now := r.now()
+	if s.Phase == v1.RolloutPhaseProgressing && s.LastUpdateTime == nil {
+		s.LastUpdateTime = &metav1.Time{Time: now}
+	}
+
+	deadline := defaultProgressDeadline
+	if rs.ProgressDeadline != nil {
+		deadline = rs.ProgressDeadline.Duration
+	}
+	expired := s.LastUpdateTime != nil && now.Sub(s.LastUpdateTime.Time) > deadline
+
+	var updated, pending []*composite.Unstructured
+	var ready, failed int
+	for _, xr := range xrs {
+		if ref := xr.GetCompositionRevisionReference(); ref == nil || ref.Name != s.Revision {
+			pending = append(pending, xr)
+			continue
+		}
+		updated = append(updated, xr)
+		isReady := xr.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue
+		if isReady {
+			ready++
+		}
+		// An updated composite resource that isn't Ready within the progress
+		// deadline fails, even if it's Synced.
+		if xr.GetCondition(xpv1.TypeSynced).Status == corev1.ConditionFalse || (!isReady && expired) {
+			failed++
+		}
+	}
+
+	s.Total = int64(len(xrs))
+	s.Updated = int64(len(updated))
+	s.Ready = int64(ready)
+	s.Failed = int64(failed)
+
+	threshold := intstr.FromInt32(0)
+	if rs.FailureThreshold != nil {
+		threshold = *rs.FailureThreshold
+	}
+	maxFailed, err := intstr.GetScaledValueFromIntOrPercent(&threshold, len(updated), false)
+	if err != nil {
+		return errors.Wrap(err, errFailureThreshold)
+	}
+
+	// Failures only fail a rollout that's progressing. Once it's complete a
+	// failing composite resource is no reason to move the others.
+	if failed > maxFailed && s.Phase == v1.RolloutPhaseProgressing {
+		s.Phase = v1.RolloutPhasePaused
+		if rs.FailurePolicy != nil && *rs.FailurePolicy == v1.RolloutFailurePolicyRollback && s.PreviousRevision != "" {
+			s.Phase = v1.RolloutPhaseRolledBack
+		}
+		s.Message = fmt.Sprintf("%d of %d updated composite resources failed", failed, len(updated))
+	}
+
+	switch s.Phase {
+	case v1.RolloutPhasePaused:
+		// Leave composite resources where they are until a new revision is
+		// created, or the rollout is retried.
+		return nil
+	case v1.RolloutPhaseRolledBack:
+		for _, xr := range updated {
+			if err := r.move(ctx, xr, s.PreviousRevision); err != nil {
+				return err
+			}
+		}
+		s.Updated, s.Ready, s.Failed = 0, 0, 0
+		return nil
+	case v1.RolloutPhaseComplete:
+		if len(pending) == 0 {
+			return nil
+		}
+		// Composite resources that weren't rolled out yet, for example
+		// because their composition update policy changed, are moved in
+		// batches too.
+		s.Phase = v1.RolloutPhaseProgressing
+		s.LastUpdateTime = &metav1.Time{Time: now}
+	case v1.RolloutPhaseProgressing:
+	}
+
+	if ready < len(updated) {
+		s.Message = fmt.Sprintf("Waiting for %d updated composite resources to become Ready", len(updated)-ready)
+		return nil
+	}
+
+	if len(pending) == 0 {
+		s.Phase = v1.RolloutPhaseComplete
+		s.Message = fmt.Sprintf("All %d composite resources were updated", len(xrs))
+		return nil
+	}
+
+	batch, err := intstr.GetScaledValueFromIntOrPercent(&rs.BatchSize, len(xrs), true)
+	if err != nil {
+		return errors.Wrap(err, errBatchSize)
+	}
+	if batch < 1 {
+		batch = 1
+	}
+	if batch > len(pending) {
+		batch = len(pending)
+	}
+
+	for _, xr := range pending[:batch] {
+		if err := r.move(ctx, xr, s.Revision); err != nil {
+			return err
+		}
+	}
+	s.Updated += int64(batch)
+	s.LastUpdateTime = &metav1.Time{Time: now}
+	s.Message = fmt.Sprintf("Updated %d of %d composite resources", s.Updated, s.Total)
+	return nil
+}
+
+// move the supplied composite resource to the supplied revision.
+func (r *Reconciler) move(ctx context.Context, xr *composite.Unstructured, rev string) error {
+	p := client.MergeFrom(xr.GetUnstructured().DeepCopy())
+	xr.SetCompositionRevisionReference(&corev1.ObjectReference{Name: rev})
+	return errors.Wrapf(r.client.Patch(ctx, xr, p), errFmtMoveXR, xr.GetName(), rev)
+}
@@ -0,0 +1,517 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/composite"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+)
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+	poll := 30 * time.Second
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	updatedAt := &metav1.Time{Time: now}
+	expiredAt := &metav1.Time{Time: now.Add(-11 * time.Minute)}
+
+	comp := func(rs *v1.RolloutStrategy, s *v1.RolloutStatus) *v1.Composition {
+		return &v1.Composition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cool-composition",
+				UID:  types.UID("no-you-uid"),
+			},
+			Spec: v1.CompositionSpec{
+				CompositeTypeRef: v1.TypeReference{APIVersion: "example.org/v1", Kind: "XCool"},
+				Rollout:          rs,
+			},
+			Status: v1.CompositionStatus{Rollout: s},
+		}
+	}
+
+	rev := func(name string, revision int64) v1.CompositionRevision {
+		return v1.CompositionRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				OwnerReferences: []metav1.OwnerReference{{
+					UID:        types.UID("no-you-uid"),
+					Controller: ptr.To(true),
+				}},
+			},
+			Spec: v1.CompositionRevisionSpec{Revision: revision},
+		}
+	}
+	revs := []v1.CompositionRevision{rev("cool-composition-old", 1), rev("cool-composition-new", 2)}
+
+	xr := func(name, rev string, c ...xpv1.Condition) kunstructured.Unstructured {
+		cp := composite.New(composite.WithConditions(c...))
+		cp.SetAPIVersion("example.org/v1")
+		cp.SetKind("XCool")
+		cp.SetName(name)
+		cp.SetCompositionReference(&corev1.ObjectReference{Name: "cool-composition"})
+		cp.SetCompositionRevisionReference(&corev1.ObjectReference{Name: rev})
+		return cp.Unstructured
+	}
+	manual := func(u kunstructured.Unstructured) kunstructured.Unstructured {
+		cp := &composite.Unstructured{Unstructured: u}
+		cp.SetCompositionUpdatePolicy(ptr.To(xpv1.UpdateManual))
+		return cp.Unstructured
+	}
+
+	batchOfOne := &v1.RolloutStrategy{BatchSize: intstr.FromInt32(1)}
+	rollback := &v1.RolloutStrategy{
+		BatchSize:     intstr.FromString("50%"),
+		FailurePolicy: ptr.To(v1.RolloutFailurePolicyRollback),
+	}
+	retried := &v1.RolloutStrategy{
+		BatchSize: intstr.FromInt32(1),
+		Retry:     "again",
+	}
+
+	type params struct {
+		comp *v1.Composition
+		revs []v1.CompositionRevision
+		xrs  []kunstructured.Unstructured
+	}
+	type want struct {
+		r      reconcile.Result
+		err    error
+		status *v1.RolloutStatus
+		moved  map[string]string
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"NoRolloutStrategy": {
+			reason: "We should not roll out revisions of a Composition without a rollout strategy.",
+			params: params{
+				comp: comp(nil, nil),
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"RolloutStrategyRemoved": {
+			reason: "We should clear the rollout status of a Composition whose rollout strategy was removed.",
+			params: params{
+				comp: comp(nil, &v1.RolloutStatus{Phase: v1.RolloutPhaseComplete, Revision: "cool-composition-new"}),
+			},
+			want: want{
+				r:      reconcile.Result{},
+				status: &v1.RolloutStatus{},
+			},
+		},
+		"FirstBatch": {
+			reason: "We should move the first batch of composite resources to a new revision.",
+			params: params{
+				comp: comp(batchOfOne, &v1.RolloutStatus{Phase: v1.RolloutPhaseComplete, Revision: "cool-composition-old", Total: 2, Updated: 2, Ready: 2}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("b", "cool-composition-old", xpv1.Available()),
+					xr("a", "cool-composition-old", xpv1.Available()),
+					manual(xr("c", "cool-composition-old", xpv1.Available())),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhaseProgressing,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          1,
+					LastUpdateTime:   updatedAt,
+					Message:          "Updated 1 of 2 composite resources",
+				},
+				moved: map[string]string{"a": "cool-composition-new"},
+			},
+		},
+		"WaitForReady": {
+			reason: "We should not move the next batch until the composite resources already moved are Ready.",
+			params: params{
+				comp: comp(batchOfOne, &v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old"}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.Creating()),
+					xr("b", "cool-composition-old", xpv1.Available()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhaseProgressing,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          1,
+					LastUpdateTime:   updatedAt,
+					Message:          "Waiting for 1 updated composite resources to become Ready",
+				},
+			},
+		},
+		"Complete": {
+			reason: "The rollout should be complete once every composite resource was moved to the new revision and is Ready.",
+			params: params{
+				comp: comp(batchOfOne, &v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old", LastUpdateTime: updatedAt}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.Available()),
+					xr("b", "cool-composition-new", xpv1.Available()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhaseComplete,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          2,
+					Ready:            2,
+					LastUpdateTime:   updatedAt,
+					Message:          "All 2 composite resources were updated",
+				},
+			},
+		},
+		"WaitForLastBatch": {
+			reason: "The rollout should not be complete until the last batch of composite resources is Ready.",
+			params: params{
+				comp: comp(batchOfOne, &v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old", LastUpdateTime: updatedAt}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.Available()),
+					xr("b", "cool-composition-new", xpv1.Creating()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhaseProgressing,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          2,
+					Ready:            1,
+					LastUpdateTime:   updatedAt,
+					Message:          "Waiting for 1 updated composite resources to become Ready",
+				},
+			},
+		},
+		"CompleteIgnoresFailures": {
+			reason: "We should not fail a complete rollout when a composite resource fails.",
+			params: params{
+				comp: comp(rollback, &v1.RolloutStatus{Phase: v1.RolloutPhaseComplete, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old", Total: 2, Updated: 2, Ready: 2, LastUpdateTime: expiredAt, Message: "All 2 composite resources were updated"}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.ReconcileError(errBoom)),
+					xr("b", "cool-composition-new", xpv1.Creating()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhaseComplete,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          2,
+					Failed:           2,
+					LastUpdateTime:   expiredAt,
+					Message:          "All 2 composite resources were updated",
+				},
+			},
+		},
+		"ProgressDeadlineExceeded": {
+			reason: "We should fail the rollout when composite resources moved to the new revision aren't Ready within the progress deadline.",
+			params: params{
+				comp: comp(batchOfOne, &v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old", LastUpdateTime: expiredAt}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.Creating()),
+					xr("b", "cool-composition-old", xpv1.Available()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhasePaused,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          1,
+					Failed:           1,
+					LastUpdateTime:   expiredAt,
+					Message:          "1 of 1 updated composite resources failed",
+				},
+			},
+		},
+		"Retry": {
+			reason: "We should roll out a revision again when a failed rollout is retried.",
+			params: params{
+				comp: comp(retried, &v1.RolloutStatus{Phase: v1.RolloutPhaseRolledBack, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old", Total: 2, LastUpdateTime: expiredAt, Message: "1 of 1 updated composite resources failed"}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-old", xpv1.Available()),
+					xr("b", "cool-composition-old", xpv1.Available()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhaseProgressing,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          1,
+					LastUpdateTime:   updatedAt,
+					LastRetry:        "again",
+					Message:          "Updated 1 of 2 composite resources",
+				},
+				moved: map[string]string{"a": "cool-composition-new"},
+			},
+		},
+		"AlreadyRetried": {
+			reason: "We should not retry a failed rollout again until the retry value changes.",
+			params: params{
+				comp: comp(retried, &v1.RolloutStatus{Phase: v1.RolloutPhasePaused, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old", LastUpdateTime: expiredAt, LastRetry: "again"}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.ReconcileError(errBoom)),
+					xr("b", "cool-composition-old", xpv1.Available()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhasePaused,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          1,
+					Failed:           1,
+					LastUpdateTime:   expiredAt,
+					LastRetry:        "again",
+				},
+			},
+		},
+		"Pause": {
+			reason: "We should pause the rollout when too many composite resources moved to the new revision fail.",
+			params: params{
+				comp: comp(batchOfOne, &v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old"}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.ReconcileError(errBoom)),
+					xr("b", "cool-composition-old", xpv1.Available()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhasePaused,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            2,
+					Updated:          1,
+					Failed:           1,
+					LastUpdateTime:   updatedAt,
+					Message:          "1 of 1 updated composite resources failed",
+				},
+			},
+		},
+		"Rollback": {
+			reason: "We should move composite resources back to the previous revision when too many of them fail and the failure policy is Rollback.",
+			params: params{
+				comp: comp(rollback, &v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "cool-composition-new", PreviousRevision: "cool-composition-old"}),
+				revs: revs,
+				xrs: []kunstructured.Unstructured{
+					xr("a", "cool-composition-new", xpv1.ReconcileError(errBoom)),
+					xr("b", "cool-composition-new", xpv1.Available()),
+					xr("c", "cool-composition-old", xpv1.Available()),
+				},
+			},
+			want: want{
+				r: reconcile.Result{RequeueAfter: poll},
+				status: &v1.RolloutStatus{
+					Phase:            v1.RolloutPhaseRolledBack,
+					Revision:         "cool-composition-new",
+					PreviousRevision: "cool-composition-old",
+					Total:            3,
+					LastUpdateTime:   updatedAt,
+					Message:          "1 of 2 updated composite resources failed",
+				},
+				moved: map[string]string{"a": "cool-composition-old", "b": "cool-composition-old"},
+			},
+		},
+		"ListCompositeResourcesError": {
+			reason: "We should return any error encountered listing composite resources.",
+			params: params{
+				comp: comp(batchOfOne, nil),
+				revs: revs,
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListXRs),
+			},
+		},
+		"MoveError": {
+			reason: "We should return any error encountered moving a composite resource to a new revision.",
+			params: params{
+				comp: comp(batchOfOne, nil),
+				revs: revs,
+				xrs:  []kunstructured.Unstructured{xr("a", "cool-composition-old", xpv1.Available())},
+			},
+			want: want{
+				err:   errors.Wrapf(errBoom, errFmtMoveXR, "a", "cool-composition-new"),
+				moved: map[string]string{"a": "cool-composition-new"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var status *v1.RolloutStatus
+			moved := map[string]string{}
+
+			c := &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1.Composition) = *tc.params.comp
+					return nil
+				}),
+				MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+					switch l := obj.(type) {
+					case *v1.CompositionRevisionList:
+						l.Items = tc.params.revs
+					case *kunstructured.UnstructuredList:
+						if tc.params.xrs == nil {
+							return errBoom
+						}
+						l.Items = tc.params.xrs
+					}
+					return nil
+				},
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					cp := &composite.Unstructured{Unstructured: *obj.(*kunstructured.Unstructured)}
+					moved[cp.GetName()] = cp.GetCompositionRevisionReference().Name
+					if name == "MoveError" {
+						return errBoom
+					}
+					return nil
+				},
+				MockStatusUpdate: func(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+					status = obj.(*v1.Composition).Status.Rollout
+					if status == nil {
+						status = &v1.RolloutStatus{}
+					}
+					return nil
+				},
+			}
+
+			r := NewReconciler(&fake.Manager{Client: c}, WithPollInterval(poll))
+			r.now = func() time.Time { return now }
+			got, err := r.Reconcile(context.Background(), reconcile.Request{})
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+			if tc.want.moved == nil {
+				tc.want.moved = map[string]string{}
+			}
+			if diff := cmp.Diff(tc.want.moved, moved); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want moved, +got moved:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNewStatus(t *testing.T) {
+	revs := []v1.CompositionRevision{
+		{ObjectMeta: metav1.ObjectMeta{Name: "rev-1"}, Spec: v1.CompositionRevisionSpec{Revision: 1}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "rev-3"}, Spec: v1.CompositionRevisionSpec{Revision: 3}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "rev-2"}, Spec: v1.CompositionRevisionSpec{Revision: 2}},
+	}
+	sort.Slice(revs, func(i, j int) bool { return revs[i].GetName() > revs[j].GetName() })
+	latest := &revs[0]
+
+	cases := map[string]struct {
+		reason string
+		s      *v1.RolloutStatus
+		retry  string
+		want   v1.RolloutStatus
+	}{
+		"NoStatus": {
+			reason: "Without a status we should assume the revision before the latest was last rolled out.",
+			want:   v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "rev-3", PreviousRevision: "rev-2"},
+		},
+		"SameRevision": {
+			reason: "The status should be unchanged if the latest revision is already being rolled out.",
+			s:      &v1.RolloutStatus{Phase: v1.RolloutPhasePaused, Revision: "rev-3", PreviousRevision: "rev-1"},
+			want:   v1.RolloutStatus{Phase: v1.RolloutPhasePaused, Revision: "rev-3", PreviousRevision: "rev-1"},
+		},
+		"PreviousRolloutComplete": {
+			reason: "A completely rolled out revision should be rolled back to if the new rollout fails.",
+			s:      &v1.RolloutStatus{Phase: v1.RolloutPhaseComplete, Revision: "rev-2", PreviousRevision: "rev-1"},
+			want:   v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "rev-3", PreviousRevision: "rev-2"},
+		},
+		"PreviousRolloutIncomplete": {
+			reason: "A revision that wasn't completely rolled out should not be rolled back to.",
+			s:      &v1.RolloutStatus{Phase: v1.RolloutPhasePaused, Revision: "rev-2", PreviousRevision: "rev-1"},
+			want:   v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "rev-3", PreviousRevision: "rev-1"},
+		},
+		"RetryHandled": {
+			reason: "A new rollout should not be retried until the retry value changes.",
+			s:      &v1.RolloutStatus{Phase: v1.RolloutPhasePaused, Revision: "rev-2", PreviousRevision: "rev-1", LastRetry: "once"},
+			retry:  "twice",
+			want:   v1.RolloutStatus{Phase: v1.RolloutPhaseProgressing, Revision: "rev-3", PreviousRevision: "rev-1", LastRetry: "twice"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := newStatus(tc.s, tc.retry, latest, revs)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nnewStatus(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -69,6 +69,9 @@ const (
 	errAddFinalizer         = "cannot add finalizer"
 	errRemoveFinalizer      = "cannot remove finalizer"
 	errUpdateStatus         = "cannot update status of usage"
+	errReplayDeletion       = "cannot replay deletion of the used resource"
+
+	errFmtNotInNamespace = "%s %q is not in the namespace of the usage %q"
 )
 
 // Event reasons.
@@ -83,27 +86,36 @@ const (
 	reasonRemoveInUseLabel event.Reason = "RemoveInUseLabel"
 	reasonAddFinalizer     event.Reason = "AddFinalizer"
 	reasonRemoveFinalizer  event.Reason = "RemoveFinalizer"
+	reasonReplayDeletion   event.Reason = "ReplayDeletion"
 
 	reasonUsageConfigured event.Reason = "UsageConfigured"
 	reasonWaitUsing       event.Reason = "WaitingUsingDeleted"
 )
 
 type selectorResolver interface {
-	resolveSelectors(ctx context.Context, u *v1alpha1.Usage) error
+	resolveSelectors(ctx context.Context, u v1alpha1.UsageResource) error
 }
 
-// Setup adds a controller that reconciles Usages by
-// defining a composite resource and starting a controller to reconcile it.
+// Setup adds controllers that reconcile Usages and NamespacedUsages by
+// protecting the used resource from deletion while it's in use.
 func Setup(mgr ctrl.Manager, o apiextensionscontroller.Options) error {
-	name := "usage/" + strings.ToLower(v1alpha1.UsageGroupKind)
+	if err := setup(mgr, o, v1alpha1.UsageGroupKind, func() v1alpha1.UsageResource { return &v1alpha1.Usage{} }); err != nil {
+		return err
+	}
+	return setup(mgr, o, v1alpha1.NamespacedUsageGroupKind, func() v1alpha1.UsageResource { return &v1alpha1.NamespacedUsage{} })
+}
+
+func setup(mgr ctrl.Manager, o apiextensionscontroller.Options, gk string, fn func() v1alpha1.UsageResource) error {
+	name := "usage/" + strings.ToLower(gk)
 	r := NewReconciler(mgr,
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
-		WithPollInterval(o.PollInterval))
+		WithPollInterval(o.PollInterval),
+		WithUsageFn(fn))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
-		For(&v1alpha1.Usage{}).
+		For(fn()).
 		WithOptions(o.ForControllerRuntime()).
 		Complete(ratelimiter.NewReconciler(name, errors.WithSilentRequeueOnConflict(r), o.GlobalRateLimiter))
 }
@@ -160,6 +172,15 @@ func WithPollInterval(after time.Duration) ReconcilerOption {
 	}
 }
 
+// WithUsageFn specifies the kind of Usage the Reconciler reconciles, by
+// returning a new, empty Usage or NamespacedUsage. It reconciles Usages by
+// default.
+func WithUsageFn(fn func() v1alpha1.UsageResource) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.newUsage = fn
+	}
+}
+
 type usageResource struct {
 	xpresource.Finalizer
 	selectorResolver
@@ -180,6 +201,8 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 			selectorResolver: newAPISelectorResolver(kube),
 		},
 
+		newUsage: func() v1alpha1.UsageResource { return &v1alpha1.Usage{} },
+
 		log:    logging.NewNopLogger(),
 		record: event.NewNopRecorder(),
 	}
@@ -194,7 +217,8 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 type Reconciler struct {
 	client xpresource.ClientApplicator
 
-	usage usageResource
+	usage    usageResource
+	newUsage func() v1alpha1.UsageResource
 
 	log    logging.Logger
 	record event.Recorder
@@ -210,7 +234,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	defer cancel()
 
 	// Get the usageResource resource for this request.
-	u := &v1alpha1.Usage{}
+	u := r.newUsage()
 	if err := r.client.Get(ctx, req.NamespacedName, u); err != nil {
 		log.Debug(errGetUsage, "error", err)
 		return reconcile.Result{}, errors.Wrap(xpresource.IgnoreNotFound(err), errGetUsage)
@@ -225,12 +249,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	r.record.Event(u, event.Normal(reasonResolveSelectors, "Selectors resolved, if any."))
 
-	of := u.Spec.Of
-	by := u.Spec.By
+	of := u.GetOf()
+	by := u.GetBy()
+
+	// A NamespacedUsage may only refer to resources in its own namespace. A
+	// Usage may only refer to cluster scoped resources, so this is empty.
+	ns := u.GetNamespace()
 
 	// Identify used xp composed as an unstructured object.
 	used := composed.New(composed.FromReference(v1.ObjectReference{
 		Kind:       of.Kind,
+		Namespace:  ns,
 		Name:       of.ResourceRef.Name,
 		APIVersion: of.APIVersion,
 	}))
@@ -240,11 +269,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			// Identify using resource as an unstructured object.
 			using := composed.New(composed.FromReference(v1.ObjectReference{
 				Kind:       by.Kind,
+				Namespace:  ns,
 				Name:       by.ResourceRef.Name,
 				APIVersion: by.APIVersion,
 			}))
 			// Get the using resource
-			err := r.client.Get(ctx, client.ObjectKey{Name: by.ResourceRef.Name}, using)
+			err := r.client.Get(ctx, client.ObjectKey{Namespace: ns, Name: by.ResourceRef.Name}, using)
 			if xpresource.IgnoreNotFound(err) != nil {
 				log.Debug(errGetUsing, "error", err)
 				err = errors.Wrap(xpresource.IgnoreNotFound(err), errGetUsing)
@@ -252,7 +282,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 				return reconcile.Result{}, err
 			}
 
-			if err == nil {
+			// We never own a using resource outside our namespace, so we
+			// don't wait for it to be deleted.
+			if err == nil && using.GetNamespace() == ns {
 				// Using resource is still there, so we need to wait for it to be deleted.
 				msg := fmt.Sprintf("Waiting for the using resource (which is a %q named %q) to be deleted.", by.Kind, by.ResourceRef.Name)
 				log.Debug(msg)
@@ -273,7 +305,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 		// Get the used resource
 		var err error
-		if err = r.client.Get(ctx, client.ObjectKey{Name: of.ResourceRef.Name}, used); xpresource.IgnoreNotFound(err) != nil {
+		if err = r.client.Get(ctx, client.ObjectKey{Namespace: ns, Name: of.ResourceRef.Name}, used); xpresource.IgnoreNotFound(err) != nil {
 			log.Debug(errGetUsed, "error", err)
 			err = errors.Wrap(err, errGetUsed)
 			r.record.Event(u, event.Warning(reasonGetUsed, err))
@@ -281,10 +313,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		}
 
 		// Remove the in-use label from the used resource if no other usages
-		// exists.
-		if err == nil {
-			usageList := &v1alpha1.UsageList{}
-			if err = r.client.List(ctx, usageList, client.MatchingFields{usage.InUseIndexKey: usage.IndexValueForObject(used.GetUnstructured())}); err != nil {
+		// exists. We never label a used resource outside our namespace.
+		if err == nil && used.GetNamespace() == ns {
+			var usages []v1alpha1.UsageResource
+			if usages, err = usage.ListUsages(ctx, r.client, used.GetUnstructured()); err != nil {
 				log.Debug(errListUsages, "error", err)
 				err = errors.Wrap(err, errListUsages)
 				r.record.Event(u, event.Warning(reasonListUsages, err))
@@ -292,7 +324,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 			}
 			// There are no "other" usageResource's referencing the used resource,
 			// so we can remove the in-use label from the used resource
-			if len(usageList.Items) < 2 {
+			if len(usages) < 2 {
 				meta.RemoveLabels(used, inUseLabelKey)
 				if err = r.client.Update(ctx, used); err != nil {
 					log.Debug(errRemoveInUseLabel, "error", err)
@@ -304,6 +336,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 					return reconcile.Result{}, err
 				}
 			}
+
+			// Replay the deletion of the used resource if it was attempted
+			// while this usage blocked it. Another usage may still block
+			// the deletion, in which case it records the attempt itself.
+			if err = r.replayDeletion(ctx, u, used); err != nil {
+				log.Debug(errReplayDeletion, "error", err)
+				err = errors.Wrap(err, errReplayDeletion)
+				r.record.Event(u, event.Warning(reasonReplayDeletion, err))
+				return reconcile.Result{}, err
+			}
 		}
 
 		// Remove the finalizer from the usage
@@ -348,7 +390,14 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	// Get the used resource
-	if err := r.client.Get(ctx, client.ObjectKey{Name: of.ResourceRef.Name}, used); err != nil {
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: ns, Name: of.ResourceRef.Name}, used); err != nil {
+		log.Debug(errGetUsed, "error", err)
+		err = errors.Wrap(err, errGetUsed)
+		r.record.Event(u, event.Warning(reasonGetUsed, err))
+		return reconcile.Result{}, err
+	}
+
+	if err := inNamespace(used, ns); err != nil {
 		log.Debug(errGetUsed, "error", err)
 		err = errors.Wrap(err, errGetUsed)
 		r.record.Event(u, event.Warning(reasonGetUsed, err))
@@ -376,12 +425,20 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		// Identify using resource as an unstructured object.
 		using := composed.New(composed.FromReference(v1.ObjectReference{
 			Kind:       by.Kind,
+			Namespace:  ns,
 			Name:       by.ResourceRef.Name,
 			APIVersion: by.APIVersion,
 		}))
 
 		// Get the using resource
-		if err := r.client.Get(ctx, client.ObjectKey{Name: by.ResourceRef.Name}, using); err != nil {
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: ns, Name: by.ResourceRef.Name}, using); err != nil {
+			log.Debug(errGetUsing, "error", err)
+			err = errors.Wrap(err, errGetUsing)
+			r.record.Event(u, event.Warning(reasonGetUsing, err))
+			return reconcile.Result{}, err
+		}
+
+		if err := inNamespace(using, ns); err != nil {
 			log.Debug(errGetUsing, "error", err)
 			err = errors.Wrap(err, errGetUsing)
 			r.record.Event(u, event.Warning(reasonGetUsing, err))
@@ -405,7 +462,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		}
 	}
 
-	u.Status.SetConditions(xpv1.Available())
+	u.SetConditions(xpv1.Available())
 	r.record.Event(u, event.Normal(reasonUsageConfigured, "Usage configured successfully."))
 	// We are only watching the Usage itself but not using or used resources.
 	// So, we need to reconcile the Usage periodically to check if the using
@@ -413,12 +470,22 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	return reconcile.Result{RequeueAfter: r.pollInterval}, errors.Wrap(r.client.Status().Update(ctx, u), errUpdateStatus)
 }
 
-func detailsAnnotation(u *v1alpha1.Usage) string {
-	if u.Spec.Reason != nil {
-		return *u.Spec.Reason
+// inNamespace returns an error if the supplied resource isn't in the supplied
+// namespace. The API server drops the namespace of a cluster scoped resource,
+// so this also rejects a NamespacedUsage of a cluster scoped resource.
+func inNamespace(o *composed.Unstructured, ns string) error {
+	if o.GetNamespace() != ns {
+		return errors.Errorf(errFmtNotInNamespace, o.GetKind(), o.GetName(), ns)
 	}
-	if u.Spec.By != nil {
-		return fmt.Sprintf("%s/%s uses %s/%s", u.Spec.By.Kind, u.Spec.By.ResourceRef.Name, u.Spec.Of.Kind, u.Spec.Of.ResourceRef.Name)
+	return nil
+}
+
+func detailsAnnotation(u v1alpha1.UsageResource) string {
+	if r := u.GetReason(); r != nil {
+		return *r
+	}
+	if by := u.GetBy(); by != nil {
+		return fmt.Sprintf("%s/%s uses %s/%s", by.Kind, by.ResourceRef.Name, u.GetOf().Kind, u.GetOf().ResourceRef.Name)
 	}
 
 	return "undefined"
@@ -431,7 +498,10 @@ func detailsAnnotation(u *v1alpha1.Usage) string {
 func RespectOwnerRefs() xpresource.ApplyOption {
 	return func(ctx context.Context, current, desired runtime.Object) error {
 		cu, ok := current.(*composed.Unstructured)
-		if !ok || cu.GetObjectKind().GroupVersionKind() != v1alpha1.UsageGroupVersionKind {
+		if !ok {
+			return nil
+		}
+		if gvk := cu.GetObjectKind().GroupVersionKind(); gvk != v1alpha1.UsageGroupVersionKind && gvk != v1alpha1.NamespacedUsageGroupVersionKind {
 			return nil
 		}
 		// This is a Usage resource, so we need to respect existing owner
@@ -442,3 +512,23 @@ func RespectOwnerRefs() xpresource.ApplyOption {
 		return nil
 	}
 }
+
+// replayDeletion deletes the used resource with the propagation policy of the
+// deletion attempt the supplied usage blocked, if any.
+func (r *Reconciler) replayDeletion(ctx context.Context, u v1alpha1.UsageResource, used *composed.Unstructured) error {
+	if rd := u.GetReplayDeletion(); rd == nil || !*rd {
+		return nil
+	}
+	policy, ok := u.GetAnnotations()[usage.AnnotationKeyDeletionAttempt]
+	if !ok {
+		return nil
+	}
+	p := metav1.DeletionPropagation(policy)
+	err := r.client.Delete(ctx, used, &client.DeleteOptions{PropagationPolicy: &p})
+	// The webhook denies the deletion with a conflict if other usages still
+	// block it.
+	if kerrors.IsConflict(err) {
+		return nil
+	}
+	return xpresource.IgnoreNotFound(err)
+}
@@ -26,6 +26,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -38,14 +39,15 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+	"github.com/crossplane/crossplane/internal/usage"
 	"github.com/crossplane/crossplane/internal/xcrd"
 )
 
 type fakeSelectorResolver struct {
-	resourceSelectorFn func(ctx context.Context, u *v1alpha1.Usage) error
+	resourceSelectorFn func(ctx context.Context, u v1alpha1.UsageResource) error
 }
 
-func (f fakeSelectorResolver) resolveSelectors(ctx context.Context, u *v1alpha1.Usage) error {
+func (f fakeSelectorResolver) resolveSelectors(ctx context.Context, u v1alpha1.UsageResource) error {
 	return f.resourceSelectorFn(ctx, u)
 }
 
@@ -97,7 +99,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return errBoom
 						},
 					}),
@@ -122,7 +124,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -151,7 +153,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -186,7 +188,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -224,7 +226,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -262,7 +264,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -311,7 +313,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -370,7 +372,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -383,6 +385,149 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{},
 			},
 		},
+		"SuccessNamespacedUsage": {
+			reason: "We should get the used and using resources from the namespace of a namespaced usage.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithUsageFn(func() v1alpha1.UsageResource { return &v1alpha1.NamespacedUsage{} }),
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+								if o, ok := obj.(*v1alpha1.NamespacedUsage); ok {
+									o.SetNamespace("cool-namespace")
+									o.Spec.Of.ResourceRef = &v1alpha1.ResourceRef{Name: "used"}
+									o.Spec.By = &v1alpha1.Resource{
+										ResourceRef: &v1alpha1.ResourceRef{Name: "using"},
+									}
+									return nil
+								}
+								if o, ok := obj.(*composed.Unstructured); ok {
+									if key.Namespace != "cool-namespace" {
+										t.Errorf("expected to get %q in namespace %q, got %q", key.Name, "cool-namespace", key.Namespace)
+									}
+									if o.GetName() == "using" {
+										o.SetAPIVersion("v1")
+										o.SetKind("AnotherKind")
+										o.SetUID("some-uid")
+									}
+									return nil
+								}
+								return errors.New("unexpected object type")
+							},
+							MockUpdate: test.NewMockUpdateFn(nil),
+							MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(obj client.Object) error {
+								o := obj.(*v1alpha1.NamespacedUsage)
+								if o.Status.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+									t.Fatalf("expected ready condition to be true")
+								}
+								return nil
+							}),
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"NamespacedUsageOfClusterScopedResource": {
+			reason: "We should return an error if a namespaced usage is of a resource that isn't in its namespace.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithUsageFn(func() v1alpha1.UsageResource { return &v1alpha1.NamespacedUsage{} }),
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								switch o := obj.(type) {
+								case *v1alpha1.NamespacedUsage:
+									o.SetNamespace("cool-namespace")
+									o.Spec.Of.Kind = "ClusterKind"
+									o.Spec.Of.ResourceRef = &v1alpha1.ResourceRef{Name: "used"}
+								case *composed.Unstructured:
+									// The API server drops the namespace of a
+									// cluster scoped resource.
+									o.SetNamespace("")
+								}
+								return nil
+							}),
+							MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+								if _, ok := obj.(*composed.Unstructured); ok {
+									t.Errorf("unexpected update of the used resource")
+								}
+								return nil
+							}),
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtNotInNamespace, "ClusterKind", "used", "cool-namespace"), errGetUsed),
+			},
+		},
+		"NamespacedUsageByClusterScopedResource": {
+			reason: "We should return an error if a namespaced usage is by a resource that isn't in its namespace.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithUsageFn(func() v1alpha1.UsageResource { return &v1alpha1.NamespacedUsage{} }),
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								switch o := obj.(type) {
+								case *v1alpha1.NamespacedUsage:
+									o.SetNamespace("cool-namespace")
+									o.Spec.Of.ResourceRef = &v1alpha1.ResourceRef{Name: "used"}
+									o.Spec.By = &v1alpha1.Resource{
+										Kind:        "ClusterKind",
+										ResourceRef: &v1alpha1.ResourceRef{Name: "using"},
+									}
+								case *composed.Unstructured:
+									if o.GetName() == "using" {
+										o.SetNamespace("")
+									}
+								}
+								return nil
+							}),
+							MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+								if _, ok := obj.(*v1alpha1.NamespacedUsage); ok && len(obj.GetOwnerReferences()) > 0 {
+									t.Errorf("unexpected owner reference to the using resource")
+								}
+								return nil
+							}),
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.Errorf(errFmtNotInNamespace, "ClusterKind", "using", "cool-namespace"), errGetUsing),
+			},
+		},
 		"SuccessNoUsingResource": {
 			reason: "We should return no error once we have successfully reconciled the usage resource.",
 			args: args{
@@ -419,7 +564,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -453,7 +598,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -487,7 +632,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -529,7 +674,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -567,7 +712,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -608,7 +753,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -642,7 +787,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -689,7 +834,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -702,6 +847,97 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{},
 			},
 		},
+		"SuccessfulDeleteReplayDeletion": {
+			reason: "We should replay a blocked deletion of the used resource with its original propagation policy.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								if o, ok := obj.(*v1alpha1.Usage); ok {
+									o.SetDeletionTimestamp(&now)
+									o.SetAnnotations(map[string]string{usage.AnnotationKeyDeletionAttempt: string(metav1.DeletePropagationForeground)})
+									o.Spec.Of.ResourceRef = &v1alpha1.ResourceRef{Name: "cool"}
+									o.Spec.ReplayDeletion = ptr.To(true)
+									return nil
+								}
+								if o, ok := obj.(*composed.Unstructured); ok {
+									o.SetLabels(map[string]string{inUseLabelKey: "true"})
+									return nil
+								}
+								return errors.New("unexpected object type")
+							}),
+							MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+								return nil
+							}),
+							MockUpdate: test.NewMockUpdateFn(nil),
+							MockDelete: func(_ context.Context, obj client.Object, opts ...client.DeleteOption) error {
+								do := &client.DeleteOptions{}
+								do.ApplyOptions(opts)
+								if do.PropagationPolicy == nil || *do.PropagationPolicy != metav1.DeletePropagationForeground {
+									t.Errorf("expected deletion to be replayed with propagation policy %q", metav1.DeletePropagationForeground)
+								}
+								return nil
+							},
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
+		"ReplayDeletionError": {
+			reason: "We should return an error if we cannot replay a blocked deletion of the used resource.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								if o, ok := obj.(*v1alpha1.Usage); ok {
+									o.SetDeletionTimestamp(&now)
+									o.SetAnnotations(map[string]string{usage.AnnotationKeyDeletionAttempt: string(metav1.DeletePropagationForeground)})
+									o.Spec.Of.ResourceRef = &v1alpha1.ResourceRef{Name: "cool"}
+									o.Spec.ReplayDeletion = ptr.To(true)
+									return nil
+								}
+								if o, ok := obj.(*composed.Unstructured); ok {
+									o.SetLabels(map[string]string{inUseLabelKey: "true"})
+									return nil
+								}
+								return errors.New("unexpected object type")
+							}),
+							MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+								return nil
+							}),
+							MockUpdate: test.NewMockUpdateFn(nil),
+							MockDelete: test.NewMockDeleteFn(errBoom),
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errReplayDeletion),
+			},
+		},
 		"SuccessfulWaitWhenUsingStillThere": {
 			reason: "We should wait until the using resource is deleted.",
 			args: args{
@@ -747,7 +983,7 @@ func TestReconcile(t *testing.T) {
 						},
 					}),
 					WithSelectorResolver(fakeSelectorResolver{
-						resourceSelectorFn: func(ctx context.Context, u *v1alpha1.Usage) error {
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
 							return nil
 						},
 					}),
@@ -760,6 +996,49 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{RequeueAfter: 30 * time.Second},
 			},
 		},
+		"SuccessfulDeleteNamespacedUsageOfClusterScopedResources": {
+			reason: "We should neither wait for nor remove the in use label from resources outside the namespace of a namespaced usage.",
+			args: args{
+				mgr: &fake.Manager{},
+				opts: []ReconcilerOption{
+					WithUsageFn(func() v1alpha1.UsageResource { return &v1alpha1.NamespacedUsage{} }),
+					WithClientApplicator(xpresource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+								switch o := obj.(type) {
+								case *v1alpha1.NamespacedUsage:
+									o.SetNamespace("cool-namespace")
+									o.SetDeletionTimestamp(&now)
+									o.Spec.Of.ResourceRef = &v1alpha1.ResourceRef{Name: "used"}
+									o.Spec.By = &v1alpha1.Resource{
+										ResourceRef: &v1alpha1.ResourceRef{Name: "using"},
+									}
+								case *composed.Unstructured:
+									o.SetNamespace("")
+									o.SetLabels(map[string]string{inUseLabelKey: "true"})
+								}
+								return nil
+							}),
+							MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+								t.Errorf("unexpected update of %q", obj.GetName())
+								return nil
+							}),
+						},
+					}),
+					WithSelectorResolver(fakeSelectorResolver{
+						resourceSelectorFn: func(ctx context.Context, u v1alpha1.UsageResource) error {
+							return nil
+						},
+					}),
+					WithFinalizer(xpresource.FinalizerFns{RemoveFinalizerFn: func(_ context.Context, _ xpresource.Object) error {
+						return nil
+					}}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
@@ -47,15 +47,15 @@ func newAPISelectorResolver(c client.Client) *apiSelectorResolver {
 	return &apiSelectorResolver{client: c}
 }
 
-func (r *apiSelectorResolver) resolveSelectors(ctx context.Context, u *v1alpha1.Usage) error {
-	of := u.Spec.Of
-	by := u.Spec.By
+func (r *apiSelectorResolver) resolveSelectors(ctx context.Context, u v1alpha1.UsageResource) error {
+	of := u.GetOf()
+	by := u.GetBy()
 
 	if of.ResourceRef == nil || len(of.ResourceRef.Name) == 0 {
 		if err := r.resolveSelector(ctx, u, &of); err != nil {
 			return errors.Wrap(err, errResolveSelectorForUsedResource)
 		}
-		u.Spec.Of = of
+		u.SetOf(of)
 		if err := r.client.Update(ctx, u); err != nil {
 			return errors.Wrap(err, errUpdateAfterResolveSelector)
 		}
@@ -69,7 +69,7 @@ func (r *apiSelectorResolver) resolveSelectors(ctx context.Context, u *v1alpha1.
 		if err := r.resolveSelector(ctx, u, by); err != nil {
 			return errors.Wrap(err, errResolveSelectorForUsingResource)
 		}
-		u.Spec.By = by
+		u.SetBy(by)
 		if err := r.client.Update(ctx, u); err != nil {
 			return errors.Wrap(err, errUpdateAfterResolveSelector)
 		}
@@ -78,7 +78,9 @@ func (r *apiSelectorResolver) resolveSelectors(ctx context.Context, u *v1alpha1.
 	return nil
 }
 
-func (r *apiSelectorResolver) resolveSelector(ctx context.Context, u *v1alpha1.Usage, rs *v1alpha1.Resource) error {
+// resolveSelector resolves the supplied resource selector. A NamespacedUsage
+// only selects resources in its own namespace.
+func (r *apiSelectorResolver) resolveSelector(ctx context.Context, u v1alpha1.UsageResource, rs *v1alpha1.Resource) error {
 	l := composed.NewList(composed.FromReferenceToList(v1.ObjectReference{
 		APIVersion: rs.APIVersion,
 		Kind:       rs.Kind,
@@ -87,7 +89,11 @@ func (r *apiSelectorResolver) resolveSelector(ctx context.Context, u *v1alpha1.U
 	if rs.ResourceSelector == nil {
 		return errors.New(errNoSelectorToResolve)
 	}
-	if err := r.client.List(ctx, l, client.MatchingLabels(rs.ResourceSelector.MatchLabels)); err != nil {
+	opts := []client.ListOption{client.MatchingLabels(rs.ResourceSelector.MatchLabels)}
+	if ns := u.GetNamespace(); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+	if err := r.client.List(ctx, l, opts...); err != nil {
 		return errors.Wrap(err, errListResourceMatchingLabels)
 	}
 
@@ -18,6 +18,8 @@ limitations under the License.
 package controller
 
 import (
+	corev1 "k8s.io/api/core/v1"
+
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 
 	"github.com/crossplane/crossplane/internal/xpkg"
@@ -39,6 +41,20 @@ type Options struct {
 	// DefaultRegistry used to pull packages.
 	DefaultRegistry string
 
+	// DependencyPullSecrets are the names of the Secrets used to pull package
+	// dependencies that can't inherit any from the package that depends on
+	// them.
+	DependencyPullSecrets []string
+
+	// DependencyPullPolicy is the pull policy of package dependencies that
+	// can't inherit one from the package that depends on them.
+	DependencyPullPolicy corev1.PullPolicy
+
+	// DependencyRuntimeConfig is the name of the runtime config used by
+	// Provider and Function dependencies that can't inherit one from a
+	// package of the same type that depends on them.
+	DependencyRuntimeConfig string
+
 	// FetcherOptions can be used to add optional parameters to
 	// NewK8sFetcher.
 	FetcherOptions []xpkg.FetcherOpt
@@ -416,6 +416,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	pr.SetPackagePullSecrets(p.GetPackagePullSecrets())
 	pr.SetIgnoreCrossplaneConstraints(p.GetIgnoreCrossplaneConstraints())
 	pr.SetSkipDependencyResolution(p.GetSkipDependencyResolution())
+	pr.SetVerificationPolicyRef(p.GetVerificationPolicyRef())
 	pr.SetCommonLabels(p.GetCommonLabels())
 
 	if pwr, ok := p.(v1.PackageWithRuntime); ok {
@@ -26,7 +26,9 @@ import (
 
 	"github.com/Masterminds/semver"
 	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,14 +36,18 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/parser"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
 	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
 	"github.com/crossplane/crossplane/internal/controller/pkg/controller"
+	"github.com/crossplane/crossplane/internal/controller/pkg/revision"
 	"github.com/crossplane/crossplane/internal/dag"
+	"github.com/crossplane/crossplane/internal/features"
 	"github.com/crossplane/crossplane/internal/xpkg"
 )
 
@@ -67,6 +73,10 @@ const (
 	errFmtNoValidVersion    = "dependency (%s) does not have version in constraints (%s)"
 	errInvalidPackageType   = "cannot create invalid package dependency type"
 	errCreateDependency     = "cannot create dependency package"
+	errGetRevision          = "cannot get dependency package revision"
+	errGetPackage           = "cannot get dependency package"
+	errUpgradeDependency    = "cannot upgrade dependency package"
+	errUpdateStatus         = "cannot update lock status"
 )
 
 // ReconcilerOption is used to configure the Reconciler.
@@ -100,6 +110,14 @@ func WithFetcher(f xpkg.Fetcher) ReconcilerOption {
 	}
 }
 
+// WithDependencyFetcher specifies how the Reconciler should fetch the
+// dependencies of the package versions it considers upgrading to.
+func WithDependencyFetcher(f DependencyFetcher) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.dependencies = f
+	}
+}
+
 // WithDefaultRegistry sets the default registry to use.
 func WithDefaultRegistry(registry string) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -107,14 +125,61 @@ func WithDefaultRegistry(registry string) ReconcilerOption {
 	}
 }
 
+// WithDefaultPullSecrets specifies the package pull secrets the Reconciler
+// should use for dependencies that can't inherit any from the package that
+// depends on them.
+func WithDefaultPullSecrets(secrets ...string) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.pullSecrets = make([]corev1.LocalObjectReference, len(secrets))
+		for i, s := range secrets {
+			r.pullSecrets[i] = corev1.LocalObjectReference{Name: s}
+		}
+	}
+}
+
+// WithDefaultPullPolicy specifies the package pull policy the Reconciler
+// should use for dependencies that can't inherit one from the package that
+// depends on them.
+func WithDefaultPullPolicy(p corev1.PullPolicy) ReconcilerOption {
+	return func(r *Reconciler) {
+		if p != "" {
+			r.pullPolicy = &p
+		}
+	}
+}
+
+// WithDefaultRuntimeConfig specifies the runtime config the Reconciler should
+// use for Provider and Function dependencies that can't inherit one from a
+// package of the same type that depends on them.
+func WithDefaultRuntimeConfig(name string) ReconcilerOption {
+	return func(r *Reconciler) {
+		if name != "" {
+			r.runtimeConfig = &v1.RuntimeConfigReference{Name: name}
+		}
+	}
+}
+
+// WithFeatureFlags specifies the feature flags to inject into the Reconciler.
+func WithFeatureFlags(f *feature.Flags) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.features = f
+	}
+}
+
 // Reconciler reconciles packages.
 type Reconciler struct {
-	client   client.Client
-	log      logging.Logger
-	lock     resource.Finalizer
-	newDag   dag.NewDAGFn
-	fetcher  xpkg.Fetcher
-	registry string
+	client       client.Client
+	log          logging.Logger
+	lock         resource.Finalizer
+	newDag       dag.NewDAGFn
+	fetcher      xpkg.Fetcher
+	dependencies DependencyFetcher
+	registry     string
+	features     *feature.Flags
+
+	pullSecrets   []corev1.LocalObjectReference
+	pullPolicy    *corev1.PullPolicy
+	runtimeConfig *v1.RuntimeConfigReference
 }
 
 // Setup adds a controller that reconciles the Lock.
@@ -129,11 +194,27 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	if err != nil {
 		return errors.Wrap(err, "cannot build fetcher")
 	}
+	metaScheme, err := xpkg.BuildMetaScheme()
+	if err != nil {
+		return errors.Wrap(err, "cannot build meta scheme for package parser")
+	}
+	objScheme, err := xpkg.BuildObjectScheme()
+	if err != nil {
+		return errors.Wrap(err, "cannot build object scheme for package parser")
+	}
 
 	r := NewReconciler(mgr,
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithFetcher(f),
+		WithDependencyFetcher(NewPackageDependencyFetcher(
+			revision.NewImageBackend(f, revision.WithDefaultRegistry(o.DefaultRegistry)),
+			parser.New(metaScheme, objScheme),
+		)),
 		WithDefaultRegistry(o.DefaultRegistry),
+		WithFeatureFlags(o.Features),
+		WithDefaultPullSecrets(o.DependencyPullSecrets...),
+		WithDefaultPullPolicy(o.DependencyPullPolicy),
+		WithDefaultRuntimeConfig(o.DependencyRuntimeConfig),
 	)
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -148,11 +229,12 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 // NewReconciler creates a new package revision reconciler.
 func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 	r := &Reconciler{
-		client:  mgr.GetClient(),
-		lock:    resource.NewAPIFinalizer(mgr.GetClient(), finalizer),
-		log:     logging.NewNopLogger(),
-		newDag:  dag.NewMapDag,
-		fetcher: xpkg.NewNopFetcher(),
+		client:       mgr.GetClient(),
+		lock:         resource.NewAPIFinalizer(mgr.GetClient(), finalizer),
+		log:          logging.NewNopLogger(),
+		newDag:       dag.NewMapDag,
+		fetcher:      xpkg.NewNopFetcher(),
+		dependencies: NopDependencyFetcher{},
 	}
 
 	for _, f := range opts {
@@ -216,14 +298,16 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 
 	// Make sure we don't have any cyclical imports. If we do, refuse to
 	// install additional packages.
-	_, err = dag.Sort()
-	if err != nil {
+	if _, err := dag.Sort(); err != nil {
 		log.Debug(errSortDAG, "error", err)
 		return reconcile.Result{}, errors.Wrap(err, errSortDAG)
 	}
 
 	if len(implied) == 0 {
-		return reconcile.Result{Requeue: false}, nil
+		if !r.features.Enabled(features.EnableAlphaDependencyUpgrades) {
+			return reconcile.Result{Requeue: false}, nil
+		}
+		return r.upgradeDependencies(ctx, log, lock)
 	}
 
 	// If we are missing a node, we want to create it. The resolver never
@@ -246,15 +330,154 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		return reconcile.Result{Requeue: false}, nil
 	}
 
-	// NOTE(hasheddan): we will be unable to fetch tags for private
-	// dependencies because we do not attach any secrets. Consider copying
-	// secrets from parent dependencies.
-	tags, err := r.fetcher.Tags(ctx, ref)
+	// A new dependency inherits its settings from a package that depends on
+	// it. This allows us to fetch tags for, and install, private
+	// dependencies.
+	s, err := r.inheritedSettings(ctx, lock, dep)
+	if err != nil {
+		log.Debug(errGetRevision, "error", err)
+		return reconcile.Result{}, err
+	}
+
+	addVer, err := r.findValidVersion(ctx, ref, s.pullSecrets, c)
 	if err != nil {
 		log.Debug(errFetchTags, "error", err)
 		return reconcile.Result{}, errors.Wrap(err, errFetchTags)
 	}
 
+	// NOTE(hasheddan): consider creating event on package revision
+	// dictating constraints.
+	if addVer == "" {
+		log.Debug(errNoValidVersion, "error", errors.Errorf(errFmtNoValidVersion, dep.Identifier(), dep.Constraints))
+		return reconcile.Result{Requeue: false}, nil
+	}
+
+	pack, ok := newPackage(dep.Type)
+	if !ok {
+		log.Debug(errInvalidPackageType)
+		return reconcile.Result{Requeue: false}, nil
+	}
+
+	pack.SetName(xpkg.ToDNSLabel(ref.Context().RepositoryStr()))
+	pack.SetSource(fmt.Sprintf(packageTagFmt, ref.String(), addVer))
+	pack.SetPackagePullSecrets(s.pullSecrets)
+	pack.SetPackagePullPolicy(s.pullPolicy)
+	if pwr, ok := pack.(v1.PackageWithRuntime); ok && s.runtimeConfig != nil {
+		pwr.SetRuntimeConfigRef(s.runtimeConfig)
+	}
+
+	// NOTE(hasheddan): consider making the lock the controller of packages
+	// it creates.
+	if err := r.client.Create(ctx, pack); err != nil {
+		log.Debug(errCreateDependency, "error", err)
+		return reconcile.Result{}, errors.Wrap(err, errCreateDependency)
+	}
+
+	return reconcile.Result{Requeue: false}, nil
+}
+
+// dependencySettings are the settings a dependency inherits from a package
+// that depends on it.
+type dependencySettings struct {
+	pullSecrets   []corev1.LocalObjectReference
+	pullPolicy    *corev1.PullPolicy
+	runtimeConfig *v1.RuntimeConfigReference
+}
+
+// inheritedSettings returns the settings the supplied dependency should inherit
+// from the packages in the Lock that depend on it. We consider dependents in
+// order of source, then name, so that a dependency with several dependents
+// always inherits the same settings. Pull secrets and pull policy are
+// inherited from the first dependent. A runtime config is only inherited from
+// the first dependent of the same type as the dependency, because runtime
+// configs are written for a particular type of package. Any setting the
+// dependency can't inherit uses the Reconciler's default.
+func (r *Reconciler) inheritedSettings(ctx context.Context, lock *v1beta1.Lock, d *v1beta1.Dependency) (dependencySettings, error) {
+	dependents := make([]v1beta1.LockPackage, 0)
+	for _, lp := range lock.Packages {
+		for _, dep := range lp.Dependencies {
+			if dep.Identifier() == d.Identifier() {
+				dependents = append(dependents, lp)
+				break
+			}
+		}
+	}
+	sort.Slice(dependents, func(i, j int) bool {
+		if dependents[i].Source != dependents[j].Source {
+			return dependents[i].Source < dependents[j].Source
+		}
+		return dependents[i].Name < dependents[j].Name
+	})
+
+	s := dependencySettings{}
+	for i, lp := range dependents {
+		ps, err := r.revisionSettings(ctx, lp)
+		if err != nil {
+			return s, err
+		}
+		if i == 0 {
+			s.pullSecrets, s.pullPolicy = ps.pullSecrets, ps.pullPolicy
+		}
+		if lp.Type == d.Type && ps.runtimeConfig != nil {
+			s.runtimeConfig = ps.runtimeConfig
+			break
+		}
+	}
+	return r.withDefaults(s), nil
+}
+
+// settingsOf returns the settings of the revision of the supplied package. Any
+// setting the revision doesn't specify uses the Reconciler's default.
+func (r *Reconciler) settingsOf(ctx context.Context, lp v1beta1.LockPackage) (dependencySettings, error) {
+	s, err := r.revisionSettings(ctx, lp)
+	return r.withDefaults(s), err
+}
+
+// revisionSettings returns the settings of the revision of the supplied
+// package.
+func (r *Reconciler) revisionSettings(ctx context.Context, lp v1beta1.LockPackage) (dependencySettings, error) {
+	s := dependencySettings{}
+
+	rev, ok := newPackageRevision(lp.Type)
+	if !ok {
+		return s, nil
+	}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: lp.Name}, rev); err != nil {
+		return s, errors.Wrap(err, errGetRevision)
+	}
+
+	s.pullSecrets = rev.GetPackagePullSecrets()
+	s.pullPolicy = rev.GetPackagePullPolicy()
+	if rwr, ok := rev.(v1.PackageRevisionWithRuntime); ok {
+		s.runtimeConfig = rwr.GetRuntimeConfigRef()
+	}
+	return s, nil
+}
+
+// withDefaults returns the supplied settings, using the Reconciler's defaults
+// for any that are unset.
+func (r *Reconciler) withDefaults(s dependencySettings) dependencySettings {
+	if len(s.pullSecrets) == 0 {
+		s.pullSecrets = r.pullSecrets
+	}
+	if s.pullPolicy == nil {
+		s.pullPolicy = r.pullPolicy
+	}
+	if s.runtimeConfig == nil {
+		s.runtimeConfig = r.runtimeConfig
+	}
+	return s
+}
+
+// findValidVersion returns the highest semantic version tag of the supplied
+// package that satisfies all of the supplied constraints, or an empty string if
+// no tag does. The supplied pull secrets are used to list tags.
+func (r *Reconciler) findValidVersion(ctx context.Context, ref name.Reference, secrets []corev1.LocalObjectReference, cs ...*semver.Constraints) (string, error) {
+	tags, err := r.fetcher.Tags(ctx, ref, v1.RefNames(secrets)...)
+	if err != nil {
+		return "", err
+	}
+
 	vs := []*semver.Version{}
 	for _, r := range tags {
 		v, err := semver.NewVersion(r)
@@ -266,46 +489,46 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	}
 
 	sort.Sort(semver.Collection(vs))
-	var addVer string
+	var ver string
 	for _, v := range vs {
-		if c.Check(v) {
-			addVer = v.Original()
+		if satisfies(v, cs...) {
+			ver = v.Original()
 		}
 	}
+	return ver, nil
+}
 
-	// NOTE(hasheddan): consider creating event on package revision
-	// dictating constraints.
-	if addVer == "" {
-		log.Debug(errNoValidVersion, "error", errors.Errorf(errFmtNoValidVersion, dep.Identifier(), dep.Constraints))
-		return reconcile.Result{Requeue: false}, nil
+func satisfies(v *semver.Version, cs ...*semver.Constraints) bool {
+	for _, c := range cs {
+		if !c.Check(v) {
+			return false
+		}
 	}
+	return true
+}
 
-	var pack v1.Package
-	switch dep.Type {
+func newPackage(t v1beta1.PackageType) (v1.Package, bool) {
+	switch t {
 	case v1beta1.ConfigurationPackageType:
-		pack = &v1.Configuration{}
+		return &v1.Configuration{}, true
 	case v1beta1.ProviderPackageType:
-		pack = &v1.Provider{}
+		return &v1.Provider{}, true
 	case v1beta1.FunctionPackageType:
-		pack = &v1beta1.Function{}
+		return &v1beta1.Function{}, true
 	default:
-		log.Debug(errInvalidPackageType)
-		return reconcile.Result{Requeue: false}, nil
+		return nil, false
 	}
+}
 
-	// NOTE(hasheddan): packages are currently created with default
-	// settings. This means that a dependency must be publicly available as
-	// no packagePullSecrets are set. Settings can be modified manually
-	// after dependency creation to address this.
-	pack.SetName(xpkg.ToDNSLabel(ref.Context().RepositoryStr()))
-	pack.SetSource(fmt.Sprintf(packageTagFmt, ref.String(), addVer))
-
-	// NOTE(hasheddan): consider making the lock the controller of packages
-	// it creates.
-	if err := r.client.Create(ctx, pack); err != nil {
-		log.Debug(errCreateDependency, "error", err)
-		return reconcile.Result{}, errors.Wrap(err, errCreateDependency)
+func newPackageRevision(t v1beta1.PackageType) (v1.PackageRevision, bool) {
+	switch t {
+	case v1beta1.ConfigurationPackageType:
+		return &v1.ConfigurationRevision{}, true
+	case v1beta1.ProviderPackageType:
+		return &v1.ProviderRevision{}, true
+	case v1beta1.FunctionPackageType:
+		return &v1beta1.FunctionRevision{}, true
+	default:
+		return nil, false
 	}
-
-	return reconcile.Result{Requeue: false}, nil
 }
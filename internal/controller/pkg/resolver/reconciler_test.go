@@ -19,33 +19,298 @@ package resolver
 import (
 	"context"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/resource/fake"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
+	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
 	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
 	"github.com/crossplane/crossplane/internal/dag"
 	fakedag "github.com/crossplane/crossplane/internal/dag/fake"
+	"github.com/crossplane/crossplane/internal/features"
 	fakexpkg "github.com/crossplane/crossplane/internal/xpkg/fake"
 )
 
+// secretsFetcher returns the supplied tags only when asked to fetch them using
+// the supplied pull secrets.
+type secretsFetcher struct {
+	fakexpkg.MockFetcher
+
+	secrets []string
+	tags    []string
+}
+
+func (f *secretsFetcher) Tags(_ context.Context, _ name.Reference, secrets ...string) ([]string, error) {
+	if diff := cmp.Diff(f.secrets, secrets, cmpopts.EquateEmpty()); diff != "" {
+		return nil, errors.Errorf("pull secrets: -want, +got:\n%s", diff)
+	}
+	return f.tags, nil
+}
+
+// repoFetcher returns the tags of each repository.
+type repoFetcher struct {
+	fakexpkg.MockFetcher
+
+	tags map[string][]string
+}
+
+func (f *repoFetcher) Tags(_ context.Context, ref name.Reference, _ ...string) ([]string, error) {
+	return f.tags[ref.Context().RepositoryStr()], nil
+}
+
+// repoDependencies returns the dependencies of each package version.
+func repoDependencies(deps map[string][]v1beta1.Dependency) DependencyFetcher {
+	return DependencyFetcherFn(func(_ context.Context, ref name.Reference, _ []corev1.LocalObjectReference) ([]v1beta1.Dependency, error) {
+		return deps[ref.Context().RepositoryStr()+":"+ref.Identifier()], nil
+	})
+}
+
 func TestReconcile(t *testing.T) {
 	errBoom := errors.New("boom")
 	testLog := logging.NewLogrLogger(zap.New(zap.UseDevMode(true), zap.WriteTo(io.Discard)).WithName("testlog"))
 
+	upgrades := &feature.Flags{}
+	upgrades.Enable(features.EnableAlphaDependencyUpgrades)
+
+	// getOutdatedDependency populates a lock with a configuration that
+	// depends on a provider whose installed version is too old, and populates
+	// that provider's revision and package.
+	getOutdatedDependency := func(skip bool) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, o client.Object) error {
+			switch o := o.(type) {
+			case *v1beta1.Lock:
+				o.Packages = []v1beta1.LockPackage{
+					{
+						Name:    "cool-config-1234",
+						Type:    v1beta1.ConfigurationPackageType,
+						Source:  "cool-repo/cool-config",
+						Version: "v0.0.1",
+						Dependencies: []v1beta1.Dependency{
+							{
+								Package:     "cool-repo/cool-provider",
+								Type:        v1beta1.ProviderPackageType,
+								Constraints: ">=v1.0.0",
+							},
+						},
+					},
+					{
+						Name:    "cool-provider-1234",
+						Type:    v1beta1.ProviderPackageType,
+						Source:  "cool-repo/cool-provider",
+						Version: "v0.5.0",
+					},
+				}
+			case *v1.ProviderRevision:
+				if key.Name != "cool-provider-1234" {
+					return errBoom
+				}
+				o.SetLabels(map[string]string{v1.LabelParentPackage: "cool-provider"})
+			case *v1.Provider:
+				if key.Name != "cool-provider" {
+					return errBoom
+				}
+				o.SetSource("cool-repo/cool-provider:v0.5.0")
+				o.SetSkipDependencyUpgrade(ptr.To(skip))
+			default:
+				return errBoom
+			}
+			return nil
+		}
+	}
+
+	// getTransitiveDependency populates a lock with a configuration that
+	// depends on a provider whose installed version is too old, which in turn
+	// depends on another provider. It populates both providers' revisions
+	// and packages.
+	getTransitiveDependency := func(skip bool) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, o client.Object) error {
+			switch o := o.(type) {
+			case *v1beta1.Lock:
+				o.Packages = []v1beta1.LockPackage{
+					{
+						Name:    "cool-config-1234",
+						Type:    v1beta1.ConfigurationPackageType,
+						Source:  "cool-repo/cool-config",
+						Version: "v0.0.1",
+						Dependencies: []v1beta1.Dependency{
+							{
+								Package:     "cool-repo/cool-provider",
+								Type:        v1beta1.ProviderPackageType,
+								Constraints: ">=v1.0.0",
+							},
+						},
+					},
+					{
+						Name:    "cool-provider-1234",
+						Type:    v1beta1.ProviderPackageType,
+						Source:  "cool-repo/cool-provider",
+						Version: "v0.5.0",
+						Dependencies: []v1beta1.Dependency{
+							{
+								Package:     "cool-repo/cool-family",
+								Type:        v1beta1.ProviderPackageType,
+								Constraints: ">=v1.0.0",
+							},
+						},
+					},
+					{
+						Name:    "cool-family-1234",
+						Type:    v1beta1.ProviderPackageType,
+						Source:  "cool-repo/cool-family",
+						Version: "v1.0.0",
+					},
+				}
+			case *v1.ProviderRevision:
+				o.SetLabels(map[string]string{v1.LabelParentPackage: strings.TrimSuffix(key.Name, "-1234")})
+			case *v1.Provider:
+				o.SetName(key.Name)
+				o.SetSource("cool-repo/" + key.Name + ":v0.5.0")
+				o.SetSkipDependencyUpgrade(ptr.To(skip && key.Name == "cool-family"))
+			default:
+				return errBoom
+			}
+			return nil
+		}
+	}
+
+	// transitiveDependencies are the dependencies of each version of the
+	// provider that getTransitiveDependency installs. Its newest version
+	// depends on a newer version of its dependency.
+	transitiveDependencies := map[string][]v1beta1.Dependency{
+		"cool-repo/cool-provider:v1.0.0": {{Package: "cool-repo/cool-family", Type: v1beta1.ProviderPackageType, Constraints: ">=v1.0.0"}},
+		"cool-repo/cool-provider:v1.1.0": {{Package: "cool-repo/cool-family", Type: v1beta1.ProviderPackageType, Constraints: ">=v2.0.0"}},
+	}
+
+	// updateProviders returns an error if asked to update a provider to a
+	// source other than the supplied one.
+	updateProviders := func(sources ...string) test.MockUpdateFn {
+		return func(_ context.Context, o client.Object, _ ...client.UpdateOption) error {
+			p, ok := o.(*v1.Provider)
+			if !ok {
+				return nil
+			}
+			for _, s := range sources {
+				if p.GetSource() == s {
+					return nil
+				}
+			}
+			t.Errorf("unexpected provider source %q", p.GetSource())
+			return nil
+		}
+	}
+
+	// updateProvider returns the supplied error when asked to update a
+	// provider, or an error if the provider's source isn't the supplied one.
+	updateProvider := func(err error, source string) test.MockUpdateFn {
+		return func(_ context.Context, o client.Object, _ ...client.UpdateOption) error {
+			p, ok := o.(*v1.Provider)
+			if !ok {
+				return nil
+			}
+			if p.GetSource() != source {
+				t.Errorf("provider source: want %q, got %q", source, p.GetSource())
+			}
+			return err
+		}
+	}
+
+	// getMissingDependency populates a lock with a provider that depends on a
+	// provider that is not installed, and populates the depending provider's
+	// revision with the supplied pull secrets.
+	getMissingDependency := func(secrets ...corev1.LocalObjectReference) test.MockGetFn {
+		return func(_ context.Context, _ client.ObjectKey, o client.Object) error {
+			switch o := o.(type) {
+			case *v1beta1.Lock:
+				o.Packages = []v1beta1.LockPackage{
+					{
+						Name:    "cool-provider-1234",
+						Type:    v1beta1.ProviderPackageType,
+						Source:  "cool-repo/cool-provider",
+						Version: "v0.0.1",
+						Dependencies: []v1beta1.Dependency{
+							{
+								Package:     "cool-repo/cool-dependency",
+								Type:        v1beta1.ProviderPackageType,
+								Constraints: ">=v1.0.0",
+							},
+						},
+					},
+				}
+			case *v1.ProviderRevision:
+				o.SetPackagePullSecrets(secrets)
+				o.SetPackagePullPolicy(ptr.To(corev1.PullAlways))
+				o.SetRuntimeConfigRef(&v1.RuntimeConfigReference{Name: "cool-runtime-config"})
+			default:
+				return errBoom
+			}
+			return nil
+		}
+	}
+
+	// getDependents populates a lock with the supplied packages, and uses the
+	// supplied function to populate their revisions.
+	getDependents := func(rev func(name string, pr v1.PackageRevision), lps ...v1beta1.LockPackage) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, o client.Object) error {
+			switch o := o.(type) {
+			case *v1beta1.Lock:
+				o.Packages = lps
+			case v1.PackageRevision:
+				rev(key.Name, o)
+			default:
+				return errBoom
+			}
+			return nil
+		}
+	}
+
+	// dependent returns a lock package of the supplied type that depends on
+	// the supplied package.
+	dependent := func(name string, t v1beta1.PackageType, dep v1beta1.Dependency) v1beta1.LockPackage {
+		return v1beta1.LockPackage{
+			Name:         name + "-1234",
+			Type:         t,
+			Source:       "cool-repo/" + name,
+			Version:      "v0.0.1",
+			Dependencies: []v1beta1.Dependency{dep},
+		}
+	}
+	coolDependency := v1beta1.Dependency{
+		Package:     "cool-repo/cool-dependency",
+		Type:        v1beta1.ProviderPackageType,
+		Constraints: ">=v1.0.0",
+	}
+
+	// wantUpgrades returns an error if the lock's status doesn't contain the
+	// supplied upgrades.
+	wantUpgrades := func(want ...v1beta1.DependencyUpgrade) test.MockSubResourceUpdateFn {
+		return func(_ context.Context, o client.Object, _ ...client.SubResourceUpdateOption) error {
+			l := o.(*v1beta1.Lock)
+			if diff := cmp.Diff(want, l.Status.Upgrades); diff != "" {
+				t.Errorf("lock status: -want, +got:\n%s", diff)
+			}
+			return nil
+		}
+	}
+
 	type args struct {
 		mgr manager.Manager
 		req reconcile.Request
@@ -489,6 +754,432 @@ func TestReconcile(t *testing.T) {
 				r: reconcile.Result{Requeue: false},
 			},
 		},
+		"SuccessfulUpgradeDependency": {
+			reason: "We should upgrade a dependency whose installed version does not satisfy its constraints, and record the plan.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getOutdatedDependency(false),
+						MockUpdate: updateProvider(nil, "cool-repo/cool-provider:v1.1.0"),
+						MockStatusUpdate: wantUpgrades(v1beta1.DependencyUpgrade{
+							Package:        "cool-repo/cool-provider",
+							Type:           v1beta1.ProviderPackageType,
+							Constraints:    []string{">=v1.0.0"},
+							CurrentVersion: "v0.5.0",
+							DesiredVersion: "v1.1.0",
+							State:          v1beta1.DependencyUpgradeApplied,
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFeatureFlags(upgrades),
+					WithFetcher(&fakexpkg.MockFetcher{
+						MockTags: fakexpkg.NewMockTagsFn([]string{"v0.5.0", "v1.0.0", "v1.1.0"}, nil),
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"SuccessfulUpgradeDependencies": {
+			reason: "We should upgrade a dependency and its dependency if the dependency's newest satisfying version requires it.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getTransitiveDependency(false),
+						MockUpdate: updateProviders("cool-repo/cool-provider:v1.1.0", "cool-repo/cool-family:v2.0.0"),
+						MockStatusUpdate: wantUpgrades(
+							v1beta1.DependencyUpgrade{
+								Package:        "cool-repo/cool-provider",
+								Type:           v1beta1.ProviderPackageType,
+								Constraints:    []string{">=v1.0.0"},
+								CurrentVersion: "v0.5.0",
+								DesiredVersion: "v1.1.0",
+								State:          v1beta1.DependencyUpgradeApplied,
+							},
+							v1beta1.DependencyUpgrade{
+								Package:        "cool-repo/cool-family",
+								Type:           v1beta1.ProviderPackageType,
+								Constraints:    []string{">=v2.0.0"},
+								CurrentVersion: "v1.0.0",
+								DesiredVersion: "v2.0.0",
+								State:          v1beta1.DependencyUpgradeApplied,
+							},
+						),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFeatureFlags(upgrades),
+					WithFetcher(&repoFetcher{tags: map[string][]string{
+						"cool-repo/cool-provider": {"v0.5.0", "v1.0.0", "v1.1.0"},
+						"cool-repo/cool-family":   {"v1.0.0", "v2.0.0"},
+					}}),
+					WithDependencyFetcher(repoDependencies(transitiveDependencies)),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"SuccessfulUpgradeDependencyConstrainedByItsDependencies": {
+			reason: "We should not upgrade a dependency to a version whose dependencies can't be satisfied, but to the highest version whose dependencies can.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getTransitiveDependency(true),
+						MockUpdate: updateProviders("cool-repo/cool-provider:v1.0.0"),
+						MockStatusUpdate: wantUpgrades(v1beta1.DependencyUpgrade{
+							Package:        "cool-repo/cool-provider",
+							Type:           v1beta1.ProviderPackageType,
+							Constraints:    []string{">=v1.0.0"},
+							CurrentVersion: "v0.5.0",
+							DesiredVersion: "v1.0.0",
+							State:          v1beta1.DependencyUpgradeApplied,
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFeatureFlags(upgrades),
+					WithFetcher(&repoFetcher{tags: map[string][]string{
+						"cool-repo/cool-provider": {"v0.5.0", "v1.0.0", "v1.1.0"},
+						"cool-repo/cool-family":   {"v1.0.0", "v2.0.0"},
+					}}),
+					WithDependencyFetcher(repoDependencies(transitiveDependencies)),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"UnchangedUpgradesNotRecorded": {
+			reason: "We should not update the lock's status if the plan didn't change.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet: func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+							if l, ok := o.(*v1beta1.Lock); ok {
+								l.Status.Upgrades = []v1beta1.DependencyUpgrade{{
+									Package:        "cool-repo/cool-provider",
+									Type:           v1beta1.ProviderPackageType,
+									Constraints:    []string{">=v1.0.0"},
+									CurrentVersion: "v0.5.0",
+									DesiredVersion: "v1.1.0",
+									State:          v1beta1.DependencyUpgradeSkipped,
+								}}
+							}
+							return getOutdatedDependency(true)(ctx, key, o)
+						},
+						MockUpdate:       updateProvider(errBoom, ""),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFeatureFlags(upgrades),
+					WithFetcher(&fakexpkg.MockFetcher{
+						MockTags: fakexpkg.NewMockTagsFn([]string{"v0.5.0", "v1.0.0", "v1.1.0"}, nil),
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"ErrorUpgradeDependency": {
+			reason: "We should return an error if we can't update a dependency's package source.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getOutdatedDependency(false),
+						MockUpdate: updateProvider(errBoom, "cool-repo/cool-provider:v1.1.0"),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFeatureFlags(upgrades),
+					WithFetcher(&fakexpkg.MockFetcher{
+						MockTags: fakexpkg.NewMockTagsFn([]string{"v0.5.0", "v1.0.0", "v1.1.0"}, nil),
+					}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errUpgradeDependency),
+			},
+		},
+		"SkippedUpgradeDependency": {
+			reason: "We should not upgrade a dependency that opted out of upgrades, but should record the plan.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getOutdatedDependency(true),
+						MockUpdate: updateProvider(errBoom, ""),
+						MockStatusUpdate: wantUpgrades(v1beta1.DependencyUpgrade{
+							Package:        "cool-repo/cool-provider",
+							Type:           v1beta1.ProviderPackageType,
+							Constraints:    []string{">=v1.0.0"},
+							CurrentVersion: "v0.5.0",
+							DesiredVersion: "v1.1.0",
+							State:          v1beta1.DependencyUpgradeSkipped,
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFeatureFlags(upgrades),
+					WithFetcher(&fakexpkg.MockFetcher{
+						MockTags: fakexpkg.NewMockTagsFn([]string{"v0.5.0", "v1.0.0", "v1.1.0"}, nil),
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"UnsatisfiableUpgradeDependency": {
+			reason: "We should record that a dependency can't be upgraded if no version satisfies its constraints.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getOutdatedDependency(false),
+						MockUpdate: updateProvider(errBoom, ""),
+						MockStatusUpdate: wantUpgrades(v1beta1.DependencyUpgrade{
+							Package:        "cool-repo/cool-provider",
+							Type:           v1beta1.ProviderPackageType,
+							Constraints:    []string{">=v1.0.0"},
+							CurrentVersion: "v0.5.0",
+							State:          v1beta1.DependencyUpgradeUnsatisfiable,
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFeatureFlags(upgrades),
+					WithFetcher(&fakexpkg.MockFetcher{
+						MockTags: fakexpkg.NewMockTagsFn([]string{"v0.4.0", "v0.5.0"}, nil),
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"UpgradesDisabled": {
+			reason: "We should not plan or apply upgrades unless the feature is enabled.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:          getOutdatedDependency(false),
+						MockUpdate:       updateProvider(errBoom, ""),
+						MockStatusUpdate: test.NewMockSubResourceUpdateFn(errBoom),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFetcher(&fakexpkg.MockFetcher{
+						MockTags: fakexpkg.NewMockTagsFn(nil, errBoom),
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"SuccessfulCreateMissingDependencyWithInheritedSettings": {
+			reason: "A missing dependency should inherit its settings from the package that depends on it.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getMissingDependency(corev1.LocalObjectReference{Name: "cool-secret"}),
+						MockUpdate: test.NewMockUpdateFn(nil),
+						MockCreate: test.NewMockCreateFn(nil, func(o client.Object) error {
+							want := &v1.Provider{}
+							want.SetName("cool-repo-cool-dependency")
+							want.SetSource("cool-repo/cool-dependency:v1.1.0")
+							want.SetPackagePullSecrets([]corev1.LocalObjectReference{{Name: "cool-secret"}})
+							want.SetPackagePullPolicy(ptr.To(corev1.PullAlways))
+							want.SetRuntimeConfigRef(&v1.RuntimeConfigReference{Name: "cool-runtime-config"})
+							if diff := cmp.Diff(want, o); diff != "" {
+								t.Errorf("Create(...): -want, +got:\n%s", diff)
+							}
+							return nil
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithDefaultPullSecrets("default-secret"),
+					WithFetcher(&secretsFetcher{
+						secrets: []string{"cool-secret"},
+						tags:    []string{"v0.5.0", "v1.0.0", "v1.1.0"},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"SuccessfulCreateMissingDependencyWithDefaultPullSecrets": {
+			reason: "A missing dependency should use the default pull secrets if the package that depends on it has none.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getMissingDependency(),
+						MockUpdate: test.NewMockUpdateFn(nil),
+						MockCreate: test.NewMockCreateFn(nil, func(o client.Object) error {
+							p := o.(*v1.Provider)
+							if diff := cmp.Diff([]corev1.LocalObjectReference{{Name: "default-secret"}}, p.GetPackagePullSecrets()); diff != "" {
+								t.Errorf("Create(...): -want pull secrets, +got pull secrets:\n%s", diff)
+							}
+							return nil
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithDefaultPullSecrets("default-secret"),
+					WithFetcher(&secretsFetcher{
+						secrets: []string{"default-secret"},
+						tags:    []string{"v0.5.0", "v1.0.0", "v1.1.0"},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"SuccessfulCreateMissingDependencyWithDefaultSettings": {
+			reason: "A missing dependency should use the default settings if the package that depends on it has none.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet:    getDependents(func(_ string, _ v1.PackageRevision) {}, dependent("cool-config", v1beta1.ConfigurationPackageType, coolDependency)),
+						MockUpdate: test.NewMockUpdateFn(nil),
+						MockCreate: test.NewMockCreateFn(nil, func(o client.Object) error {
+							want := &v1.Provider{}
+							want.SetName("cool-repo-cool-dependency")
+							want.SetSource("cool-repo/cool-dependency:v1.1.0")
+							want.SetPackagePullSecrets([]corev1.LocalObjectReference{{Name: "default-secret"}})
+							want.SetPackagePullPolicy(ptr.To(corev1.PullNever))
+							want.SetRuntimeConfigRef(&v1.RuntimeConfigReference{Name: "default-runtime-config"})
+							if diff := cmp.Diff(want, o); diff != "" {
+								t.Errorf("Create(...): -want, +got:\n%s", diff)
+							}
+							return nil
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithDefaultPullSecrets("default-secret"),
+					WithDefaultPullPolicy(corev1.PullNever),
+					WithDefaultRuntimeConfig("default-runtime-config"),
+					WithFetcher(&secretsFetcher{
+						secrets: []string{"default-secret"},
+						tags:    []string{"v0.5.0", "v1.0.0", "v1.1.0"},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"SuccessfulCreateMissingDependencyWithoutRuntimeConfigOfOtherType": {
+			reason: "A missing dependency should not inherit the runtime config of a package of another type that depends on it.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet: getDependents(func(_ string, pr v1.PackageRevision) {
+							pr.SetPackagePullSecrets([]corev1.LocalObjectReference{{Name: "cool-secret"}})
+							pr.(v1.PackageRevisionWithRuntime).SetRuntimeConfigRef(&v1.RuntimeConfigReference{Name: "cool-provider-runtime-config"})
+						}, dependent("cool-provider", v1beta1.ProviderPackageType, v1beta1.Dependency{
+							Package:     "cool-repo/cool-function",
+							Type:        v1beta1.FunctionPackageType,
+							Constraints: ">=v1.0.0",
+						})),
+						MockUpdate: test.NewMockUpdateFn(nil),
+						MockCreate: test.NewMockCreateFn(nil, func(o client.Object) error {
+							want := &v1beta1.Function{}
+							want.SetName("cool-repo-cool-function")
+							want.SetSource("cool-repo/cool-function:v1.1.0")
+							want.SetPackagePullSecrets([]corev1.LocalObjectReference{{Name: "cool-secret"}})
+							want.SetRuntimeConfigRef(&v1.RuntimeConfigReference{Name: "default-runtime-config"})
+							if diff := cmp.Diff(want, o); diff != "" {
+								t.Errorf("Create(...): -want, +got:\n%s", diff)
+							}
+							return nil
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithDefaultRuntimeConfig("default-runtime-config"),
+					WithFetcher(&secretsFetcher{
+						secrets: []string{"cool-secret"},
+						tags:    []string{"v0.5.0", "v1.0.0", "v1.1.0"},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"SuccessfulCreateMissingDependencyWithSettingsOfFirstDependent": {
+			reason: "A missing dependency with several dependents should inherit the settings of the dependent with the lowest source, regardless of their order in the lock.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet: getDependents(func(name string, pr v1.PackageRevision) {
+							pr.SetPackagePullSecrets([]corev1.LocalObjectReference{{Name: strings.TrimSuffix(name, "-1234") + "-secret"}})
+						},
+							dependent("cool-provider-b", v1beta1.ProviderPackageType, coolDependency),
+							dependent("cool-provider-a", v1beta1.ProviderPackageType, coolDependency),
+						),
+						MockUpdate: test.NewMockUpdateFn(nil),
+						MockCreate: test.NewMockCreateFn(nil, func(o client.Object) error {
+							p := o.(*v1.Provider)
+							if diff := cmp.Diff([]corev1.LocalObjectReference{{Name: "cool-provider-a-secret"}}, p.GetPackagePullSecrets()); diff != "" {
+								t.Errorf("Create(...): -want pull secrets, +got pull secrets:\n%s", diff)
+							}
+							return nil
+						}),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithFetcher(&secretsFetcher{
+						secrets: []string{"cool-provider-a-secret"},
+						tags:    []string{"v0.5.0", "v1.0.0", "v1.1.0"},
+					}),
+				},
+			},
+			want: want{
+				r: reconcile.Result{Requeue: false},
+			},
+		},
+		"ErrorGetParentRevision": {
+			reason: "We should return an error if we can't get the revision of the package that depends on a missing dependency.",
+			args: args{
+				mgr: &fake.Manager{
+					Client: &test.MockClient{
+						MockGet: func(ctx context.Context, key client.ObjectKey, o client.Object) error {
+							if _, ok := o.(*v1.ProviderRevision); ok {
+								return errBoom
+							}
+							return getMissingDependency()(ctx, key, o)
+						},
+						MockUpdate: test.NewMockUpdateFn(nil),
+					},
+				},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetRevision),
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -0,0 +1,587 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Masterminds/semver"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/parser"
+
+	pkgmetav1 "github.com/crossplane/crossplane/apis/pkg/meta/v1"
+	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/internal/controller/pkg/revision"
+	"github.com/crossplane/crossplane/internal/xpkg"
+)
+
+const (
+	errFetchPackage      = "cannot fetch package"
+	errParsePackage      = "cannot parse package"
+	errNotOneMeta        = "package must have exactly one meta type"
+	errNotMeta           = "meta type is not a valid package"
+	errFetchDependencies = "cannot fetch dependencies of package version"
+)
+
+// maxUpgradeCandidates is the maximum number of package versions we fetch the
+// dependencies of while searching for a satisfying version set. It bounds the
+// number of images we pull in a single reconcile.
+const maxUpgradeCandidates = 32
+
+// A DependencyFetcher fetches the dependencies of a version of a package.
+type DependencyFetcher interface {
+	Fetch(ctx context.Context, ref name.Reference, secrets []corev1.LocalObjectReference) ([]v1beta1.Dependency, error)
+}
+
+// A DependencyFetcherFn fetches the dependencies of a version of a package.
+type DependencyFetcherFn func(ctx context.Context, ref name.Reference, secrets []corev1.LocalObjectReference) ([]v1beta1.Dependency, error)
+
+// Fetch the dependencies of a version of a package.
+func (fn DependencyFetcherFn) Fetch(ctx context.Context, ref name.Reference, secrets []corev1.LocalObjectReference) ([]v1beta1.Dependency, error) {
+	return fn(ctx, ref, secrets)
+}
+
+// A NopDependencyFetcher returns no dependencies.
+type NopDependencyFetcher struct{}
+
+// Fetch returns no dependencies.
+func (NopDependencyFetcher) Fetch(_ context.Context, _ name.Reference, _ []corev1.LocalObjectReference) ([]v1beta1.Dependency, error) {
+	return nil, nil
+}
+
+// A PackageDependencyFetcher fetches the dependencies of a version of a
+// package by fetching and parsing its image.
+type PackageDependencyFetcher struct {
+	backend parser.Backend
+	parser  parser.Parser
+}
+
+// NewPackageDependencyFetcher returns a DependencyFetcher that reads package
+// images using the supplied backend and parser.
+func NewPackageDependencyFetcher(b parser.Backend, p parser.Parser) *PackageDependencyFetcher {
+	return &PackageDependencyFetcher{backend: b, parser: p}
+}
+
+// Fetch the dependencies of the package image at the supplied reference.
+func (f *PackageDependencyFetcher) Fetch(ctx context.Context, ref name.Reference, secrets []corev1.LocalObjectReference) ([]v1beta1.Dependency, error) {
+	// The image backend reads the source and pull secrets of the package it
+	// should fetch from a package revision.
+	pr := &v1.ConfigurationRevision{}
+	pr.SetSource(ref.String())
+	pr.SetPackagePullSecrets(secrets)
+
+	rc, err := f.backend.Init(ctx, revision.PackageRevision(pr))
+	if err != nil {
+		return nil, errors.Wrap(err, errFetchPackage)
+	}
+	pkg, err := f.parser.Parse(ctx, rc)
+	if err != nil {
+		return nil, errors.Wrap(err, errParsePackage)
+	}
+	if len(pkg.GetMeta()) != 1 {
+		return nil, errors.New(errNotOneMeta)
+	}
+	meta, ok := xpkg.TryConvertToPkg(pkg.GetMeta()[0], &pkgmetav1.Provider{}, &pkgmetav1.Configuration{})
+	if !ok {
+		return nil, errors.New(errNotMeta)
+	}
+
+	deps := make([]v1beta1.Dependency, 0, len(meta.GetDependencies()))
+	for _, dep := range meta.GetDependencies() {
+		d := v1beta1.Dependency{Constraints: dep.Version}
+		switch {
+		case dep.Configuration != nil:
+			d.Package = *dep.Configuration
+			d.Type = v1beta1.ConfigurationPackageType
+		case dep.Provider != nil:
+			d.Package = *dep.Provider
+			d.Type = v1beta1.ProviderPackageType
+		case dep.Function != nil:
+			d.Package = *dep.Function
+			d.Type = v1beta1.FunctionPackageType
+		}
+		deps = append(deps, d)
+	}
+	return deps, nil
+}
+
+// upgradeDependencies searches for a version of every package in the Lock
+// that satisfies the constraints of the packages that depend on it, taking
+// into account the dependencies of each version it considers. If it finds one
+// it updates the source of each package whose installed version differs.
+// Packages that opted out of upgrades keep their installed version. The plan
+// is recorded in the Lock's status.
+//
+// An upgraded package may depend on packages that aren't in the Lock yet. We
+// create them once the package's new revision adds its dependencies to the
+// Lock, at which point we'll search again.
+func (r *Reconciler) upgradeDependencies(ctx context.Context, log logging.Logger, lock *v1beta1.Lock) (reconcile.Result, error) { //nolint:gocyclo // Only slightly over (11).
+	s := newUpgradeSolver(lock.Packages)
+	s.versions = r.versionsOf
+	s.dependencies = r.dependenciesOf
+
+	pkgs := map[string]v1.Package{}
+	for _, id := range s.order {
+		if len(s.installedConstraints(id)) == 0 {
+			continue
+		}
+		pkg, err := r.packageOf(ctx, s.packages[id])
+		if err != nil {
+			log.Debug(errUpgradeDependency, "error", err, "package", id)
+			return reconcile.Result{}, errors.Wrap(err, errUpgradeDependency)
+		}
+		pkgs[id] = pkg
+		s.pinned[id] = ptr.Deref(pkg.GetSkipDependencyUpgrade(), false)
+	}
+
+	solved, err := s.Solve(ctx)
+	if err != nil {
+		log.Debug(errUpgradeDependency, "error", err)
+		return reconcile.Result{}, errors.Wrap(err, errUpgradeDependency)
+	}
+
+	var upgrades []v1beta1.DependencyUpgrade
+	for _, id := range s.order {
+		lp := s.packages[id]
+		u := v1beta1.DependencyUpgrade{
+			Package:        id,
+			Type:           lp.Type,
+			CurrentVersion: lp.Version,
+		}
+
+		if solved {
+			if s.chosen[id] == lp.Version {
+				continue
+			}
+			_, u.Constraints = s.constraints(id)
+			u.DesiredVersion = s.chosen[id]
+			u.State = v1beta1.DependencyUpgradeApplied
+			if err := r.applyUpgrade(ctx, pkgs[id], lp, u.DesiredVersion); err != nil {
+				log.Debug(errUpgradeDependency, "error", err, "package", id)
+				return reconcile.Result{}, errors.Wrap(err, errUpgradeDependency)
+			}
+			log.Debug("Upgraded dependency", "package", id, "current-version", u.CurrentVersion, "desired-version", u.DesiredVersion)
+			upgrades = append(upgrades, u)
+			continue
+		}
+
+		// We couldn't find a satisfying version set. Report the packages
+		// whose installed version doesn't satisfy its dependents.
+		cs, strs := parseConstraints(s.installedConstraints(id))
+		if v, err := semver.NewVersion(lp.Version); err != nil || satisfies(v, cs...) {
+			continue
+		}
+		u.Constraints = strs
+		u.State = v1beta1.DependencyUpgradeUnsatisfiable
+		if s.pinned[id] {
+			u.State = v1beta1.DependencyUpgradeSkipped
+			if u.DesiredVersion, err = s.highest(ctx, lp, cs); err != nil {
+				log.Debug(errFetchTags, "error", err, "package", id)
+				return reconcile.Result{}, err
+			}
+		}
+		log.Debug("Cannot upgrade dependency", "package", id, "current-version", u.CurrentVersion, "state", u.State)
+		upgrades = append(upgrades, u)
+	}
+
+	if cmp.Equal(lock.Status.Upgrades, upgrades, cmpopts.EquateEmpty()) {
+		return reconcile.Result{Requeue: false}, nil
+	}
+	lock.Status.Upgrades = upgrades
+	return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, lock), errUpdateStatus)
+}
+
+// versionsOf returns the semantic version tags of the supplied package, highest
+// first.
+func (r *Reconciler) versionsOf(ctx context.Context, lp v1beta1.LockPackage) ([]*semver.Version, error) {
+	ref, err := name.ParseReference(lp.Source, name.WithDefaultRegistry(r.registry))
+	if err != nil {
+		// We can't upgrade a package with an invalid source. Its revision
+		// will report it.
+		return nil, nil
+	}
+	s, err := r.settingsOf(ctx, lp)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.fetcher.Tags(ctx, ref, v1.RefNames(s.pullSecrets)...)
+	if err != nil {
+		return nil, errors.Wrap(err, errFetchTags)
+	}
+
+	vs := []*semver.Version{}
+	for _, t := range tags {
+		v, err := semver.NewVersion(t)
+		if err != nil {
+			// We skip any tags that are not valid semantic versions.
+			continue
+		}
+		vs = append(vs, v)
+	}
+	sort.Sort(sort.Reverse(semver.Collection(vs)))
+	return vs, nil
+}
+
+// dependenciesOf returns the dependencies of the supplied version of the
+// supplied package.
+func (r *Reconciler) dependenciesOf(ctx context.Context, lp v1beta1.LockPackage, version string) ([]v1beta1.Dependency, error) {
+	ref, err := name.ParseReference(fmt.Sprintf(packageTagFmt, lp.Source, version), name.WithDefaultRegistry(r.registry))
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidDependency)
+	}
+	s, err := r.settingsOf(ctx, lp)
+	if err != nil {
+		return nil, err
+	}
+	deps, err := r.dependencies.Fetch(ctx, ref, s.pullSecrets)
+	return deps, errors.Wrap(err, errFetchDependencies)
+}
+
+// packageOf returns the package that owns the revision of the supplied Lock
+// package.
+func (r *Reconciler) packageOf(ctx context.Context, lp v1beta1.LockPackage) (v1.Package, error) {
+	rev, ok := newPackageRevision(lp.Type)
+	if !ok {
+		return nil, errors.New(errInvalidPackageType)
+	}
+	if err := r.client.Get(ctx, types.NamespacedName{Name: lp.Name}, rev); err != nil {
+		return nil, errors.Wrap(err, errGetRevision)
+	}
+
+	pkg, _ := newPackage(lp.Type)
+	if err := r.client.Get(ctx, types.NamespacedName{Name: rev.GetLabels()[v1.LabelParentPackage]}, pkg); err != nil {
+		return nil, errors.Wrap(err, errGetPackage)
+	}
+	return pkg, nil
+}
+
+// applyUpgrade updates the source of the supplied package to the supplied
+// version. The package is fetched if it's nil.
+func (r *Reconciler) applyUpgrade(ctx context.Context, pkg v1.Package, lp v1beta1.LockPackage, version string) error {
+	if pkg == nil {
+		var err error
+		if pkg, err = r.packageOf(ctx, lp); err != nil {
+			return err
+		}
+	}
+
+	ref, err := name.ParseReference(pkg.GetSource(), name.WithDefaultRegistry(""))
+	if err != nil {
+		return errors.Wrap(err, errInvalidDependency)
+	}
+	src := fmt.Sprintf(packageTagFmt, xpkg.ParsePackageSourceFromReference(ref), version)
+	if pkg.GetSource() == src {
+		return nil
+	}
+	pkg.SetSource(src)
+	return r.client.Update(ctx, pkg)
+}
+
+// An upgradeSolver searches for a version of every package in the Lock that
+// satisfies the constraints of the packages that depend on it. It considers
+// packages in dependency order, preferring each package's installed version,
+// then its highest satisfying version, and backtracks when a version's
+// dependencies can't be satisfied.
+type upgradeSolver struct {
+	packages map[string]v1beta1.LockPackage
+
+	// order lists package sources such that each package comes before its
+	// dependencies.
+	order    []string
+	position map[string]int
+
+	// pinned packages opted out of upgrades.
+	pinned map[string]bool
+
+	versions     func(ctx context.Context, lp v1beta1.LockPackage) ([]*semver.Version, error)
+	dependencies func(ctx context.Context, lp v1beta1.LockPackage, version string) ([]v1beta1.Dependency, error)
+
+	// budget is the number of package versions whose dependencies we may
+	// still fetch.
+	budget int
+
+	chosen  map[string]string
+	deps    map[string][]v1beta1.Dependency
+	tags    map[string][]*semver.Version
+	fetched map[string][]v1beta1.Dependency
+}
+
+func newUpgradeSolver(lps []v1beta1.LockPackage) *upgradeSolver {
+	s := &upgradeSolver{
+		packages: make(map[string]v1beta1.LockPackage, len(lps)),
+		position: make(map[string]int, len(lps)),
+		pinned:   map[string]bool{},
+		budget:   maxUpgradeCandidates,
+		chosen:   map[string]string{},
+		deps:     map[string][]v1beta1.Dependency{},
+		tags:     map[string][]*semver.Version{},
+		fetched:  map[string][]v1beta1.Dependency{},
+	}
+	for _, lp := range lps {
+		s.packages[lp.Source] = lp
+	}
+	s.order = dependentsFirst(s.packages)
+	for i, id := range s.order {
+		s.position[id] = i
+	}
+	return s
+}
+
+// dependentsFirst orders the supplied packages such that each package comes
+// before its dependencies. Packages are otherwise ordered by source, so that
+// the order is deterministic.
+func dependentsFirst(pkgs map[string]v1beta1.LockPackage) []string {
+	dependents := make(map[string]int, len(pkgs))
+	for _, lp := range pkgs {
+		for _, dep := range lp.Dependencies {
+			if _, ok := pkgs[dep.Package]; ok {
+				dependents[dep.Package]++
+			}
+		}
+	}
+
+	ready := make([]string, 0, len(pkgs))
+	for id := range pkgs {
+		if dependents[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	order := make([]string, 0, len(pkgs))
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+		for _, dep := range pkgs[id].Dependencies {
+			if _, ok := pkgs[dep.Package]; !ok {
+				continue
+			}
+			dependents[dep.Package]--
+			if dependents[dep.Package] == 0 {
+				ready = append(ready, dep.Package)
+			}
+		}
+	}
+	return order
+}
+
+// Solve returns true if it found a version of every package that satisfies
+// the constraints of the packages that depend on it.
+func (s *upgradeSolver) Solve(ctx context.Context) (bool, error) {
+	return s.solve(ctx, 0)
+}
+
+func (s *upgradeSolver) solve(ctx context.Context, i int) (bool, error) {
+	if i == len(s.order) {
+		return true, nil
+	}
+	if s.budget < 0 {
+		return false, nil
+	}
+	id := s.order[i]
+	lp := s.packages[id]
+
+	cs, _ := s.constraints(id)
+	candidates, err := s.candidates(ctx, lp, cs)
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range candidates {
+		deps := lp.Dependencies
+		if v != lp.Version {
+			var ok bool
+			deps, ok, err = s.fetch(ctx, lp, v)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				return false, nil
+			}
+		}
+		// A version's dependencies may differ from those of the installed
+		// version. Make sure they're satisfied by the versions we already
+		// chose for the packages that come before it.
+		if !s.satisfied(i, deps) {
+			continue
+		}
+		s.chosen[id], s.deps[id] = v, deps
+		ok, err := s.solve(ctx, i+1)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// candidates returns the versions of the supplied package that satisfy the
+// supplied constraints, in order of preference.
+func (s *upgradeSolver) candidates(ctx context.Context, lp v1beta1.LockPackage, cs []*semver.Constraints) ([]string, error) {
+	current, err := semver.NewVersion(lp.Version)
+
+	// We can't check or change the version of packages that aren't installed
+	// by semantic version tag, e.g. those installed by digest. We never
+	// change the version of packages nothing depends on.
+	if err != nil || len(cs) == 0 {
+		return []string{lp.Version}, nil
+	}
+
+	candidates := make([]string, 0)
+	if satisfies(current, cs...) {
+		candidates = append(candidates, lp.Version)
+	}
+	if s.pinned[lp.Source] {
+		return candidates, nil
+	}
+
+	vs, err := s.tagsOf(ctx, lp)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range vs {
+		if v.Original() != lp.Version && satisfies(v, cs...) {
+			candidates = append(candidates, v.Original())
+		}
+	}
+	return candidates, nil
+}
+
+// highest returns the highest version of the supplied package that satisfies
+// the supplied constraints, or an empty string if none does.
+func (s *upgradeSolver) highest(ctx context.Context, lp v1beta1.LockPackage, cs []*semver.Constraints) (string, error) {
+	vs, err := s.tagsOf(ctx, lp)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range vs {
+		if satisfies(v, cs...) {
+			return v.Original(), nil
+		}
+	}
+	return "", nil
+}
+
+func (s *upgradeSolver) tagsOf(ctx context.Context, lp v1beta1.LockPackage) ([]*semver.Version, error) {
+	if vs, ok := s.tags[lp.Source]; ok {
+		return vs, nil
+	}
+	vs, err := s.versions(ctx, lp)
+	if err != nil {
+		return nil, err
+	}
+	s.tags[lp.Source] = vs
+	return vs, nil
+}
+
+// fetch returns the dependencies of the supplied version of the supplied
+// package. It returns false if we've exhausted our budget.
+func (s *upgradeSolver) fetch(ctx context.Context, lp v1beta1.LockPackage, version string) ([]v1beta1.Dependency, bool, error) {
+	key := fmt.Sprintf(packageTagFmt, lp.Source, version)
+	if deps, ok := s.fetched[key]; ok {
+		return deps, true, nil
+	}
+	s.budget--
+	if s.budget < 0 {
+		return nil, false, nil
+	}
+	deps, err := s.dependencies(ctx, lp, version)
+	if err != nil {
+		return nil, false, err
+	}
+	s.fetched[key] = deps
+	return deps, true, nil
+}
+
+// satisfied returns true if the versions chosen for the first i packages
+// satisfy the supplied dependencies.
+func (s *upgradeSolver) satisfied(i int, deps []v1beta1.Dependency) bool {
+	for _, dep := range deps {
+		j, ok := s.position[dep.Package]
+		if !ok || j >= i {
+			continue
+		}
+		v, err := semver.NewVersion(s.chosen[dep.Package])
+		if err != nil {
+			continue
+		}
+		c, err := semver.NewConstraint(dep.Constraints)
+		if err != nil {
+			continue
+		}
+		if !c.Check(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// constraints returns the constraints the versions chosen for the packages
+// that depend on the supplied package place upon it.
+func (s *upgradeSolver) constraints(id string) ([]*semver.Constraints, []string) {
+	var raw []string
+	for _, dependent := range s.order[:s.position[id]] {
+		for _, dep := range s.deps[dependent] {
+			if dep.Package == id {
+				raw = append(raw, dep.Constraints)
+			}
+		}
+	}
+	return parseConstraints(raw)
+}
+
+// installedConstraints returns the constraints the installed versions of the
+// packages that depend on the supplied package place upon it.
+func (s *upgradeSolver) installedConstraints(id string) []string {
+	var raw []string
+	for _, dependent := range s.order {
+		for _, dep := range s.packages[dependent].Dependencies {
+			if dep.Package == id {
+				raw = append(raw, dep.Constraints)
+			}
+		}
+	}
+	return raw
+}
+
+// parseConstraints parses the supplied constraints, skipping invalid ones. The
+// revisions that depend on a package report its invalid constraints.
+func parseConstraints(raw []string) ([]*semver.Constraints, []string) {
+	cs := make([]*semver.Constraints, 0, len(raw))
+	strs := make([]string, 0, len(raw))
+	for _, c := range raw {
+		sc, err := semver.NewConstraint(c)
+		if err != nil {
+			continue
+		}
+		cs = append(cs, sc)
+		strs = append(strs, c)
+	}
+	return cs, strs
+}
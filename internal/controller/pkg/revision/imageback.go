@@ -97,6 +97,11 @@ func (i *ImageBackend) Init(ctx context.Context, bo ...parser.BackendOption) (io
 	if err != nil {
 		return nil, errors.Wrap(err, errBadReference)
 	}
+	// Fetch the exact image that was verified, if any, in case the tag has
+	// since been moved.
+	if n.digest != "" {
+		ref = ref.Context().Digest(n.digest)
+	}
 	// Fetch image from registry.
 	img, err := i.fetcher.Fetch(ctx, ref, v1.RefNames(n.pr.GetPackagePullSecrets())...)
 	if err != nil {
@@ -178,7 +183,8 @@ func (i *ImageBackend) Init(ctx context.Context, bo ...parser.BackendOption) (io
 // options.
 // NOTE(hasheddan): see usage in ImageBackend Init() for reasoning.
 type nestedBackend struct {
-	pr v1.PackageRevision
+	pr     v1.PackageRevision
+	digest string
 }
 
 // Init is a nop because nestedBackend does not actually meant to act as a
@@ -197,3 +203,14 @@ func PackageRevision(pr v1.PackageRevision) parser.BackendOption {
 		i.pr = pr
 	}
 }
+
+// PackageDigest sets the digest of the package image ImageBackend should fetch.
+func PackageDigest(d string) parser.BackendOption {
+	return func(p parser.Backend) {
+		i, ok := p.(*nestedBackend)
+		if !ok {
+			return
+		}
+		i.digest = d
+	}
+}
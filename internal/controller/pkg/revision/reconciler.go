@@ -53,6 +53,7 @@ import (
 	"github.com/crossplane/crossplane/internal/controller/pkg/controller"
 	"github.com/crossplane/crossplane/internal/dag"
 	"github.com/crossplane/crossplane/internal/features"
+	"github.com/crossplane/crossplane/internal/initializer"
 	"github.com/crossplane/crossplane/internal/version"
 	"github.com/crossplane/crossplane/internal/xpkg"
 )
@@ -121,6 +122,7 @@ const (
 	reasonSync         event.Reason = "SyncPackage"
 	reasonDeactivate   event.Reason = "DeactivateRevision"
 	reasonPaused       event.Reason = "ReconciliationPaused"
+	reasonVerify       event.Reason = "VerifyPackage"
 )
 
 // ReconcilerOption is used to configure the Reconciler.
@@ -207,6 +209,14 @@ func WithParserBackend(p parser.Backend) ReconcilerOption {
 	}
 }
 
+// WithVerifier specifies how the Reconciler should verify the signatures and
+// attestations of package images.
+func WithVerifier(v Verifier) ReconcilerOption {
+	return func(r *Reconciler) {
+		r.verifier = v
+	}
+}
+
 // WithLinter specifies how the Reconciler should lint a package.
 func WithLinter(l parser.Linter) ReconcilerOption {
 	return func(r *Reconciler) {
@@ -260,6 +270,7 @@ type Reconciler struct {
 	objects        Establisher
 	parser         parser.Parser
 	linter         parser.Linter
+	verifier       Verifier
 	versioner      version.Operations
 	backend        parser.Backend
 	log            logging.Logger
@@ -312,6 +323,7 @@ func SetupProviderRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithNewPackageRevisionFn(nr),
 		WithParser(parser.New(metaScheme, objScheme)),
 		WithParserBackend(NewImageBackend(fetcher, WithDefaultRegistry(o.DefaultRegistry))),
+		WithVerifier(NewPolicyVerifier(mgr.GetClient(), xpkg.NewCosignVerifier(fetcher), o.DefaultRegistry)),
 		WithLinter(xpkg.NewProviderLinter()),
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
@@ -364,6 +376,7 @@ func SetupConfigurationRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithEstablisher(NewAPIEstablisher(mgr.GetClient(), o.Namespace)),
 		WithParser(parser.New(metaScheme, objScheme)),
 		WithParserBackend(NewImageBackend(f, WithDefaultRegistry(o.DefaultRegistry))),
+		WithVerifier(NewPolicyVerifier(mgr.GetClient(), xpkg.NewCosignVerifier(f), o.DefaultRegistry)),
 		WithLinter(xpkg.NewConfigurationLinter()),
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
@@ -420,6 +433,7 @@ func SetupFunctionRevision(mgr ctrl.Manager, o controller.Options) error {
 		WithNewPackageRevisionFn(nr),
 		WithParser(parser.New(metaScheme, objScheme)),
 		WithParserBackend(NewImageBackend(fetcher, WithDefaultRegistry(o.DefaultRegistry))),
+		WithVerifier(NewPolicyVerifier(mgr.GetClient(), xpkg.NewCosignVerifier(fetcher), o.DefaultRegistry)),
 		WithLinter(xpkg.NewFunctionLinter()),
 		WithLogger(o.Logger.WithValues("controller", name)),
 		WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
@@ -429,7 +443,8 @@ func SetupFunctionRevision(mgr ctrl.Manager, o controller.Options) error {
 	}
 
 	if o.PackageRuntime == controller.PackageRuntimeDeployment {
-		ro = append(ro, WithRuntimeHooks(NewFunctionHooks(mgr.GetClient(), o.DefaultRegistry)))
+		ro = append(ro, WithRuntimeHooks(NewFunctionHooks(mgr.GetClient(), o.DefaultRegistry,
+			FunctionHooksWithClientDNSName(initializer.DNSNameForServiceAccount(o.ServiceAccount, o.Namespace)))))
 
 		if o.Features.Enabled(features.EnableBetaDeploymentRuntimeConfigs) {
 			cb = cb.Watches(&v1beta1.DeploymentRuntimeConfig{}, &EnqueueRequestForReferencingFunctionRevisions{
@@ -452,6 +467,7 @@ func NewReconciler(mgr manager.Manager, opts ...ReconcilerOption) *Reconciler {
 		objects:   NewNopEstablisher(),
 		parser:    parser.New(nil, nil),
 		linter:    parser.NewPackageLinter(nil, nil, nil),
+		verifier:  NopVerifier{},
 		versioner: version.New(),
 		log:       logging.NewNopLogger(),
 		record:    event.NewNopRecorder(),
@@ -627,6 +643,33 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 		id = pr.GetSource()
 	}
 
+	// Verify the package image if the revision references a verification
+	// policy. We don't need to verify again if the image we cached was
+	// verified against the policy as it is now.
+	var digest string
+	switch {
+	case pr.GetVerificationPolicyRef() == nil:
+	case !r.features.Enabled(features.EnableAlphaPackageVerification):
+		// Don't let anyone believe the package image was verified. We install
+		// it anyway, as we would have before verification was supported.
+		if pr.GetCondition(v1.TypeVerified).Reason != v1.ReasonVerificationDisabled {
+			r.record.Event(pr, event.Warning(reasonVerify, errors.New(errVerificationDisabled)))
+		}
+		pr.SetConditions(v1.VerificationDisabled())
+	default:
+		d, err := r.verify(ctx, pr, id, pullPolicyNever)
+		if err != nil {
+			log.Debug(errVerifyPackage, "error", err)
+			pr.SetConditions(v1.VerificationFailed().WithMessage(err.Error()))
+			_ = r.client.Status().Update(ctx, pr)
+
+			r.record.Event(pr, event.Warning(reasonVerify, err))
+
+			return reconcile.Result{}, err
+		}
+		digest = d
+	}
+
 	var rc io.ReadCloser
 	cacheWrite := make(chan error)
 
@@ -661,7 +704,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	// If we didn't get a ReadCloser from cache, we need to get it from image.
 	if rc == nil {
 		// Initialize parser backend to obtain package contents.
-		imgrc, err := r.backend.Init(ctx, PackageRevision(pr))
+		imgrc, err := r.backend.Init(ctx, PackageRevision(pr), PackageDigest(digest))
 		if err != nil {
 			err = errors.Wrap(err, errInitParserBackend)
 			pr.SetConditions(v1.Unhealthy().WithMessage(err.Error()))
@@ -872,6 +915,40 @@ func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reco
 	return reconcile.Result{Requeue: false}, errors.Wrap(r.client.Status().Update(ctx, pr), errUpdateStatus)
 }
 
+// verify the package revision's image, returning the digest of the verified
+// image. Verification is skipped if the cached image was verified against the
+// VerificationPolicy as it is now. Otherwise any cached contents are discarded
+// so that the image we just verified is fetched instead.
+func (r *Reconciler) verify(ctx context.Context, pr v1.PackageRevision, id string, pullPolicyNever bool) (string, error) {
+	ph, err := r.verifier.PolicyHash(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+
+	vs := pr.GetVerificationStatus()
+	if vs != nil && vs.PolicyHash == ph && vs.Digest != "" && r.cache.Has(id) && pr.GetCondition(v1.TypeVerified).Status == corev1.ConditionTrue {
+		return vs.Digest, nil
+	}
+
+	d, err := r.verifier.Verify(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+	if pr.GetCondition(v1.TypeVerified).Status != corev1.ConditionTrue {
+		r.record.Event(pr, event.Normal(reasonVerify, "Successfully verified package image "+d))
+	}
+	pr.SetConditions(v1.Verified())
+	pr.SetVerificationStatus(&v1.VerificationStatus{Digest: d, PolicyHash: ph})
+
+	// With pull policy Never the cache is the only source of the package. Its
+	// contents are keyed by the source, which the verifier required to
+	// reference the digest it verified.
+	if !pullPolicyNever {
+		_ = r.cache.Delete(id)
+	}
+	return d, nil
+}
+
 func (r *Reconciler) deactivateRevision(ctx context.Context, pr v1.PackageRevision, runtimeManifestBuilder ManifestBuilder) error {
 	// Remove self from the lock if we are present.
 	if err := r.lock.RemoveSelf(ctx, pr); err != nil {
@@ -37,6 +37,7 @@ import (
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/parser"
@@ -45,6 +46,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/internal/features"
 	verfake "github.com/crossplane/crossplane/internal/version/fake"
 	"github.com/crossplane/crossplane/internal/xpkg"
 	xpkgfake "github.com/crossplane/crossplane/internal/xpkg/fake"
@@ -58,6 +60,20 @@ func (e *ErrBackend) Init(_ context.Context, _ ...parser.BackendOption) (io.Read
 	return nil, e.err
 }
 
+var _ parser.Backend = &DigestBackend{}
+
+// DigestBackend returns the digest of the package image it was asked to fetch
+// as an error.
+type DigestBackend struct{}
+
+func (e *DigestBackend) Init(_ context.Context, bo ...parser.BackendOption) (io.ReadCloser, error) {
+	n := &nestedBackend{}
+	for _, o := range bo {
+		o(n)
+	}
+	return nil, errors.New(n.digest)
+}
+
 var _ Establisher = &MockEstablisher{}
 
 type MockEstablisher struct {
@@ -161,6 +177,27 @@ func (m *MockDependencyManager) RemoveSelf(_ context.Context, _ v1.PackageRevisi
 	return m.MockRemoveSelf()
 }
 
+type MockVerifier struct {
+	MockPolicyHash func() (string, error)
+	MockVerify     func() (string, error)
+}
+
+func NewMockPolicyHashFn(h string, err error) func() (string, error) {
+	return func() (string, error) { return h, err }
+}
+
+func NewMockVerifyFn(d string, err error) func() (string, error) {
+	return func() (string, error) { return d, err }
+}
+
+func (v *MockVerifier) PolicyHash(_ context.Context, _ v1.PackageRevision) (string, error) {
+	return v.MockPolicyHash()
+}
+
+func (v *MockVerifier) Verify(_ context.Context, _ v1.PackageRevision) (string, error) {
+	return v.MockVerify()
+}
+
 var providerBytes = []byte(`apiVersion: meta.pkg.crossplane.io/v1
 kind: Provider
 metadata:
@@ -180,6 +217,9 @@ func TestReconcile(t *testing.T) {
 	pullPolicy := corev1.PullNever
 	trueVal := true
 
+	verification := &feature.Flags{}
+	verification.Enable(features.EnableAlphaPackageVerification)
+
 	metaScheme, _ := xpkg.BuildMetaScheme()
 	objScheme, _ := xpkg.BuildObjectScheme()
 
@@ -523,6 +563,258 @@ func TestReconcile(t *testing.T) {
 				err: errors.Wrap(errBoom, errInitParserBackend),
 			},
 		},
+		"ErrVerifyPackage": {
+			reason: "We should return an error if we fail to verify the package image.",
+			args: args{
+				mgr: &fake.Manager{},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithNewPackageRevisionFn(func() v1.PackageRevision { return &v1.ConfigurationRevision{} }),
+					WithClientApplicator(resource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+								pr := o.(*v1.ConfigurationRevision)
+								pr.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								pr.SetDesiredState(v1.PackageRevisionActive)
+								pr.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								return nil
+							}),
+							MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(o client.Object) error {
+								want := &v1.ConfigurationRevision{}
+								want.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								want.SetDesiredState(v1.PackageRevisionActive)
+								want.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								want.SetConditions(v1.VerificationFailed().WithMessage("boom"))
+
+								if diff := cmp.Diff(want, o); diff != "" {
+									t.Errorf("-want, +got:\n%s", diff)
+								}
+								return nil
+							}),
+						},
+					}),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithCache(&xpkgfake.MockCache{
+						MockHas: xpkgfake.NewMockCacheHasFn(false),
+					}),
+					WithFeatureFlags(verification),
+					WithVerifier(&MockVerifier{
+						MockPolicyHash: NewMockPolicyHashFn("c001", nil),
+						MockVerify:     NewMockVerifyFn("", errBoom),
+					}),
+				},
+			},
+			want: want{
+				err: errBoom,
+			},
+		},
+		"VerifiedPackage": {
+			reason: "We should mark the revision as verified and fetch the verified package image.",
+			args: args{
+				mgr: &fake.Manager{},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithNewPackageRevisionFn(func() v1.PackageRevision { return &v1.ConfigurationRevision{} }),
+					WithClientApplicator(resource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+								pr := o.(*v1.ConfigurationRevision)
+								pr.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								pr.SetDesiredState(v1.PackageRevisionActive)
+								pr.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								return nil
+							}),
+							MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(o client.Object) error {
+								want := &v1.ConfigurationRevision{}
+								want.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								want.SetDesiredState(v1.PackageRevisionActive)
+								want.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								want.SetConditions(v1.Verified())
+								want.SetVerificationStatus(&v1.VerificationStatus{Digest: "sha256:c0ffee", PolicyHash: "c001"})
+								want.SetConditions(v1.Unhealthy().WithMessage("cannot initialize parser backend: sha256:c0ffee"))
+
+								if diff := cmp.Diff(want, o); diff != "" {
+									t.Errorf("-want, +got:\n%s", diff)
+								}
+								return nil
+							}),
+						},
+					}),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithCache(&xpkgfake.MockCache{
+						MockHas:    xpkgfake.NewMockCacheHasFn(false),
+						MockDelete: xpkgfake.NewMockCacheDeleteFn(nil),
+					}),
+					WithFeatureFlags(verification),
+					WithVerifier(&MockVerifier{
+						MockPolicyHash: NewMockPolicyHashFn("c001", nil),
+						MockVerify:     NewMockVerifyFn("sha256:c0ffee", nil),
+					}),
+					WithParserBackend(&DigestBackend{}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("sha256:c0ffee"), errInitParserBackend),
+			},
+		},
+		"VerificationDisabled": {
+			reason: "We should mark the revision as not verified, but still fetch its package image, if it references a verification policy while package verification is disabled.",
+			args: args{
+				mgr: &fake.Manager{},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithNewPackageRevisionFn(func() v1.PackageRevision { return &v1.ConfigurationRevision{} }),
+					WithClientApplicator(resource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+								pr := o.(*v1.ConfigurationRevision)
+								pr.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								pr.SetDesiredState(v1.PackageRevisionActive)
+								pr.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								return nil
+							}),
+							MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(o client.Object) error {
+								want := &v1.ConfigurationRevision{}
+								want.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								want.SetDesiredState(v1.PackageRevisionActive)
+								want.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								want.SetConditions(v1.VerificationDisabled())
+								want.SetConditions(v1.Unhealthy().WithMessage("cannot initialize parser backend: "))
+
+								if diff := cmp.Diff(want, o); diff != "" {
+									t.Errorf("-want, +got:\n%s", diff)
+								}
+								return nil
+							}),
+						},
+					}),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithCache(&xpkgfake.MockCache{
+						MockHas:    xpkgfake.NewMockCacheHasFn(false),
+						MockDelete: xpkgfake.NewMockCacheDeleteFn(nil),
+					}),
+					WithVerifier(&MockVerifier{
+						MockVerify: func() (string, error) {
+							t.Errorf("Verify should not be called")
+							return "", nil
+						},
+					}),
+					WithParserBackend(&DigestBackend{}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New(""), errInitParserBackend),
+			},
+		},
+		"PreviouslyVerifiedPackage": {
+			reason: "We should not verify the package image again if the cached image was verified against the current policy.",
+			args: args{
+				mgr: &fake.Manager{},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithNewPackageRevisionFn(func() v1.PackageRevision { return &v1.ConfigurationRevision{} }),
+					WithClientApplicator(resource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+								pr := o.(*v1.ConfigurationRevision)
+								pr.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								pr.SetDesiredState(v1.PackageRevisionActive)
+								pr.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								pr.SetConditions(v1.Verified())
+								pr.SetVerificationStatus(&v1.VerificationStatus{Digest: "sha256:c0ffee", PolicyHash: "c001"})
+								return nil
+							}),
+						},
+					}),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithCache(&xpkgfake.MockCache{
+						MockHas:    xpkgfake.NewMockCacheHasFn(true),
+						MockGet:    xpkgfake.NewMockCacheGetFn(nil, errBoom),
+						MockDelete: xpkgfake.NewMockCacheDeleteFn(nil),
+					}),
+					WithFeatureFlags(verification),
+					WithVerifier(&MockVerifier{
+						MockPolicyHash: NewMockPolicyHashFn("c001", nil),
+						MockVerify: func() (string, error) {
+							t.Errorf("Verify should not be called")
+							return "", nil
+						},
+					}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetCache),
+			},
+		},
+		"VerificationPolicyChanged": {
+			reason: "We should verify the package image again and fetch it if the policy changed since the cached image was verified.",
+			args: args{
+				mgr: &fake.Manager{},
+				req: reconcile.Request{NamespacedName: types.NamespacedName{Name: "test"}},
+				rec: []ReconcilerOption{
+					WithNewPackageRevisionFn(func() v1.PackageRevision { return &v1.ConfigurationRevision{} }),
+					WithClientApplicator(resource.ClientApplicator{
+						Client: &test.MockClient{
+							MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+								pr := o.(*v1.ConfigurationRevision)
+								pr.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								pr.SetDesiredState(v1.PackageRevisionActive)
+								pr.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								pr.SetConditions(v1.Verified())
+								pr.SetVerificationStatus(&v1.VerificationStatus{Digest: "sha256:c0ffee", PolicyHash: "c001"})
+								return nil
+							}),
+							MockStatusUpdate: test.NewMockSubResourceUpdateFn(nil, func(o client.Object) error {
+								want := &v1.ConfigurationRevision{}
+								want.SetGroupVersionKind(v1.ConfigurationRevisionGroupVersionKind)
+								want.SetDesiredState(v1.PackageRevisionActive)
+								want.SetVerificationPolicyRef(&v1.VerificationPolicyReference{Name: "cool-policy"})
+								want.SetConditions(v1.Verified())
+								want.SetVerificationStatus(&v1.VerificationStatus{Digest: "sha256:decaf", PolicyHash: "c002"})
+								want.SetConditions(v1.Unhealthy().WithMessage("cannot initialize parser backend: sha256:decaf"))
+
+								if diff := cmp.Diff(want, o); diff != "" {
+									t.Errorf("-want, +got:\n%s", diff)
+								}
+								return nil
+							}),
+						},
+					}),
+					WithFinalizer(resource.FinalizerFns{AddFinalizerFn: func(_ context.Context, _ resource.Object) error {
+						return nil
+					}}),
+					WithCache(func() *xpkgfake.MockCache {
+						// The cache holds the image verified against the old
+						// policy until the reconciler deletes it.
+						deleted := false
+						return &xpkgfake.MockCache{
+							MockHas: func() bool { return !deleted },
+							MockDelete: func() error {
+								deleted = true
+								return nil
+							},
+						}
+					}()),
+					WithFeatureFlags(verification),
+					WithVerifier(&MockVerifier{
+						MockPolicyHash: NewMockPolicyHashFn("c002", nil),
+						MockVerify:     NewMockVerifyFn("sha256:decaf", nil),
+					}),
+					WithParserBackend(&DigestBackend{}),
+				},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("sha256:decaf"), errInitParserBackend),
+			},
+		},
 		"ErrParseFromCache": {
 			reason: "We should return an error if fail to parse the package from the cache.",
 			args: args{
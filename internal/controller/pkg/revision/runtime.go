@@ -56,6 +56,13 @@ const (
 	tlsClientCertDirEnvVar   = "TLS_CLIENT_CERTS_DIR"
 	tlsClientCertsVolumeName = "tls-client-certs"
 	tlsClientCertsDir        = "/tls/client"
+
+	// tlsAuthorizedClientDNSNameEnvVar tells a Function which DNS name the
+	// client certificate of a caller must have, i.e. that of Crossplane
+	// calling this Function. A Function should reject calls whose client
+	// certificate is signed by the CA in its server certs dir, but doesn't
+	// include this DNS name.
+	tlsAuthorizedClientDNSNameEnvVar = "TLS_AUTHORIZED_CLIENT_DNS_NAME"
 )
 
 var (
@@ -228,7 +235,9 @@ func (b *RuntimeManifestBuilder) Deployment(serviceAccount string, overrides ...
 		allOverrides = append(allOverrides, DeploymentRuntimeWithImagePullPolicy(*b.revision.GetPackagePullPolicy()))
 	}
 
-	if b.revision.GetTLSClientSecretName() != nil {
+	// A Function's TLS client secret stores the certificate Crossplane uses
+	// to call the Function, so we don't mount it in the Function's runtime.
+	if b.revision.GetTLSClientSecretName() != nil && b.packageType() != "function" {
 		allOverrides = append(allOverrides, DeploymentRuntimeWithTLSClientSecret(*b.revision.GetTLSClientSecretName()))
 	}
 
@@ -55,17 +55,39 @@ const (
 type FunctionHooks struct {
 	client          resource.ClientApplicator
 	defaultRegistry string
+
+	// clientDNSName identifies Crossplane in the client certificates it uses
+	// to call Functions.
+	clientDNSName string
+}
+
+// A FunctionHooksOption configures FunctionHooks.
+type FunctionHooksOption func(h *FunctionHooks)
+
+// FunctionHooksWithClientDNSName configures the DNS name that identifies
+// Crossplane in the client certificates it uses to call Functions. Functions
+// are told to only authorize callers with this DNS name.
+func FunctionHooksWithClientDNSName(name string) FunctionHooksOption {
+	return func(h *FunctionHooks) {
+		h.clientDNSName = name
+	}
 }
 
 // NewFunctionHooks returns a new FunctionHooks.
-func NewFunctionHooks(client client.Client, defaultRegistry string) *FunctionHooks {
-	return &FunctionHooks{
+func NewFunctionHooks(client client.Client, defaultRegistry string, o ...FunctionHooksOption) *FunctionHooks {
+	h := &FunctionHooks{
 		client: resource.ClientApplicator{
 			Client:     client,
 			Applicator: resource.NewAPIPatchingApplicator(client),
 		},
 		defaultRegistry: defaultRegistry,
 	}
+
+	for _, fn := range o {
+		fn(h)
+	}
+
+	return h
 }
 
 // Pre performs operations meant to happen before establishing objects.
@@ -99,9 +121,27 @@ func (h *FunctionHooks) Pre(ctx context.Context, _ runtime.Object, pr v1.Package
 		return errors.Wrap(err, errApplyFunctionSecret)
 	}
 
-	if err := initializer.NewTLSCertificateGenerator(secServer.Namespace, initializer.RootCACertSecretName,
+	opts := []initializer.TLSCertificateGeneratorOption{
 		initializer.TLSCertificateGeneratorWithServerSecretName(secServer.GetName(), initializer.DNSNamesForService(svc.Name, svc.Namespace)),
-		initializer.TLSCertificateGeneratorWithOwner([]metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(pr, pr.GetObjectKind().GroupVersionKind()))})).Run(ctx, h.client); err != nil {
+		initializer.TLSCertificateGeneratorWithOwner([]metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(pr, pr.GetObjectKind().GroupVersionKind()))}),
+	}
+
+	// Each Function gets its own client certificate, which Crossplane uses
+	// to call it. The certificate identifies both Crossplane and the
+	// Function it may be used to call. Revisions created by older versions
+	// of Crossplane may not have a client secret yet, in which case
+	// Crossplane falls back to its own client certificate.
+	if h.clientDNSName != "" {
+		if secClient := build.TLSClientSecret(); secClient != nil {
+			if err := h.client.Apply(ctx, secClient); err != nil {
+				return errors.Wrap(err, errApplyFunctionSecret)
+			}
+			names := []string{h.clientDNSName, initializer.DNSNameForFunctionClient(pr.GetLabels()[v1.LabelParentPackage], h.clientDNSName)}
+			opts = append(opts, initializer.TLSCertificateGeneratorWithClientSecretName(secClient.GetName(), names))
+		}
+	}
+
+	if err := initializer.NewTLSCertificateGenerator(secServer.Namespace, initializer.RootCACertSecretName, opts...).Run(ctx, h.client); err != nil {
 		return errors.Wrapf(err, "cannot generate TLS certificates for %q", pr.GetLabels()[v1.LabelParentPackage])
 	}
 
@@ -127,7 +167,14 @@ func (h *FunctionHooks) Post(ctx context.Context, pkg runtime.Object, pr v1.Pack
 		return errors.Wrap(err, errParseFunctionImage)
 	}
 
-	d := build.Deployment(sa.Name, functionDeploymentOverrides(image)...)
+	// Functions with their own client certificate only authorize callers
+	// using it. Others authorize Crossplane's own client certificate.
+	authorized := h.clientDNSName
+	if authorized != "" && build.TLSClientSecret() != nil {
+		authorized = initializer.DNSNameForFunctionClient(pr.GetLabels()[v1.LabelParentPackage], h.clientDNSName)
+	}
+
+	d := build.Deployment(sa.Name, functionDeploymentOverrides(image, authorized)...)
 	// Create/Apply the SA only if the deployment references it.
 	// This is to avoid creating a SA that is NOT used by the deployment when
 	// the SA is managed externally by the user and configured by setting
@@ -176,7 +223,7 @@ func (h *FunctionHooks) Deactivate(ctx context.Context, _ v1.PackageRevisionWith
 	return nil
 }
 
-func functionDeploymentOverrides(image string) []DeploymentOverride {
+func functionDeploymentOverrides(image, clientDNSName string) []DeploymentOverride {
 	do := []DeploymentOverride{
 		DeploymentRuntimeWithAdditionalPorts([]corev1.ContainerPort{
 			{
@@ -188,6 +235,12 @@ func functionDeploymentOverrides(image string) []DeploymentOverride {
 
 	do = append(do, DeploymentRuntimeWithOptionalImage(image))
 
+	if clientDNSName != "" {
+		do = append(do, DeploymentRuntimeWithAdditionalEnvironments([]corev1.EnvVar{
+			{Name: tlsAuthorizedClientDNSNameEnvVar, Value: clientDNSName},
+		}))
+	}
+
 	return do
 }
 
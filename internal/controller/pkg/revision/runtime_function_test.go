@@ -18,6 +18,8 @@ package revision
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"testing"
 
@@ -42,6 +44,7 @@ import (
 func TestFunctionPreHook(t *testing.T) {
 	type args struct {
 		client    client.Client
+		opts      []FunctionHooksOption
 		pkg       runtime.Object
 		rev       v1.PackageRevisionWithRuntime
 		manifests ManifestBuilder
@@ -113,11 +116,94 @@ func TestFunctionPreHook(t *testing.T) {
 				},
 			},
 		},
+		"SuccessWithClientSecret": {
+			reason: "Successful run of pre hook should generate a client certificate that identifies Crossplane, and Crossplane calling the Function.",
+			args: args{
+				opts: []FunctionHooksOption{FunctionHooksWithClientDNSName("crossplane.crossplane-system")},
+				pkg: &pkgmetav1beta1.Function{
+					Spec: pkgmetav1beta1.FunctionSpec{},
+				},
+				rev: &v1beta1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{v1.LabelParentPackage: "cool-fn"},
+					},
+					Spec: v1beta1.FunctionRevisionSpec{
+						PackageRevisionSpec: v1.PackageRevisionSpec{
+							DesiredState: v1.PackageRevisionActive,
+						},
+						PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+							TLSServerSecretName: ptr.To("some-server-secret"),
+							TLSClientSecretName: ptr.To("some-client-secret"),
+						},
+					},
+				},
+				manifests: &MockManifestBuilder{
+					ServiceFn: func(overrides ...ServiceOverride) *corev1.Service {
+						return &corev1.Service{}
+					},
+					TLSServerSecretFn: func() *corev1.Secret {
+						return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "some-server-secret"}}
+					},
+					TLSClientSecretFn: func() *corev1.Secret {
+						return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "some-client-secret"}}
+					},
+				},
+				client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						if svc, ok := obj.(*corev1.Service); ok {
+							svc.Name = "some-service"
+							svc.Namespace = "some-namespace"
+						}
+						return nil
+					},
+					MockPatch: func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						return nil
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						s, ok := obj.(*corev1.Secret)
+						if !ok || s.GetName() != "some-client-secret" {
+							return nil
+						}
+						block, _ := pem.Decode(s.Data[corev1.TLSCertKey])
+						if block == nil {
+							return errors.New("client secret has no certificate")
+						}
+						c, err := x509.ParseCertificate(block.Bytes)
+						if err != nil {
+							return err
+						}
+						if diff := cmp.Diff([]string{"crossplane.crossplane-system", "cool-fn.crossplane.crossplane-system"}, c.DNSNames); diff != "" {
+							return errors.Errorf("client certificate DNS names: -want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				rev: &v1beta1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{v1.LabelParentPackage: "cool-fn"},
+					},
+					Spec: v1beta1.FunctionRevisionSpec{
+						PackageRevisionSpec: v1.PackageRevisionSpec{
+							DesiredState: v1.PackageRevisionActive,
+						},
+						PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
+							TLSServerSecretName: ptr.To("some-server-secret"),
+							TLSClientSecretName: ptr.To("some-client-secret"),
+						},
+					},
+					Status: v1beta1.FunctionRevisionStatus{
+						Endpoint: fmt.Sprintf(serviceEndpointFmt, "some-service", "some-namespace", servicePort),
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			h := NewFunctionHooks(tc.args.client, xpkg.DefaultRegistry)
+			h := NewFunctionHooks(tc.args.client, xpkg.DefaultRegistry, tc.args.opts...)
 			err := h.Pre(context.TODO(), tc.args.pkg, tc.args.rev, tc.args.manifests)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -132,6 +218,7 @@ func TestFunctionPreHook(t *testing.T) {
 
 func TestFunctionPostHook(t *testing.T) {
 	type args struct {
+		opts      []FunctionHooksOption
 		client    client.Client
 		pkg       runtime.Object
 		rev       v1.PackageRevisionWithRuntime
@@ -398,6 +485,73 @@ func TestFunctionPostHook(t *testing.T) {
 				},
 			},
 		},
+		"SuccessfulWithClientSecret": {
+			reason: "Should tell a function with its own client certificate to only authorize callers identified as Crossplane calling the function.",
+			args: args{
+				opts: []FunctionHooksOption{FunctionHooksWithClientDNSName("crossplane.crossplane-system")},
+				pkg:  &pkgmetav1beta1.Function{},
+				rev: &v1beta1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{v1.LabelParentPackage: "cool-fn"},
+					},
+					Spec: v1beta1.FunctionRevisionSpec{
+						PackageRevisionSpec: v1.PackageRevisionSpec{
+							Package:      functionImage,
+							DesiredState: v1.PackageRevisionActive,
+						},
+					},
+				},
+				manifests: &MockManifestBuilder{
+					ServiceAccountFn: func(overrides ...ServiceAccountOverride) *corev1.ServiceAccount {
+						return &corev1.ServiceAccount{}
+					},
+					TLSClientSecretFn: func() *corev1.Secret {
+						return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "some-client-secret"}}
+					},
+					DeploymentFn: func(serviceAccount string, overrides ...DeploymentOverride) *appsv1.Deployment {
+						d := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{}},
+						}}}}
+						for _, o := range overrides {
+							o(d)
+						}
+						want := []corev1.EnvVar{{Name: tlsAuthorizedClientDNSNameEnvVar, Value: "cool-fn.crossplane.crossplane-system"}}
+						if diff := cmp.Diff(want, d.Spec.Template.Spec.Containers[0].Env); diff != "" {
+							t.Errorf("Deployment environment: -want, +got:\n%s", diff)
+						}
+						return d
+					},
+				},
+				client: &test.MockClient{
+					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+						return nil
+					},
+					MockPatch: func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if d, ok := obj.(*appsv1.Deployment); ok {
+							d.Status.Conditions = []appsv1.DeploymentCondition{{
+								Type:   appsv1.DeploymentAvailable,
+								Status: corev1.ConditionTrue,
+							}}
+							return nil
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				rev: &v1beta1.FunctionRevision{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{v1.LabelParentPackage: "cool-fn"},
+					},
+					Spec: v1beta1.FunctionRevisionSpec{
+						PackageRevisionSpec: v1.PackageRevisionSpec{
+							Package:      functionImage,
+							DesiredState: v1.PackageRevisionActive,
+						},
+					},
+				},
+			},
+		},
 		"SuccessfulWithExternallyManagedSA": {
 			reason: "Should be successful without creating an SA, when the SA is managed externally",
 			args: args{
@@ -467,7 +621,7 @@ func TestFunctionPostHook(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			h := NewFunctionHooks(tc.args.client, xpkg.DefaultRegistry)
+			h := NewFunctionHooks(tc.args.client, xpkg.DefaultRegistry, tc.args.opts...)
 			err := h.Post(context.TODO(), tc.args.pkg, tc.args.rev, tc.args.manifests)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
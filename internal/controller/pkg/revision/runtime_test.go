@@ -88,6 +88,7 @@ var (
 			},
 			PackageRevisionRuntimeSpec: v1.PackageRevisionRuntimeSpec{
 				TLSServerSecretName: ptr.To(tlsServerSecretName),
+				TLSClientSecretName: ptr.To(tlsClientSecretName),
 			},
 		},
 	}
@@ -328,12 +329,29 @@ func TestRuntimeManifestBuilderDeployment(t *testing.T) {
 					namespace: namespace,
 				},
 				serviceAccountName: functionRevisionName,
-				overrides:          functionDeploymentOverrides(functionImage),
+				overrides:          functionDeploymentOverrides(functionImage, ""),
 			},
 			want: want{
 				want: deploymentFunction(functionName, functionRevisionName, functionImage),
 			},
 		},
+		"FunctionDeploymentWithClientDNSName": {
+			reason: "The Function should be told which client DNS name to authorize, and Crossplane's client secret should not be mounted",
+			args: args{
+				builder: &RuntimeManifestBuilder{
+					revision:  functionRevision,
+					namespace: namespace,
+				},
+				serviceAccountName: functionRevisionName,
+				overrides:          functionDeploymentOverrides(functionImage, "crossplane.crossplane-system"),
+			},
+			want: want{
+				want: deploymentFunction(functionName, functionRevisionName, functionImage, func(deployment *appsv1.Deployment) {
+					deployment.Spec.Template.Spec.Containers[0].Env = append(deployment.Spec.Template.Spec.Containers[0].Env,
+						corev1.EnvVar{Name: "TLS_AUTHORIZED_CLIENT_DNS_NAME", Value: "crossplane.crossplane-system"})
+				}),
+			},
+		},
 		"FunctionDeploymentWithControllerConfig": {
 			reason: "Overrides from the controller config should be applied to the deployment",
 			args: args{
@@ -347,7 +365,7 @@ func TestRuntimeManifestBuilderDeployment(t *testing.T) {
 					},
 				},
 				serviceAccountName: functionRevisionName,
-				overrides:          functionDeploymentOverrides(functionImage),
+				overrides:          functionDeploymentOverrides(functionImage, ""),
 			},
 			want: want{
 				want: deploymentFunction(functionName, functionRevisionName, functionImage, func(deployment *appsv1.Deployment) {
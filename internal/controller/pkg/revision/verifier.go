@@ -0,0 +1,149 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	"github.com/crossplane/crossplane/internal/xpkg"
+)
+
+const (
+	errNoVerifier            = "no package verifier configured"
+	errNoVerificationPolicy  = "package revision does not reference a verification policy"
+	errGetVerificationPolicy = "cannot get verification policy"
+	errVerifyPackage         = "cannot verify package"
+	errHashPolicy            = "cannot hash verification policy"
+	errPullNeverNotDigest    = "cannot verify a package with pull policy Never unless its source references a digest"
+	errFmtDigestMismatch     = "verified package image digest %q does not match the digest %q of the cached package"
+	errVerificationDisabled  = "package references a verification policy, but package verification is disabled; the package image was not verified"
+)
+
+// A Verifier verifies the signatures and attestations of a package revision's
+// image.
+type Verifier interface {
+	// PolicyHash returns a hash of the VerificationPolicy the package revision
+	// references. A package image that was verified against a policy with the
+	// same hash doesn't need to be verified again.
+	PolicyHash(ctx context.Context, pr v1.PackageRevision) (string, error)
+
+	// Verify the package revision's image, returning the digest of the
+	// verified image.
+	Verify(ctx context.Context, pr v1.PackageRevision) (string, error)
+}
+
+// A NopVerifier returns an error for any package revision it's asked to
+// verify.
+type NopVerifier struct{}
+
+// PolicyHash always returns an error.
+func (NopVerifier) PolicyHash(_ context.Context, _ v1.PackageRevision) (string, error) {
+	return "", errors.New(errNoVerifier)
+}
+
+// Verify always returns an error.
+func (NopVerifier) Verify(_ context.Context, _ v1.PackageRevision) (string, error) {
+	return "", errors.New(errNoVerifier)
+}
+
+// A PolicyVerifier verifies a package revision's image against the
+// VerificationPolicy the revision references.
+type PolicyVerifier struct {
+	client   client.Reader
+	verifier xpkg.Verifier
+	registry string
+}
+
+// NewPolicyVerifier returns a Verifier that verifies a package revision's image
+// against the VerificationPolicy the revision references.
+func NewPolicyVerifier(c client.Reader, v xpkg.Verifier, registry string) *PolicyVerifier {
+	return &PolicyVerifier{client: c, verifier: v, registry: registry}
+}
+
+// PolicyHash returns a hash of the spec of the VerificationPolicy the package
+// revision references.
+func (v *PolicyVerifier) PolicyHash(ctx context.Context, pr v1.PackageRevision) (string, error) {
+	vp, err := v.policy(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+	j, err := json.Marshal(vp.Spec)
+	if err != nil {
+		return "", errors.Wrap(err, errHashPolicy)
+	}
+	h := sha256.Sum256(j)
+	return hex.EncodeToString(h[:]), nil
+}
+
+// Verify the signatures and attestations of a package revision's image. A
+// package with pull policy Never is installed from the package cache, so its
+// source must reference the digest of the cached image. That digest is
+// verified, rather than whatever the source's tag currently resolves to.
+func (v *PolicyVerifier) Verify(ctx context.Context, pr v1.PackageRevision) (string, error) {
+	vp, err := v.policy(ctx, pr)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := name.ParseReference(pr.GetSource(), name.WithDefaultRegistry(v.registry))
+	if err != nil {
+		return "", errors.Wrap(err, errBadReference)
+	}
+
+	var pinned string
+	if pp := pr.GetPackagePullPolicy(); pp != nil && *pp == corev1.PullNever {
+		d, ok := ref.(name.Digest)
+		if !ok {
+			return "", errors.New(errPullNeverNotDigest)
+		}
+		pinned = d.DigestStr()
+	}
+
+	h, err := v.verifier.Verify(ctx, ref, vp.Spec, v1.RefNames(pr.GetPackagePullSecrets())...)
+	if err != nil {
+		return "", errors.Wrap(err, errVerifyPackage)
+	}
+	if pinned != "" && h.String() != pinned {
+		return "", errors.Errorf(errFmtDigestMismatch, h.String(), pinned)
+	}
+	return h.String(), nil
+}
+
+func (v *PolicyVerifier) policy(ctx context.Context, pr v1.PackageRevision) (*v1alpha1.VerificationPolicy, error) {
+	pref := pr.GetVerificationPolicyRef()
+	if pref == nil {
+		return nil, errors.New(errNoVerificationPolicy)
+	}
+
+	vp := &v1alpha1.VerificationPolicy{}
+	if err := v.client.Get(ctx, types.NamespacedName{Name: pref.Name}, vp); err != nil {
+		return nil, errors.Wrap(err, errGetVerificationPolicy)
+	}
+	return vp, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package revision
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/name"
+	conregv1 "github.com/google/go-containerregistry/pkg/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	"github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+)
+
+const (
+	testDigest  = "sha256:c0ffeec0ffeec0ffeec0ffeec0ffeec0ffeec0ffeec0ffeec0ffeec0ffeec0ff"
+	otherDigest = "sha256:decafdecafdecafdecafdecafdecafdecafdecafdecafdecafdecafdecafdeca"
+)
+
+type MockXpkgVerifier struct {
+	MockVerify func(ref name.Reference) (conregv1.Hash, error)
+}
+
+func (v *MockXpkgVerifier) Verify(_ context.Context, ref name.Reference, _ v1alpha1.VerificationPolicySpec, _ ...string) (conregv1.Hash, error) {
+	return v.MockVerify(ref)
+}
+
+func TestPolicyVerifierVerify(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	getPolicy := test.NewMockGetFn(nil, func(o client.Object) error {
+		o.(*v1alpha1.VerificationPolicy).Spec = v1alpha1.VerificationPolicySpec{
+			Authorities: []v1alpha1.VerificationAuthority{{Name: "cool"}},
+		}
+		return nil
+	})
+
+	type args struct {
+		c  client.Reader
+		v  *MockXpkgVerifier
+		pr v1.PackageRevision
+	}
+	type want struct {
+		digest string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"NoPolicyReference": {
+			reason: "We should return an error if the revision doesn't reference a verification policy.",
+			args: args{
+				pr: &v1.ConfigurationRevision{},
+			},
+			want: want{
+				err: errors.New(errNoVerificationPolicy),
+			},
+		},
+		"GetPolicyError": {
+			reason: "We should return an error if we can't get the verification policy.",
+			args: args{
+				c: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+				pr: &v1.ConfigurationRevision{Spec: v1.PackageRevisionSpec{
+					VerificationPolicyRef: &v1.VerificationPolicyReference{Name: "cool-policy"},
+				}},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errGetVerificationPolicy),
+			},
+		},
+		"Verified": {
+			reason: "We should return the digest of the verified image.",
+			args: args{
+				c: &test.MockClient{MockGet: getPolicy},
+				v: &MockXpkgVerifier{MockVerify: func(_ name.Reference) (conregv1.Hash, error) {
+					return conregv1.NewHash(testDigest)
+				}},
+				pr: &v1.ConfigurationRevision{Spec: v1.PackageRevisionSpec{
+					Package:               "xpkg.upbound.io/cool/package:v1",
+					VerificationPolicyRef: &v1.VerificationPolicyReference{Name: "cool-policy"},
+				}},
+			},
+			want: want{
+				digest: testDigest,
+			},
+		},
+		"PullNeverTag": {
+			reason: "We should refuse to verify a package with pull policy Never whose source doesn't reference a digest.",
+			args: args{
+				c: &test.MockClient{MockGet: getPolicy},
+				pr: &v1.ConfigurationRevision{Spec: v1.PackageRevisionSpec{
+					Package:               "xpkg.upbound.io/cool/package:v1",
+					PackagePullPolicy:     ptr.To(corev1.PullNever),
+					VerificationPolicyRef: &v1.VerificationPolicyReference{Name: "cool-policy"},
+				}},
+			},
+			want: want{
+				err: errors.New(errPullNeverNotDigest),
+			},
+		},
+		"PullNeverDigestMismatch": {
+			reason: "We should return an error if the verified digest isn't the digest of the cached package.",
+			args: args{
+				c: &test.MockClient{MockGet: getPolicy},
+				v: &MockXpkgVerifier{MockVerify: func(_ name.Reference) (conregv1.Hash, error) {
+					return conregv1.NewHash(otherDigest)
+				}},
+				pr: &v1.ConfigurationRevision{Spec: v1.PackageRevisionSpec{
+					Package:               "xpkg.upbound.io/cool/package@" + testDigest,
+					PackagePullPolicy:     ptr.To(corev1.PullNever),
+					VerificationPolicyRef: &v1.VerificationPolicyReference{Name: "cool-policy"},
+				}},
+			},
+			want: want{
+				err: errors.Errorf(errFmtDigestMismatch, otherDigest, testDigest),
+			},
+		},
+		"PullNeverVerified": {
+			reason: "We should verify the digest the source of a package with pull policy Never references.",
+			args: args{
+				c: &test.MockClient{MockGet: getPolicy},
+				v: &MockXpkgVerifier{MockVerify: func(ref name.Reference) (conregv1.Hash, error) {
+					return conregv1.NewHash(ref.Identifier())
+				}},
+				pr: &v1.ConfigurationRevision{Spec: v1.PackageRevisionSpec{
+					Package:               "xpkg.upbound.io/cool/package@" + testDigest,
+					PackagePullPolicy:     ptr.To(corev1.PullNever),
+					VerificationPolicyRef: &v1.VerificationPolicyReference{Name: "cool-policy"},
+				}},
+			},
+			want: want{
+				digest: testDigest,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v := NewPolicyVerifier(tc.args.c, tc.args.v, "xpkg.upbound.io")
+			d, err := v.Verify(context.Background(), tc.args.pr)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nVerify(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.digest, d); diff != "" {
+				t.Errorf("\n%s\nVerify(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPolicyVerifierPolicyHash(t *testing.T) {
+	pr := &v1.ConfigurationRevision{Spec: v1.PackageRevisionSpec{
+		VerificationPolicyRef: &v1.VerificationPolicyReference{Name: "cool-policy"},
+	}}
+	withAuthority := func(a string) client.Reader {
+		return &test.MockClient{MockGet: test.NewMockGetFn(nil, func(o client.Object) error {
+			o.(*v1alpha1.VerificationPolicy).Spec = v1alpha1.VerificationPolicySpec{
+				Authorities: []v1alpha1.VerificationAuthority{{Name: a}},
+			}
+			return nil
+		})}
+	}
+
+	h1, err := NewPolicyVerifier(withAuthority("cool"), nil, "").PolicyHash(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("PolicyHash(...): %s", err)
+	}
+	h2, err := NewPolicyVerifier(withAuthority("cool"), nil, "").PolicyHash(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("PolicyHash(...): %s", err)
+	}
+	h3, err := NewPolicyVerifier(withAuthority("cooler"), nil, "").PolicyHash(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("PolicyHash(...): %s", err)
+	}
+
+	if h1 != h2 {
+		t.Errorf("PolicyHash(...): want the same hash for the same policy, got %q and %q", h1, h2)
+	}
+	if h1 == h3 {
+		t.Errorf("PolicyHash(...): want different hashes for different policies, got %q", h1)
+	}
+}
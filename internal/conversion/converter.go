@@ -0,0 +1,228 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/structpb"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+
+	"github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/xfn"
+)
+
+// FunctionContextKeyDesiredVersion is the Function context key that tells a
+// conversion Function which version to convert to.
+const FunctionContextKeyDesiredVersion = "apiextensions.crossplane.io/desired-version"
+
+const (
+	errNoFunctionRunner = "cannot run conversion Function: Composition Functions are not enabled"
+	errFmtNoConversion  = "cannot convert from version %q to version %q: no conversion is declared between them, or via storage version %q"
+	errFmtGetField      = "cannot get field %q"
+	errFmtDeleteField   = "cannot delete field %q"
+	errFmtSetField      = "cannot set field %q"
+	errFmtRunFunction   = "cannot run conversion Function %q"
+	errFmtFatalResult   = "conversion Function %q returned a fatal result: %s"
+	errFmtNoDesired     = "conversion Function %q didn't return a desired composite resource"
+	errStructFromObject = "cannot create protobuf Struct from object"
+	errBuildContext     = "cannot build Function context"
+)
+
+// A Converter converts a composite resource or claim to another version.
+type Converter interface {
+	Convert(ctx context.Context, xrd *v1.CompositeResourceDefinition, obj *kunstructured.Unstructured, toVersion string) (*kunstructured.Unstructured, error)
+}
+
+// A ConverterFn converts a composite resource or claim to another version.
+type ConverterFn func(ctx context.Context, xrd *v1.CompositeResourceDefinition, obj *kunstructured.Unstructured, toVersion string) (*kunstructured.Unstructured, error)
+
+// Convert the supplied object to the supplied version.
+func (fn ConverterFn) Convert(ctx context.Context, xrd *v1.CompositeResourceDefinition, obj *kunstructured.Unstructured, toVersion string) (*kunstructured.Unstructured, error) {
+	return fn(ctx, xrd, obj, toVersion)
+}
+
+// A DeclarativeConverter converts composite resources and claims using the
+// conversions declared by their CompositeResourceDefinition.
+type DeclarativeConverter struct {
+	runner xfn.FunctionRunner
+}
+
+// A DeclarativeConverterOption configures a DeclarativeConverter.
+type DeclarativeConverterOption func(c *DeclarativeConverter)
+
+// WithFunctionRunner configures the FunctionRunner a DeclarativeConverter uses
+// to run conversion Functions.
+func WithFunctionRunner(r xfn.FunctionRunner) DeclarativeConverterOption {
+	return func(c *DeclarativeConverter) {
+		c.runner = r
+	}
+}
+
+// NewDeclarativeConverter returns a Converter that converts composite
+// resources and claims using the conversions declared by their XRD.
+func NewDeclarativeConverter(opts ...DeclarativeConverterOption) *DeclarativeConverter {
+	c := &DeclarativeConverter{}
+	for _, fn := range opts {
+		fn(c)
+	}
+	return c
+}
+
+// Convert the supplied object to the supplied version. Objects are converted
+// using the conversion the XRD declares from the object's version to the
+// desired version. If there is no such conversion the object is converted to
+// the storage version, then from the storage version to the desired version.
+// It's an error if neither path is declared.
+func (c *DeclarativeConverter) Convert(ctx context.Context, xrd *v1.CompositeResourceDefinition, obj *kunstructured.Unstructured, toVersion string) (*kunstructured.Unstructured, error) {
+	from := obj.GetObjectKind().GroupVersionKind().Version
+	storage := xrd.GetCompositeGroupVersionKind().Version
+
+	if conv, _ := xrd.Spec.GetConversion(from, toVersion); conv != nil || from == toVersion {
+		return c.convert(ctx, xrd, obj, toVersion)
+	}
+
+	toStorage, _ := xrd.Spec.GetConversion(from, storage)
+	fromStorage, _ := xrd.Spec.GetConversion(storage, toVersion)
+	if toStorage == nil || fromStorage == nil {
+		return nil, errors.Errorf(errFmtNoConversion, from, toVersion, storage)
+	}
+
+	via, err := c.convert(ctx, xrd, obj, storage)
+	if err != nil {
+		return nil, err
+	}
+	return c.convert(ctx, xrd, via, toVersion)
+}
+
+// convert the supplied object to the supplied version using the conversion the
+// XRD declares directly between the two versions. An object that is already at
+// the supplied version is returned unchanged.
+func (c *DeclarativeConverter) convert(ctx context.Context, xrd *v1.CompositeResourceDefinition, obj *kunstructured.Unstructured, toVersion string) (*kunstructured.Unstructured, error) {
+	from := obj.GetObjectKind().GroupVersionKind()
+	if from.Version == toVersion {
+		return obj.DeepCopy(), nil
+	}
+
+	conv, reverse := xrd.Spec.GetConversion(from.Version, toVersion)
+	if conv == nil {
+		return nil, errors.Errorf(errFmtNoConversion, from.Version, toVersion, xrd.GetCompositeGroupVersionKind().Version)
+	}
+
+	var out *kunstructured.Unstructured
+	var err error
+	if conv.FunctionRef != nil {
+		out, err = c.convertWithFunction(ctx, conv.FunctionRef.Name, obj, toVersion)
+	} else {
+		out, err = convertWithMappings(obj, conv.Mappings, reverse)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	out.SetAPIVersion(schema.GroupVersion{Group: from.Group, Version: toVersion}.String())
+	return out, nil
+}
+
+// convertWithMappings moves the fields of the supplied object according to
+// the supplied mappings. All mapped fields are read before any are written, so
+// mappings may swap fields.
+func convertWithMappings(obj *kunstructured.Unstructured, mappings []v1.ConversionMapping, reverse bool) (*kunstructured.Unstructured, error) {
+	out := obj.DeepCopy()
+	in := fieldpath.Pave(obj.Object)
+	p := fieldpath.Pave(out.Object)
+
+	type move struct {
+		to    string
+		value any
+	}
+	moves := make([]move, 0, len(mappings))
+	for _, m := range mappings {
+		from, to := m.FromFieldPath, m.ToFieldPath
+		if reverse {
+			from, to = to, from
+		}
+		v, err := in.GetValue(from)
+		if fieldpath.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtGetField, from)
+		}
+		if err := p.DeleteField(from); err != nil {
+			return nil, errors.Wrapf(err, errFmtDeleteField, from)
+		}
+		moves = append(moves, move{to: to, value: v})
+	}
+
+	for _, m := range moves {
+		if err := p.SetValue(m.to, m.value); err != nil {
+			return nil, errors.Wrapf(err, errFmtSetField, m.to)
+		}
+	}
+
+	return out, nil
+}
+
+// convertWithFunction converts the supplied object by running the named
+// Function. The object is sent as the observed composite resource, and the
+// Function returns the converted object as the desired composite resource.
+// The object's metadata is preserved, because a conversion may not change it.
+func (c *DeclarativeConverter) convertWithFunction(ctx context.Context, name string, obj *kunstructured.Unstructured, toVersion string) (*kunstructured.Unstructured, error) {
+	if c.runner == nil {
+		return nil, errors.New(errNoFunctionRunner)
+	}
+
+	s, err := structpb.NewStruct(obj.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, errStructFromObject)
+	}
+	fctx, err := structpb.NewStruct(map[string]any{FunctionContextKeyDesiredVersion: toVersion})
+	if err != nil {
+		return nil, errors.Wrap(err, errBuildContext)
+	}
+
+	req := &v1beta1.RunFunctionRequest{
+		Observed: &v1beta1.State{Composite: &v1beta1.Resource{Resource: s}},
+		Context:  fctx,
+	}
+	rsp, err := c.runner.RunFunction(ctx, name, req)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtRunFunction, name)
+	}
+
+	for _, rs := range rsp.GetResults() {
+		if rs.GetSeverity() == v1beta1.Severity_SEVERITY_FATAL {
+			return nil, errors.Errorf(errFmtFatalResult, name, rs.GetMessage())
+		}
+	}
+
+	d := rsp.GetDesired().GetComposite().GetResource()
+	if d == nil {
+		return nil, errors.Errorf(errFmtNoDesired, name)
+	}
+
+	out := &kunstructured.Unstructured{Object: d.AsMap()}
+	out.Object["metadata"] = obj.DeepCopy().Object["metadata"]
+	out.SetKind(obj.GetKind())
+	return out, nil
+}
@@ -0,0 +1,313 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/composite"
+)
+
+func TestConvert(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	obj := func() *kunstructured.Unstructured {
+		return &kunstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.org/v1alpha1",
+			"kind":       "XDatabase",
+			"metadata":   map[string]any{"name": "cool-db"},
+			"spec": map[string]any{
+				"size":    "large",
+				"engine":  "postgres",
+				"storage": int64(20),
+			},
+		}}
+	}
+
+	xrd := &v1.CompositeResourceDefinition{
+		Spec: v1.CompositeResourceDefinitionSpec{
+			Conversions: []v1.CompositeResourceConversion{
+				{
+					FromVersion: "v1alpha1",
+					ToVersion:   "v1beta1",
+					Mappings: []v1.ConversionMapping{
+						{FromFieldPath: "spec.size", ToFieldPath: "spec.parameters.size"},
+						{FromFieldPath: "spec.storage", ToFieldPath: "spec.parameters.storageGB"},
+					},
+				},
+				{
+					FromVersion: "v1beta1",
+					ToVersion:   "v1",
+					FunctionRef: &v1.FunctionReference{Name: "function-convert"},
+				},
+			},
+		},
+	}
+
+	type params struct {
+		opts []DeclarativeConverterOption
+	}
+	type args struct {
+		xrd       *v1.CompositeResourceDefinition
+		obj       *kunstructured.Unstructured
+		toVersion string
+	}
+	type want struct {
+		obj *kunstructured.Unstructured
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		params params
+		args   args
+		want   want
+	}{
+		"NoConversion": {
+			reason: "We should return an error if the XRD declares no conversion between the versions, directly or via the storage version.",
+			args: args{
+				xrd: &v1.CompositeResourceDefinition{
+					Spec: v1.CompositeResourceDefinitionSpec{
+						Versions: []v1.CompositeResourceDefinitionVersion{
+							{Name: "v1alpha1"},
+							{Name: "v1beta1"},
+							{Name: "v1", Referenceable: true},
+						},
+						Conversions: []v1.CompositeResourceConversion{
+							{FromVersion: "v1alpha1", ToVersion: "v1"},
+						},
+					},
+				},
+				obj:       obj(),
+				toVersion: "v1beta1",
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoConversion, "v1alpha1", "v1beta1", "v1"),
+			},
+		},
+		"ConvertViaStorageVersion": {
+			reason: "We should convert to the storage version, then to the desired version, if the XRD declares no direct conversion between the versions.",
+			args: args{
+				xrd: &v1.CompositeResourceDefinition{
+					Spec: v1.CompositeResourceDefinitionSpec{
+						Versions: []v1.CompositeResourceDefinitionVersion{
+							{Name: "v1alpha1"},
+							{Name: "v1beta1", Referenceable: true},
+							{Name: "v1"},
+						},
+						Conversions: []v1.CompositeResourceConversion{
+							{
+								FromVersion: "v1alpha1",
+								ToVersion:   "v1beta1",
+								Mappings: []v1.ConversionMapping{
+									{FromFieldPath: "spec.size", ToFieldPath: "spec.parameters.size"},
+								},
+							},
+							{
+								FromVersion: "v1",
+								ToVersion:   "v1beta1",
+								Mappings: []v1.ConversionMapping{
+									{FromFieldPath: "spec.sizing", ToFieldPath: "spec.parameters.size"},
+								},
+							},
+						},
+					},
+				},
+				obj:       obj(),
+				toVersion: "v1",
+			},
+			want: want{
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1",
+					"kind":       "XDatabase",
+					"metadata":   map[string]any{"name": "cool-db"},
+					"spec": map[string]any{
+						"engine":     "postgres",
+						"parameters": map[string]any{},
+						"sizing":     "large",
+						"storage":    int64(20),
+					},
+				}},
+			},
+		},
+		"Mappings": {
+			reason: "We should move mapped fields and copy unmapped fields.",
+			args: args{
+				xrd:       xrd,
+				obj:       obj(),
+				toVersion: "v1beta1",
+			},
+			want: want{
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1beta1",
+					"kind":       "XDatabase",
+					"metadata":   map[string]any{"name": "cool-db"},
+					"spec": map[string]any{
+						"engine": "postgres",
+						"parameters": map[string]any{
+							"size":      "large",
+							"storageGB": int64(20),
+						},
+					},
+				}},
+			},
+		},
+		"ReversedMappings": {
+			reason: "We should reverse mappings to convert from a conversion's toVersion to its fromVersion.",
+			args: args{
+				xrd: xrd,
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1beta1",
+					"kind":       "XDatabase",
+					"metadata":   map[string]any{"name": "cool-db"},
+					"spec": map[string]any{
+						"engine": "postgres",
+						"parameters": map[string]any{
+							"size": "large",
+						},
+					},
+				}},
+				toVersion: "v1alpha1",
+			},
+			want: want{
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1alpha1",
+					"kind":       "XDatabase",
+					"metadata":   map[string]any{"name": "cool-db"},
+					"spec": map[string]any{
+						"engine":     "postgres",
+						"size":       "large",
+						"parameters": map[string]any{},
+					},
+				}},
+			},
+		},
+		"NoFunctionRunner": {
+			reason: "We should return an error if a conversion uses a Function but Functions aren't enabled.",
+			args: args{
+				xrd: xrd,
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1beta1",
+					"kind":       "XDatabase",
+				}},
+				toVersion: "v1",
+			},
+			want: want{
+				err: errors.New(errNoFunctionRunner),
+			},
+		},
+		"RunFunctionError": {
+			reason: "We should return an error if we can't run the conversion Function.",
+			params: params{
+				opts: []DeclarativeConverterOption{WithFunctionRunner(composite.FunctionRunnerFn(func(_ context.Context, _ string, _ *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error) {
+					return nil, errBoom
+				}))},
+			},
+			args: args{
+				xrd: xrd,
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1beta1",
+					"kind":       "XDatabase",
+				}},
+				toVersion: "v1",
+			},
+			want: want{
+				err: errors.Wrapf(errBoom, errFmtRunFunction, "function-convert"),
+			},
+		},
+		"FatalResult": {
+			reason: "We should return an error if the conversion Function returns a fatal result.",
+			params: params{
+				opts: []DeclarativeConverterOption{WithFunctionRunner(composite.FunctionRunnerFn(func(_ context.Context, _ string, _ *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error) {
+					return &v1beta1.RunFunctionResponse{Results: []*v1beta1.Result{{Severity: v1beta1.Severity_SEVERITY_FATAL, Message: "nope"}}}, nil
+				}))},
+			},
+			args: args{
+				xrd: xrd,
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1beta1",
+					"kind":       "XDatabase",
+				}},
+				toVersion: "v1",
+			},
+			want: want{
+				err: errors.Errorf(errFmtFatalResult, "function-convert", "nope"),
+			},
+		},
+		"Function": {
+			reason: "We should return the desired composite resource returned by the conversion Function, with the original metadata.",
+			params: params{
+				opts: []DeclarativeConverterOption{WithFunctionRunner(composite.FunctionRunnerFn(func(_ context.Context, name string, req *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error) {
+					if name != "function-convert" {
+						return nil, errors.Errorf("unexpected Function %q", name)
+					}
+					if v := req.GetContext().GetFields()[FunctionContextKeyDesiredVersion].GetStringValue(); v != "v1" {
+						return nil, errors.Errorf("unexpected desired version %q", v)
+					}
+					xr := req.GetObserved().GetComposite().GetResource().AsMap()
+					xr["spec"] = map[string]any{"tier": "gold"}
+					xr["metadata"] = map[string]any{"name": "changed"}
+					s, err := structpb.NewStruct(xr)
+					if err != nil {
+						return nil, err
+					}
+					return &v1beta1.RunFunctionResponse{Desired: &v1beta1.State{Composite: &v1beta1.Resource{Resource: s}}}, nil
+				}))},
+			},
+			args: args{
+				xrd: xrd,
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1beta1",
+					"kind":       "XDatabase",
+					"metadata":   map[string]any{"name": "cool-db"},
+				}},
+				toVersion: "v1",
+			},
+			want: want{
+				obj: &kunstructured.Unstructured{Object: map[string]any{
+					"apiVersion": "example.org/v1",
+					"kind":       "XDatabase",
+					"metadata":   map[string]any{"name": "cool-db"},
+					"spec":       map[string]any{"tier": "gold"},
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewDeclarativeConverter(tc.params.opts...)
+			got, err := c.Convert(context.Background(), tc.args.xrd, tc.args.obj, tc.args.toVersion)
+			if diff := cmp.Diff(tc.want.obj, got); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package conversion contains the Handler for the webhook that converts
+// composite resources and claims between versions.
+package conversion
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	apiextensionscontroller "github.com/crossplane/crossplane/internal/controller/apiextensions/controller"
+	"github.com/crossplane/crossplane/internal/xcrd"
+)
+
+const (
+	errDecodeReview    = "cannot decode ConversionReview"
+	errNoRequest       = "ConversionReview has no request"
+	errListXRDs        = "cannot list CompositeResourceDefinitions"
+	errFmtUnmarshalObj = "cannot unmarshal object at index %d"
+	errFmtNoXRD        = "no CompositeResourceDefinition defines %q"
+	errFmtConvertObj   = "cannot convert %s %q to version %q"
+	errParseAPIVersion = "cannot parse desired API version"
+	errFmtWrongGroup   = "cannot convert %q to a different API group %q"
+)
+
+// SetupWebhookWithManager registers the conversion webhook with the manager.
+func SetupWebhookWithManager(mgr ctrl.Manager, o apiextensionscontroller.Options) {
+	mgr.GetWebhookServer().Register(xcrd.ConversionWebhookPath, NewHandler(
+		mgr.GetClient(),
+		WithConverter(NewDeclarativeConverter(WithFunctionRunner(o.FunctionRunner))),
+		WithLogger(o.Logger.WithValues("webhook", "conversion")),
+	))
+}
+
+// A Handler serves ConversionReviews for composite resources and claims.
+type Handler struct {
+	reader    client.Reader
+	converter Converter
+	log       logging.Logger
+}
+
+// A HandlerOption configures a Handler.
+type HandlerOption func(*Handler)
+
+// WithLogger configures the logger for the Handler.
+func WithLogger(l logging.Logger) HandlerOption {
+	return func(h *Handler) {
+		h.log = l
+	}
+}
+
+// WithConverter configures how the Handler converts objects.
+func WithConverter(c Converter) HandlerOption {
+	return func(h *Handler) {
+		h.converter = c
+	}
+}
+
+// NewHandler returns a new Handler.
+func NewHandler(reader client.Reader, opts ...HandlerOption) *Handler {
+	h := &Handler{
+		reader:    reader,
+		converter: NewDeclarativeConverter(),
+		log:       logging.NewNopLogger(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	return h
+}
+
+// ServeHTTP serves a ConversionReview.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	review := &extv1.ConversionReview{}
+	if err := json.NewDecoder(r.Body).Decode(review); err != nil {
+		http.Error(w, errors.Wrap(err, errDecodeReview).Error(), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(w, errNoRequest, http.StatusBadRequest)
+		return
+	}
+
+	review.Response = h.Convert(r.Context(), review.Request)
+	review.Request = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(review); err != nil {
+		h.log.Info("Cannot write ConversionReview response", "error", err)
+	}
+}
+
+// Convert the objects in the supplied ConversionRequest.
+func (h *Handler) Convert(ctx context.Context, req *extv1.ConversionRequest) *extv1.ConversionResponse {
+	objs, err := h.convert(ctx, req)
+	if err != nil {
+		h.log.Debug("Cannot convert objects", "uid", req.UID, "error", err)
+		return &extv1.ConversionResponse{
+			UID:    req.UID,
+			Result: metav1.Status{Status: metav1.StatusFailure, Message: err.Error()},
+		}
+	}
+	return &extv1.ConversionResponse{
+		UID:              req.UID,
+		ConvertedObjects: objs,
+		Result:           metav1.Status{Status: metav1.StatusSuccess},
+	}
+}
+
+func (h *Handler) convert(ctx context.Context, req *extv1.ConversionRequest) ([]runtime.RawExtension, error) {
+	to, err := schema.ParseGroupVersion(req.DesiredAPIVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseAPIVersion)
+	}
+
+	xrds := &v1.CompositeResourceDefinitionList{}
+	if err := h.reader.List(ctx, xrds); err != nil {
+		return nil, errors.Wrap(err, errListXRDs)
+	}
+
+	out := make([]runtime.RawExtension, len(req.Objects))
+	for i, raw := range req.Objects {
+		obj := &kunstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raw.Raw); err != nil {
+			return nil, errors.Wrapf(err, errFmtUnmarshalObj, i)
+		}
+
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		if gvk.Group != to.Group {
+			return nil, errors.Errorf(errFmtWrongGroup, obj.GetAPIVersion(), req.DesiredAPIVersion)
+		}
+
+		xrd := definedBy(xrds.Items, gvk.GroupKind())
+		if xrd == nil {
+			return nil, errors.Errorf(errFmtNoXRD, gvk.GroupKind())
+		}
+
+		converted, err := h.converter.Convert(ctx, xrd, obj, to.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtConvertObj, gvk.Kind, obj.GetName(), to.Version)
+		}
+
+		out[i] = runtime.RawExtension{Object: converted}
+	}
+
+	return out, nil
+}
+
+// definedBy returns the XRD that defines the supplied kind of composite
+// resource or claim, or nil if none does.
+func definedBy(xrds []v1.CompositeResourceDefinition, gk schema.GroupKind) *v1.CompositeResourceDefinition {
+	for i := range xrds {
+		xrd := &xrds[i]
+		if xrd.Spec.Group != gk.Group {
+			continue
+		}
+		if xrd.Spec.Names.Kind == gk.Kind {
+			return xrd
+		}
+		if xrd.Spec.ClaimNames != nil && xrd.Spec.ClaimNames.Kind == gk.Kind {
+			return xrd
+		}
+	}
+	return nil
+}
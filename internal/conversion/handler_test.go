@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conversion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+)
+
+func TestHandlerConvert(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	xrds := func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		l := obj.(*v1.CompositeResourceDefinitionList)
+		l.Items = []v1.CompositeResourceDefinition{{
+			Spec: v1.CompositeResourceDefinitionSpec{
+				Group:      "example.org",
+				Names:      extv1.CustomResourceDefinitionNames{Kind: "XDatabase"},
+				ClaimNames: &extv1.CustomResourceDefinitionNames{Kind: "Database"},
+				Conversions: []v1.CompositeResourceConversion{{
+					FromVersion: "v1alpha1",
+					ToVersion:   "v1beta1",
+					Mappings:    []v1.ConversionMapping{{FromFieldPath: "spec.size", ToFieldPath: "spec.parameters.size"}},
+				}},
+			},
+		}}
+		return nil
+	}
+
+	type args struct {
+		reader client.Reader
+		req    *extv1.ConversionRequest
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *extv1.ConversionResponse
+	}{
+		"ListXRDsError": {
+			reason: "We should return a failure if we can't list XRDs.",
+			args: args{
+				reader: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+				req:    &extv1.ConversionRequest{UID: "cool-uid", DesiredAPIVersion: "example.org/v1beta1"},
+			},
+			want: &extv1.ConversionResponse{
+				UID:    "cool-uid",
+				Result: metav1.Status{Status: metav1.StatusFailure, Message: errors.Wrap(errBoom, errListXRDs).Error()},
+			},
+		},
+		"NoXRD": {
+			reason: "We should return a failure if no XRD defines the object's kind.",
+			args: args{
+				reader: &test.MockClient{MockList: xrds},
+				req: &extv1.ConversionRequest{
+					UID:               "cool-uid",
+					DesiredAPIVersion: "example.org/v1beta1",
+					Objects:           []runtime.RawExtension{{Raw: []byte(`{"apiVersion":"example.org/v1alpha1","kind":"XCache"}`)}},
+				},
+			},
+			want: &extv1.ConversionResponse{
+				UID:    "cool-uid",
+				Result: metav1.Status{Status: metav1.StatusFailure, Message: errors.Errorf(errFmtNoXRD, "XCache.example.org").Error()},
+			},
+		},
+		"Success": {
+			reason: "We should convert composite resources and claims defined by an XRD.",
+			args: args{
+				reader: &test.MockClient{MockList: xrds},
+				req: &extv1.ConversionRequest{
+					UID:               "cool-uid",
+					DesiredAPIVersion: "example.org/v1beta1",
+					Objects: []runtime.RawExtension{
+						{Raw: []byte(`{"apiVersion":"example.org/v1alpha1","kind":"XDatabase","spec":{"size":"large"}}`)},
+						{Raw: []byte(`{"apiVersion":"example.org/v1alpha1","kind":"Database","spec":{"size":"small"}}`)},
+					},
+				},
+			},
+			want: &extv1.ConversionResponse{
+				UID: "cool-uid",
+				ConvertedObjects: []runtime.RawExtension{
+					{Object: &kunstructured.Unstructured{Object: map[string]any{
+						"apiVersion": "example.org/v1beta1",
+						"kind":       "XDatabase",
+						"spec":       map[string]any{"parameters": map[string]any{"size": "large"}},
+					}}},
+					{Object: &kunstructured.Unstructured{Object: map[string]any{
+						"apiVersion": "example.org/v1beta1",
+						"kind":       "Database",
+						"spec":       map[string]any{"parameters": map[string]any{"size": "small"}},
+					}}},
+				},
+				Result: metav1.Status{Status: metav1.StatusSuccess},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			h := NewHandler(tc.args.reader)
+			got := h.Convert(context.Background(), tc.args.req)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConvert(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -40,6 +40,23 @@ const (
 	// compositions, i.e. watching MRs and reconciling compositions immediately
 	// when any MR is updated.
 	EnableRealtimeCompositions feature.Flag = "EnableRealtimeCompositions"
+
+	// EnableAlphaFunctionResponseCache enables alpha support for caching
+	// Composition Function responses for the TTL the Function returns.
+	EnableAlphaFunctionResponseCache feature.Flag = "EnableAlphaFunctionResponseCache"
+
+	// EnableAlphaDependencyUpgrades enables alpha support for automatically
+	// upgrading package dependencies whose installed version no longer
+	// satisfies the constraints of the packages that depend on them.
+	EnableAlphaDependencyUpgrades feature.Flag = "EnableAlphaDependencyUpgrades"
+
+	// EnableAlphaPackageVerification enables alpha support for verifying the
+	// cosign signatures and attestations of package images.
+	EnableAlphaPackageVerification feature.Flag = "EnableAlphaPackageVerification"
+
+	// EnableAlphaCompositionRollouts enables alpha support for progressively
+	// rolling out new CompositionRevisions to composite resources.
+	EnableAlphaCompositionRollouts feature.Flag = "EnableAlphaCompositionRollouts"
 )
 
 // Beta Feature Flags
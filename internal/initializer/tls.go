@@ -353,3 +353,17 @@ func DNSNamesForService(service, namespace string) []string {
 		service + "." + namespace + ".svc",
 	}
 }
+
+// DNSNameForServiceAccount returns the DNS name that identifies a client using
+// the given service account and namespace in its TLS client certificate.
+func DNSNameForServiceAccount(serviceAccount, namespace string) string {
+	return serviceAccount + "." + namespace
+}
+
+// DNSNameForFunctionClient returns the DNS name that identifies the given
+// client when calling the named Function. Each Function's client certificate
+// includes it, so a Function can reject certificates issued for calling other
+// Functions.
+func DNSNameForFunctionClient(function, clientDNSName string) string {
+	return function + "." + clientDNSName
+}
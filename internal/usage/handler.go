@@ -19,12 +19,16 @@ package usage
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -33,6 +37,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	xpunstructured "github.com/crossplane/crossplane-runtime/pkg/resource/unstructured"
 
 	"github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
@@ -43,29 +48,52 @@ const (
 	// indexing and retrieving needed CRDs
 	InUseIndexKey = "inuse.apiversion.kind.name"
 
+	// AnnotationKeyDeletionAttempt is the annotation key used to record that
+	// deletion of the used resource was attempted, and blocked, while a Usage
+	// that replays deletions existed. Its value is the propagation policy of
+	// the deletion attempt.
+	AnnotationKeyDeletionAttempt = "usage.crossplane.io/deletion-attempt-with-policy"
+
 	// Error strings.
 	errFmtUnexpectedOp = "unexpected operation %q, expected \"DELETE\""
+	errDeleteOptions   = "cannot unmarshal delete options"
+	errRecordAttempt   = "cannot record deletion attempt on usage"
 )
 
 // IndexValueForObject returns the index value for the given object.
 func IndexValueForObject(u *unstructured.Unstructured) string {
-	return indexValue(u.GetAPIVersion(), u.GetKind(), u.GetName())
+	return indexValue(u.GetAPIVersion(), u.GetKind(), u.GetNamespace(), u.GetName())
 }
 
-func indexValue(apiVersion, kind, name string) string {
+func indexValue(apiVersion, kind, namespace, name string) string {
+	if namespace != "" {
+		return fmt.Sprintf("%s.%s.%s/%s", apiVersion, kind, namespace, name)
+	}
 	return fmt.Sprintf("%s.%s.%s", apiVersion, kind, name)
 }
 
+// indexUsage returns the index values for the supplied Usage or
+// NamespacedUsage. A NamespacedUsage may only be of a resource in its own
+// namespace.
+func indexUsage(obj client.Object) []string {
+	u, ok := obj.(v1alpha1.UsageResource)
+	if !ok {
+		return []string{}
+	}
+	of := u.GetOf()
+	if of.ResourceRef == nil || len(of.ResourceRef.Name) == 0 {
+		return []string{}
+	}
+	return []string{indexValue(of.APIVersion, of.Kind, u.GetNamespace(), of.ResourceRef.Name)}
+}
+
 // SetupWebhookWithManager sets up the webhook with the manager.
 func SetupWebhookWithManager(mgr ctrl.Manager, options controller.Options) error {
 	indexer := mgr.GetFieldIndexer()
-	if err := indexer.IndexField(context.Background(), &v1alpha1.Usage{}, InUseIndexKey, func(obj client.Object) []string {
-		u := obj.(*v1alpha1.Usage)
-		if u.Spec.Of.ResourceRef == nil || len(u.Spec.Of.ResourceRef.Name) == 0 {
-			return []string{}
-		}
-		return []string{indexValue(u.Spec.Of.APIVersion, u.Spec.Of.Kind, u.Spec.Of.ResourceRef.Name)}
-	}); err != nil {
+	if err := indexer.IndexField(context.Background(), &v1alpha1.Usage{}, InUseIndexKey, indexUsage); err != nil {
+		return err
+	}
+	if err := indexer.IndexField(context.Background(), &v1alpha1.NamespacedUsage{}, InUseIndexKey, indexUsage); err != nil {
 		return err
 	}
 
@@ -79,7 +107,7 @@ func SetupWebhookWithManager(mgr ctrl.Manager, options controller.Options) error
 
 // Handler implements the admission Handler for Composition.
 type Handler struct {
-	reader client.Reader
+	client client.Client
 	log    logging.Logger
 }
 
@@ -94,9 +122,9 @@ func WithLogger(l logging.Logger) HandlerOption {
 }
 
 // NewHandler returns a new Handler.
-func NewHandler(reader client.Reader, opts ...HandlerOption) *Handler {
+func NewHandler(c client.Client, opts ...HandlerOption) *Handler {
 	h := &Handler{
-		reader: reader,
+		client: c,
 		log:    logging.NewNopLogger(),
 	}
 
@@ -118,21 +146,35 @@ func (h *Handler) Handle(ctx context.Context, request admission.Request) admissi
 		if err := u.UnmarshalJSON(request.OldObject.Raw); err != nil {
 			return admission.Errored(http.StatusBadRequest, err)
 		}
-		return h.validateNoUsages(ctx, u)
+		opts := &metav1.DeleteOptions{}
+		if len(request.Options.Raw) > 0 {
+			if err := json.Unmarshal(request.Options.Raw, opts); err != nil {
+				return admission.Errored(http.StatusBadRequest, errors.Wrap(err, errDeleteOptions))
+			}
+		}
+		return h.validateNoUsages(ctx, u, opts, ptr.Deref(request.DryRun, false))
 	default:
 		return admission.Errored(http.StatusBadRequest, errors.Errorf(errFmtUnexpectedOp, request.Operation))
 	}
 }
 
-func (h *Handler) validateNoUsages(ctx context.Context, u *unstructured.Unstructured) admission.Response {
-	h.log.Debug("Validating no usages", "apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "name", u.GetName())
-	usageList := &v1alpha1.UsageList{}
-	if err := h.reader.List(ctx, usageList, client.MatchingFields{InUseIndexKey: IndexValueForObject(u)}); err != nil {
-		h.log.Debug("Error when getting Usages", "apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "name", u.GetName(), "err", err)
+func (h *Handler) validateNoUsages(ctx context.Context, u *unstructured.Unstructured, opts *metav1.DeleteOptions, dryRun bool) admission.Response {
+	h.log.Debug("Validating no usages", "apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "namespace", u.GetNamespace(), "name", u.GetName())
+	usages, err := ListUsages(ctx, h.client, u)
+	if err != nil {
+		h.log.Debug("Error when getting Usages", "apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "namespace", u.GetNamespace(), "name", u.GetName(), "err", err)
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
-	if len(usageList.Items) > 0 {
-		msg := inUseMessage(usageList)
+	if len(usages) > 0 {
+		// A dry run must not have side effects, so we only record real
+		// deletion attempts.
+		if !dryRun {
+			if err := h.recordDeletionAttempt(ctx, usages, opts); err != nil {
+				h.log.Debug("Error when recording deletion attempt", "apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "namespace", u.GetNamespace(), "name", u.GetName(), "err", err)
+				return admission.Errored(http.StatusInternalServerError, err)
+			}
+		}
+		msg := inUseMessage(usages)
 		h.log.Debug("Usage found, deletion not allowed", "apiVersion", u.GetAPIVersion(), "kind", u.GetKind(), "name", u.GetName(), "msg", msg)
 		return admission.Response{
 			AdmissionResponse: admissionv1.AdmissionResponse{
@@ -148,15 +190,81 @@ func (h *Handler) validateNoUsages(ctx context.Context, u *unstructured.Unstruct
 	return admission.Allowed("")
 }
 
-func inUseMessage(usages *v1alpha1.UsageList) string {
-	first := usages.Items[0]
-	if first.Spec.By != nil {
-		return fmt.Sprintf("This resource is in-use by %d Usage(s), including the Usage %q by resource %s/%s.", len(usages.Items), first.Name, first.Spec.By.Kind, first.Spec.By.ResourceRef.Name)
+// recordDeletionAttempt records the propagation policy of a blocked deletion
+// attempt on the supplied usages that replay deletions, so they can replay it
+// once they're deleted. It retries if a usage changed since it was listed.
+func (h *Handler) recordDeletionAttempt(ctx context.Context, usages []v1alpha1.UsageResource, opts *metav1.DeleteOptions) error {
+	policy := metav1.DeletePropagationBackground
+	if opts.PropagationPolicy != nil {
+		policy = *opts.PropagationPolicy
+	}
+	for _, u := range usages {
+		stale := false
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if stale {
+				if err := h.client.Get(ctx, client.ObjectKeyFromObject(u), u); err != nil {
+					return err
+				}
+			}
+			if r := u.GetReplayDeletion(); r == nil || !*r {
+				return nil
+			}
+			if u.GetAnnotations()[AnnotationKeyDeletionAttempt] == string(policy) {
+				return nil
+			}
+			meta.AddAnnotations(u, map[string]string{AnnotationKeyDeletionAttempt: string(policy)})
+			err := h.client.Update(ctx, u)
+			stale = kerrors.IsConflict(err)
+			return err
+		})
+		if err != nil {
+			return errors.Wrap(err, errRecordAttempt)
+		}
+	}
+	return nil
+}
+
+// ListUsages returns the Usages and NamespacedUsages of the supplied resource.
+// Only a resource in a namespace may be used by a NamespacedUsage.
+func ListUsages(ctx context.Context, c client.Reader, u *unstructured.Unstructured) ([]v1alpha1.UsageResource, error) {
+	usages := make([]v1alpha1.UsageResource, 0)
+
+	ul := &v1alpha1.UsageList{}
+	if err := c.List(ctx, ul, client.MatchingFields{InUseIndexKey: IndexValueForObject(u)}); err != nil {
+		return nil, err
+	}
+	for i := range ul.Items {
+		usages = append(usages, &ul.Items[i])
+	}
+
+	if u.GetNamespace() == "" {
+		return usages, nil
+	}
+
+	nl := &v1alpha1.NamespacedUsageList{}
+	if err := c.List(ctx, nl, client.InNamespace(u.GetNamespace()), client.MatchingFields{InUseIndexKey: IndexValueForObject(u)}); err != nil {
+		return nil, err
+	}
+	for i := range nl.Items {
+		usages = append(usages, &nl.Items[i])
+	}
+
+	return usages, nil
+}
+
+func inUseMessage(usages []v1alpha1.UsageResource) string {
+	first := usages[0]
+	kind := v1alpha1.UsageKind
+	if first.GetNamespace() != "" {
+		kind = v1alpha1.NamespacedUsageKind
+	}
+	if by := first.GetBy(); by != nil {
+		return fmt.Sprintf("This resource is in-use by %d Usage(s), including the %s %q by resource %s/%s.", len(usages), kind, first.GetName(), by.Kind, by.ResourceRef.Name)
 	}
-	if first.Spec.Reason != nil {
-		return fmt.Sprintf("This resource is in-use by %d Usage(s), including the Usage %q with reason: %q.", len(usages.Items), first.Name, *first.Spec.Reason)
+	if r := first.GetReason(); r != nil {
+		return fmt.Sprintf("This resource is in-use by %d Usage(s), including the %s %q with reason: %q.", len(usages), kind, first.GetName(), *r)
 	}
 	// Either spec.by or spec.reason should be set, which we enforce with a CEL
 	// rule. This is just a fallback.
-	return fmt.Sprintf("This resource is in-use by %d Usage(s), including the Usage %q.", len(usages.Items), first.Name)
+	return fmt.Sprintf("This resource is in-use by %d Usage(s), including the %s %q.", len(usages), kind, first.GetName())
 }
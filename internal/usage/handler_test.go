@@ -23,8 +23,11 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -42,7 +45,7 @@ var errBoom = errors.New("boom")
 func TestHandle(t *testing.T) {
 	protected := "This resource is protected!"
 	type args struct {
-		reader  client.Reader
+		client  client.Client
 		request admission.Request
 	}
 	type want struct {
@@ -121,7 +124,7 @@ func TestHandle(t *testing.T) {
 		"DeleteAllowedNoUsages": {
 			reason: "We should allow a delete request if there is no usages for the given object.",
 			args: args{
-				reader: &test.MockClient{
+				client: &test.MockClient{
 					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						return nil
 					},
@@ -147,7 +150,7 @@ func TestHandle(t *testing.T) {
 		"DeleteRejectedCannotList": {
 			reason: "We should reject a delete request if we cannot list usages.",
 			args: args{
-				reader: &test.MockClient{
+				client: &test.MockClient{
 					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						return errBoom
 					},
@@ -173,7 +176,7 @@ func TestHandle(t *testing.T) {
 		"DeleteBlockedWithUsageBy": {
 			reason: "We should reject a delete request if there are usages for the given object with \"by\" defined.",
 			args: args{
-				reader: &test.MockClient{
+				client: &test.MockClient{
 					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						l := list.(*v1alpha1.UsageList)
 						l.Items = []v1alpha1.Usage{
@@ -231,7 +234,7 @@ func TestHandle(t *testing.T) {
 		"DeleteBlockedWithUsageReason": {
 			reason: "We should reject a delete request if there are usages for the given object with \"reason\" defined.",
 			args: args{
-				reader: &test.MockClient{
+				client: &test.MockClient{
 					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						l := list.(*v1alpha1.UsageList)
 						l.Items = []v1alpha1.Usage{
@@ -283,7 +286,7 @@ func TestHandle(t *testing.T) {
 		"DeleteBlockedWithUsageNone": {
 			reason: "We should reject a delete request if there are usages for the given object without \"reason\" or \"by\" defined.",
 			args: args{
-				reader: &test.MockClient{
+				client: &test.MockClient{
 					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
 						l := list.(*v1alpha1.UsageList)
 						l.Items = []v1alpha1.Usage{
@@ -331,10 +334,317 @@ func TestHandle(t *testing.T) {
 				},
 			},
 		},
+		"DeleteBlockedWithNamespacedUsage": {
+			reason: "We should reject a delete request if there are namespaced usages for the given namespaced object.",
+			args: args{
+				client: &test.MockClient{
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						switch l := list.(type) {
+						case *v1alpha1.UsageList:
+							return nil
+						case *v1alpha1.NamespacedUsageList:
+							lo := &client.ListOptions{}
+							lo.ApplyOptions(opts)
+							if lo.Namespace != "cool-namespace" {
+								t.Errorf("unexpected namespace: %q", lo.Namespace)
+							}
+							l.Items = []v1alpha1.NamespacedUsage{
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Namespace: "cool-namespace",
+										Name:      "app-uses-database",
+									},
+									Spec: v1alpha1.UsageSpec{
+										Of: v1alpha1.Resource{
+											APIVersion: "example.org/v1alpha1",
+											Kind:       "Database",
+											ResourceRef: &v1alpha1.ResourceRef{
+												Name: "cool-database",
+											},
+										},
+										By: &v1alpha1.Resource{
+											APIVersion: "example.org/v1alpha1",
+											Kind:       "App",
+											ResourceRef: &v1alpha1.ResourceRef{
+												Name: "cool-app",
+											},
+										},
+									},
+								},
+							}
+						}
+						return nil
+					},
+				},
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "example.org/v1alpha1",
+								"kind": "Database",
+								"metadata": {
+									"namespace": "cool-namespace",
+									"name": "cool-database"
+								}}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Response{
+					AdmissionResponse: admissionv1.AdmissionResponse{
+						Allowed: false,
+						Result: &metav1.Status{
+							Code:   int32(http.StatusConflict),
+							Reason: metav1.StatusReason("This resource is in-use by 1 Usage(s), including the NamespacedUsage \"app-uses-database\" by resource App/cool-app."),
+						},
+					},
+				},
+			},
+		},
+		"DeleteBlockedRecordsDeletionAttempt": {
+			reason: "We should record the propagation policy of a blocked delete request on usages that replay deletions.",
+			args: args{
+				client: &test.MockClient{
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						l := list.(*v1alpha1.UsageList)
+						l.Items = []v1alpha1.Usage{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "used-by-some-resource",
+								},
+								Spec: v1alpha1.UsageSpec{
+									Of: v1alpha1.Resource{
+										APIVersion: "nop.crossplane.io/v1alpha1",
+										Kind:       "NopResource",
+										ResourceRef: &v1alpha1.ResourceRef{
+											Name: "used-resource",
+										},
+									},
+									Reason:         &protected,
+									ReplayDeletion: ptr.To(true),
+								},
+							},
+						}
+						return nil
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						if got := obj.GetAnnotations()[AnnotationKeyDeletionAttempt]; got != string(metav1.DeletePropagationForeground) {
+							t.Errorf("expected deletion attempt annotation %q, got %q", metav1.DeletePropagationForeground, got)
+						}
+						return nil
+					},
+				},
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "nop.crossplane.io/v1alpha1",
+								"kind": "NopResource",
+								"metadata": {
+									"name": "used-resource"
+								}}`),
+						},
+						Options: runtime.RawExtension{
+							Raw: []byte(`{"propagationPolicy": "Foreground"}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Response{
+					AdmissionResponse: admissionv1.AdmissionResponse{
+						Allowed: false,
+						Result: &metav1.Status{
+							Code:   int32(http.StatusConflict),
+							Reason: metav1.StatusReason("This resource is in-use by 1 Usage(s), including the Usage \"used-by-some-resource\" with reason: \"This resource is protected!\"."),
+						},
+					},
+				},
+			},
+		},
+		"DeleteBlockedRecordDeletionAttemptError": {
+			reason: "We should return an internal error if we can't record a blocked delete request on a usage that replays deletions.",
+			args: args{
+				client: &test.MockClient{
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						l := list.(*v1alpha1.UsageList)
+						l.Items = []v1alpha1.Usage{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "used-by-some-resource",
+								},
+								Spec: v1alpha1.UsageSpec{
+									Of: v1alpha1.Resource{
+										APIVersion: "nop.crossplane.io/v1alpha1",
+										Kind:       "NopResource",
+										ResourceRef: &v1alpha1.ResourceRef{
+											Name: "used-resource",
+										},
+									},
+									Reason:         &protected,
+									ReplayDeletion: ptr.To(true),
+								},
+							},
+						}
+						return nil
+					},
+					MockUpdate: test.NewMockUpdateFn(errBoom),
+				},
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "nop.crossplane.io/v1alpha1",
+								"kind": "NopResource",
+								"metadata": {
+									"name": "used-resource"
+								}}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Errored(http.StatusInternalServerError, errors.Wrap(errBoom, errRecordAttempt)),
+			},
+		},
+		"DeleteBlockedDryRun": {
+			reason: "We should not record a blocked dry run delete request, since it must not have side effects.",
+			args: args{
+				client: &test.MockClient{
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						l := list.(*v1alpha1.UsageList)
+						l.Items = []v1alpha1.Usage{
+							{
+								ObjectMeta: metav1.ObjectMeta{
+									Name: "used-by-some-resource",
+								},
+								Spec: v1alpha1.UsageSpec{
+									Of: v1alpha1.Resource{
+										APIVersion: "nop.crossplane.io/v1alpha1",
+										Kind:       "NopResource",
+										ResourceRef: &v1alpha1.ResourceRef{
+											Name: "used-resource",
+										},
+									},
+									Reason:         &protected,
+									ReplayDeletion: ptr.To(true),
+								},
+							},
+						}
+						return nil
+					},
+					MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+						t.Errorf("unexpected update of usage %q", obj.GetName())
+						return nil
+					},
+				},
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						DryRun:    ptr.To(true),
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "nop.crossplane.io/v1alpha1",
+								"kind": "NopResource",
+								"metadata": {
+									"name": "used-resource"
+								}}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Response{
+					AdmissionResponse: admissionv1.AdmissionResponse{
+						Allowed: false,
+						Result: &metav1.Status{
+							Code:   int32(http.StatusConflict),
+							Reason: metav1.StatusReason("This resource is in-use by 1 Usage(s), including the Usage \"used-by-some-resource\" with reason: \"This resource is protected!\"."),
+						},
+					},
+				},
+			},
+		},
+		"DeleteBlockedRecordsDeletionAttemptAfterConflict": {
+			reason: "We should get the latest version of a usage and retry if recording a blocked delete request conflicts.",
+			args: args{
+				client: func() client.Client {
+					updates := 0
+					return &test.MockClient{
+						MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+							l := list.(*v1alpha1.UsageList)
+							l.Items = []v1alpha1.Usage{
+								{
+									ObjectMeta: metav1.ObjectMeta{
+										Name: "used-by-some-resource",
+									},
+									Spec: v1alpha1.UsageSpec{
+										Of: v1alpha1.Resource{
+											APIVersion: "nop.crossplane.io/v1alpha1",
+											Kind:       "NopResource",
+											ResourceRef: &v1alpha1.ResourceRef{
+												Name: "used-resource",
+											},
+										},
+										Reason:         &protected,
+										ReplayDeletion: ptr.To(true),
+									},
+								},
+							}
+							return nil
+						},
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							// The latest version of the usage doesn't have
+							// the annotation we failed to add.
+							obj.SetAnnotations(nil)
+							return nil
+						}),
+						MockUpdate: func(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+							updates++
+							if updates == 1 {
+								return kerrors.NewConflict(schema.GroupResource{}, obj.GetName(), errBoom)
+							}
+							if got := obj.GetAnnotations()[AnnotationKeyDeletionAttempt]; got != string(metav1.DeletePropagationBackground) {
+								t.Errorf("expected deletion attempt annotation %q, got %q", metav1.DeletePropagationBackground, got)
+							}
+							return nil
+						},
+					}
+				}(),
+				request: admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						Operation: admissionv1.Delete,
+						OldObject: runtime.RawExtension{
+							Raw: []byte(`{
+								"apiVersion": "nop.crossplane.io/v1alpha1",
+								"kind": "NopResource",
+								"metadata": {
+									"name": "used-resource"
+								}}`),
+						},
+					},
+				},
+			},
+			want: want{
+				resp: admission.Response{
+					AdmissionResponse: admissionv1.AdmissionResponse{
+						Allowed: false,
+						Result: &metav1.Status{
+							Code:   int32(http.StatusConflict),
+							Reason: metav1.StatusReason("This resource is in-use by 1 Usage(s), including the Usage \"used-by-some-resource\" with reason: \"This resource is protected!\"."),
+						},
+					},
+				},
+			},
+		},
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			h := NewHandler(tc.args.reader, WithLogger(logging.NewNopLogger()))
+			h := NewHandler(tc.args.client, WithLogger(logging.NewNopLogger()))
 			got := h.Handle(context.Background(), tc.args.request)
 			if diff := cmp.Diff(tc.want.resp, got); diff != "" {
 				t.Errorf("%s\nHandle(...): -want response, +got:\n%s", tc.reason, diff)
@@ -87,6 +87,7 @@ func (v *validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 
 	// Validate the composition itself, we'll disable it on the Validator below.
 	warns, validationErrs := comp.Validate()
+	validationErrs = append(validationErrs, composition.ValidateCELExpressions(comp)...)
 	if len(validationErrs) != 0 {
 		return warns, kerrors.NewInvalid(comp.GroupVersionKind().GroupKind(), comp.GetName(), validationErrs)
 	}
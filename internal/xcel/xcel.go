@@ -0,0 +1,119 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package xcel compiles the CEL expressions Compositions may contain.
+package xcel
+
+import (
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// PerCallLimit is the cost limit of evaluating a single expression. It matches
+// the limit the API server enforces for CEL validation rules.
+const PerCallLimit = 1000000
+
+// maxCachedPrograms is the number of compiled programs a Cache holds before
+// it's flushed.
+const maxCachedPrograms = 1000
+
+// Error strings.
+const (
+	errNewEnv        = "cannot create CEL environment"
+	errCompile       = "cannot compile CEL expression"
+	errProgram       = "cannot create CEL program"
+	errFmtNotBoolean = "CEL expression must evaluate to a boolean, not %s"
+)
+
+// CompileReadinessCheck compiles the supplied CEL readiness check expression.
+// It returns an error if the expression is invalid, or if it doesn't evaluate
+// to a boolean. The composed resource is available to the expression as the
+// 'object' variable.
+func CompileReadinessCheck(expr string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewEnv)
+	}
+	ast, iss := env.Compile(expr)
+	if iss.Err() != nil {
+		return nil, errors.Wrap(iss.Err(), errCompile)
+	}
+	if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+		return nil, errors.Errorf(errFmtNotBoolean, t)
+	}
+	prg, err := env.Program(ast, cel.CostLimit(PerCallLimit))
+	return prg, errors.Wrap(err, errProgram)
+}
+
+// NewTransformEnv returns the CEL environment expression transforms are
+// compiled and evaluated in. The input is available as the 'input' variable,
+// which is of the supplied type. The environment includes CEL's string,
+// encoder, list, math, and set extensions.
+func NewTransformEnv(input *cel.Type) (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("input", input),
+		ext.Bindings(),
+		ext.Encoders(),
+		ext.Lists(),
+		ext.Math(),
+		ext.Sets(),
+		ext.Strings(),
+	)
+	return env, errors.Wrap(err, errNewEnv)
+}
+
+type compiled struct {
+	prg cel.Program
+	err error
+}
+
+// A Cache caches compiled CEL programs. It's safe for concurrent use.
+type Cache struct {
+	mu       sync.RWMutex
+	programs map[string]compiled
+}
+
+// NewCache returns an empty cache of compiled CEL programs.
+func NewCache() *Cache {
+	return &Cache{programs: make(map[string]compiled)}
+}
+
+// Program returns the cached program for the supplied key, calling compile to
+// compile and cache it if it isn't cached. Failures to compile are cached too,
+// so an invalid expression is only compiled once.
+func (c *Cache) Program(key string, compile func() (cel.Program, error)) (cel.Program, error) {
+	c.mu.RLock()
+	p, ok := c.programs[key]
+	c.mu.RUnlock()
+	if ok {
+		return p.prg, p.err
+	}
+
+	prg, err := compile()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// Expressions are user supplied, so bound how many we keep around.
+	if len(c.programs) >= maxCachedPrograms {
+		c.programs = make(map[string]compiled)
+	}
+	c.programs[key] = compiled{prg: prg, err: err}
+	return prg, err
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xcel
+
+import (
+	"testing"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestCompileReadinessCheck(t *testing.T) {
+	type want struct {
+		ready bool
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		expr   string
+		object map[string]any
+		want   want
+	}{
+		"InvalidExpression": {
+			reason: "We should return an error if the expression doesn't compile.",
+			expr:   "object.status.readyReplicas ==",
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"NotBoolean": {
+			reason: "We should return an error if the expression doesn't evaluate to a boolean.",
+			expr:   "'ready'",
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"Ready": {
+			reason: "We should compile an expression that evaluates to a boolean.",
+			expr:   "object.status.readyReplicas == object.spec.replicas",
+			object: map[string]any{
+				"spec":   map[string]any{"replicas": int64(3)},
+				"status": map[string]any{"readyReplicas": int64(3)},
+			},
+			want: want{
+				ready: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			prg, err := CompileReadinessCheck(tc.expr)
+			if diff := cmp.Diff(tc.want.err, err, cmpopts.EquateErrors()); diff != "" {
+				t.Fatalf("\n%s\nCompileReadinessCheck(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			out, _, err := prg.Eval(map[string]any{"object": tc.object})
+			if err != nil {
+				t.Fatalf("\n%s\nEval(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.ready, out.Value()); diff != "" {
+				t.Errorf("\n%s\nEval(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompileReadinessCheckCostLimit(t *testing.T) {
+	// Each comprehension multiplies the cost of the one it's nested in.
+	prg, err := CompileReadinessCheck("[1,2,3,4,5,6,7,8,9,10].all(a, [1,2,3,4,5,6,7,8,9,10].all(b, [1,2,3,4,5,6,7,8,9,10].all(c, [1,2,3,4,5,6,7,8,9,10].all(d, [1,2,3,4,5,6,7,8,9,10].all(e, [1,2,3,4,5,6,7,8,9,10].all(f, a+b+c+d+e+f > 0))))))")
+	if err != nil {
+		t.Fatalf("CompileReadinessCheck(...): %s", err)
+	}
+	if _, _, err := prg.Eval(map[string]any{"object": map[string]any{}}); err == nil {
+		t.Errorf("Eval(...): want an error evaluating an expression that exceeds the cost limit")
+	}
+}
+
+func TestCache(t *testing.T) {
+	c := NewCache()
+	calls := 0
+	compile := func() (cel.Program, error) {
+		calls++
+		return CompileReadinessCheck("true")
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Program("true", compile); err != nil {
+			t.Fatalf("Program(...): %s", err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Program(...): want 1 compilation, got %d", calls)
+	}
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xcrd
+
+import (
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+)
+
+// ConversionWebhookPath is the path at which Crossplane serves conversion of
+// composite resources and claims.
+const ConversionWebhookPath = "/convert"
+
+// SetConversionWebhook configures the supplied CRD to be converted by
+// Crossplane's conversion webhook, if the supplied XRD declares conversions.
+// The supplied client config must identify Crossplane's webhook service. Its
+// path is always set to ConversionWebhookPath.
+func SetConversionWebhook(crd *extv1.CustomResourceDefinition, xrd *v1.CompositeResourceDefinition, cc extv1.WebhookClientConfig) {
+	if len(xrd.Spec.Conversions) == 0 {
+		return
+	}
+
+	cfg := cc.DeepCopy()
+	if cfg.Service != nil {
+		cfg.Service.Path = ptr.To(ConversionWebhookPath)
+	}
+
+	crd.Spec.Conversion = &extv1.CustomResourceConversion{
+		Strategy: extv1.WebhookConverter,
+		Webhook: &extv1.WebhookConversion{
+			ClientConfig:             cfg,
+			ConversionReviewVersions: []string{"v1"},
+		},
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xcrd
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/utils/ptr"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+)
+
+func TestSetConversionWebhook(t *testing.T) {
+	cc := extv1.WebhookClientConfig{
+		Service: &extv1.ServiceReference{
+			Name:      "crossplane-webhooks",
+			Namespace: "crossplane-system",
+			Port:      ptr.To[int32](9443),
+		},
+		CABundle: []byte("ca"),
+	}
+
+	type args struct {
+		crd *extv1.CustomResourceDefinition
+		xrd *v1.CompositeResourceDefinition
+		cc  extv1.WebhookClientConfig
+	}
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   *extv1.CustomResourceDefinition
+	}{
+		"NoConversions": {
+			reason: "We shouldn't touch the CRD's conversion config if the XRD doesn't declare conversions.",
+			args: args{
+				crd: &extv1.CustomResourceDefinition{Spec: extv1.CustomResourceDefinitionSpec{
+					Conversion: &extv1.CustomResourceConversion{Strategy: extv1.NoneConverter},
+				}},
+				xrd: &v1.CompositeResourceDefinition{},
+				cc:  cc,
+			},
+			want: &extv1.CustomResourceDefinition{Spec: extv1.CustomResourceDefinitionSpec{
+				Conversion: &extv1.CustomResourceConversion{Strategy: extv1.NoneConverter},
+			}},
+		},
+		"Conversions": {
+			reason: "We should configure the CRD to use Crossplane's conversion webhook if the XRD declares conversions.",
+			args: args{
+				crd: &extv1.CustomResourceDefinition{},
+				xrd: &v1.CompositeResourceDefinition{Spec: v1.CompositeResourceDefinitionSpec{
+					Conversions: []v1.CompositeResourceConversion{{FromVersion: "v1alpha1", ToVersion: "v1beta1"}},
+				}},
+				cc: cc,
+			},
+			want: &extv1.CustomResourceDefinition{Spec: extv1.CustomResourceDefinitionSpec{
+				Conversion: &extv1.CustomResourceConversion{
+					Strategy: extv1.WebhookConverter,
+					Webhook: &extv1.WebhookConversion{
+						ClientConfig: &extv1.WebhookClientConfig{
+							Service: &extv1.ServiceReference{
+								Name:      "crossplane-webhooks",
+								Namespace: "crossplane-system",
+								Port:      ptr.To[int32](9443),
+								Path:      ptr.To(ConversionWebhookPath),
+							},
+							CABundle: []byte("ca"),
+						},
+						ConversionReviewVersions: []string{"v1"},
+					},
+				},
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetConversionWebhook(tc.args.crd, tc.args.xrd, tc.args.cc)
+			if diff := cmp.Diff(tc.want, tc.args.crd); diff != "" {
+				t.Errorf("\n%s\nSetConversionWebhook(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -163,6 +163,13 @@ func genCrdVersion(vr v1.CompositeResourceDefinitionVersion, maxNameLength int64
 			Status: &extv1.CustomResourceSubresourceStatus{},
 		},
 	}
+	if sc := vr.Scale; sc != nil {
+		crdv.Subresources.Scale = &extv1.CustomResourceSubresourceScale{
+			SpecReplicasPath:   sc.SpecReplicasPath,
+			StatusReplicasPath: sc.StatusReplicasPath,
+			LabelSelectorPath:  sc.LabelSelectorPath,
+		}
+	}
 	s, err := parseSchema(vr.Schema)
 	if err != nil {
 		return nil, errors.Wrapf(err, errParseValidation)
@@ -472,6 +472,26 @@ func TestForCompositeResource(t *testing.T) {
 														"lastPublishedTime": {Type: "string", Format: "date-time"},
 													},
 												},
+												"claimConditionTypes": {
+													Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"compositionConditionTypes": {
+													Description: "Types of the composite resource's conditions that were set by its Composition.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
 											},
 											XValidations: extv1.ValidationRules{
 												{
@@ -789,6 +809,26 @@ func TestForCompositeResource(t *testing.T) {
 														"lastPublishedTime": {Type: "string", Format: "date-time"},
 													},
 												},
+												"claimConditionTypes": {
+													Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"compositionConditionTypes": {
+													Description: "Types of the composite resource's conditions that were set by its Composition.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
 											},
 											XValidations: extv1.ValidationRules{
 												{
@@ -1057,6 +1097,26 @@ func TestForCompositeResource(t *testing.T) {
 														"lastPublishedTime": {Type: "string", Format: "date-time"},
 													},
 												},
+												"claimConditionTypes": {
+													Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"compositionConditionTypes": {
+													Description: "Types of the composite resource's conditions that were set by its Composition.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
 											},
 										},
 									},
@@ -1340,6 +1400,26 @@ func TestForCompositeResource(t *testing.T) {
 														"lastPublishedTime": {Type: "string", Format: "date-time"},
 													},
 												},
+												"claimConditionTypes": {
+													Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"compositionConditionTypes": {
+													Description: "Types of the composite resource's conditions that were set by its Composition.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
 											},
 											XValidations: extv1.ValidationRules{
 												{
@@ -1633,6 +1713,26 @@ func TestForCompositeResource(t *testing.T) {
 														"lastPublishedTime": {Type: "string", Format: "date-time"},
 													},
 												},
+												"claimConditionTypes": {
+													Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"compositionConditionTypes": {
+													Description: "Types of the composite resource's conditions that were set by its Composition.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
 											},
 											XValidations: extv1.ValidationRules{
 												{
@@ -1686,6 +1786,86 @@ func TestForCompositeResource(t *testing.T) {
 	}
 }
 
+func TestScaleSubresource(t *testing.T) {
+	xrd := func(sc *v1.CompositeResourceScale) *v1.CompositeResourceDefinition {
+		return &v1.CompositeResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.CompositeResourceDefinitionSpec{
+				Group: group,
+				Names: extv1.CustomResourceDefinitionNames{
+					Plural:   plural,
+					Singular: singular,
+					Kind:     kind,
+					ListKind: listKind,
+				},
+				ClaimNames: &extv1.CustomResourceDefinitionNames{
+					Plural:   "coolclaims",
+					Singular: "coolclaim",
+					Kind:     "CoolClaim",
+					ListKind: "CoolClaimList",
+				},
+				Versions: []v1.CompositeResourceDefinitionVersion{{
+					Name:          version,
+					Referenceable: true,
+					Served:        true,
+					Schema:        &v1.CompositeResourceValidation{OpenAPIV3Schema: runtime.RawExtension{Raw: []byte(schema)}},
+					Scale:         sc,
+				}},
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		reason string
+		xrd    *v1.CompositeResourceDefinition
+		want   *extv1.CustomResourceSubresources
+	}{
+		"NoScale": {
+			reason: "Only the status subresource should be enabled if the version doesn't configure scale.",
+			xrd:    xrd(nil),
+			want: &extv1.CustomResourceSubresources{
+				Status: &extv1.CustomResourceSubresourceStatus{},
+			},
+		},
+		"Scale": {
+			reason: "The scale subresource should be enabled if the version configures it.",
+			xrd: xrd(&v1.CompositeResourceScale{
+				SpecReplicasPath:   ".spec.replicas",
+				StatusReplicasPath: ".status.replicas",
+				LabelSelectorPath:  ptr.To(".status.selector"),
+			}),
+			want: &extv1.CustomResourceSubresources{
+				Status: &extv1.CustomResourceSubresourceStatus{},
+				Scale: &extv1.CustomResourceSubresourceScale{
+					SpecReplicasPath:   ".spec.replicas",
+					StatusReplicasPath: ".status.replicas",
+					LabelSelectorPath:  ptr.To(".status.selector"),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			xr, err := ForCompositeResource(tc.xrd)
+			if err != nil {
+				t.Fatalf("ForCompositeResource(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, xr.Spec.Versions[0].Subresources); diff != "" {
+				t.Errorf("\n%s\nForCompositeResource(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			cm, err := ForCompositeResourceClaim(tc.xrd)
+			if err != nil {
+				t.Fatalf("ForCompositeResourceClaim(...): %v", err)
+			}
+			if diff := cmp.Diff(tc.want, cm.Spec.Versions[0].Subresources); diff != "" {
+				t.Errorf("\n%s\nForCompositeResourceClaim(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestValidateClaimNames(t *testing.T) {
 	cases := map[string]struct {
 		d    *v1.CompositeResourceDefinition
@@ -2124,6 +2304,26 @@ func TestForCompositeResourceClaim(t *testing.T) {
 														"lastPublishedTime": {Type: "string", Format: "date-time"},
 													},
 												},
+												"claimConditionTypes": {
+													Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"compositionConditionTypes": {
+													Description: "Types of the composite resource's conditions that were set by its Composition.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
 											},
 											XValidations: extv1.ValidationRules{
 												{
@@ -2408,6 +2608,26 @@ func TestForCompositeResourceClaim(t *testing.T) {
 														"lastPublishedTime": {Type: "string", Format: "date-time"},
 													},
 												},
+												"claimConditionTypes": {
+													Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
+												"compositionConditionTypes": {
+													Description: "Types of the composite resource's conditions that were set by its Composition.",
+													Type:        "array",
+													XListType:   ptr.To("set"),
+													Items: &extv1.JSONSchemaPropsOrArray{
+														Schema: &extv1.JSONSchemaProps{
+															Type: "string",
+														},
+													},
+												},
 											},
 											XValidations: extv1.ValidationRules{
 												{
@@ -2706,6 +2926,26 @@ func TestForCompositeResourceClaimEmptyXrd(t *testing.T) {
 												"lastPublishedTime": {Type: "string", Format: "date-time"},
 											},
 										},
+										"claimConditionTypes": {
+											Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+											Type:        "array",
+											XListType:   ptr.To("set"),
+											Items: &extv1.JSONSchemaPropsOrArray{
+												Schema: &extv1.JSONSchemaProps{
+													Type: "string",
+												},
+											},
+										},
+										"compositionConditionTypes": {
+											Description: "Types of the composite resource's conditions that were set by its Composition.",
+											Type:        "array",
+											XListType:   ptr.To("set"),
+											Items: &extv1.JSONSchemaPropsOrArray{
+												Schema: &extv1.JSONSchemaProps{
+													Type: "string",
+												},
+											},
+										},
 									},
 								},
 							},
@@ -28,6 +28,14 @@ const (
 	LabelKeyClaimNamespace        = "crossplane.io/claim-namespace"
 )
 
+// FieldClaimConditionTypes is the field path of the list of composite resource
+// condition types that should be propagated to its claim.
+const FieldClaimConditionTypes = "status.claimConditionTypes"
+
+// FieldCompositionConditionTypes is the field path of the list of condition
+// types that the composition process set on a composite resource.
+const FieldCompositionConditionTypes = "status.compositionConditionTypes"
+
 // CompositionRevisionRef should be propagated dynamically
 var CompositionRevisionRef = "compositionRevisionRef"
 
@@ -356,6 +364,26 @@ func CompositeResourceStatusProps() map[string]extv1.JSONSchemaProps {
 				"lastPublishedTime": {Type: "string", Format: "date-time"},
 			},
 		},
+		"claimConditionTypes": {
+			Description: "Types of the composite resource's conditions that should be propagated to its claim.",
+			Type:        "array",
+			XListType:   ptr.To("set"),
+			Items: &extv1.JSONSchemaPropsOrArray{
+				Schema: &extv1.JSONSchemaProps{
+					Type: "string",
+				},
+			},
+		},
+		"compositionConditionTypes": {
+			Description: "Types of the composite resource's conditions that were set by its Composition.",
+			Type:        "array",
+			XListType:   ptr.To("set"),
+			Items: &extv1.JSONSchemaPropsOrArray{
+				Schema: &extv1.JSONSchemaProps{
+					Type: "string",
+				},
+			},
+		},
 	}
 }
 
@@ -17,8 +17,12 @@ specific language governing permissions and limitations under the License.
 package xfn
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
 	"sync"
 	"time"
 
@@ -26,6 +30,9 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -33,6 +40,7 @@ import (
 	"github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/internal/initializer"
 )
 
 // Error strings
@@ -45,6 +53,14 @@ const (
 	errFmtRunFunction   = "cannot run Function %q"
 	errFmtEmptyEndpoint = "cannot determine gRPC target: active FunctionRevision %q has an empty status.endpoint"
 	errFmtDialFunction  = "cannot gRPC dial target %q from status.endpoint of active FunctionRevision %q"
+	errFmtCredentials   = "cannot load TLS credentials for active FunctionRevision %q"
+
+	errFmtGetSecret         = "cannot get TLS secret %q"
+	errFmtLoadKeyPair       = "cannot load client certificate from TLS secret %q"
+	errFmtInvalidCA         = "cannot parse CA certificate from TLS secret %q"
+	errFmtInvalidServerCert = "cannot parse server certificate from TLS secret %q"
+	errServerCertNotPinned  = "server certificate does not match the certificate pinned by the active FunctionRevision"
+	errServerCertNotPresent = "server did not present a certificate"
 )
 
 // TODO(negz): Should any of these be configurable?
@@ -69,12 +85,31 @@ type PackagedFunctionRunner struct {
 	creds        credentials.TransportCredentials
 	interceptors []InterceptorCreator
 
+	// secretNamespace is the namespace of the TLS secrets referenced by
+	// FunctionRevisions. Per-Function TLS is disabled if it's empty.
+	secretNamespace string
+
 	connsMx sync.RWMutex
-	conns   map[string]*grpc.ClientConn
+	conns   map[string]*functionConn
 
 	log logging.Logger
 }
 
+// A functionConn is a gRPC client connection to the active revision of a
+// Function.
+type functionConn struct {
+	*grpc.ClientConn
+
+	// revision is the name of the FunctionRevision the connection was
+	// created for.
+	revision string
+
+	// secrets identifies the versions of the TLS secrets the connection's
+	// credentials were loaded from. It's empty if the connection uses the
+	// runner's default credentials.
+	secrets string
+}
+
 // An InterceptorCreator creates gRPC UnaryClientInterceptors for functions.
 type InterceptorCreator interface {
 	// CreateInterceptor creates an interceptor for the named function. It also
@@ -100,6 +135,18 @@ func WithTLSConfig(cfg *tls.Config) PackagedFunctionRunnerOption {
 	}
 }
 
+// WithFunctionTLSSecrets configures the PackagedFunctionRunner to call each
+// Function using the client certificate generated for it, and to only trust
+// the server certificate of the Function's active FunctionRevision. TLS
+// secrets are read from the supplied namespace. FunctionRevisions that don't
+// reference both a client and a server TLS secret, or whose TLS secrets don't
+// exist yet, are called using the credentials configured by WithTLSConfig.
+func WithFunctionTLSSecrets(namespace string) PackagedFunctionRunnerOption {
+	return func(r *PackagedFunctionRunner) {
+		r.secretNamespace = namespace
+	}
+}
+
 // WithInterceptorCreators configures the interceptors the
 // PackagedFunctionRunner should create for each function.
 func WithInterceptorCreators(ics ...InterceptorCreator) PackagedFunctionRunnerOption {
@@ -114,7 +161,7 @@ func NewPackagedFunctionRunner(c client.Reader, o ...PackagedFunctionRunnerOptio
 	r := &PackagedFunctionRunner{
 		client: c,
 		creds:  insecure.NewCredentials(),
-		conns:  make(map[string]*grpc.ClientConn),
+		conns:  make(map[string]*functionConn),
 		log:    logging.NewNopLogger(),
 	}
 
@@ -187,23 +234,37 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 		return nil, errors.Errorf(errFmtEmptyEndpoint, active.GetName())
 	}
 
+	cs, ss, err := r.tlsSecrets(ctx, active)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtCredentials, active.GetName())
+	}
+	secrets := secretsVersion(cs, ss)
+
 	r.connsMx.RLock()
 	conn, ok := r.conns[name]
 	r.connsMx.RUnlock()
 
 	if ok {
-		// We have a connection for the up-to-date endpoint. Return it.
-		if conn.Target() == active.Status.Endpoint {
-			return conn, nil
+		// We have a connection for the up-to-date endpoint and revision,
+		// created using the current version of its TLS secrets. Return it.
+		if conn.Target() == active.Status.Endpoint && conn.revision == active.GetName() && conn.secrets == secrets {
+			return conn.ClientConn, nil
 		}
 
-		// This connection is to an old endpoint. We need to close it and create
-		// a new connection. Close only returns an error is if the connection is
-		// already closed or in the process of closing.
-		log.Debug("Closing gRPC client connection with stale target", "old-target", conn.Target(), "new-target", active.Status.Endpoint)
+		// This connection is to an old endpoint, or was created using the
+		// credentials of an old revision or old TLS secrets (or our default
+		// credentials, before the TLS secrets existed). We need to close it
+		// and create a new connection. Close only returns an error is if the
+		// connection is already closed or in the process of closing.
+		log.Debug("Closing stale gRPC client connection", "old-target", conn.Target(), "new-target", active.Status.Endpoint, "old-revision", conn.revision, "new-revision", active.GetName(), "old-secrets", conn.secrets, "new-secrets", secrets)
 		_ = conn.Close()
 	}
 
+	creds, err := r.credentials(cs, ss)
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtCredentials, active.GetName())
+	}
+
 	// This context is only used for setting up the connection.
 	ctx, cancel := context.WithTimeout(ctx, dialFunctionTimeout)
 	defer cancel()
@@ -213,8 +274,8 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 		is[i] = r.interceptors[i].CreateInterceptor(name, active.Spec.Package)
 	}
 
-	conn, err := grpc.DialContext(ctx, active.Status.Endpoint,
-		grpc.WithTransportCredentials(r.creds),
+	cc, err := grpc.DialContext(ctx, active.Status.Endpoint,
+		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultServiceConfig(lbRoundRobin),
 		grpc.WithChainUnaryInterceptor(is...))
 	if err != nil {
@@ -222,11 +283,94 @@ func (r *PackagedFunctionRunner) getClientConn(ctx context.Context, name string)
 	}
 
 	r.connsMx.Lock()
-	r.conns[name] = conn
+	r.conns[name] = &functionConn{ClientConn: cc, revision: active.GetName(), secrets: secrets}
 	r.connsMx.Unlock()
 
-	log.Debug("Created new gRPC client connection", "target", active.Status.Endpoint)
-	return conn, nil
+	log.Debug("Created new gRPC client connection", "target", active.Status.Endpoint, "revision", active.GetName())
+	return cc, nil
+}
+
+// tlsSecrets returns the client and server TLS secrets of the supplied active
+// FunctionRevision. It returns nil secrets if per-Function TLS is disabled, if
+// the revision doesn't reference its TLS secrets, or if the package manager
+// hasn't created them yet.
+func (r *PackagedFunctionRunner) tlsSecrets(ctx context.Context, rev *pkgv1beta1.FunctionRevision) (*corev1.Secret, *corev1.Secret, error) {
+	cn, sn := rev.GetTLSClientSecretName(), rev.GetTLSServerSecretName()
+	if r.secretNamespace == "" || cn == nil || sn == nil {
+		return nil, nil, nil
+	}
+
+	cs := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.secretNamespace, Name: *cn}, cs); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrapf(err, errFmtGetSecret, *cn)
+	}
+	ss := &corev1.Secret{}
+	if err := r.client.Get(ctx, types.NamespacedName{Namespace: r.secretNamespace, Name: *sn}, ss); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, errors.Wrapf(err, errFmtGetSecret, *sn)
+	}
+	return cs, ss, nil
+}
+
+// secretsVersion identifies the versions of the supplied TLS secrets, so we
+// can tell when a connection's credentials are stale. It returns an empty
+// string if there are no TLS secrets.
+func secretsVersion(cs, ss *corev1.Secret) string {
+	if cs == nil || ss == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s/%s,%s/%s", cs.GetUID(), cs.GetResourceVersion(), ss.GetUID(), ss.GetResourceVersion())
+}
+
+// credentials returns the transport credentials used to call a Function. If
+// we have the Function's TLS secrets we present the client certificate
+// generated for the Function and only trust the server certificate stored in
+// its server secret. Otherwise we use the runner's default credentials.
+func (r *PackagedFunctionRunner) credentials(cs, ss *corev1.Secret) (credentials.TransportCredentials, error) {
+	if cs == nil || ss == nil {
+		return r.creds, nil
+	}
+	cn, sn := cs.GetName(), ss.GetName()
+
+	cert, err := tls.X509KeyPair(cs.Data[corev1.TLSCertKey], cs.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return nil, errors.Wrapf(err, errFmtLoadKeyPair, cn)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(cs.Data[initializer.SecretKeyCACert]) {
+		return nil, errors.Errorf(errFmtInvalidCA, cn)
+	}
+
+	block, _ := pem.Decode(ss.Data[corev1.TLSCertKey])
+	if block == nil {
+		return nil, errors.Errorf(errFmtInvalidServerCert, sn)
+	}
+	pinned := block.Bytes
+
+	return credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		// VerifyConnection is called after the server's certificate chain
+		// is verified against our CA. Every Function's server certificate
+		// is signed by the same CA, so we also make sure we're talking to
+		// the Function we meant to call.
+		VerifyConnection: func(s tls.ConnectionState) error {
+			if len(s.PeerCertificates) == 0 {
+				return errors.New(errServerCertNotPresent)
+			}
+			if !bytes.Equal(s.PeerCertificates[0].Raw, pinned) {
+				return errors.New(errServerCertNotPinned)
+			}
+			return nil
+		},
+	}), nil
 }
 
 // GarbageCollectConnections runs every interval until the supplied context is
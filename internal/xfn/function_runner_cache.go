@@ -0,0 +1,439 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
+)
+
+// Error strings
+const (
+	errHashRequest       = "cannot hash RunFunctionRequest"
+	errMarshalResponse   = "cannot marshal RunFunctionResponse"
+	errUnmarshalResponse = "cannot unmarshal RunFunctionResponse"
+	errReadCacheFile     = "cannot read cached RunFunctionResponse"
+	errWriteCacheFile    = "cannot write cached RunFunctionResponse"
+	errListCacheFiles    = "cannot list cached RunFunctionResponses"
+	errShortCacheFile    = "cached RunFunctionResponse is truncated"
+)
+
+const (
+	// DefaultCacheMaxEntries is the default maximum number of
+	// RunFunctionResponses a CachingFunctionRunner will hold in memory.
+	DefaultCacheMaxEntries = 10000
+
+	// DefaultCacheMaxTTL is the default maximum amount of time a
+	// CachingFunctionRunner will cache a RunFunctionResponse, regardless of the
+	// TTL the Function requested.
+	DefaultCacheMaxTTL = 24 * time.Hour
+
+	// Cache files are prefixed with an 8 byte, big endian deadline in
+	// nanoseconds since the Unix epoch, followed by the serialized
+	// RunFunctionResponse.
+	cacheFileHeaderLen = 8
+
+	cacheFileTmpSuffix = ".tmp"
+)
+
+// A FunctionRunner runs a single Composition Function.
+type FunctionRunner interface {
+	// RunFunction runs the named Composition Function.
+	RunFunction(ctx context.Context, name string, req *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error)
+}
+
+// A CacheRecorder records cache operations.
+type CacheRecorder interface {
+	// Hit records a cache hit for the named Function.
+	Hit(name string)
+
+	// Miss records a cache miss for the named Function.
+	Miss(name string)
+
+	// Write records that a response from the named Function was cached.
+	Write(name string)
+
+	// Evict records that a response from the named Function was evicted from
+	// memory, either because it expired or because the cache was full.
+	Evict(name string)
+}
+
+type nopCacheRecorder struct{}
+
+func (nopCacheRecorder) Hit(_ string)   {}
+func (nopCacheRecorder) Miss(_ string)  {}
+func (nopCacheRecorder) Write(_ string) {}
+func (nopCacheRecorder) Evict(_ string) {}
+
+type cacheEntry struct {
+	key      string
+	name     string
+	rsp      *v1beta1.RunFunctionResponse
+	deadline time.Time
+}
+
+// A CachingFunctionRunner wraps another FunctionRunner, caching the
+// RunFunctionResponses it returns. Responses are cached for the TTL the
+// Function returns in its response metadata. Responses without a TTL, and
+// responses with fatal results, are never cached.
+//
+// Responses are cached in memory, in least-recently-used order. When the cache
+// is full the least recently used response is evicted. If the runner is
+// configured with a filesystem, evicted responses are written to the
+// filesystem rather than discarded, and read back on demand. You must call
+// GarbageCollectEntries in order to ensure expired responses are removed.
+type CachingFunctionRunner struct {
+	wrapped FunctionRunner
+
+	maxEntries int
+	maxTTL     time.Duration
+	fs         afero.Fs
+
+	mx      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+
+	metrics CacheRecorder
+	log     logging.Logger
+
+	// now is overridden by tests.
+	now func() time.Time
+}
+
+// A CachingFunctionRunnerOption configures a CachingFunctionRunner.
+type CachingFunctionRunnerOption func(r *CachingFunctionRunner)
+
+// WithCacheLogger configures the logger the CachingFunctionRunner should use.
+func WithCacheLogger(l logging.Logger) CachingFunctionRunnerOption {
+	return func(r *CachingFunctionRunner) {
+		r.log = l
+	}
+}
+
+// WithCacheRecorder configures how the CachingFunctionRunner should record
+// cache metrics.
+func WithCacheRecorder(m CacheRecorder) CachingFunctionRunnerOption {
+	return func(r *CachingFunctionRunner) {
+		r.metrics = m
+	}
+}
+
+// WithCacheMaxEntries configures the maximum number of responses the
+// CachingFunctionRunner will hold in memory. A value of zero or less means the
+// number of entries is unbounded.
+func WithCacheMaxEntries(n int) CachingFunctionRunnerOption {
+	return func(r *CachingFunctionRunner) {
+		r.maxEntries = n
+	}
+}
+
+// WithCacheMaxTTL configures the maximum amount of time the
+// CachingFunctionRunner will cache a response. Responses that specify a longer
+// TTL will be cached for this long.
+func WithCacheMaxTTL(ttl time.Duration) CachingFunctionRunnerOption {
+	return func(r *CachingFunctionRunner) {
+		r.maxTTL = ttl
+	}
+}
+
+// WithCacheFilesystem configures the CachingFunctionRunner to spill responses
+// that are evicted from memory to the supplied filesystem. Cached responses are
+// written to the root of the filesystem, so it should usually be an
+// afero.BasePathFs dedicated to the cache.
+func WithCacheFilesystem(fs afero.Fs) CachingFunctionRunnerOption {
+	return func(r *CachingFunctionRunner) {
+		r.fs = fs
+	}
+}
+
+// NewCachingFunctionRunner returns a FunctionRunner that caches the responses
+// of the supplied FunctionRunner.
+func NewCachingFunctionRunner(wrapped FunctionRunner, o ...CachingFunctionRunnerOption) *CachingFunctionRunner {
+	r := &CachingFunctionRunner{
+		wrapped:    wrapped,
+		maxEntries: DefaultCacheMaxEntries,
+		maxTTL:     DefaultCacheMaxTTL,
+		lru:        list.New(),
+		entries:    make(map[string]*list.Element),
+		metrics:    nopCacheRecorder{},
+		log:        logging.NewNopLogger(),
+		now:        time.Now,
+	}
+
+	for _, fn := range o {
+		fn(r)
+	}
+
+	return r
+}
+
+// RunFunction returns a cached response to the supplied RunFunctionRequest if
+// one exists. Otherwise it runs the named Function and caches its response.
+func (r *CachingFunctionRunner) RunFunction(ctx context.Context, name string, req *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error) {
+	key, err := RequestHash(name, req)
+	if err != nil {
+		// We can still run the Function, we just can't cache it.
+		r.log.Debug("Cannot hash RunFunctionRequest, not caching response", "function", name, "error", err)
+		return r.wrapped.RunFunction(ctx, name, req)
+	}
+
+	if rsp, ok := r.get(name, key); ok {
+		r.metrics.Hit(name)
+		return rsp, nil
+	}
+	r.metrics.Miss(name)
+
+	rsp, err := r.wrapped.RunFunction(ctx, name, req)
+	if err != nil {
+		return rsp, err
+	}
+
+	ttl := rsp.GetMeta().GetTtl().AsDuration()
+	if ttl <= 0 || hasFatalResult(rsp) {
+		return rsp, nil
+	}
+	if ttl > r.maxTTL {
+		ttl = r.maxTTL
+	}
+
+	r.put(&cacheEntry{key: key, name: name, rsp: proto.Clone(rsp).(*v1beta1.RunFunctionResponse), deadline: r.now().Add(ttl)})
+	r.metrics.Write(name)
+
+	return rsp, nil
+}
+
+// RequestHash returns a stable hash of the supplied RunFunctionRequest to the
+// named Function. Two semantically identical requests to the same Function
+// produce the same hash.
+func RequestHash(name string, req *v1beta1.RunFunctionRequest) (string, error) {
+	// Deterministic marshalling sorts map keys (including the fields of
+	// Struct well-known types), so identical requests produce identical bytes.
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", errors.Wrap(err, errHashRequest)
+	}
+
+	h := sha256.New()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write(b)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hasFatalResult(rsp *v1beta1.RunFunctionResponse) bool {
+	for _, rs := range rsp.GetResults() {
+		if rs.GetSeverity() == v1beta1.Severity_SEVERITY_FATAL {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *CachingFunctionRunner) get(name, key string) (*v1beta1.RunFunctionResponse, bool) {
+	r.mx.Lock()
+	if el, ok := r.entries[key]; ok {
+		e := el.Value.(*cacheEntry)
+		if r.now().Before(e.deadline) {
+			r.lru.MoveToFront(el)
+			r.mx.Unlock()
+			return proto.Clone(e.rsp).(*v1beta1.RunFunctionResponse), true
+		}
+		r.lru.Remove(el)
+		delete(r.entries, key)
+		r.mx.Unlock()
+		r.metrics.Evict(e.name)
+		return nil, false
+	}
+	r.mx.Unlock()
+
+	if r.fs == nil {
+		return nil, false
+	}
+
+	e, err := r.readFile(key)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			r.log.Debug("Cannot read cached RunFunctionResponse from filesystem", "key", key, "error", err)
+		}
+		return nil, false
+	}
+
+	// The response was either expired, or is about to be promoted back into
+	// memory. Either way we don't need it on disk anymore.
+	_ = r.fs.Remove(key)
+
+	if !r.now().Before(e.deadline) {
+		return nil, false
+	}
+
+	e.name = name
+	r.put(e)
+	return proto.Clone(e.rsp).(*v1beta1.RunFunctionResponse), true
+}
+
+func (r *CachingFunctionRunner) put(e *cacheEntry) {
+	r.mx.Lock()
+	if el, ok := r.entries[e.key]; ok {
+		el.Value = e
+		r.lru.MoveToFront(el)
+		r.mx.Unlock()
+		return
+	}
+	r.entries[e.key] = r.lru.PushFront(e)
+
+	evicted := make([]*cacheEntry, 0)
+	for r.maxEntries > 0 && r.lru.Len() > r.maxEntries {
+		el := r.lru.Back()
+		ev := el.Value.(*cacheEntry)
+		r.lru.Remove(el)
+		delete(r.entries, ev.key)
+		evicted = append(evicted, ev)
+	}
+	r.mx.Unlock()
+
+	// We write evicted entries outside the lock so we don't block callers on
+	// filesystem I/O.
+	for _, ev := range evicted {
+		r.metrics.Evict(ev.name)
+		if r.fs == nil {
+			continue
+		}
+		if err := r.writeFile(ev); err != nil {
+			r.log.Debug("Cannot write evicted RunFunctionResponse to filesystem", "function", ev.name, "key", ev.key, "error", err)
+		}
+	}
+}
+
+func (r *CachingFunctionRunner) readFile(key string) (*cacheEntry, error) {
+	b, err := afero.ReadFile(r.fs, key)
+	if err != nil {
+		return nil, errors.Wrap(err, errReadCacheFile)
+	}
+	if len(b) < cacheFileHeaderLen {
+		return nil, errors.New(errShortCacheFile)
+	}
+
+	rsp := &v1beta1.RunFunctionResponse{}
+	if err := proto.Unmarshal(b[cacheFileHeaderLen:], rsp); err != nil {
+		return nil, errors.Wrap(err, errUnmarshalResponse)
+	}
+
+	deadline := time.Unix(0, int64(binary.BigEndian.Uint64(b[:cacheFileHeaderLen])))
+	return &cacheEntry{key: key, rsp: rsp, deadline: deadline}, nil
+}
+
+func (r *CachingFunctionRunner) writeFile(e *cacheEntry) error {
+	b, err := proto.Marshal(e.rsp)
+	if err != nil {
+		return errors.Wrap(err, errMarshalResponse)
+	}
+
+	data := make([]byte, cacheFileHeaderLen, cacheFileHeaderLen+len(b))
+	binary.BigEndian.PutUint64(data, uint64(e.deadline.UnixNano()))
+	data = append(data, b...)
+
+	// Write to a temporary file and rename it, so that a concurrent reader
+	// never sees a partially written response.
+	tmp := e.key + cacheFileTmpSuffix
+	if err := afero.WriteFile(r.fs, tmp, data, 0o600); err != nil {
+		return errors.Wrap(err, errWriteCacheFile)
+	}
+	return errors.Wrap(r.fs.Rename(tmp, e.key), errWriteCacheFile)
+}
+
+// GarbageCollectEntries runs every interval until the supplied context is
+// cancelled. It garbage collects expired cached responses.
+func (r *CachingFunctionRunner) GarbageCollectEntries(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Debug("Stopping RunFunctionResponse cache garbage collector", "error", ctx.Err())
+			return
+		case <-t.C:
+			if _, err := r.GarbageCollectEntriesNow(ctx); err != nil {
+				r.log.Info("Cannot garbage collect cached RunFunctionResponses", "error", err)
+			}
+		}
+	}
+}
+
+// GarbageCollectEntriesNow immediately garbage collects any expired cached
+// responses, both in memory and on the filesystem. It returns the number of
+// responses garbage collected.
+func (r *CachingFunctionRunner) GarbageCollectEntriesNow(_ context.Context) (int, error) {
+	now := r.now()
+	evicted := make([]*cacheEntry, 0)
+
+	r.mx.Lock()
+	for el := r.lru.Front(); el != nil; {
+		next := el.Next()
+		e := el.Value.(*cacheEntry)
+		if !now.Before(e.deadline) {
+			r.lru.Remove(el)
+			delete(r.entries, e.key)
+			evicted = append(evicted, e)
+		}
+		el = next
+	}
+	r.mx.Unlock()
+
+	for _, e := range evicted {
+		r.metrics.Evict(e.name)
+	}
+
+	if r.fs == nil {
+		return len(evicted), nil
+	}
+
+	files, err := afero.ReadDir(r.fs, "/")
+	if err != nil {
+		return len(evicted), errors.Wrap(err, errListCacheFiles)
+	}
+
+	removed := 0
+	for _, f := range files {
+		// Skip directories, and files that are still being written.
+		if f.IsDir() || strings.HasSuffix(f.Name(), cacheFileTmpSuffix) {
+			continue
+		}
+		e, err := r.readFile(f.Name())
+		if err != nil || !now.Before(e.deadline) {
+			// Unreadable files will never be served, so we remove them too.
+			_ = r.fs.Remove(f.Name())
+			removed++
+		}
+	}
+
+	return len(evicted) + removed, nil
+}
@@ -0,0 +1,303 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License. You may obtain a copy of the
+License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied. See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package xfn
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
+)
+
+type fnRunnerFn func(ctx context.Context, name string, req *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error)
+
+func (fn fnRunnerFn) RunFunction(ctx context.Context, name string, req *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error) {
+	return fn(ctx, name, req)
+}
+
+// countingRunner returns the supplied response, and counts how many times it
+// was called.
+func countingRunner(rsp *v1beta1.RunFunctionResponse, err error, calls *int) FunctionRunner {
+	return fnRunnerFn(func(_ context.Context, _ string, _ *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error) {
+		*calls++
+		return rsp, err
+	})
+}
+
+func TestCachingRunFunction(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Now()
+
+	req := &v1beta1.RunFunctionRequest{Meta: &v1beta1.RequestMeta{Tag: "hi"}}
+	other := &v1beta1.RunFunctionRequest{Meta: &v1beta1.RequestMeta{Tag: "hello"}}
+
+	cacheable := &v1beta1.RunFunctionResponse{Meta: &v1beta1.ResponseMeta{Tag: "hi", Ttl: durationpb.New(1 * time.Minute)}}
+	uncacheable := &v1beta1.RunFunctionResponse{Meta: &v1beta1.ResponseMeta{Tag: "hi"}}
+	fatal := &v1beta1.RunFunctionResponse{
+		Meta:    &v1beta1.ResponseMeta{Tag: "hi", Ttl: durationpb.New(1 * time.Minute)},
+		Results: []*v1beta1.Result{{Severity: v1beta1.Severity_SEVERITY_FATAL}},
+	}
+
+	type params struct {
+		rsp *v1beta1.RunFunctionResponse
+		err error
+		o   []CachingFunctionRunnerOption
+	}
+	type args struct {
+		// Requests are run in order, advancing the clock by advance between
+		// each one.
+		reqs    []*v1beta1.RunFunctionRequest
+		advance time.Duration
+	}
+	type want struct {
+		rsp   *v1beta1.RunFunctionResponse
+		err   error
+		calls int
+	}
+	cases := map[string]struct {
+		reason string
+		params params
+		args   args
+		want   want
+	}{
+		"RunFunctionError": {
+			reason: "We should return any error encountered running the wrapped Function, and not cache it.",
+			params: params{
+				err: errBoom,
+			},
+			args: args{
+				reqs: []*v1beta1.RunFunctionRequest{req, req},
+			},
+			want: want{
+				err:   errBoom,
+				calls: 2,
+			},
+		},
+		"CacheHit": {
+			reason: "We should only run the wrapped Function once if it returned a TTL and the request didn't change.",
+			params: params{
+				rsp: cacheable,
+			},
+			args: args{
+				reqs: []*v1beta1.RunFunctionRequest{req, req, req},
+			},
+			want: want{
+				rsp:   cacheable,
+				calls: 1,
+			},
+		},
+		"DifferentRequest": {
+			reason: "We should run the wrapped Function again if the request changed.",
+			params: params{
+				rsp: cacheable,
+			},
+			args: args{
+				reqs: []*v1beta1.RunFunctionRequest{req, other},
+			},
+			want: want{
+				rsp:   cacheable,
+				calls: 2,
+			},
+		},
+		"NoTTL": {
+			reason: "We should not cache a response that doesn't specify a TTL.",
+			params: params{
+				rsp: uncacheable,
+			},
+			args: args{
+				reqs: []*v1beta1.RunFunctionRequest{req, req},
+			},
+			want: want{
+				rsp:   uncacheable,
+				calls: 2,
+			},
+		},
+		"FatalResult": {
+			reason: "We should not cache a response that contains a fatal result.",
+			params: params{
+				rsp: fatal,
+			},
+			args: args{
+				reqs: []*v1beta1.RunFunctionRequest{req, req},
+			},
+			want: want{
+				rsp:   fatal,
+				calls: 2,
+			},
+		},
+		"Expired": {
+			reason: "We should run the wrapped Function again if the cached response's TTL has expired.",
+			params: params{
+				rsp: cacheable,
+			},
+			args: args{
+				reqs:    []*v1beta1.RunFunctionRequest{req, req},
+				advance: 2 * time.Minute,
+			},
+			want: want{
+				rsp:   cacheable,
+				calls: 2,
+			},
+		},
+		"MaxTTL": {
+			reason: "We should cap the TTL of a cached response at the configured maximum.",
+			params: params{
+				rsp: cacheable,
+				o:   []CachingFunctionRunnerOption{WithCacheMaxTTL(30 * time.Second)},
+			},
+			args: args{
+				reqs:    []*v1beta1.RunFunctionRequest{req, req},
+				advance: 45 * time.Second,
+			},
+			want: want{
+				rsp:   cacheable,
+				calls: 2,
+			},
+		},
+		"EvictedFromMemory": {
+			reason: "We should run the wrapped Function again if its response was evicted from a full cache.",
+			params: params{
+				rsp: cacheable,
+				o:   []CachingFunctionRunnerOption{WithCacheMaxEntries(1)},
+			},
+			args: args{
+				reqs: []*v1beta1.RunFunctionRequest{req, other, req},
+			},
+			want: want{
+				rsp:   cacheable,
+				calls: 3,
+			},
+		},
+		"SpilledToFilesystem": {
+			reason: "We should read a response that was evicted from a full cache back from the filesystem.",
+			params: params{
+				rsp: cacheable,
+				o:   []CachingFunctionRunnerOption{WithCacheMaxEntries(1), WithCacheFilesystem(afero.NewMemMapFs())},
+			},
+			args: args{
+				reqs: []*v1beta1.RunFunctionRequest{req, other, req, other},
+			},
+			want: want{
+				rsp:   cacheable,
+				calls: 2,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			r := NewCachingFunctionRunner(countingRunner(tc.params.rsp, tc.params.err, &calls), tc.params.o...)
+
+			clock := now
+			r.now = func() time.Time { return clock }
+
+			var rsp *v1beta1.RunFunctionResponse
+			var err error
+			for _, req := range tc.args.reqs {
+				rsp, err = r.RunFunction(context.Background(), "cool-fn", req)
+				clock = clock.Add(tc.args.advance)
+			}
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want rsp, +got rsp:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want calls, +got calls:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGarbageCollectEntriesNow(t *testing.T) {
+	now := time.Now()
+
+	short := &v1beta1.RunFunctionResponse{Meta: &v1beta1.ResponseMeta{Ttl: durationpb.New(1 * time.Minute)}}
+	long := &v1beta1.RunFunctionResponse{Meta: &v1beta1.ResponseMeta{Ttl: durationpb.New(1 * time.Hour)}}
+
+	type params struct {
+		o []CachingFunctionRunnerOption
+	}
+	type want struct {
+		collected int
+		err       error
+	}
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"InMemory": {
+			reason: "We should garbage collect expired responses from memory.",
+			want: want{
+				collected: 2,
+			},
+		},
+		"OnFilesystem": {
+			reason: "We should garbage collect expired responses from memory and from the filesystem.",
+			params: params{
+				o: []CachingFunctionRunnerOption{WithCacheMaxEntries(2), WithCacheFilesystem(afero.NewMemMapFs())},
+			},
+			want: want{
+				collected: 2,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rsps := []*v1beta1.RunFunctionResponse{short, long, short, long}
+			i := 0
+			wrapped := fnRunnerFn(func(_ context.Context, _ string, _ *v1beta1.RunFunctionRequest) (*v1beta1.RunFunctionResponse, error) {
+				rsp := rsps[i]
+				i++
+				return rsp, nil
+			})
+
+			r := NewCachingFunctionRunner(wrapped, tc.params.o...)
+			r.now = func() time.Time { return now }
+
+			for j := range rsps {
+				req := &v1beta1.RunFunctionRequest{Meta: &v1beta1.RequestMeta{Tag: string(rune('a' + j))}}
+				if _, err := r.RunFunction(context.Background(), "cool-fn", req); err != nil {
+					t.Fatalf("r.RunFunction(...): %v", err)
+				}
+			}
+
+			r.now = func() time.Time { return now.Add(10 * time.Minute) }
+
+			collected, err := r.GarbageCollectEntriesNow(context.Background())
+			if diff := cmp.Diff(tc.want.collected, collected); diff != "" {
+				t.Errorf("\n%s\nr.GarbageCollectEntriesNow(...): -want collected, +got collected:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.GarbageCollectEntriesNow(...): -want err, +got err:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -119,3 +119,81 @@ func (m *Metrics) CreateInterceptor(name, pkg string) grpc.UnaryClientIntercepto
 		return err
 	}
 }
+
+// CacheMetrics are metrics for cached composition function runs.
+type CacheMetrics struct {
+	hits      *prometheus.CounterVec
+	misses    *prometheus.CounterVec
+	writes    *prometheus.CounterVec
+	evictions *prometheus.CounterVec
+}
+
+// NewCacheMetrics creates metrics for cached composition function runs.
+func NewCacheMetrics() *CacheMetrics {
+	return &CacheMetrics{
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "composition",
+			Name:      "run_function_response_cache_hits_total",
+			Help:      "Total number of RunFunctionResponse cache hits.",
+		}, []string{"function_name"}),
+
+		misses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "composition",
+			Name:      "run_function_response_cache_misses_total",
+			Help:      "Total number of RunFunctionResponse cache misses.",
+		}, []string{"function_name"}),
+
+		writes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "composition",
+			Name:      "run_function_response_cache_writes_total",
+			Help:      "Total number of RunFunctionResponses written to the cache.",
+		}, []string{"function_name"}),
+
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Subsystem: "composition",
+			Name:      "run_function_response_cache_evictions_total",
+			Help:      "Total number of RunFunctionResponses evicted from the in-memory cache.",
+		}, []string{"function_name"}),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector to the provided channel and returns once
+// the last descriptor has been sent.
+func (m *CacheMetrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hits.Describe(ch)
+	m.misses.Describe(ch)
+	m.writes.Describe(ch)
+	m.evictions.Describe(ch)
+}
+
+// Collect is called by the Prometheus registry when collecting
+// metrics. The implementation sends each collected metric via the
+// provided channel and returns once the last metric has been sent.
+func (m *CacheMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.hits.Collect(ch)
+	m.misses.Collect(ch)
+	m.writes.Collect(ch)
+	m.evictions.Collect(ch)
+}
+
+// Hit records a cache hit for the named function.
+func (m *CacheMetrics) Hit(name string) {
+	m.hits.With(prometheus.Labels{"function_name": name}).Inc()
+}
+
+// Miss records a cache miss for the named function.
+func (m *CacheMetrics) Miss(name string) {
+	m.misses.With(prometheus.Labels{"function_name": name}).Inc()
+}
+
+// Write records that a response from the named function was cached.
+func (m *CacheMetrics) Write(name string) {
+	m.writes.With(prometheus.Labels{"function_name": name}).Inc()
+}
+
+// Evict records that a response from the named function was evicted from
+// the in-memory cache.
+func (m *CacheMetrics) Evict(name string) {
+	m.evictions.With(prometheus.Labels{"function_name": name}).Inc()
+}
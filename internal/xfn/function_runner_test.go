@@ -17,15 +17,27 @@ package xfn
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/testing/protocmp"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,6 +46,7 @@ import (
 	"github.com/crossplane/crossplane/apis/apiextensions/fn/proto/v1beta1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+	"github.com/crossplane/crossplane/internal/initializer"
 )
 
 func TestRunFunction(t *testing.T) {
@@ -210,6 +223,196 @@ func TestRunFunction(t *testing.T) {
 	}
 }
 
+func TestRunFunctionWithFunctionTLS(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	ca := NewTestCertificate(t, nil, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	})
+	server := NewTestCertificate(t, ca, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	otherServer := NewTestCertificate(t, ca, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "other-server"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	cl := NewTestCertificate(t, ca, &x509.Certificate{
+		SerialNumber: big.NewInt(4),
+		Subject:      pkix.Name{CommonName: "client"},
+		DNSNames:     []string{"crossplane.crossplane-system", "cool-fn.crossplane.crossplane-system"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	otherClient := NewTestCertificate(t, ca, &x509.Certificate{
+		SerialNumber: big.NewInt(5),
+		Subject:      pkix.Name{CommonName: "other-client"},
+		DNSNames:     []string{"crossplane.crossplane-system", "other-fn.crossplane.crossplane-system"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	// The Function's server presents its certificate, and only authorizes
+	// clients that present a certificate signed by our CA that identifies
+	// Crossplane calling this Function, per TLS_AUTHORIZED_CLIENT_DNS_NAME.
+	lis := NewTLSGRPCServer(t, server, ca, "cool-fn.crossplane.crossplane-system", &MockFunctionServer{rsp: &v1beta1.RunFunctionResponse{
+		Meta: &v1beta1.ResponseMeta{Tag: "hi!"},
+	}})
+	defer lis.Close()
+
+	// The credentials we fall back to for Functions without TLS secrets.
+	clcert, err := tls.X509KeyPair(cl.cert, cl.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.parsed)
+	fallback := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{clcert},
+		RootCAs:      pool,
+	}
+
+	target := strings.Replace(lis.Addr().String(), "127.0.0.1", "dns:///localhost", 1)
+
+	type params struct {
+		c client.Client
+		o []PackagedFunctionRunnerOption
+	}
+	type want struct {
+		rsp *v1beta1.RunFunctionResponse
+		err error
+
+		// rejected is true if the Function should reject our client
+		// certificate. gRPC doesn't tell us why the Function rejected
+		// it, so we only check that the request failed.
+		rejected bool
+	}
+	cases := map[string]struct {
+		reason string
+		params params
+		want   want
+	}{
+		"GetClientSecretError": {
+			reason: "We should return an error if we can't get the Function's TLS client secret",
+			params: params{
+				c: &test.MockClient{
+					MockList: NewListFn(target, WithTLSSecrets("cool-fn-tls-client", "cool-fn-tls-server")),
+					MockGet:  test.NewMockGetFn(errBoom),
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.Wrapf(errors.Wrapf(errBoom, errFmtGetSecret, "cool-fn-tls-client"), errFmtCredentials, "cool-fn-revision-a"), errFmtGetClientConn, "cool-fn"),
+			},
+		},
+		"ClientSecretNotFound": {
+			reason: "We should fall back to our default credentials if the Function's TLS secrets don't exist yet",
+			params: params{
+				c: &test.MockClient{
+					MockList: NewListFn(target, WithTLSSecrets("cool-fn-tls-client", "cool-fn-tls-server")),
+					MockGet:  test.NewMockGetFn(kerrors.NewNotFound(corev1.Resource("secret"), "cool-fn-tls-client")),
+				},
+				o: []PackagedFunctionRunnerOption{WithTLSConfig(fallback)},
+			},
+			want: want{
+				rsp: &v1beta1.RunFunctionResponse{
+					Meta: &v1beta1.ResponseMeta{Tag: "hi!"},
+				},
+			},
+		},
+		"PinnedServerCertificate": {
+			reason: "We should successfully make a request if the Function presents the server certificate pinned by its active revision",
+			params: params{
+				c: &test.MockClient{
+					MockList: NewListFn(target, WithTLSSecrets("cool-fn-tls-client", "cool-fn-tls-server")),
+					MockGet:  NewTLSSecretsGetFn(cl, server, ca),
+				},
+			},
+			want: want{
+				rsp: &v1beta1.RunFunctionResponse{
+					Meta: &v1beta1.ResponseMeta{Tag: "hi!"},
+				},
+			},
+		},
+		"UnpinnedServerCertificate": {
+			reason: "We should refuse to make a request if the Function presents a server certificate other than the one pinned by its active revision, even if our CA signed it",
+			params: params{
+				c: &test.MockClient{
+					MockList: NewListFn(target, WithTLSSecrets("cool-fn-tls-client", "cool-fn-tls-server")),
+					MockGet:  NewTLSSecretsGetFn(cl, otherServer, ca),
+				},
+			},
+			want: want{
+				err: errors.New(errServerCertNotPinned),
+			},
+		},
+		"UnauthorizedClientCertificate": {
+			reason: "The Function should refuse a request made using a client certificate generated for calling another Function",
+			params: params{
+				c: &test.MockClient{
+					MockList: NewListFn(target, WithTLSSecrets("cool-fn-tls-client", "cool-fn-tls-server")),
+					MockGet:  NewTLSSecretsGetFn(otherClient, server, ca),
+				},
+			},
+			want: want{
+				rejected: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := NewPackagedFunctionRunner(tc.params.c, append(tc.params.o, WithFunctionTLSSecrets("crossplane-system"))...)
+			rsp, err := r.RunFunction(context.Background(), "cool-fn", &v1beta1.RunFunctionRequest{})
+
+			if diff := cmp.Diff(tc.want.rsp, rsp, protocmp.Transform()); diff != "" {
+				t.Errorf("\n%s\nr.RunFunction(...): -want, +got:\n%s", tc.reason, diff)
+			}
+
+			// The handshake error is wrapped by gRPC, so we can only
+			// check that it mentions why the handshake failed.
+			if tc.want.err != nil && err != nil && strings.Contains(err.Error(), errServerCertNotPinned) {
+				err = tc.want.err
+			}
+			switch {
+			case tc.want.rejected:
+				if err == nil {
+					t.Errorf("\n%s\nr.RunFunction(...): want error, got nil", tc.reason)
+				}
+			default:
+				if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+					t.Errorf("\n%s\nr.RunFunction(...): -want error, +got error:\n%s", tc.reason, diff)
+				}
+			}
+
+			// Close any gRPC clients.
+			if _, err := r.GarbageCollectConnectionsNow(context.Background()); err != nil {
+				t.Logf("Error closing client connections: %s", err)
+			}
+		})
+	}
+}
+
 func TestGetClientConn(t *testing.T) {
 	// TestRunFunction exercises most of the getClientConn code. Here we just
 	// test some cases that don't fit well in our usual table-driven format.
@@ -281,6 +484,111 @@ func TestGetClientConn(t *testing.T) {
 	}
 }
 
+func TestGetClientConnTLSSecrets(t *testing.T) {
+	// Here we test that we replace a cached connection when the Function's TLS
+	// secrets are created or updated, which doesn't fit well in our usual
+	// table-driven format.
+
+	ca := NewTestCertificate(t, nil, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	})
+	server := NewTestCertificate(t, ca, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "server"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	cl := NewTestCertificate(t, ca, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "client"},
+		DNSNames:     []string{"crossplane.crossplane-system", "cool-fn.crossplane.crossplane-system"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	lis := NewTLSGRPCServer(t, server, ca, "cool-fn.crossplane.crossplane-system", &MockFunctionServer{rsp: &v1beta1.RunFunctionResponse{
+		Meta: &v1beta1.ResponseMeta{Tag: "hi!"},
+	}})
+	defer lis.Close()
+
+	target := strings.Replace(lis.Addr().String(), "127.0.0.1", "dns:///localhost", 1)
+
+	// The Function's TLS secrets don't exist yet.
+	c := &test.MockClient{
+		MockList: NewListFn(target, WithTLSSecrets("cool-fn-tls-client", "cool-fn-tls-server")),
+		MockGet:  test.NewMockGetFn(kerrors.NewNotFound(corev1.Resource("secret"), "cool-fn-tls-client")),
+	}
+
+	r := NewPackagedFunctionRunner(c, WithFunctionTLSSecrets("crossplane-system"))
+
+	fallback, err := r.getClientConn(context.Background(), "cool-fn")
+	if err != nil {
+		t.Fatalf("r.getClientConn(...): %s", err)
+	}
+
+	// Once the TLS secrets exist, we should stop using the connection that
+	// was created with our default credentials.
+	version := "1"
+	get := NewTLSSecretsGetFn(cl, server, ca)
+	c.MockGet = func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.SetResourceVersion(version)
+		return get(ctx, key, obj)
+	}
+
+	var secured *grpc.ClientConn
+	t.Run("ReplaceFallbackConnection", func(t *testing.T) {
+		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+		if conn == fallback {
+			t.Errorf("\nr.getClientConn(...): want a new connection once the TLS secrets exist, got the connection created with default credentials")
+		}
+		secured = conn
+	})
+
+	// If the TLS secrets haven't changed, we should return our cached
+	// connection.
+	t.Run("ReuseExistingConnection", func(t *testing.T) {
+		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+		if conn != secured {
+			t.Errorf("\nr.getClientConn(...): want the cached connection, got a new connection")
+		}
+	})
+
+	// If the TLS secrets were updated, for example because the server
+	// certificate was rotated, we should create a new connection.
+	version = "2"
+	t.Run("ReplaceRotatedConnection", func(t *testing.T) {
+		conn, err := r.getClientConn(context.Background(), "cool-fn")
+		if diff := cmp.Diff(nil, err, test.EquateErrors()); diff != "" {
+			t.Errorf("\nr.getClientConn(...): -want error, +got error:\n%s", diff)
+		}
+		if conn == secured {
+			t.Errorf("\nr.getClientConn(...): want a new connection once the TLS secrets change, got the cached connection")
+		}
+	})
+
+	// Close any gRPC clients.
+	if _, err := r.GarbageCollectConnectionsNow(context.Background()); err != nil {
+		t.Logf("Error closing client connections: %s", err)
+	}
+}
+
 func TestGarbageCollectConnectionsNow(t *testing.T) {
 	// TestRunFunction exercises most of the GarbageCollectConnectionsNow code.
 	// Here we just test some cases that don't fit well in our usual
@@ -305,7 +613,7 @@ func TestGarbageCollectConnectionsNow(t *testing.T) {
 
 	// Add our connection to our pool.
 	r.connsMx.Lock()
-	r.conns["cool-fn"] = conn
+	r.conns["cool-fn"] = &functionConn{ClientConn: conn}
 	r.connsMx.Unlock()
 
 	t.Run("FunctionStillExistsDoNotGarbageCollect", func(t *testing.T) {
@@ -344,7 +652,7 @@ func TestGarbageCollectConnectionsNow(t *testing.T) {
 	})
 }
 
-func NewListFn(target string) test.MockListFn {
+func NewListFn(target string, o ...func(r *pkgv1beta1.FunctionRevision)) test.MockListFn {
 	return test.NewMockListFn(nil, func(obj client.ObjectList) error {
 		l, ok := obj.(*pkgv1beta1.FunctionRevisionList)
 		if !ok {
@@ -352,25 +660,139 @@ func NewListFn(target string) test.MockListFn {
 			// return none, to make sure we GC everything.
 			return nil
 		}
-		l.Items = []pkgv1beta1.FunctionRevision{
-			{
-				ObjectMeta: metav1.ObjectMeta{
-					Name: "cool-fn-revision-a",
-				},
-				Spec: pkgv1beta1.FunctionRevisionSpec{
-					PackageRevisionSpec: pkgv1.PackageRevisionSpec{
-						DesiredState: pkgv1.PackageRevisionActive,
-					},
-				},
-				Status: pkgv1beta1.FunctionRevisionStatus{
-					Endpoint: target,
+		rev := pkgv1beta1.FunctionRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "cool-fn-revision-a",
+			},
+			Spec: pkgv1beta1.FunctionRevisionSpec{
+				PackageRevisionSpec: pkgv1.PackageRevisionSpec{
+					DesiredState: pkgv1.PackageRevisionActive,
 				},
 			},
+			Status: pkgv1beta1.FunctionRevisionStatus{
+				Endpoint: target,
+			},
+		}
+		for _, fn := range o {
+			fn(&rev)
 		}
+		l.Items = []pkgv1beta1.FunctionRevision{rev}
 		return nil
 	})
 }
 
+func WithTLSSecrets(cl, srv string) func(r *pkgv1beta1.FunctionRevision) {
+	return func(r *pkgv1beta1.FunctionRevision) {
+		r.SetTLSClientSecretName(&cl)
+		r.SetTLSServerSecretName(&srv)
+	}
+}
+
+// A TestCertificate is a PEM encoded certificate and key.
+type TestCertificate struct {
+	cert []byte
+	key  []byte
+
+	parsed *x509.Certificate
+	signer *ecdsa.PrivateKey
+}
+
+// NewTestCertificate creates a certificate from the supplied template. It's
+// signed by the supplied parent, or self-signed if the parent is nil.
+func NewTestCertificate(t *testing.T, parent *TestCertificate, tmpl *x509.Certificate) *TestCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, signer := tmpl, key
+	if parent != nil {
+		p, signer = parent.parsed, parent.signer
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, p, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kder, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &TestCertificate{
+		cert:   pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		key:    pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kder}),
+		parsed: parsed,
+		signer: key,
+	}
+}
+
+// NewTLSSecretsGetFn returns a MockGetFn that returns a TLS client secret
+// containing the supplied client certificate, or a TLS server secret
+// containing the supplied server certificate.
+func NewTLSSecretsGetFn(cl, srv, ca *TestCertificate) test.MockGetFn {
+	return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		s := obj.(*corev1.Secret)
+		c := srv
+		if strings.HasSuffix(key.Name, "-tls-client") {
+			c = cl
+		}
+		s.Data = map[string][]byte{
+			corev1.TLSCertKey:           c.cert,
+			corev1.TLSPrivateKeyKey:     c.key,
+			initializer.SecretKeyCACert: ca.cert,
+		}
+		return nil
+	}
+}
+
+// NewTLSGRPCServer starts a gRPC server that presents the supplied server
+// certificate, and requires clients to present a certificate signed by the
+// supplied CA. Like a Function honoring TLS_AUTHORIZED_CLIENT_DNS_NAME, it
+// also requires the client certificate to include the supplied DNS name.
+func NewTLSGRPCServer(t *testing.T, server, ca *TestCertificate, authorized string, ss v1beta1.FunctionRunnerServiceServer) net.Listener {
+	t.Helper()
+
+	cert, err := tls.X509KeyPair(server.cert, server.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.parsed)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("Listening for gRPC connections on %q", lis.Addr().String())
+
+	go func() {
+		s := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+			MinVersion:   tls.VersionTLS12,
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+			VerifyConnection: func(s tls.ConnectionState) error {
+				if len(s.PeerCertificates) == 0 {
+					return errors.New("no client certificate")
+				}
+				return s.PeerCertificates[0].VerifyHostname(authorized)
+			},
+		})))
+		v1beta1.RegisterFunctionRunnerServiceServer(s, ss)
+		_ = s.Serve(lis)
+	}()
+
+	// The caller must close this listener to terminate the server.
+	return lis
+}
+
 func NewGRPCServer(t *testing.T, ss v1beta1.FunctionRunnerServiceServer) net.Listener {
 	// Listen on a random port.
 	lis, err := net.Listen("tcp", "127.0.0.1:0")
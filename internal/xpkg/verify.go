@@ -0,0 +1,537 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	protobundle "github.com/sigstore/protobuf-specs/gen/pb-go/bundle/v1"
+	protocommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
+	protodsse "github.com/sigstore/protobuf-specs/gen/pb-go/dsse"
+	protorekor "github.com/sigstore/protobuf-specs/gen/pb-go/rekor/v1"
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/signature"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+)
+
+// Cosign stores the signatures and attestations of an image as the layers of
+// images tagged with the image's digest and these suffixes.
+const (
+	cosignSignatureSuffix   = "sig"
+	cosignAttestationSuffix = "att"
+)
+
+// Annotations cosign adds to signature and attestation layers.
+const (
+	annotationCosignSignature   = "dev.cosignproject.cosign/signature"
+	annotationCosignCertificate = "dev.sigstore.cosign/certificate"
+	annotationCosignBundle      = "dev.sigstore.cosign/bundle"
+)
+
+const (
+	// maxSignatureLayerSize is the maximum size of a signature or attestation
+	// layer we'll read.
+	maxSignatureLayerSize = 16 << 20 // 16 MB
+
+	dssePayloadTypeInToto = "application/vnd.in-toto+json"
+
+	// Version 0.1 bundles prove log inclusion using a signed entry timestamp,
+	// which is what cosign records alongside each signature.
+	bundleMediaType = "application/vnd.dev.sigstore.bundle+json;version=0.1"
+)
+
+const (
+	errParsePublicKey        = "cannot parse public key"
+	errParseFulcioRoots      = "cannot parse Fulcio root certificates"
+	errParseRekorPublicKey   = "cannot parse Rekor public key"
+	errParseCertificate      = "cannot parse signing certificate"
+	errParseBundle           = "cannot parse transparency log bundle"
+	errParseIdentity         = "cannot parse keyless identity"
+	errNewVerifier           = "cannot create signature verifier"
+	errResolveDigest         = "cannot resolve package image digest"
+	errFetchSignatures       = "cannot fetch package image signatures"
+	errFetchAttestations     = "cannot fetch package image attestations"
+	errNoValidSignature      = "package image has no valid signature from a trusted authority"
+	errFmtAuthority          = "authority %q must specify exactly one of key or keyless"
+	errFmtNoRekorPublicKey   = "authority %q must specify a Rekor public key unless it ignores the transparency log"
+	errFmtNoValidAttestation = "package image has no valid %q attestation from a trusted authority"
+)
+
+// A Verifier verifies that a package image satisfies a verification policy.
+type Verifier interface {
+	// Verify the supplied image. It returns the digest of the verified image.
+	Verify(ctx context.Context, ref name.Reference, p v1alpha1.VerificationPolicySpec, secrets ...string) (v1.Hash, error)
+}
+
+// A CosignVerifier verifies the cosign signatures and attestations of package
+// images. It converts them to Sigstore bundles, which it verifies using
+// sigstore-go.
+type CosignVerifier struct {
+	fetcher Fetcher
+}
+
+// NewCosignVerifier returns a Verifier that uses the supplied Fetcher to fetch
+// cosign signatures and attestations.
+func NewCosignVerifier(f Fetcher) *CosignVerifier {
+	return &CosignVerifier{fetcher: f}
+}
+
+// Verify that the supplied image is signed by at least one of the policy's
+// authorities, and that it has all of the policy's required attestations.
+func (v *CosignVerifier) Verify(ctx context.Context, ref name.Reference, p v1alpha1.VerificationPolicySpec, secrets ...string) (v1.Hash, error) { //nolint:gocyclo // Only slightly over.
+	as, err := newAuthorities(p.Authorities)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+
+	d, err := v.fetcher.Head(ctx, ref, secrets...)
+	if err != nil {
+		return v1.Hash{}, errors.Wrap(err, errResolveDigest)
+	}
+	h := d.Digest
+
+	sigs, err := v.fetchLayers(ctx, ref, h, cosignSignatureSuffix, secrets)
+	if err != nil {
+		return v1.Hash{}, errors.Wrap(err, errFetchSignatures)
+	}
+	var errs []error
+	verified := false
+	for _, l := range sigs {
+		b, err := signatureBundle(l, h)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, err := as.verify(b, verify.WithArtifact(bytes.NewReader(l.content))); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		verified = true
+		break
+	}
+	if !verified {
+		if len(errs) == 0 {
+			return v1.Hash{}, errors.New(errNoValidSignature)
+		}
+		return v1.Hash{}, errors.Wrap(errors.Join(errs...), errNoValidSignature)
+	}
+
+	if len(p.Attestations) == 0 {
+		return h, nil
+	}
+
+	atts, err := v.fetchLayers(ctx, ref, h, cosignAttestationSuffix, secrets)
+	if err != nil {
+		return v1.Hash{}, errors.Wrap(err, errFetchAttestations)
+	}
+	digest, err := hex.DecodeString(h.Hex)
+	if err != nil {
+		return v1.Hash{}, errors.Wrap(err, errResolveDigest)
+	}
+	errs = nil
+	predicates := map[string]bool{}
+	for _, l := range atts {
+		bs, err := attestationBundles(l)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, b := range bs {
+			// The statement is only part of the result if its envelope's
+			// signature was verified, so we can trust its predicate type.
+			r, err := as.verify(b, verify.WithArtifactDigest(h.Algorithm, digest))
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			predicates[r.Statement.GetPredicateType()] = true
+			break
+		}
+	}
+	for _, a := range p.Attestations {
+		if predicates[a.PredicateType] {
+			continue
+		}
+		if len(errs) == 0 {
+			return v1.Hash{}, errors.Errorf(errFmtNoValidAttestation, a.PredicateType)
+		}
+		return v1.Hash{}, errors.Wrapf(errors.Join(errs...), errFmtNoValidAttestation, a.PredicateType)
+	}
+
+	return h, nil
+}
+
+// A signatureLayer is a layer of a cosign signature or attestation image.
+type signatureLayer struct {
+	annotations map[string]string
+	content     []byte
+}
+
+func (v *CosignVerifier) fetchLayers(ctx context.Context, ref name.Reference, h v1.Hash, suffix string, secrets []string) ([]signatureLayer, error) {
+	tag := ref.Context().Tag(fmt.Sprintf("%s-%s.%s", h.Algorithm, h.Hex, suffix))
+	img, err := v.fetcher.Fetch(ctx, tag, secrets...)
+	if err != nil {
+		return nil, err
+	}
+	m, err := img.Manifest()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]signatureLayer, 0, len(m.Layers))
+	for _, d := range m.Layers {
+		l, err := img.LayerByDigest(d.Digest)
+		if err != nil {
+			return nil, err
+		}
+		rc, err := l.Compressed()
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(io.LimitReader(rc, maxSignatureLayerSize))
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, signatureLayer{annotations: d.Annotations, content: b})
+	}
+	return out, nil
+}
+
+// A simpleSigningPayload is the payload of a cosign signature.
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// signatureBundle returns a Sigstore bundle for the supplied cosign signature.
+// The signed artifact is the layer's content.
+func signatureBundle(l signatureLayer, h v1.Hash) (*bundle.Bundle, error) {
+	p := &simpleSigningPayload{}
+	if err := json.Unmarshal(l.content, p); err != nil {
+		return nil, errors.Wrap(err, "cannot parse signature payload")
+	}
+	if p.Critical.Image.DockerManifestDigest != h.String() {
+		return nil, errors.Errorf("signature is for digest %q, not %q", p.Critical.Image.DockerManifestDigest, h.String())
+	}
+	sig, err := base64.StdEncoding.DecodeString(l.annotations[annotationCosignSignature])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode signature")
+	}
+	vm, err := verificationMaterial(l.annotations)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(l.content)
+	return bundle.NewBundle(&protobundle.Bundle{
+		MediaType:            bundleMediaType,
+		VerificationMaterial: vm,
+		Content: &protobundle.Bundle_MessageSignature{MessageSignature: &protocommon.MessageSignature{
+			MessageDigest: &protocommon.HashOutput{Algorithm: protocommon.HashAlgorithm_SHA2_256, Digest: sum[:]},
+			Signature:     sig,
+		}},
+	})
+}
+
+// A dsseEnvelope is the envelope of a cosign attestation.
+// https://github.com/secure-systems-lab/dsse/blob/master/envelope.md
+type dsseEnvelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     []byte `json:"payload"`
+	Signatures  []struct {
+		KeyID string `json:"keyid"`
+		Sig   []byte `json:"sig"`
+	} `json:"signatures"`
+}
+
+// attestationBundles returns a Sigstore bundle for each signature of the
+// supplied cosign attestation. A bundle's envelope may only have one signature.
+func attestationBundles(l signatureLayer) ([]*bundle.Bundle, error) {
+	e := &dsseEnvelope{}
+	if err := json.Unmarshal(l.content, e); err != nil {
+		return nil, errors.Wrap(err, "cannot parse attestation envelope")
+	}
+	if e.PayloadType != dssePayloadTypeInToto {
+		return nil, errors.Errorf("unsupported attestation payload type %q", e.PayloadType)
+	}
+	out := make([]*bundle.Bundle, 0, len(e.Signatures))
+	for _, s := range e.Signatures {
+		vm, err := verificationMaterial(l.annotations)
+		if err != nil {
+			return nil, err
+		}
+		b, err := bundle.NewBundle(&protobundle.Bundle{
+			MediaType:            bundleMediaType,
+			VerificationMaterial: vm,
+			Content: &protobundle.Bundle_DsseEnvelope{DsseEnvelope: &protodsse.Envelope{
+				Payload:     e.Payload,
+				PayloadType: e.PayloadType,
+				Signatures:  []*protodsse.Signature{{Sig: s.Sig, Keyid: s.KeyID}},
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
+
+// A rekorBundle is the proof that a signature was recorded in a Rekor
+// transparency log, as cosign annotates it.
+type rekorBundle struct {
+	SignedEntryTimestamp []byte `json:"SignedEntryTimestamp"`
+	Payload              struct {
+		Body           []byte `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogIndex       int64  `json:"logIndex"`
+		LogID          string `json:"logID"`
+	} `json:"Payload"`
+}
+
+// verificationMaterial returns the verification material of a cosign
+// signature or attestation, i.e. its signing certificate (or a key hint if it
+// was signed using a key) and its transparency log entry, if any.
+func verificationMaterial(a map[string]string) (*protobundle.VerificationMaterial, error) {
+	vm := &protobundle.VerificationMaterial{
+		Content: &protobundle.VerificationMaterial_PublicKey{PublicKey: &protocommon.PublicKeyIdentifier{}},
+	}
+	if c := a[annotationCosignCertificate]; c != "" {
+		certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(c))
+		if err != nil {
+			return nil, errors.Wrap(err, errParseCertificate)
+		}
+		if len(certs) != 1 {
+			return nil, errors.New(errParseCertificate)
+		}
+		vm.Content = &protobundle.VerificationMaterial_Certificate{Certificate: &protocommon.X509Certificate{RawBytes: certs[0].Raw}}
+	}
+	if b := a[annotationCosignBundle]; b != "" {
+		e, err := tlogEntry([]byte(b))
+		if err != nil {
+			return nil, errors.Wrap(err, errParseBundle)
+		}
+		vm.TlogEntries = []*protorekor.TransparencyLogEntry{e}
+	}
+	return vm, nil
+}
+
+func tlogEntry(data []byte) (*protorekor.TransparencyLogEntry, error) {
+	rb := &rekorBundle{}
+	if err := json.Unmarshal(data, rb); err != nil {
+		return nil, err
+	}
+	id, err := hex.DecodeString(rb.Payload.LogID)
+	if err != nil {
+		return nil, err
+	}
+	kv := struct {
+		Kind       string `json:"kind"`
+		APIVersion string `json:"apiVersion"`
+	}{}
+	if err := json.Unmarshal(rb.Payload.Body, &kv); err != nil {
+		return nil, err
+	}
+	return &protorekor.TransparencyLogEntry{
+		LogIndex:          rb.Payload.LogIndex,
+		LogId:             &protocommon.LogId{KeyId: id},
+		KindVersion:       &protorekor.KindVersion{Kind: kv.Kind, Version: kv.APIVersion},
+		IntegratedTime:    rb.Payload.IntegratedTime,
+		InclusionPromise:  &protorekor.InclusionPromise{SignedEntryTimestamp: rb.SignedEntryTimestamp},
+		CanonicalizedBody: rb.Payload.Body,
+	}, nil
+}
+
+// An authority can verify Sigstore bundles.
+type authority struct {
+	verifier *verify.SignedEntityVerifier
+	policy   []verify.PolicyOption
+}
+
+type authorities []authority
+
+// verify returns the result of the first authority that verifies the supplied
+// bundle.
+func (as authorities) verify(b *bundle.Bundle, artifact verify.ArtifactPolicyOption) (*verify.VerificationResult, error) {
+	errs := make([]error, 0, len(as))
+	for _, a := range as {
+		r, err := a.verifier.Verify(b, verify.NewPolicy(artifact, a.policy...))
+		if err == nil {
+			return r, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+func newAuthorities(in []v1alpha1.VerificationAuthority) (authorities, error) {
+	out := make(authorities, 0, len(in))
+	for _, a := range in {
+		var (
+			na  authority
+			err error
+		)
+		switch {
+		case a.Key != nil && a.Keyless == nil:
+			na, err = newKeyAuthority(a.Name, a.Key)
+		case a.Keyless != nil && a.Key == nil:
+			na, err = newKeylessAuthority(a.Keyless)
+		default:
+			err = errors.Errorf(errFmtAuthority, a.Name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, na)
+	}
+	return out, nil
+}
+
+// newKeyAuthority returns an authority that verifies signatures made using a
+// key pair. The signatures must be recorded in a transparency log unless the
+// authority ignores it.
+func newKeyAuthority(name string, in *v1alpha1.KeyAuthority) (authority, error) {
+	k, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(in.PublicKey))
+	if err != nil {
+		return authority{}, errors.Wrap(err, errParsePublicKey)
+	}
+	sv, err := signature.LoadVerifier(k, crypto.SHA256)
+	if err != nil {
+		return authority{}, errors.Wrap(err, errParsePublicKey)
+	}
+	key := root.NewExpiringKey(sv, time.Time{}, time.Time{})
+
+	// Cosign doesn't record a hint identifying the key, so every hint
+	// identifies the authority's key.
+	tm := root.TrustedMaterialCollection{root.NewTrustedPublicKeyMaterial(func(string) (root.TimeConstrainedVerifier, error) {
+		return key, nil
+	})}
+	opts := []verify.VerifierOption{verify.WithoutAnyObserverTimestampsUnsafe()}
+
+	if !in.IgnoreTlog {
+		if in.RekorPublicKey == "" {
+			return authority{}, errors.Errorf(errFmtNoRekorPublicKey, name)
+		}
+		tr, err := newTrustedRoot(nil, in.RekorPublicKey)
+		if err != nil {
+			return authority{}, err
+		}
+		tm = append(tm, tr)
+		opts = []verify.VerifierOption{verify.WithTransparencyLog(1), verify.WithIntegratedTimestamps(1)}
+	}
+
+	v, err := verify.NewSignedEntityVerifier(tm, opts...)
+	if err != nil {
+		return authority{}, errors.Wrap(err, errNewVerifier)
+	}
+	return authority{verifier: v, policy: []verify.PolicyOption{verify.WithKey()}}, nil
+}
+
+// newKeylessAuthority returns an authority that verifies signatures made using
+// a short-lived certificate that was recorded in a transparency log.
+func newKeylessAuthority(in *v1alpha1.KeylessAuthority) (authority, error) {
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(in.FulcioRoots))
+	if err != nil {
+		return authority{}, errors.Wrap(err, errParseFulcioRoots)
+	}
+	if len(certs) == 0 {
+		return authority{}, errors.New(errParseFulcioRoots)
+	}
+	tr, err := newTrustedRoot(certs, in.RekorPublicKey)
+	if err != nil {
+		return authority{}, err
+	}
+
+	// Signing certificates are short-lived, so they're verified as of the time
+	// the signature was recorded in the transparency log.
+	v, err := verify.NewSignedEntityVerifier(tr, verify.WithTransparencyLog(1), verify.WithIntegratedTimestamps(1))
+	if err != nil {
+		return authority{}, errors.Wrap(err, errNewVerifier)
+	}
+
+	policy := make([]verify.PolicyOption, 0, len(in.Identities))
+	for _, id := range in.Identities {
+		ci, err := verify.NewShortCertificateIdentity(id.Issuer, "", id.Subject, "")
+		if err != nil {
+			return authority{}, errors.Wrap(err, errParseIdentity)
+		}
+		policy = append(policy, verify.WithCertificateIdentity(ci))
+	}
+	return authority{verifier: v, policy: policy}, nil
+}
+
+// newTrustedRoot returns a trusted root that trusts the supplied Fulcio
+// certificates and Rekor public key.
+func newTrustedRoot(fulcio []*x509.Certificate, rekor string) (*root.TrustedRoot, error) {
+	// Self-signed certificates are roots. Others are intermediates.
+	var roots, intermediates []*x509.Certificate
+	for _, c := range fulcio {
+		if bytes.Equal(c.RawSubject, c.RawIssuer) && c.CheckSignatureFrom(c) == nil {
+			roots = append(roots, c)
+			continue
+		}
+		intermediates = append(intermediates, c)
+	}
+	cas := make([]root.CertificateAuthority, len(roots))
+	for i, r := range roots {
+		cas[i] = root.CertificateAuthority{Root: r, Intermediates: intermediates}
+	}
+
+	k, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(rekor))
+	if err != nil {
+		return nil, errors.Wrap(err, errParseRekorPublicKey)
+	}
+	der, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseRekorPublicKey)
+	}
+	// A Rekor log is identified by the hash of its public key.
+	id := sha256.Sum256(der)
+	logs := map[string]*root.TransparencyLog{
+		hex.EncodeToString(id[:]): {
+			ID:                id[:],
+			HashFunc:          crypto.SHA256,
+			SignatureHashFunc: crypto.SHA256,
+			PublicKey:         k,
+			// The policy doesn't say when the log started, so we trust any
+			// entry it signed.
+			ValidityPeriodStart: time.Unix(0, 0),
+		},
+	}
+
+	return root.NewTrustedRoot(root.TrustedRootMediaType01, cas, nil, nil, logs)
+}
@@ -0,0 +1,650 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package xpkg
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+)
+
+var _ Fetcher = &signatureFetcher{}
+
+// A signatureFetcher resolves every reference to the supplied digest, and
+// fetches the supplied signature and attestation images.
+type signatureFetcher struct {
+	digest       v1.Hash
+	signatures   v1.Image
+	attestations v1.Image
+}
+
+func (f *signatureFetcher) Fetch(_ context.Context, ref name.Reference, _ ...string) (v1.Image, error) {
+	switch {
+	case strings.HasSuffix(ref.Identifier(), "."+cosignSignatureSuffix) && f.signatures != nil:
+		return f.signatures, nil
+	case strings.HasSuffix(ref.Identifier(), "."+cosignAttestationSuffix) && f.attestations != nil:
+		return f.attestations, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *signatureFetcher) Head(_ context.Context, _ name.Reference, _ ...string) (*v1.Descriptor, error) {
+	return &v1.Descriptor{Digest: f.digest}, nil
+}
+
+func (f *signatureFetcher) Tags(_ context.Context, _ name.Reference, _ ...string) ([]string, error) {
+	return nil, nil
+}
+
+func mustGenerateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func mustPublicKeyPEM(t *testing.T, k crypto.PublicKey) string {
+	t.Helper()
+	b, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: b}))
+}
+
+func mustSign(t *testing.T, k *ecdsa.PrivateKey, payload []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, k, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig
+}
+
+func mustImage(t *testing.T, mt types.MediaType, layers ...signatureLayer) v1.Image {
+	t.Helper()
+	adds := make([]mutate.Addendum, len(layers))
+	for i, l := range layers {
+		adds[i] = mutate.Addendum{Layer: static.NewLayer(l.content, mt), Annotations: l.annotations}
+	}
+	img, err := mutate.Append(empty.Image, adds...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func sha256Hex(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+func signaturePayload(h v1.Hash) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"example.org/cool/package"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, h.String()))
+}
+
+func attestationStatement(h v1.Hash, predicateType string) []byte {
+	return []byte(fmt.Sprintf(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":%q,"subject":[{"name":"example.org/cool/package","digest":{%q:%q}}],"predicate":{}}`, predicateType, h.Algorithm, h.Hex))
+}
+
+// A rekor is a fake transparency log.
+type rekor struct {
+	key     *ecdsa.PrivateKey
+	logTime time.Time
+}
+
+func newRekor(t *testing.T) *rekor {
+	t.Helper()
+	return &rekor{key: mustGenerateKey(t), logTime: time.Now().Add(-1 * time.Hour)}
+}
+
+// bundle returns a cosign bundle annotation that proves the supplied entry was
+// recorded in the log.
+func (r *rekor) bundle(t *testing.T, body []byte) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(r.key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := sha256.Sum256(der)
+
+	// The signed entry timestamp signs the canonical JSON of the payload, so
+	// its fields are in lexical order.
+	payload := struct {
+		Body           []byte `json:"body"`
+		IntegratedTime int64  `json:"integratedTime"`
+		LogID          string `json:"logID"`
+		LogIndex       int64  `json:"logIndex"`
+	}{
+		Body:           body,
+		IntegratedTime: r.logTime.Unix(),
+		LogID:          hex.EncodeToString(id[:]),
+		LogIndex:       42,
+	}
+	set := mustSign(t, r.key, mustMarshal(t, payload))
+	return string(mustMarshal(t, map[string]any{"SignedEntryTimestamp": set, "Payload": payload}))
+}
+
+// hashedRekord returns a transparency log entry for a signature of the
+// supplied payload.
+func hashedRekord(t *testing.T, payload, sig []byte, publicKey string) []byte {
+	t.Helper()
+	return mustMarshal(t, map[string]any{
+		"apiVersion": "0.0.1",
+		"kind":       "hashedrekord",
+		"spec": map[string]any{
+			"data": map[string]any{"hash": map[string]any{"algorithm": "sha256", "value": sha256Hex(payload)}},
+			"signature": map[string]any{
+				"content":   sig,
+				"publicKey": map[string]any{"content": []byte(publicKey)},
+			},
+		},
+	})
+}
+
+// inToto returns a transparency log entry for a signature of the supplied
+// attestation envelope.
+func inToto(t *testing.T, envelope, statement, sig []byte, publicKey string) []byte {
+	t.Helper()
+	return mustMarshal(t, map[string]any{
+		"apiVersion": "0.0.2",
+		"kind":       "intoto",
+		"spec": map[string]any{
+			"content": map[string]any{
+				"envelope": map[string]any{
+					"payloadType": dssePayloadTypeInToto,
+					// Rekor double encodes DSSE signatures.
+					"signatures": []map[string]any{{"sig": []byte(base64.StdEncoding.EncodeToString(sig)), "publicKey": []byte(publicKey)}},
+				},
+				"hash":        map[string]any{"algorithm": "sha256", "value": sha256Hex(envelope)},
+				"payloadHash": map[string]any{"algorithm": "sha256", "value": sha256Hex(statement)},
+			},
+		},
+	})
+}
+
+// keySignature returns a signature layer for the supplied digest, signed by
+// the supplied key. It's recorded in the supplied log, if any.
+func keySignature(t *testing.T, k *ecdsa.PrivateKey, h v1.Hash, r *rekor) signatureLayer {
+	t.Helper()
+	p := signaturePayload(h)
+	sig := mustSign(t, k, p)
+	l := signatureLayer{
+		annotations: map[string]string{annotationCosignSignature: base64.StdEncoding.EncodeToString(sig)},
+		content:     p,
+	}
+	if r != nil {
+		l.annotations[annotationCosignBundle] = r.bundle(t, hashedRekord(t, p, sig, mustPublicKeyPEM(t, k.Public())))
+	}
+	return l
+}
+
+// keyAttestation returns an attestation layer for the supplied digest, signed
+// by the supplied key. It's recorded in the supplied log, if any.
+func keyAttestation(t *testing.T, k *ecdsa.PrivateKey, h v1.Hash, predicateType string, r *rekor) signatureLayer {
+	t.Helper()
+	st := attestationStatement(h, predicateType)
+	pae := []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(dssePayloadTypeInToto), dssePayloadTypeInToto, len(st), st))
+	sig := mustSign(t, k, pae)
+	e := mustMarshal(t, map[string]any{
+		"payloadType": dssePayloadTypeInToto,
+		"payload":     st,
+		"signatures":  []map[string]any{{"keyid": "", "sig": sig}},
+	})
+	l := signatureLayer{annotations: map[string]string{}, content: e}
+	if r != nil {
+		l.annotations[annotationCosignBundle] = r.bundle(t, inToto(t, e, st, sig, mustPublicKeyPEM(t, k.Public())))
+	}
+	return l
+}
+
+// A fulcio is a fake keyless signing infrastructure.
+type fulcio struct {
+	ca    *x509.Certificate
+	caKey *ecdsa.PrivateKey
+	rekor *rekor
+}
+
+func newFulcio(t *testing.T) *fulcio {
+	t.Helper()
+	f := &fulcio{caKey: mustGenerateKey(t), rekor: newRekor(t)}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             f.rekor.logTime.Add(-24 * time.Hour),
+		NotAfter:              f.rekor.logTime.Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, f.caKey.Public(), f.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.ca, err = x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func (f *fulcio) authority(t *testing.T, ids ...v1alpha1.KeylessIdentity) v1alpha1.VerificationAuthority {
+	t.Helper()
+	return v1alpha1.VerificationAuthority{
+		Name: "keyless",
+		Keyless: &v1alpha1.KeylessAuthority{
+			Identities:     ids,
+			FulcioRoots:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: f.ca.Raw})),
+			RekorPublicKey: mustPublicKeyPEM(t, f.rekor.key.Public()),
+		},
+	}
+}
+
+// signature returns a signature layer for the supplied digest, signed using a
+// short-lived certificate issued to the supplied identity, and recorded in the
+// supplied transparency log.
+func (f *fulcio) signature(t *testing.T, id v1alpha1.KeylessIdentity, h v1.Hash, r *rekor) signatureLayer {
+	t.Helper()
+	k := mustGenerateKey(t)
+	issuer, err := asn1.MarshalWithParams(id.Issuer, "utf8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		NotBefore:      r.logTime.Add(-5 * time.Minute),
+		NotAfter:       r.logTime.Add(5 * time.Minute),
+		EmailAddresses: []string{id.Subject},
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		// The OIDC issuer extension.
+		// https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+		ExtraExtensions: []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}, Value: issuer}},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, f.ca, k.Public(), f.caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+
+	p := signaturePayload(h)
+	sig := mustSign(t, k, p)
+	return signatureLayer{
+		annotations: map[string]string{
+			annotationCosignSignature:   base64.StdEncoding.EncodeToString(sig),
+			annotationCosignCertificate: cert,
+			annotationCosignBundle:      r.bundle(t, hashedRekord(t, p, sig, cert)),
+		},
+		content: p,
+	}
+}
+
+func TestCosignVerifierVerify(t *testing.T) {
+	h := v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("a", 64)}
+	other := v1.Hash{Algorithm: "sha256", Hex: strings.Repeat("b", 64)}
+
+	log := newRekor(t)
+	trusted := mustGenerateKey(t)
+	untrusted := mustGenerateKey(t)
+	key := v1alpha1.VerificationAuthority{Name: "key", Key: &v1alpha1.KeyAuthority{
+		PublicKey:      mustPublicKeyPEM(t, trusted.Public()),
+		RekorPublicKey: mustPublicKeyPEM(t, log.key.Public()),
+	}}
+	keyWithoutTlog := v1alpha1.VerificationAuthority{Name: "key", Key: &v1alpha1.KeyAuthority{
+		PublicKey:  mustPublicKeyPEM(t, trusted.Public()),
+		IgnoreTlog: true,
+	}}
+
+	f := newFulcio(t)
+	id := v1alpha1.KeylessIdentity{Issuer: "https://issuer.example.org", Subject: "dev@example.org"}
+	stranger := v1alpha1.KeylessIdentity{Issuer: "https://issuer.example.org", Subject: "stranger@example.org"}
+
+	signatures := types.MediaType("application/vnd.dev.cosign.simplesigning.v1+json")
+	attestations := types.MediaType("application/vnd.dsse.envelope.v1+json")
+
+	slsa := "https://slsa.dev/provenance/v0.2"
+
+	type params struct {
+		f Fetcher
+	}
+	type args struct {
+		p v1alpha1.VerificationPolicySpec
+	}
+	type want struct {
+		h   v1.Hash
+		err error
+	}
+	cases := map[string]struct {
+		reason string
+		params params
+		args   args
+		want   want
+	}{
+		"InvalidAuthority": {
+			reason: "We should return an error if an authority specifies neither a key nor a keyless identity.",
+			params: params{
+				f: &signatureFetcher{digest: h},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{{Name: "cool"}}},
+			},
+			want: want{
+				err: errors.Errorf(errFmtAuthority, "cool"),
+			},
+		},
+		"NoRekorPublicKey": {
+			reason: "We should return an error if a key authority that doesn't ignore the transparency log doesn't specify a Rekor public key.",
+			params: params{
+				f: &signatureFetcher{digest: h},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{{Name: "cool", Key: &v1alpha1.KeyAuthority{PublicKey: mustPublicKeyPEM(t, trusted.Public())}}}},
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoRekorPublicKey, "cool"),
+			},
+		},
+		"Unsigned": {
+			reason: "We should return an error if we can't fetch the image's signatures.",
+			params: params{
+				f: &signatureFetcher{digest: h},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{key}},
+			},
+			want: want{
+				err: errors.Wrap(errors.New("not found"), errFetchSignatures),
+			},
+		},
+		"KeySigned": {
+			reason: "We should return the digest of an image signed by a trusted key and recorded in the transparency log.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, keySignature(t, trusted, h, log))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{key}},
+			},
+			want: want{
+				h: h,
+			},
+		},
+		"KeySignedNotLogged": {
+			reason: "We should return an error if an image signed by a trusted key wasn't recorded in the transparency log.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, keySignature(t, trusted, h, nil))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{key}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"KeySignedUntrustedLog": {
+			reason: "We should return an error if an image signed by a trusted key was recorded in an untrusted transparency log.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, keySignature(t, trusted, h, newRekor(t)))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{key}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"KeySignedIgnoreTlog": {
+			reason: "We should return the digest of an image signed by a trusted key if the authority ignores the transparency log.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, keySignature(t, trusted, h, nil))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{keyWithoutTlog}},
+			},
+			want: want{
+				h: h,
+			},
+		},
+		"UntrustedKey": {
+			reason: "We should return an error if the image is only signed by an untrusted key.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, keySignature(t, untrusted, h, log))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{key}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"WrongDigest": {
+			reason: "We should return an error if the image's signature is for a different digest.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, keySignature(t, trusted, other, log))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{key}},
+			},
+			want: want{
+				err: errors.Wrap(errors.Join(errors.Errorf("signature is for digest %q, not %q", other.String(), h.String())), errNoValidSignature),
+			},
+		},
+		"Attested": {
+			reason: "We should return the digest of a signed image that has all required attestations.",
+			params: params{
+				f: &signatureFetcher{
+					digest:       h,
+					signatures:   mustImage(t, signatures, keySignature(t, trusted, h, log)),
+					attestations: mustImage(t, attestations, keyAttestation(t, trusted, h, slsa, log)),
+				},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{
+					Authorities:  []v1alpha1.VerificationAuthority{key},
+					Attestations: []v1alpha1.RequiredAttestation{{Name: "provenance", PredicateType: slsa}},
+				},
+			},
+			want: want{
+				h: h,
+			},
+		},
+		"UntrustedAttestation": {
+			reason: "We should return an error if a required attestation is signed by an untrusted key.",
+			params: params{
+				f: &signatureFetcher{
+					digest:       h,
+					signatures:   mustImage(t, signatures, keySignature(t, trusted, h, log)),
+					attestations: mustImage(t, attestations, keyAttestation(t, untrusted, h, slsa, log)),
+				},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{
+					Authorities:  []v1alpha1.VerificationAuthority{key},
+					Attestations: []v1alpha1.RequiredAttestation{{Name: "provenance", PredicateType: slsa}},
+				},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"AttestationForOtherDigest": {
+			reason: "We should return an error if a required attestation is for a different digest.",
+			params: params{
+				f: &signatureFetcher{
+					digest:       h,
+					signatures:   mustImage(t, signatures, keySignature(t, trusted, h, log)),
+					attestations: mustImage(t, attestations, keyAttestation(t, trusted, other, slsa, log)),
+				},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{
+					Authorities:  []v1alpha1.VerificationAuthority{key},
+					Attestations: []v1alpha1.RequiredAttestation{{Name: "provenance", PredicateType: slsa}},
+				},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"UnparseableAttestation": {
+			reason: "We should return why we couldn't parse an image's attestations if it doesn't have a required attestation.",
+			params: params{
+				f: &signatureFetcher{
+					digest:       h,
+					signatures:   mustImage(t, signatures, keySignature(t, trusted, h, log)),
+					attestations: mustImage(t, attestations, signatureLayer{content: []byte(`{"payloadType":"text/plain"}`)}),
+				},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{
+					Authorities:  []v1alpha1.VerificationAuthority{key},
+					Attestations: []v1alpha1.RequiredAttestation{{Name: "provenance", PredicateType: slsa}},
+				},
+			},
+			want: want{
+				err: errors.Wrapf(errors.Join(errors.Errorf("unsupported attestation payload type %q", "text/plain")), errFmtNoValidAttestation, slsa),
+			},
+		},
+		"MissingAttestation": {
+			reason: "We should return an error if the image doesn't have a required attestation.",
+			params: params{
+				f: &signatureFetcher{
+					digest:       h,
+					signatures:   mustImage(t, signatures, keySignature(t, trusted, h, log)),
+					attestations: mustImage(t, attestations, keyAttestation(t, trusted, h, "https://example.org/other", log)),
+				},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{
+					Authorities:  []v1alpha1.VerificationAuthority{key},
+					Attestations: []v1alpha1.RequiredAttestation{{Name: "provenance", PredicateType: slsa}},
+				},
+			},
+			want: want{
+				err: errors.Errorf(errFmtNoValidAttestation, slsa),
+			},
+		},
+		"KeylessSigned": {
+			reason: "We should return the digest of an image signed by a trusted keyless identity.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, f.signature(t, id, h, f.rekor))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{f.authority(t, id)}},
+			},
+			want: want{
+				h: h,
+			},
+		},
+		"UntrustedIdentity": {
+			reason: "We should return an error if the image is only signed by an untrusted keyless identity.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, f.signature(t, stranger, h, f.rekor))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{f.authority(t, id)}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"UntrustedRekor": {
+			reason: "We should return an error if a keyless signature was not recorded in the trusted transparency log.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, f.signature(t, id, h, newRekor(t)))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{f.authority(t, id)}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+		"KeylessSignedForKeyAuthority": {
+			reason: "We should return an error if a key authority is asked to verify a keyless signature.",
+			params: params{
+				f: &signatureFetcher{digest: h, signatures: mustImage(t, signatures, f.signature(t, id, h, log))},
+			},
+			args: args{
+				p: v1alpha1.VerificationPolicySpec{Authorities: []v1alpha1.VerificationAuthority{key}},
+			},
+			want: want{
+				err: cmpopts.AnyError,
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			ref, _ := name.ParseReference("example.org/cool/package:v1.0.0")
+			v := NewCosignVerifier(tc.params.f)
+			h, err := v.Verify(context.Background(), ref, tc.args.p)
+
+			if diff := cmp.Diff(tc.want.h, h); diff != "" {
+				t.Errorf("\n%s\nv.Verify(...): -want digest, +got digest:\n%s", tc.reason, diff)
+			}
+			// We don't match the errors sigstore-go returns exactly.
+			equateErrors := test.EquateErrors()
+			if tc.want.err == cmpopts.AnyError { //nolint:errorlint // We want to know whether the test wants any error.
+				equateErrors = cmpopts.EquateErrors()
+			}
+			if diff := cmp.Diff(tc.want.err, err, equateErrors); diff != "" {
+				t.Errorf("\n%s\nv.Verify(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composition
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	"github.com/crossplane/crossplane/internal/controller/apiextensions/composite"
+	"github.com/crossplane/crossplane/internal/xcel"
+)
+
+// ValidateCELExpressions returns an error for each CEL expression in the
+// supplied Composition that doesn't compile. Expression transforms and
+// readiness checks may be CEL expressions.
+func ValidateCELExpressions(comp *v1.Composition) field.ErrorList {
+	errs := field.ErrorList{}
+	for i, ps := range comp.Spec.PatchSets {
+		for j, p := range ps.Patches {
+			errs = append(errs, validateTransformExpressions(field.NewPath("spec", "patchSets").Index(i).Child("patches").Index(j), p.Transforms)...)
+		}
+	}
+	if env := comp.Spec.Environment; env != nil {
+		for j, p := range env.Patches {
+			errs = append(errs, validateTransformExpressions(field.NewPath("spec", "environment", "patches").Index(j), p.Transforms)...)
+		}
+	}
+	for i, r := range comp.Spec.Resources {
+		for j, p := range r.Patches {
+			errs = append(errs, validateTransformExpressions(field.NewPath("spec", "resources").Index(i).Child("patches").Index(j), p.Transforms)...)
+		}
+		for j, rc := range r.ReadinessChecks {
+			if rc.Type != v1.ReadinessCheckTypeCEL || rc.Expression == "" {
+				continue
+			}
+			if _, err := xcel.CompileReadinessCheck(rc.Expression); err != nil {
+				errs = append(errs, field.Invalid(field.NewPath("spec", "resources").Index(i).Child("readinessChecks").Index(j).Child("expression"), rc.Expression, err.Error()))
+			}
+		}
+	}
+	return errs
+}
+
+// validateTransformExpressions returns an error for each CEL expression
+// transform of the supplied patch that doesn't compile.
+func validateTransformExpressions(patch *field.Path, ts []v1.Transform) field.ErrorList {
+	errs := field.ErrorList{}
+	for k, t := range ts {
+		if t.Type != v1.TransformTypeExpression || t.Expression == nil || t.Expression.GetLanguage() != v1.ExpressionLanguageCEL {
+			continue
+		}
+		if _, err := composite.CompileExpression(*t.Expression, ""); err != nil {
+			errs = append(errs, field.Invalid(patch.Child("transforms").Index(k).Child("expression", "expression"), t.Expression.Expression, err.Error()))
+		}
+	}
+	return errs
+}
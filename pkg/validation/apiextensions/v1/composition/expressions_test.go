@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package composition
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	v1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+)
+
+func TestValidateCELExpressions(t *testing.T) {
+	withReadinessCheck := func(expr string) *v1.Composition {
+		return &v1.Composition{Spec: v1.CompositionSpec{Resources: []v1.ComposedTemplate{{
+			ReadinessChecks: []v1.ReadinessCheck{{Type: v1.ReadinessCheckTypeCEL, Expression: expr}},
+		}}}}
+	}
+
+	withTransform := func(expr string) *v1.Composition {
+		return &v1.Composition{Spec: v1.CompositionSpec{
+			PatchSets: []v1.PatchSet{{Patches: []v1.Patch{{Transforms: []v1.Transform{{
+				Type:       v1.TransformTypeExpression,
+				Expression: &v1.ExpressionTransform{Expression: expr, OutputType: v1.TransformIOTypeString},
+			}}}}}},
+		}}
+	}
+
+	cases := map[string]struct {
+		reason string
+		comp   *v1.Composition
+		want   field.ErrorList
+	}{
+		"ValidReadinessCheck": {
+			reason: "A readiness check expression that compiles should be valid.",
+			comp:   withReadinessCheck("object.status.readyReplicas == object.spec.replicas"),
+			want:   field.ErrorList{},
+		},
+		"InvalidReadinessCheck": {
+			reason: "A readiness check expression that doesn't compile should be invalid.",
+			comp:   withReadinessCheck("object.status.readyReplicas =="),
+			want: field.ErrorList{{
+				Type:  field.ErrorTypeInvalid,
+				Field: "spec.resources[0].readinessChecks[0].expression",
+			}},
+		},
+		"NotBooleanReadinessCheck": {
+			reason: "A readiness check expression that doesn't evaluate to a boolean should be invalid.",
+			comp:   withReadinessCheck("'ready'"),
+			want: field.ErrorList{{
+				Type:  field.ErrorTypeInvalid,
+				Field: "spec.resources[0].readinessChecks[0].expression",
+			}},
+		},
+		"ValidTransform": {
+			reason: "An expression transform that compiles should be valid.",
+			comp:   withTransform("input.join(',')"),
+			want:   field.ErrorList{},
+		},
+		"InvalidTransform": {
+			reason: "An expression transform that doesn't compile should be invalid.",
+			comp:   withTransform("input.split("),
+			want: field.ErrorList{{
+				Type:  field.ErrorTypeInvalid,
+				Field: "spec.patchSets[0].patches[0].transforms[0].expression.expression",
+			}},
+		},
+		"WrongOutputTypeTransform": {
+			reason: "An expression transform known not to output its declared output type should be invalid.",
+			comp:   withTransform("1 + 1"),
+			want: field.ErrorList{{
+				Type:  field.ErrorTypeInvalid,
+				Field: "spec.patchSets[0].patches[0].transforms[0].expression.expression",
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ValidateCELExpressions(tc.comp)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(field.Error{}, "Detail", "BadValue")); diff != "" {
+				t.Errorf("%s\nValidateCELExpressions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -507,6 +507,17 @@ func IsValidInputForTransform(t *v1.Transform, fromType v1.TransformIOType) erro
 		if _, err := composite.GetConversionFunc(t.Convert, fromType); err != nil {
 			return err
 		}
+	case v1.TransformTypeExpression:
+		if t.Expression == nil {
+			return errors.Errorf("expression transform requires configuration")
+		}
+		// Go templates accept any input type.
+		if t.Expression.GetLanguage() != v1.ExpressionLanguageCEL {
+			return nil
+		}
+		if _, err := composite.CompileExpression(*t.Expression, fromType); err != nil {
+			return err
+		}
 	default:
 		return errors.Errorf("unknown transform type %s", t.Type)
 	}
@@ -330,6 +330,62 @@ func TestValidateTransforms(t *testing.T) {
 				toType:   "string",
 			},
 		},
+		"AcceptExpressionTransforms": {
+			reason: "Should accept expression transforms that output the type of the toFieldPath",
+			args: args{
+				transforms: []v1.Transform{
+					{
+						Type: v1.TransformTypeExpression,
+						Expression: &v1.ExpressionTransform{
+							Expression: "input.split(',')",
+							OutputType: v1.TransformIOTypeArray,
+						},
+					},
+				},
+				fromType: "string",
+				toType:   "array",
+			},
+		},
+		"RejectExpressionTransformsWrongOutputType": {
+			reason: "Should reject expression transforms that don't output the type of the toFieldPath",
+			want: want{err: &field.Error{
+				Type:  field.ErrorTypeInvalid,
+				Field: "transforms",
+			}},
+			args: args{
+				transforms: []v1.Transform{
+					{
+						Type: v1.TransformTypeExpression,
+						Expression: &v1.ExpressionTransform{
+							Expression: "input.split(',')",
+							OutputType: v1.TransformIOTypeArray,
+						},
+					},
+				},
+				fromType: "string",
+				toType:   "string",
+			},
+		},
+		"RejectExpressionTransformsWrongInputType": {
+			reason: "Should reject expression transforms that aren't valid for the type of the fromFieldPath",
+			want: want{err: &field.Error{
+				Type:  field.ErrorTypeInvalid,
+				Field: "transforms[0]",
+			}},
+			args: args{
+				transforms: []v1.Transform{
+					{
+						Type: v1.TransformTypeExpression,
+						Expression: &v1.ExpressionTransform{
+							Expression: "input.split(',')",
+							OutputType: v1.TransformIOTypeArray,
+						},
+					},
+				},
+				fromType: "integer",
+				toType:   "array",
+			},
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -99,7 +99,8 @@ func (c crdGetterMap) GetAll(_ context.Context) (map[schema.GroupKind]apiextensi
 func WithLogicalValidation() ValidatorOption {
 	return func(v *Validator) {
 		v.logicalValidation = func(in *v1.Composition) ([]string, field.ErrorList) {
-			return in.Validate()
+			warns, errs := in.Validate()
+			return warns, append(errs, ValidateCELExpressions(in)...)
 		}
 	}
 }